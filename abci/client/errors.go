@@ -1,6 +1,7 @@
 package abcicli
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/cometbft/cometbft/abci/types"
@@ -23,3 +24,7 @@ type ErrUnexpectedResponse struct {
 func (e ErrUnexpectedResponse) Error() string {
 	return fmt.Sprintf("unexpected response %T: %s", e.Response.Value, e.Reason)
 }
+
+// ErrClientReconnecting is returned by a reconnecting client while it is
+// establishing a new connection to the application.
+var ErrClientReconnecting = errors.New("abci client is reconnecting to the application")
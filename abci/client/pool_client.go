@@ -0,0 +1,225 @@
+package abcicli
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// poolClient spreads the requests over several clients connected to the same
+// application, so that they are served in parallel. The clients are used in
+// turn, skipping those reporting an error (e.g. reconnecting).
+//
+// The pool stops with an error as soon as one of its clients does.
+type poolClient struct {
+	service.BaseService
+
+	clients []Client
+	next    atomic.Uint64
+
+	mtx cmtsync.Mutex
+	err error
+}
+
+var _ Client = (*poolClient)(nil)
+
+// NewPoolClient returns a client spreading the requests over the given
+// clients. It panics if clients is empty.
+func NewPoolClient(clients []Client) Client {
+	if len(clients) == 0 {
+		panic("abci client pool must not be empty")
+	}
+	cli := &poolClient{
+		clients: clients,
+	}
+	cli.BaseService = *service.NewBaseService(nil, "poolClient", cli)
+	return cli
+}
+
+// SetLogger implements Service by setting the logger of the pool and of its
+// clients.
+func (cli *poolClient) SetLogger(l log.Logger) {
+	cli.BaseService.SetLogger(l)
+	for i, c := range cli.clients {
+		c.SetLogger(l.With("pool_conn", i))
+	}
+}
+
+// OnStart implements Service by starting all the clients of the pool.
+func (cli *poolClient) OnStart() error {
+	for i, c := range cli.clients {
+		if err := c.Start(); err != nil {
+			cli.stopClients(cli.clients[:i])
+			return err
+		}
+	}
+	for _, c := range cli.clients {
+		go cli.monitorRoutine(c)
+	}
+	return nil
+}
+
+// OnStop implements Service by stopping all the clients of the pool.
+func (cli *poolClient) OnStop() {
+	cli.stopClients(cli.clients)
+}
+
+// Error returns an error if the pool was stopped abruptly, or the error of
+// the client it would use next.
+func (cli *poolClient) Error() error {
+	cli.mtx.Lock()
+	err := cli.err
+	cli.mtx.Unlock()
+	if err != nil {
+		return err
+	}
+	return cli.pick().Error()
+}
+
+// SetResponseCallback sets a callback, which will be executed for each
+// non-error & non-empty response of any client of the pool.
+func (cli *poolClient) SetResponseCallback(resCb Callback) {
+	for _, c := range cli.clients {
+		c.SetResponseCallback(resCb)
+	}
+}
+
+//----------------------------------------
+
+func (cli *poolClient) monitorRoutine(c Client) {
+	select {
+	case <-c.Quit():
+	case <-cli.Quit():
+		return
+	}
+	if !cli.IsRunning() {
+		return
+	}
+
+	err := c.Error()
+	if err == nil {
+		err = fmt.Errorf("abci client %v stopped", c)
+	}
+	cli.mtx.Lock()
+	if cli.err == nil {
+		cli.err = err
+	}
+	cli.mtx.Unlock()
+
+	cli.Logger.Error(fmt.Sprintf("Stopping abci.poolClient for error: %v", err.Error()))
+	if err := cli.Stop(); err != nil {
+		cli.Logger.Error("Error stopping abci.poolClient", "err", err)
+	}
+}
+
+func (cli *poolClient) stopClients(clients []Client) {
+	for _, c := range clients {
+		if !c.IsRunning() {
+			continue
+		}
+		if err := c.Stop(); err != nil {
+			cli.Logger.Error("Error stopping abci client", "err", err)
+		}
+	}
+}
+
+// pick returns the next usable client, or the next client if none is.
+func (cli *poolClient) pick() Client {
+	n := cli.next.Add(1)
+	size := uint64(len(cli.clients))
+	for i := uint64(0); i < size; i++ {
+		if c := cli.clients[(n+i)%size]; usable(c) {
+			return c
+		}
+	}
+	return cli.clients[n%size]
+}
+
+// usable returns true if c reports no error and, if it reconnects, is
+// connected.
+func usable(c Client) bool {
+	if rc, ok := c.(*reconnectingClient); ok {
+		return rc.connected()
+	}
+	return c.Error() == nil
+}
+
+//----------------------------------------
+
+func (cli *poolClient) Flush(ctx context.Context) error {
+	for _, c := range cli.clients {
+		if err := c.Flush(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cli *poolClient) Echo(ctx context.Context, msg string) (*types.EchoResponse, error) {
+	return cli.pick().Echo(ctx, msg)
+}
+
+func (cli *poolClient) Info(ctx context.Context, req *types.InfoRequest) (*types.InfoResponse, error) {
+	return cli.pick().Info(ctx, req)
+}
+
+func (cli *poolClient) CheckTx(ctx context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	return cli.pick().CheckTx(ctx, req)
+}
+
+func (cli *poolClient) CheckTxAsync(ctx context.Context, req *types.CheckTxRequest) (*ReqRes, error) {
+	return cli.pick().CheckTxAsync(ctx, req)
+}
+
+func (cli *poolClient) Query(ctx context.Context, req *types.QueryRequest) (*types.QueryResponse, error) {
+	return cli.pick().Query(ctx, req)
+}
+
+func (cli *poolClient) Commit(ctx context.Context, req *types.CommitRequest) (*types.CommitResponse, error) {
+	return cli.pick().Commit(ctx, req)
+}
+
+func (cli *poolClient) InitChain(ctx context.Context, req *types.InitChainRequest) (*types.InitChainResponse, error) {
+	return cli.pick().InitChain(ctx, req)
+}
+
+func (cli *poolClient) ListSnapshots(ctx context.Context, req *types.ListSnapshotsRequest) (*types.ListSnapshotsResponse, error) {
+	return cli.pick().ListSnapshots(ctx, req)
+}
+
+func (cli *poolClient) OfferSnapshot(ctx context.Context, req *types.OfferSnapshotRequest) (*types.OfferSnapshotResponse, error) {
+	return cli.pick().OfferSnapshot(ctx, req)
+}
+
+func (cli *poolClient) LoadSnapshotChunk(ctx context.Context, req *types.LoadSnapshotChunkRequest) (*types.LoadSnapshotChunkResponse, error) {
+	return cli.pick().LoadSnapshotChunk(ctx, req)
+}
+
+func (cli *poolClient) ApplySnapshotChunk(ctx context.Context, req *types.ApplySnapshotChunkRequest) (*types.ApplySnapshotChunkResponse, error) {
+	return cli.pick().ApplySnapshotChunk(ctx, req)
+}
+
+func (cli *poolClient) PrepareProposal(ctx context.Context, req *types.PrepareProposalRequest) (*types.PrepareProposalResponse, error) {
+	return cli.pick().PrepareProposal(ctx, req)
+}
+
+func (cli *poolClient) ProcessProposal(ctx context.Context, req *types.ProcessProposalRequest) (*types.ProcessProposalResponse, error) {
+	return cli.pick().ProcessProposal(ctx, req)
+}
+
+func (cli *poolClient) ExtendVote(ctx context.Context, req *types.ExtendVoteRequest) (*types.ExtendVoteResponse, error) {
+	return cli.pick().ExtendVote(ctx, req)
+}
+
+func (cli *poolClient) VerifyVoteExtension(ctx context.Context, req *types.VerifyVoteExtensionRequest) (*types.VerifyVoteExtensionResponse, error) {
+	return cli.pick().VerifyVoteExtension(ctx, req)
+}
+
+func (cli *poolClient) FinalizeBlock(ctx context.Context, req *types.FinalizeBlockRequest) (*types.FinalizeBlockResponse, error) {
+	return cli.pick().FinalizeBlock(ctx, req)
+}
@@ -0,0 +1,63 @@
+package abcicli_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/types"
+)
+
+type countingApp struct {
+	types.BaseApplication
+	queries int
+}
+
+func (app *countingApp) Query(context.Context, *types.QueryRequest) (*types.QueryResponse, error) {
+	app.queries++
+	return &types.QueryResponse{}, nil
+}
+
+func TestPoolClient(t *testing.T) {
+	ctx := context.Background()
+	apps := []*countingApp{{}, {}, {}}
+	clients := make([]abcicli.Client, 0, len(apps))
+	for _, app := range apps {
+		clients = append(clients, abcicli.NewLocalClient(nil, app))
+	}
+
+	c := abcicli.NewPoolClient(clients)
+	require.NoError(t, c.Start())
+	for i := 0; i < 3*len(apps); i++ {
+		_, err := c.Query(ctx, &types.QueryRequest{})
+		require.NoError(t, err)
+	}
+	for _, app := range apps {
+		assert.Equal(t, 3, app.queries)
+	}
+
+	require.NoError(t, c.Stop())
+	for _, client := range clients {
+		assert.False(t, client.IsRunning())
+	}
+}
+
+func TestPoolClientStopsForError(t *testing.T) {
+	failing := abcicli.NewLocalClient(nil, types.NewBaseApplication())
+	c := abcicli.NewPoolClient([]abcicli.Client{abcicli.NewLocalClient(nil, types.NewBaseApplication()), failing})
+	require.NoError(t, c.Start())
+	require.NoError(t, c.Error())
+
+	// A client of the pool stops on its own.
+	require.NoError(t, failing.Stop())
+	select {
+	case <-c.Quit():
+	case <-time.After(time.Second):
+		t.Fatal("expected the pool to stop")
+	}
+	require.Error(t, c.Error())
+}
@@ -0,0 +1,499 @@
+package abcicli
+
+import (
+	"container/list"
+	"context"
+	"time"
+
+	"github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/service"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// ReconnectBackoff bounds the delay between two attempts of a reconnecting
+// client to connect to the application. The delay starts at Min and doubles
+// after each failed attempt, up to Max.
+type ReconnectBackoff struct {
+	Min time.Duration
+	Max time.Duration
+}
+
+// ReconnectCallback is called by a reconnecting client once it established
+// a new connection to the application. The requests keep failing with
+// ErrClientReconnecting until the callback calls resume, which makes the new
+// connection current and sends the CheckTx requests of type CHECK that the
+// previous connection left without a response again, in the order they were
+// first sent. The requests of type RECHECK are dropped. The callback must call
+// resume, e.g. after aborting the recheck of the txs.
+type ReconnectCallback func(resume func())
+
+// ReconnectNotifier is implemented by the clients which reconnect to the
+// application when the connection breaks.
+type ReconnectNotifier interface {
+	SetReconnectCallback(cb ReconnectCallback)
+}
+
+// reconnectingClient forwards the requests to a client connected to the
+// application and replaces it by a new one whenever it stops, e.g. because
+// the application restarted. While the new client is being connected,
+// requests fail with ErrClientReconnecting, except Flush, which succeeds
+// since no request is in flight on a broken connection.
+//
+// The CheckTx requests of type CHECK in flight when the connection breaks
+// are sent again on the new connection, so that their callbacks are
+// eventually invoked (e.g. for the mempool to evict the txs from its cache).
+// The other requests in flight are lost, so this client must not be used for
+// the consensus connection.
+type reconnectingClient struct {
+	service.BaseService
+
+	newClient   func() (Client, error)
+	mustConnect bool
+	backoff     ReconnectBackoff
+
+	mtx         cmtsync.Mutex
+	client      Client
+	resCb       Callback
+	reconnectCb ReconnectCallback
+	pending     *list.List // CheckTx requests in flight, in the order they were sent
+}
+
+// pendingCheckTx is a CheckTx request in flight on the connection conn. The
+// ReqRes returned to the caller completes once a connection returns a
+// response, possibly after the request was sent again on a new connection.
+type pendingCheckTx struct {
+	req    *types.CheckTxRequest
+	reqRes *ReqRes
+	conn   Client
+	elem   *list.Element
+}
+
+var _ Client = (*reconnectingClient)(nil)
+
+// NewReconnectingClient returns a client which connects to the application
+// with a client created by newClient, and creates a new one whenever the
+// current one stops. newClient must return clients which fail to start if
+// the application cannot be reached, so that the attempts follow backoff.
+// If mustConnect is true, the client will return an error upon start if it
+// fails to connect else it will continue to retry.
+func NewReconnectingClient(newClient func() (Client, error), mustConnect bool, backoff ReconnectBackoff) Client {
+	cli := &reconnectingClient{
+		newClient:   newClient,
+		mustConnect: mustConnect,
+		backoff:     backoff,
+		pending:     list.New(),
+	}
+	cli.BaseService = *service.NewBaseService(nil, "reconnectingClient", cli)
+	return cli
+}
+
+// SetLogger implements Service by setting the logger of the client and of
+// its current connection.
+func (cli *reconnectingClient) SetLogger(l log.Logger) {
+	cli.BaseService.SetLogger(l)
+
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.client != nil {
+		cli.client.SetLogger(l)
+	}
+}
+
+// OnStart implements Service by connecting to the application and spawning
+// the routine which reconnects when the connection breaks.
+func (cli *reconnectingClient) OnStart() error {
+	c, err := cli.connect()
+	if err != nil {
+		if cli.mustConnect {
+			return err
+		}
+		cli.Logger.Error("Failed to connect to the application. Retrying...", "err", err)
+		if c = cli.reconnect(); c == nil {
+			return service.ErrAlreadyStopped
+		}
+	}
+	if !cli.setClient(c) {
+		return service.ErrAlreadyStopped
+	}
+
+	go cli.reconnectRoutine(c)
+
+	return nil
+}
+
+// OnStop implements Service by stopping the current connection, and marking
+// the CheckTx requests in flight as complete, without a response.
+func (cli *reconnectingClient) OnStop() {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if cli.client != nil {
+		if err := cli.client.Stop(); err != nil {
+			cli.Logger.Error("Error stopping abci client", "err", err)
+		}
+	}
+	for e := cli.pending.Front(); e != nil; e = cli.pending.Front() {
+		p := cli.pending.Remove(e).(*pendingCheckTx)
+		p.elem = nil
+		p.reqRes.Done()
+	}
+}
+
+// Error returns the error of the current connection, if any. It returns nil
+// while the client is reconnecting, since the client is still usable: the
+// requests fail until it reconnects, but it does not stop.
+func (cli *reconnectingClient) Error() error {
+	c, err := cli.current()
+	if err != nil {
+		return nil
+	}
+	return c.Error()
+}
+
+// connected returns true if the client has a working connection to the
+// application.
+func (cli *reconnectingClient) connected() bool {
+	c, err := cli.current()
+	return err == nil && c.Error() == nil
+}
+
+// SetResponseCallback sets a callback, which will be executed for each
+// non-error & non-empty response of the current and future connections.
+func (cli *reconnectingClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.resCb = resCb
+	if cli.client != nil {
+		cli.client.SetResponseCallback(resCb)
+	}
+}
+
+// SetReconnectCallback implements ReconnectNotifier.
+func (cli *reconnectingClient) SetReconnectCallback(cb ReconnectCallback) {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	cli.reconnectCb = cb
+}
+
+//----------------------------------------
+
+func (cli *reconnectingClient) reconnectRoutine(c Client) {
+	for {
+		select {
+		case <-c.Quit():
+		case <-cli.Quit():
+			return
+		}
+		if !cli.IsRunning() {
+			return
+		}
+
+		cli.Logger.Error("Connection to the application terminated. Reconnecting...", "err", c.Error())
+		prev := c
+		if c = cli.reconnect(); c == nil {
+			return
+		}
+
+		resumed := false
+		resume := func() {
+			if resumed = cli.setClient(c); resumed {
+				cli.Logger.Info("Reconnected to the application")
+				cli.resendCheckTxs(prev, c)
+			}
+		}
+		cli.mtx.Lock()
+		cb := cli.reconnectCb
+		cli.mtx.Unlock()
+		if cb != nil {
+			cb(resume)
+		} else {
+			resume()
+		}
+		if !resumed {
+			return
+		}
+	}
+}
+
+// resendCheckTxs sends the CheckTx requests of type CHECK left without a
+// response by the connection prev on the connection c, in order. The
+// requests of type RECHECK are completed without a response.
+func (cli *reconnectingClient) resendCheckTxs(prev, c Client) {
+	cli.mtx.Lock()
+	var lost []*pendingCheckTx
+	for e := cli.pending.Front(); e != nil; {
+		p := e.Value.(*pendingCheckTx)
+		e = e.Next()
+		if p.conn != prev {
+			continue
+		}
+		if p.req.Type == types.CHECK_TX_TYPE_RECHECK {
+			cli.pending.Remove(p.elem)
+			p.elem = nil
+			p.reqRes.Done()
+			continue
+		}
+		lost = append(lost, p)
+	}
+	cli.mtx.Unlock()
+
+	if len(lost) > 0 {
+		cli.Logger.Info("Sending the CheckTx requests in flight again", "num", len(lost))
+	}
+	for _, p := range lost {
+		if err := cli.sendCheckTx(context.Background(), c, p); err != nil {
+			// The request is sent again once c is replaced.
+			cli.Logger.Error("Failed to send CheckTx request again", "err", err)
+		}
+	}
+}
+
+// sendCheckTx sends a CheckTx request on the connection c, and completes the
+// ReqRes of the request once c returns a response. If c stops before, the
+// request stays pending, to be sent again on the next connection.
+func (cli *reconnectingClient) sendCheckTx(ctx context.Context, c Client, p *pendingCheckTx) error {
+	cli.mtx.Lock()
+	// A request sent again keeps its place in the queue.
+	if p.elem == nil {
+		p.elem = cli.pending.PushBack(p)
+	}
+	p.conn = c
+	cli.mtx.Unlock()
+
+	reqRes, err := c.CheckTxAsync(ctx, p.req)
+	if err != nil {
+		return err
+	}
+	reqRes.SetCallback(func(res *types.Response) {
+		if res == nil {
+			return
+		}
+		if !cli.removePending(p) {
+			return
+		}
+		p.reqRes.Response = res
+		p.reqRes.Done()
+		p.reqRes.InvokeCallback()
+	})
+	return nil
+}
+
+// removePending removes p from the requests in flight, and returns false if
+// it was already removed.
+func (cli *reconnectingClient) removePending(p *pendingCheckTx) bool {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	if p.elem == nil {
+		return false
+	}
+	cli.pending.Remove(p.elem)
+	p.elem = nil
+	return true
+}
+
+// reconnect connects to the application, retrying with an exponential
+// backoff. It returns nil if the client stopped in the meantime.
+func (cli *reconnectingClient) reconnect() Client {
+	delay := cli.backoff.Min
+	for {
+		select {
+		case <-time.After(delay):
+		case <-cli.Quit():
+			return nil
+		}
+
+		c, err := cli.connect()
+		if err == nil {
+			return c
+		}
+		delay = min(2*delay, cli.backoff.Max)
+		cli.Logger.Error("Failed to reconnect to the application", "err", err, "retry_in", delay)
+	}
+}
+
+func (cli *reconnectingClient) connect() (Client, error) {
+	c, err := cli.newClient()
+	if err != nil {
+		return nil, err
+	}
+	c.SetLogger(cli.Logger)
+	if err := c.Start(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// setClient makes c the current connection. If the client stopped in the
+// meantime, it stops c and returns false.
+func (cli *reconnectingClient) setClient(c Client) bool {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+
+	if !cli.IsRunning() {
+		if err := c.Stop(); err != nil {
+			cli.Logger.Error("Error stopping abci client", "err", err)
+		}
+		return false
+	}
+	if cli.resCb != nil {
+		c.SetResponseCallback(cli.resCb)
+	}
+	cli.client = c
+	return true
+}
+
+func (cli *reconnectingClient) current() (Client, error) {
+	cli.mtx.Lock()
+	c := cli.client
+	cli.mtx.Unlock()
+	if c == nil || !c.IsRunning() {
+		return nil, ErrClientReconnecting
+	}
+	return c, nil
+}
+
+//----------------------------------------
+
+// Flush flushes the current connection. It returns immediately while the
+// client is reconnecting: the requests in flight on the broken connection
+// were either completed or will be sent again.
+func (cli *reconnectingClient) Flush(ctx context.Context) error {
+	c, err := cli.current()
+	if err != nil {
+		return nil
+	}
+	return c.Flush(ctx)
+}
+
+func (cli *reconnectingClient) Echo(ctx context.Context, msg string) (*types.EchoResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.Echo(ctx, msg)
+}
+
+func (cli *reconnectingClient) Info(ctx context.Context, req *types.InfoRequest) (*types.InfoResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.Info(ctx, req)
+}
+
+func (cli *reconnectingClient) CheckTx(ctx context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.CheckTx(ctx, req)
+}
+
+func (cli *reconnectingClient) CheckTxAsync(ctx context.Context, req *types.CheckTxRequest) (*ReqRes, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	p := &pendingCheckTx{req: req, reqRes: NewReqRes(types.ToCheckTxRequest(req))}
+	if err := cli.sendCheckTx(ctx, c, p); err != nil {
+		cli.removePending(p)
+		return nil, err
+	}
+	return p.reqRes, nil
+}
+
+func (cli *reconnectingClient) Query(ctx context.Context, req *types.QueryRequest) (*types.QueryResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.Query(ctx, req)
+}
+
+func (cli *reconnectingClient) Commit(ctx context.Context, req *types.CommitRequest) (*types.CommitResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.Commit(ctx, req)
+}
+
+func (cli *reconnectingClient) InitChain(ctx context.Context, req *types.InitChainRequest) (*types.InitChainResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.InitChain(ctx, req)
+}
+
+func (cli *reconnectingClient) ListSnapshots(ctx context.Context, req *types.ListSnapshotsRequest) (*types.ListSnapshotsResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.ListSnapshots(ctx, req)
+}
+
+func (cli *reconnectingClient) OfferSnapshot(ctx context.Context, req *types.OfferSnapshotRequest) (*types.OfferSnapshotResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.OfferSnapshot(ctx, req)
+}
+
+func (cli *reconnectingClient) LoadSnapshotChunk(ctx context.Context, req *types.LoadSnapshotChunkRequest) (*types.LoadSnapshotChunkResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.LoadSnapshotChunk(ctx, req)
+}
+
+func (cli *reconnectingClient) ApplySnapshotChunk(ctx context.Context, req *types.ApplySnapshotChunkRequest) (*types.ApplySnapshotChunkResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.ApplySnapshotChunk(ctx, req)
+}
+
+func (cli *reconnectingClient) PrepareProposal(ctx context.Context, req *types.PrepareProposalRequest) (*types.PrepareProposalResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.PrepareProposal(ctx, req)
+}
+
+func (cli *reconnectingClient) ProcessProposal(ctx context.Context, req *types.ProcessProposalRequest) (*types.ProcessProposalResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.ProcessProposal(ctx, req)
+}
+
+func (cli *reconnectingClient) ExtendVote(ctx context.Context, req *types.ExtendVoteRequest) (*types.ExtendVoteResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.ExtendVote(ctx, req)
+}
+
+func (cli *reconnectingClient) VerifyVoteExtension(ctx context.Context, req *types.VerifyVoteExtensionRequest) (*types.VerifyVoteExtensionResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.VerifyVoteExtension(ctx, req)
+}
+
+func (cli *reconnectingClient) FinalizeBlock(ctx context.Context, req *types.FinalizeBlockRequest) (*types.FinalizeBlockResponse, error) {
+	c, err := cli.current()
+	if err != nil {
+		return nil, err
+	}
+	return c.FinalizeBlock(ctx, req)
+}
@@ -0,0 +1,251 @@
+package abcicli_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	"github.com/cometbft/cometbft/abci/server"
+	"github.com/cometbft/cometbft/abci/types"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/internal/service"
+)
+
+func TestReconnectingClient(t *testing.T) {
+	ctx := context.Background()
+	addr := fmt.Sprintf("localhost:%d", 20000+cmtrand.Int32()%10000)
+	startServer := func() service.Service {
+		s := server.NewSocketServer(addr, types.NewBaseApplication())
+		require.NoError(t, s.Start())
+		return s
+	}
+
+	s := startServer()
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) { return abcicli.NewSocketClient(addr, true), nil },
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	res, err := c.Echo(ctx, "hello")
+	require.NoError(t, err)
+	require.Equal(t, "hello", res.Message)
+
+	// The application restarts.
+	require.NoError(t, s.Stop())
+	require.Eventually(t, func() bool {
+		_, err := c.Echo(ctx, "hello")
+		return errors.Is(err, abcicli.ErrClientReconnecting)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.True(t, c.IsRunning())
+	// The client is still usable while reconnecting.
+	require.NoError(t, c.Error())
+	require.NoError(t, c.Flush(ctx))
+
+	s = startServer()
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+	require.Eventually(t, func() bool {
+		res, err := c.Echo(ctx, "again")
+		return err == nil && res.Message == "again"
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, c.Error())
+}
+
+func TestReconnectingClientMustConnect(t *testing.T) {
+	addr := fmt.Sprintf("localhost:%d", 20000+cmtrand.Int32()%10000)
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) { return abcicli.NewSocketClient(addr, true), nil },
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	require.Error(t, c.Start())
+}
+
+// droppingClient is a client whose connection drops while a CheckTx request
+// is in flight.
+type droppingClient struct {
+	abcicli.Client
+}
+
+func (c *droppingClient) CheckTxAsync(_ context.Context, req *types.CheckTxRequest) (*abcicli.ReqRes, error) {
+	reqRes := abcicli.NewReqRes(types.ToCheckTxRequest(req))
+	reqRes.Done()
+	go func() { _ = c.Stop() }()
+	return reqRes, nil
+}
+
+func TestReconnectingClientResendsCheckTx(t *testing.T) {
+	var conns int
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) {
+			conns++
+			local := abcicli.NewLocalClient(nil, types.NewBaseApplication())
+			if conns == 1 {
+				return &droppingClient{local}, nil
+			}
+			return local, nil
+		},
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	var globalCbs atomic.Int32
+	c.SetResponseCallback(func(*types.Request, *types.Response) { globalCbs.Add(1) })
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	reqRes, err := c.CheckTxAsync(context.Background(), &types.CheckTxRequest{Tx: []byte("tx"), Type: types.CHECK_TX_TYPE_CHECK})
+	require.NoError(t, err)
+	resCh := make(chan *types.Response, 1)
+	reqRes.SetCallback(func(res *types.Response) { resCh <- res })
+
+	// The request is sent again once reconnected, and completed with the
+	// response of the new connection.
+	select {
+	case res := <-resCh:
+		require.NotNil(t, res.GetCheckTx())
+		assert.Equal(t, types.CodeTypeOK, res.GetCheckTx().Code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the CheckTx callback to be invoked")
+	}
+	reqRes.Wait()
+	assert.Equal(t, int32(1), globalCbs.Load())
+	assert.Equal(t, 2, conns)
+}
+
+func TestReconnectingClientStopReleasesCheckTx(t *testing.T) {
+	var conns int
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) {
+			conns++
+			if conns == 1 {
+				return &droppingClient{abcicli.NewLocalClient(nil, types.NewBaseApplication())}, nil
+			}
+			return nil, errors.New("application unreachable")
+		},
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	require.NoError(t, c.Start())
+
+	reqRes, err := c.CheckTxAsync(context.Background(), &types.CheckTxRequest{Tx: []byte("tx"), Type: types.CHECK_TX_TYPE_CHECK})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool {
+		_, err := c.Echo(context.Background(), "hello")
+		return errors.Is(err, abcicli.ErrClientReconnecting)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// Stopping the client completes the requests which were never sent again.
+	require.NoError(t, c.Stop())
+	done := make(chan struct{})
+	go func() {
+		reqRes.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the CheckTx request to complete")
+	}
+	assert.Nil(t, reqRes.Response)
+}
+
+// holdingClient is a client which never responds to the CheckTx requests.
+type holdingClient struct {
+	abcicli.Client
+}
+
+func (*holdingClient) CheckTxAsync(_ context.Context, req *types.CheckTxRequest) (*abcicli.ReqRes, error) {
+	return abcicli.NewReqRes(types.ToCheckTxRequest(req)), nil
+}
+
+// recordingApp records the txs it checks.
+type recordingApp struct {
+	types.BaseApplication
+	txs chan []byte
+}
+
+func (app *recordingApp) CheckTx(_ context.Context, req *types.CheckTxRequest) (*types.CheckTxResponse, error) {
+	app.txs <- req.Tx
+	return &types.CheckTxResponse{Code: types.CodeTypeOK}, nil
+}
+
+func TestReconnectingClientResendsCheckTxInOrder(t *testing.T) {
+	app := &recordingApp{txs: make(chan []byte, 10)}
+	first := &holdingClient{abcicli.NewLocalClient(nil, app)}
+	var conns atomic.Int32
+	c := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) {
+			if conns.Add(1) == 1 {
+				return first, nil
+			}
+			return abcicli.NewLocalClient(nil, app), nil
+		},
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	c.SetResponseCallback(func(*types.Request, *types.Response) {})
+	resumed := make(chan struct{})
+	c.(abcicli.ReconnectNotifier).SetReconnectCallback(func(resume func()) {
+		// The new connection is not used before resume is called.
+		_, err := c.Echo(context.Background(), "hello")
+		assert.ErrorIs(t, err, abcicli.ErrClientReconnecting)
+		resume()
+		close(resumed)
+	})
+	require.NoError(t, c.Start())
+	t.Cleanup(func() {
+		if err := c.Stop(); err != nil {
+			t.Log(err)
+		}
+	})
+
+	var reqRess []*abcicli.ReqRes
+	for _, req := range []*types.CheckTxRequest{
+		{Tx: []byte("a"), Type: types.CHECK_TX_TYPE_CHECK},
+		{Tx: []byte("b"), Type: types.CHECK_TX_TYPE_RECHECK},
+		{Tx: []byte("c"), Type: types.CHECK_TX_TYPE_CHECK},
+	} {
+		reqRes, err := c.CheckTxAsync(context.Background(), req)
+		require.NoError(t, err)
+		reqRess = append(reqRess, reqRes)
+	}
+	require.NoError(t, first.Stop())
+
+	select {
+	case <-resumed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected the client to reconnect")
+	}
+
+	// Only the CHECK requests are sent again, in order.
+	for _, reqRes := range reqRess {
+		reqRes.Wait()
+	}
+	require.Len(t, app.txs, 2)
+	assert.Equal(t, []byte("a"), <-app.txs)
+	assert.Equal(t, []byte("c"), <-app.txs)
+	assert.NotNil(t, reqRess[0].Response)
+	assert.Nil(t, reqRess[1].Response)
+	assert.NotNil(t, reqRess[2].Response)
+}
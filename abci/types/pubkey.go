@@ -3,6 +3,7 @@ package types
 import (
 	fmt "fmt"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
@@ -23,6 +24,29 @@ func Ed25519ValidatorUpdate(pk []byte, power int64) ValidatorUpdate {
 	}
 }
 
+// Bls12381ValidatorUpdate returns the update of the validator with the
+// BLS12-381 public key pk, which must come with a proof of possession of the
+// corresponding private key (see bls12381.PrivKey.ProvePossession).
+func Bls12381ValidatorUpdate(pk []byte, proofOfPossession []byte, power int64) ValidatorUpdate {
+	pke := bls12381.PubKey(pk)
+
+	pkp, err := cryptoenc.PubKeyToProto(pke)
+	if err != nil {
+		panic(err)
+	}
+
+	return ValidatorUpdate{
+		// Address:
+		PubKey:            pkp,
+		Power:             power,
+		ProofOfPossession: proofOfPossession,
+	}
+}
+
+// UpdateValidator returns the update of the validator with the public key pk
+// of the given type. BLS12-381 validators can only be removed (power 0) this
+// way, as adding them requires a proof of possession: use
+// Bls12381ValidatorUpdate instead.
 func UpdateValidator(pk []byte, power int64, keyType string) ValidatorUpdate {
 	switch keyType {
 	case "", ed25519.KeyType:
@@ -38,6 +62,11 @@ func UpdateValidator(pk []byte, power int64, keyType string) ValidatorUpdate {
 			PubKey: pkp,
 			Power:  power,
 		}
+	case bls12381.KeyType:
+		if power != 0 {
+			panic("bls12_381 validators require a proof of possession, use Bls12381ValidatorUpdate")
+		}
+		return Bls12381ValidatorUpdate(pk, nil, power)
 	default:
 		panic(fmt.Sprintf("key type %s not supported", keyType))
 	}
@@ -257,6 +257,7 @@ type Request struct {
 	// Sum of all possible messages.
 	//
 	// Types that are valid to be assigned to Value:
+	//
 	//	*Request_Echo
 	//	*Request_Flush
 	//	*Request_Info
@@ -2290,6 +2291,13 @@ type CheckTxResponse struct {
 	GasUsed   int64   `protobuf:"varint,6,opt,name=gas_used,proto3" json:"gas_used,omitempty"`
 	Events    []Event `protobuf:"bytes,7,rep,name=events,proto3" json:"events,omitempty"`
 	Codespace string  `protobuf:"bytes,8,opt,name=codespace,proto3" json:"codespace,omitempty"`
+	// Priority and sender are only used by the "priority" mempool. Transactions
+	// with a higher priority are reaped first and evict lower priority ones
+	// when the mempool is full. Transactions from the same sender are reaped
+	// in the order they were received. Both fields are ignored by the other
+	// mempool types.
+	Priority int64  `protobuf:"varint,12,opt,name=priority,proto3" json:"priority,omitempty"`
+	Sender   string `protobuf:"bytes,13,opt,name=sender,proto3" json:"sender,omitempty"`
 }
 
 func (m *CheckTxResponse) Reset()         { *m = CheckTxResponse{} }
@@ -2381,6 +2389,20 @@ func (m *CheckTxResponse) GetCodespace() string {
 	return ""
 }
 
+func (m *CheckTxResponse) GetPriority() int64 {
+	if m != nil {
+		return m.Priority
+	}
+	return 0
+}
+
+func (m *CheckTxResponse) GetSender() string {
+	if m != nil {
+		return m.Sender
+	}
+	return ""
+}
+
 // CommitResponse indicates how much blocks should CometBFT retain.
 type CommitResponse struct {
 	RetainHeight int64 `protobuf:"varint,3,opt,name=retain_height,json=retainHeight,proto3" json:"retain_height,omitempty"`
@@ -3353,6 +3375,10 @@ func (m *Validator) GetPower() int64 {
 type ValidatorUpdate struct {
 	PubKey v11.PublicKey `protobuf:"bytes,1,opt,name=pub_key,json=pubKey,proto3" json:"pub_key"`
 	Power  int64         `protobuf:"varint,2,opt,name=power,proto3" json:"power,omitempty"`
+	// Proof that the validator owns the private key of pub_key, required for
+	// BLS12-381 keys. It is the signature of the public key with the
+	// proof-of-possession domain separation tag.
+	ProofOfPossession []byte `protobuf:"bytes,3,opt,name=proof_of_possession,json=proofOfPossession,proto3" json:"proof_of_possession,omitempty"`
 }
 
 func (m *ValidatorUpdate) Reset()         { *m = ValidatorUpdate{} }
@@ -3402,6 +3428,13 @@ func (m *ValidatorUpdate) GetPower() int64 {
 	return 0
 }
 
+func (m *ValidatorUpdate) GetProofOfPossession() []byte {
+	if m != nil {
+		return m.ProofOfPossession
+	}
+	return nil
+}
+
 // VoteInfo contains the information about the vote.
 type VoteInfo struct {
 	Validator   Validator      `protobuf:"bytes,1,opt,name=validator,proto3" json:"validator"`
@@ -3747,204 +3780,205 @@ func init() {
 func init() { proto.RegisterFile("cometbft/abci/v1/types.proto", fileDescriptor_95dd8f7b670b96e3) }
 
 var fileDescriptor_95dd8f7b670b96e3 = []byte{
-	// 3140 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0xb5, 0xd6, 0xe0, 0x41, 0x02, 0x07, 0x00, 0x39, 0x6c, 0x92, 0x12, 0x44, 0xcb, 0x24, 0x35, 0xb4,
-	0x2c, 0x59, 0xb2, 0xc9, 0x2b, 0xd9, 0xd7, 0x8f, 0xeb, 0x57, 0x81, 0x10, 0x68, 0x92, 0xa2, 0x08,
-	0x78, 0x00, 0xf2, 0x5a, 0xaa, 0x7b, 0x33, 0x1e, 0x00, 0x0d, 0x62, 0x2c, 0x00, 0x33, 0x9e, 0x69,
-	0xd0, 0x60, 0xb2, 0x4a, 0x2a, 0x76, 0xa5, 0xbc, 0xf2, 0x26, 0x9b, 0x54, 0x52, 0x95, 0xaa, 0x54,
-	0x56, 0xa9, 0xca, 0x3a, 0xbf, 0x20, 0xe5, 0x55, 0xe2, 0x65, 0x56, 0x4e, 0xca, 0xde, 0x65, 0x91,
-	0x9d, 0xab, 0xb2, 0x4c, 0xf5, 0x63, 0x5e, 0xc0, 0x0c, 0x49, 0xc9, 0xce, 0x22, 0x95, 0xec, 0xa6,
-	0xbb, 0xbf, 0x73, 0xba, 0xfb, 0x74, 0xf7, 0x79, 0x7c, 0x00, 0x5c, 0x69, 0x99, 0x7d, 0x4c, 0x9a,
-	0x1d, 0xb2, 0xa1, 0x37, 0x5b, 0xc6, 0xc6, 0xf1, 0xed, 0x0d, 0x72, 0x62, 0x61, 0x67, 0xdd, 0xb2,
-	0x4d, 0x62, 0x22, 0xd9, 0x1d, 0x5d, 0xa7, 0xa3, 0xeb, 0xc7, 0xb7, 0x97, 0x9e, 0xf6, 0xf0, 0x2d,
-	0xfb, 0xc4, 0x22, 0x26, 0x95, 0x78, 0x84, 0x4f, 0x84, 0xc0, 0xd2, 0x72, 0xc4, 0xb0, 0x65, 0x9b,
-	0x66, 0x67, 0x62, 0x9c, 0x4d, 0xc3, 0x86, 0x75, 0x5b, 0xef, 0xbb, 0xf2, 0x57, 0x27, 0xc7, 0x8f,
-	0xf5, 0x9e, 0xd1, 0xd6, 0x89, 0x69, 0x0b, 0xc8, 0xc2, 0x91, 0x79, 0x64, 0xb2, 0xcf, 0x0d, 0xfa,
-	0x25, 0x7a, 0x57, 0x8e, 0x4c, 0xf3, 0xa8, 0x87, 0x37, 0x58, 0xab, 0x39, 0xec, 0x6c, 0x10, 0xa3,
-	0x8f, 0x1d, 0xa2, 0xf7, 0x2d, 0x0e, 0x50, 0xfe, 0x98, 0x85, 0x69, 0x15, 0x7f, 0x38, 0xc4, 0x0e,
-	0x41, 0x2f, 0x42, 0x0a, 0xb7, 0xba, 0x66, 0x51, 0x5a, 0x95, 0x6e, 0xe4, 0xee, 0x3c, 0xbd, 0x3e,
-	0xbe, 0xcb, 0xf5, 0x4a, 0xab, 0x6b, 0x0a, 0xf0, 0xf6, 0x05, 0x95, 0x81, 0xd1, 0xcb, 0x90, 0xee,
-	0xf4, 0x86, 0x4e, 0xb7, 0x98, 0x60, 0x52, 0xcb, 0x93, 0x52, 0x5b, 0x74, 0xd8, 0x17, 0xe3, 0x70,
-	0x3a, 0x99, 0x31, 0xe8, 0x98, 0xc5, 0x64, 0xdc, 0x64, 0x3b, 0x83, 0x4e, 0x70, 0x32, 0x0a, 0x46,
-	0x65, 0x00, 0x63, 0x60, 0x10, 0xad, 0xd5, 0xd5, 0x8d, 0x41, 0x31, 0xcd, 0x44, 0x95, 0x28, 0x51,
-	0x83, 0x94, 0x29, 0xc4, 0x97, 0xcf, 0x1a, 0x6e, 0x1f, 0x5d, 0xf1, 0x87, 0x43, 0x6c, 0x9f, 0x14,
-	0xa7, 0xe2, 0x56, 0xfc, 0x2e, 0x1d, 0x0e, 0xac, 0x98, 0xc1, 0xd1, 0x9b, 0x90, 0x69, 0x75, 0x71,
-	0xeb, 0x91, 0x46, 0x46, 0xc5, 0x0c, 0x13, 0x5d, 0x9d, 0x14, 0x2d, 0x53, 0x44, 0x63, 0xe4, 0x0b,
-	0x4f, 0xb7, 0x78, 0x0f, 0x7a, 0x0d, 0xa6, 0x5a, 0x66, 0xbf, 0x6f, 0x90, 0x62, 0x8e, 0x09, 0xaf,
-	0x44, 0x08, 0xb3, 0x71, 0x5f, 0x56, 0x08, 0xa0, 0x2a, 0xcc, 0xf4, 0x0c, 0x87, 0x68, 0xce, 0x40,
-	0xb7, 0x9c, 0xae, 0x49, 0x9c, 0x62, 0x9e, 0xa9, 0x78, 0x76, 0x52, 0xc5, 0x9e, 0xe1, 0x90, 0xba,
-	0x0b, 0xf3, 0x35, 0x15, 0x7a, 0xc1, 0x7e, 0xaa, 0xd0, 0xec, 0x74, 0xb0, 0xed, 0x69, 0x2c, 0x16,
-	0xe2, 0x14, 0x56, 0x29, 0xce, 0x95, 0x0c, 0x28, 0x34, 0x83, 0xfd, 0xe8, 0xff, 0x60, 0xbe, 0x67,
-	0xea, 0x6d, 0x4f, 0x9f, 0xd6, 0xea, 0x0e, 0x07, 0x8f, 0x8a, 0x33, 0x4c, 0xeb, 0xcd, 0x88, 0x65,
-	0x9a, 0x7a, 0xdb, 0x15, 0x2e, 0x53, 0xa8, 0xaf, 0x79, 0xae, 0x37, 0x3e, 0x86, 0x34, 0x58, 0xd0,
-	0x2d, 0xab, 0x77, 0x32, 0xae, 0x7e, 0x96, 0xa9, 0xbf, 0x35, 0xa9, 0xbe, 0x44, 0xd1, 0x31, 0xfa,
-	0x91, 0x3e, 0x31, 0x88, 0x0e, 0x40, 0xb6, 0x6c, 0x6c, 0xe9, 0x36, 0xd6, 0x2c, 0xdb, 0xb4, 0x4c,
-	0x47, 0xef, 0x15, 0x65, 0xa6, 0xfc, 0xc6, 0xa4, 0xf2, 0x1a, 0x47, 0xd6, 0x04, 0xd0, 0xd7, 0x3c,
-	0x6b, 0x85, 0x47, 0xb8, 0x5a, 0xb3, 0x85, 0x1d, 0xc7, 0x57, 0x3b, 0x17, 0xaf, 0x96, 0x21, 0x23,
-	0xd5, 0x86, 0x46, 0xd0, 0x16, 0xe4, 0xf0, 0x88, 0xe0, 0x41, 0x5b, 0x3b, 0x36, 0x09, 0x2e, 0x22,
-	0xa6, 0x71, 0x2d, 0xe2, 0xb9, 0x32, 0xd0, 0xa1, 0x49, 0xb0, 0xaf, 0x0c, 0xb0, 0xd7, 0x89, 0x9a,
-	0xb0, 0x78, 0x8c, 0x6d, 0xa3, 0x73, 0xc2, 0xf4, 0x68, 0x6c, 0xc4, 0x31, 0xcc, 0x41, 0x71, 0x9e,
-	0x69, 0x7c, 0x7e, 0x52, 0xe3, 0x21, 0x83, 0x53, 0xe1, 0x8a, 0x0b, 0xf6, 0x55, 0xcf, 0x1f, 0x4f,
-	0x8e, 0xd2, 0x9b, 0xd6, 0x31, 0x06, 0x7a, 0xcf, 0xf8, 0x3e, 0xd6, 0x9a, 0x3d, 0xb3, 0xf5, 0xa8,
-	0xb8, 0x10, 0x77, 0xd3, 0xb6, 0x04, 0x6e, 0x93, 0xc2, 0x02, 0x37, 0xad, 0x13, 0xec, 0xdf, 0x9c,
-	0x86, 0xf4, 0xb1, 0xde, 0x1b, 0xe2, 0xdd, 0x54, 0x26, 0x25, 0xa7, 0x77, 0x53, 0x99, 0x69, 0x39,
-	0xb3, 0x9b, 0xca, 0x64, 0x65, 0xd8, 0x4d, 0x65, 0x40, 0xce, 0x29, 0xd7, 0x21, 0x17, 0xf0, 0x53,
-	0xa8, 0x08, 0xd3, 0x7d, 0xec, 0x38, 0xfa, 0x11, 0x66, 0x7e, 0x2d, 0xab, 0xba, 0x4d, 0x65, 0x06,
-	0xf2, 0x41, 0xd7, 0xa4, 0x7c, 0x26, 0x41, 0x2e, 0xe0, 0x74, 0xa8, 0xe4, 0x31, 0xb6, 0x99, 0x41,
-	0x84, 0xa4, 0x68, 0xa2, 0x35, 0x28, 0xb0, 0xbd, 0x68, 0xee, 0x38, 0xf5, 0x7d, 0x29, 0x35, 0xcf,
-	0x3a, 0x0f, 0x05, 0x68, 0x05, 0x72, 0xd6, 0x1d, 0xcb, 0x83, 0x24, 0x19, 0x04, 0xac, 0x3b, 0x96,
-	0x0b, 0xb8, 0x0a, 0x79, 0xba, 0x75, 0x0f, 0x91, 0x62, 0x93, 0xe4, 0x68, 0x9f, 0x80, 0x28, 0x7f,
-	0x48, 0x80, 0x3c, 0xee, 0xcc, 0xd0, 0xab, 0x90, 0xa2, 0x5e, 0x5c, 0xb8, 0xe9, 0xa5, 0x75, 0xee,
-	0xe2, 0xd7, 0x5d, 0x17, 0xbf, 0xde, 0x70, 0x5d, 0xfc, 0x66, 0xe6, 0xf3, 0x2f, 0x57, 0x2e, 0x7c,
-	0xf6, 0xe7, 0x15, 0x49, 0x65, 0x12, 0xe8, 0x32, 0xf5, 0x60, 0xba, 0x31, 0xd0, 0x8c, 0x36, 0x5b,
-	0x72, 0x96, 0x7a, 0x27, 0xdd, 0x18, 0xec, 0xb4, 0xd1, 0x7d, 0x90, 0x5b, 0xe6, 0xc0, 0xc1, 0x03,
-	0x67, 0xe8, 0x68, 0x3c, 0xf6, 0x08, 0xd7, 0x1c, 0xf0, 0xaf, 0x3c, 0x06, 0x32, 0x47, 0x25, 0xa0,
-	0x35, 0x86, 0x54, 0x67, 0x5b, 0xe1, 0x0e, 0xf4, 0x0e, 0x80, 0x17, 0xa0, 0x9c, 0x62, 0x6a, 0x35,
-	0x79, 0x23, 0x77, 0xe7, 0x6a, 0xc4, 0x7d, 0x72, 0x31, 0x07, 0x56, 0x5b, 0x27, 0x78, 0x33, 0x45,
-	0x17, 0xac, 0x06, 0x44, 0xd1, 0xb3, 0x30, 0xab, 0x5b, 0x96, 0xe6, 0x10, 0x9d, 0x60, 0xad, 0x79,
-	0x42, 0xb0, 0xc3, 0xdc, 0x7e, 0x5e, 0x2d, 0xe8, 0x96, 0x55, 0xa7, 0xbd, 0x9b, 0xb4, 0x13, 0x5d,
-	0x83, 0x19, 0xea, 0xe1, 0x0d, 0xbd, 0xa7, 0x75, 0xb1, 0x71, 0xd4, 0x25, 0xcc, 0xbb, 0x27, 0xd5,
-	0x82, 0xe8, 0xdd, 0x66, 0x9d, 0x4a, 0x1b, 0xf2, 0x41, 0xe7, 0x8e, 0x10, 0xa4, 0xda, 0x3a, 0xd1,
-	0x99, 0x2d, 0xf3, 0x2a, 0xfb, 0xa6, 0x7d, 0x96, 0x4e, 0xba, 0xc2, 0x42, 0xec, 0x1b, 0x5d, 0x84,
-	0x29, 0xa1, 0x36, 0xc9, 0xd4, 0x8a, 0x16, 0x5a, 0x80, 0xb4, 0x65, 0x9b, 0xc7, 0x98, 0x1d, 0x5e,
-	0x46, 0xe5, 0x0d, 0xe5, 0x01, 0xcc, 0x84, 0xe3, 0x00, 0x9a, 0x81, 0x04, 0x19, 0x89, 0x59, 0x12,
-	0x64, 0x84, 0x6e, 0x43, 0x8a, 0x1a, 0x93, 0x69, 0x9b, 0x89, 0x8a, 0x7e, 0x42, 0xbe, 0x71, 0x62,
-	0x61, 0x95, 0x41, 0x77, 0x53, 0x99, 0x84, 0x9c, 0x54, 0x66, 0xa1, 0x10, 0x8a, 0x12, 0xca, 0x45,
-	0x58, 0x88, 0xf2, 0xf9, 0x8a, 0x01, 0x0b, 0x51, 0xae, 0x1b, 0xbd, 0x0c, 0x19, 0xcf, 0xe9, 0xbb,
-	0x37, 0x68, 0x62, 0x76, 0x4f, 0xc8, 0xc3, 0xd2, 0xbb, 0x43, 0x0f, 0xa2, 0xab, 0x8b, 0x50, 0x9f,
-	0x57, 0xa7, 0x75, 0xcb, 0xda, 0xd6, 0x9d, 0xae, 0xf2, 0x3e, 0x14, 0xe3, 0xfc, 0x79, 0xc0, 0x70,
-	0x12, 0x7b, 0x00, 0xae, 0xe1, 0x2e, 0xc2, 0x54, 0xc7, 0xb4, 0xfb, 0x3a, 0x61, 0xca, 0x0a, 0xaa,
-	0x68, 0x51, 0x83, 0x72, 0xdf, 0x9e, 0x64, 0xdd, 0xbc, 0xa1, 0x68, 0x70, 0x39, 0xd6, 0xa5, 0x53,
-	0x11, 0x63, 0xd0, 0xc6, 0xdc, 0xbc, 0x05, 0x95, 0x37, 0x7c, 0x45, 0x7c, 0xb1, 0xbc, 0x41, 0xa7,
-	0x75, 0xf0, 0xa0, 0x8d, 0x6d, 0xa6, 0x3f, 0xab, 0x8a, 0x96, 0xf2, 0xb3, 0x24, 0x5c, 0x8c, 0xf6,
-	0xeb, 0x68, 0x15, 0xf2, 0x7d, 0x7d, 0xa4, 0x91, 0x91, 0xb8, 0x7e, 0x12, 0xbb, 0x00, 0xd0, 0xd7,
-	0x47, 0x8d, 0x11, 0xbf, 0x7b, 0x32, 0x24, 0xc9, 0xc8, 0x29, 0x26, 0x56, 0x93, 0x37, 0xf2, 0x2a,
-	0xfd, 0x44, 0x87, 0x30, 0xd7, 0x33, 0x5b, 0x7a, 0x4f, 0xeb, 0xe9, 0x0e, 0xd1, 0x44, 0xd8, 0xe7,
-	0xcf, 0xe9, 0x99, 0x38, 0x3f, 0x8d, 0xdb, 0xfc, 0x60, 0xa9, 0x0b, 0x12, 0x0f, 0x61, 0x96, 0x29,
-	0xd9, 0xd3, 0x1d, 0xc2, 0x87, 0x50, 0x05, 0x72, 0x7d, 0xc3, 0x69, 0xe2, 0xae, 0x7e, 0x6c, 0x98,
-	0xb6, 0x78, 0x57, 0x11, 0xb7, 0xe7, 0xbe, 0x0f, 0x12, 0xaa, 0x82, 0x72, 0x81, 0x43, 0x49, 0x87,
-	0x6e, 0xb3, 0xeb, 0x59, 0xa6, 0x1e, 0xdb, 0xb3, 0xfc, 0x17, 0x2c, 0x0c, 0xf0, 0x88, 0x68, 0xfe,
-	0xcb, 0xe5, 0x37, 0x65, 0x9a, 0x19, 0x1f, 0xd1, 0x31, 0xef, 0xad, 0x3b, 0xf4, 0xd2, 0xa0, 0xe7,
-	0x58, 0x6c, 0xb4, 0x4c, 0x07, 0xdb, 0x9a, 0xde, 0x6e, 0xdb, 0xd8, 0x71, 0x58, 0x56, 0x95, 0x67,
-	0xf1, 0x8e, 0xf5, 0x97, 0x78, 0xb7, 0xf2, 0x29, 0x3b, 0x9c, 0xa8, 0xe8, 0xe8, 0x9a, 0x5e, 0xf2,
-	0x4d, 0xdf, 0x80, 0x05, 0x21, 0xdf, 0x0e, 0x59, 0x9f, 0xa7, 0xa7, 0x57, 0xe2, 0x92, 0xae, 0x80,
-	0xd5, 0x91, 0x2b, 0x1f, 0x6f, 0xf8, 0xe4, 0x13, 0x1a, 0x1e, 0x41, 0x8a, 0x99, 0x25, 0xc5, 0xdd,
-	0x0d, 0xfd, 0xfe, 0x57, 0x3b, 0x8c, 0x8f, 0x93, 0x30, 0x37, 0x91, 0x58, 0x78, 0x1b, 0x93, 0x22,
-	0x37, 0x96, 0x88, 0xdc, 0x58, 0xf2, 0xb1, 0x37, 0x26, 0x4e, 0x3b, 0x75, 0xf6, 0x69, 0xa7, 0xbf,
-	0xcb, 0xd3, 0x9e, 0x7a, 0xc2, 0xd3, 0xfe, 0xa7, 0x9e, 0xc3, 0xcf, 0x25, 0x58, 0x8a, 0x4f, 0xc7,
-	0x22, 0x0f, 0xe4, 0x16, 0xcc, 0x79, 0x4b, 0xf1, 0xd4, 0x73, 0xf7, 0x28, 0x7b, 0x03, 0x42, 0x7f,
-	0x6c, 0xc4, 0xbb, 0x06, 0x33, 0x63, 0xd9, 0x22, 0xbf, 0xcc, 0x85, 0xe3, 0xe0, 0x32, 0x94, 0x4f,
-	0x92, 0xb0, 0x10, 0x95, 0xd0, 0x45, 0xbc, 0x58, 0x15, 0xe6, 0xdb, 0xb8, 0x65, 0xb4, 0x9f, 0xf8,
-	0xc1, 0xce, 0x09, 0xf1, 0xff, 0xbc, 0xd7, 0x88, 0x7b, 0xf2, 0x6b, 0x80, 0x8c, 0x8a, 0x1d, 0x8b,
-	0x26, 0x68, 0xa8, 0x0c, 0x59, 0x3c, 0x6a, 0x61, 0x8b, 0xb8, 0x49, 0x6d, 0x4c, 0xdd, 0x20, 0x20,
-	0xae, 0x1c, 0xad, 0x9f, 0x3d, 0x39, 0xf4, 0x92, 0xa0, 0x09, 0x62, 0x0b, 0x7e, 0x9e, 0x7e, 0x7b,
-	0xa2, 0x9c, 0x27, 0x78, 0xc5, 0xe5, 0x09, 0x92, 0x71, 0xd5, 0xaf, 0x48, 0xc6, 0x3d, 0x39, 0x41,
-	0x14, 0xbc, 0x24, 0x88, 0x82, 0x54, 0xdc, 0x74, 0x3c, 0x67, 0xf7, 0xa7, 0x63, 0x4c, 0xc1, 0xdd,
-	0x10, 0x53, 0x30, 0x15, 0xb7, 0xd5, 0x40, 0x72, 0xed, 0x6f, 0xd5, 0xa7, 0x0a, 0x5e, 0x71, 0xa9,
-	0x82, 0xe9, 0xb8, 0x45, 0x8b, 0x6c, 0xd2, 0x5f, 0x34, 0xe7, 0x0a, 0xde, 0x0a, 0x70, 0x05, 0x59,
-	0x26, 0x7b, 0xf5, 0x14, 0xae, 0xc0, 0x93, 0xf6, 0xc8, 0x82, 0xff, 0xf1, 0xc8, 0x82, 0x7c, 0x2c,
-	0xd3, 0x20, 0xd2, 0x40, 0x4f, 0xd8, 0x65, 0x0b, 0x6a, 0x13, 0x6c, 0x01, 0x2f, 0xee, 0xaf, 0x9f,
-	0xc9, 0x16, 0x78, 0xaa, 0xc6, 0xe8, 0x82, 0xda, 0x04, 0x5d, 0x30, 0x13, 0xa7, 0x71, 0x2c, 0xe7,
-	0xf4, 0x35, 0x86, 0xf9, 0x82, 0xff, 0x8f, 0xe6, 0x0b, 0x62, 0x0b, 0xfa, 0x88, 0xfc, 0xd2, 0x53,
-	0x1d, 0x41, 0x18, 0xbc, 0x1f, 0x43, 0x18, 0xc8, 0x71, 0x85, 0x6d, 0x54, 0x76, 0xe9, 0x4d, 0x10,
-	0xc5, 0x18, 0x1c, 0x46, 0x30, 0x06, 0xbc, 0xb4, 0x7f, 0xee, 0x1c, 0x8c, 0x81, 0xa7, 0x7a, 0x82,
-	0x32, 0x38, 0x8c, 0xa0, 0x0c, 0x50, 0xbc, 0xde, 0xb1, 0xa4, 0x28, 0xa8, 0x37, 0xcc, 0x19, 0xbc,
-	0x13, 0xe6, 0x0c, 0xe6, 0x4f, 0xcf, 0x45, 0x79, 0x68, 0xf7, 0xb4, 0x05, 0x49, 0x83, 0x56, 0x1c,
-	0x69, 0xc0, 0xeb, 0xfa, 0x17, 0xce, 0x49, 0x1a, 0x78, 0xba, 0x23, 0x59, 0x83, 0xda, 0x04, 0x6b,
-	0xb0, 0x18, 0x77, 0xe1, 0xc6, 0x82, 0x8c, 0x7f, 0xe1, 0x62, 0x69, 0x83, 0xb4, 0x3c, 0xb5, 0x9b,
-	0xca, 0x64, 0xe4, 0x2c, 0x27, 0x0c, 0x76, 0x53, 0x99, 0x9c, 0x9c, 0x57, 0x9e, 0xa3, 0x69, 0xcd,
-	0x98, 0xdf, 0xa3, 0x45, 0x04, 0xb6, 0x6d, 0xd3, 0x16, 0x04, 0x00, 0x6f, 0x28, 0x37, 0x20, 0x1f,
-	0x74, 0x71, 0xa7, 0x50, 0x0c, 0xb3, 0x50, 0x08, 0x79, 0x35, 0xe5, 0x77, 0x12, 0xe4, 0x83, 0xfe,
-	0x2a, 0x54, 0x80, 0x66, 0x45, 0x01, 0x1a, 0x20, 0x1e, 0x12, 0x61, 0xe2, 0x61, 0x05, 0x72, 0xb4,
-	0x08, 0x1b, 0xe3, 0x14, 0x74, 0xcb, 0xe3, 0x14, 0x6e, 0xc2, 0x1c, 0x8b, 0xa1, 0x9c, 0x9e, 0x10,
-	0x71, 0x2a, 0xc5, 0xe2, 0xd4, 0x2c, 0x1d, 0x60, 0xc6, 0xe0, 0xb5, 0x30, 0x7a, 0x01, 0xe6, 0x03,
-	0x58, 0xaf, 0xb8, 0xe3, 0xe5, 0xb5, 0xec, 0xa1, 0x4b, 0xa2, 0xca, 0xfb, 0xbd, 0x04, 0x73, 0x13,
-	0xee, 0x32, 0x92, 0x37, 0x90, 0xbe, 0x2b, 0xde, 0x20, 0xf1, 0xe4, 0xbc, 0x41, 0xb0, 0x5c, 0x4d,
-	0x86, 0xcb, 0xd5, 0xbf, 0x4b, 0x50, 0x08, 0xb9, 0x6d, 0x7a, 0x08, 0x2d, 0xb3, 0x8d, 0x45, 0x01,
-	0xc9, 0xbe, 0x69, 0x9e, 0xd2, 0x33, 0x8f, 0x44, 0x99, 0x48, 0x3f, 0x29, 0xca, 0x0b, 0x44, 0x59,
-	0x11, 0x66, 0xbc, 0xda, 0x93, 0xe7, 0x02, 0xa2, 0xf6, 0x94, 0x21, 0xf9, 0x08, 0x73, 0x7e, 0x39,
-	0xaf, 0xd2, 0x4f, 0x8a, 0x63, 0xd7, 0x4f, 0xc4, 0x74, 0xde, 0x40, 0xaf, 0x41, 0x96, 0xfd, 0x0a,
-	0xa0, 0x99, 0x96, 0x23, 0x28, 0xe5, 0x40, 0xbe, 0xc3, 0x7f, 0x2a, 0x10, 0xef, 0xdc, 0xec, 0x54,
-	0x2d, 0x47, 0xcd, 0x58, 0xe2, 0x2b, 0x90, 0x85, 0x64, 0x43, 0x59, 0xc8, 0x15, 0xc8, 0xd2, 0xe5,
-	0x3b, 0x96, 0xde, 0xc2, 0x45, 0x60, 0x2b, 0xf5, 0x3b, 0x94, 0xdf, 0x24, 0x60, 0x76, 0x2c, 0xea,
-	0x44, 0x6e, 0xde, 0xbd, 0x95, 0x89, 0x00, 0x2d, 0x72, 0x3e, 0x83, 0x2c, 0x03, 0x1c, 0xe9, 0x8e,
-	0xf6, 0x91, 0x3e, 0x20, 0xb8, 0x2d, 0xac, 0x12, 0xe8, 0x41, 0x4b, 0x90, 0xa1, 0xad, 0xa1, 0x83,
-	0xdb, 0x82, 0xa1, 0xf1, 0xda, 0x68, 0x07, 0xa6, 0xf0, 0x31, 0x1e, 0x10, 0xa7, 0x38, 0xcd, 0x0e,
-	0xfe, 0x52, 0x84, 0x7b, 0xa2, 0xe3, 0x9b, 0x45, 0x7a, 0xdc, 0x7f, 0xfd, 0x72, 0x45, 0xe6, 0xf0,
-	0xe7, 0xcd, 0xbe, 0x41, 0x70, 0xdf, 0x22, 0x27, 0xaa, 0x50, 0x10, 0x36, 0x43, 0x66, 0xcc, 0x0c,
-	0x8c, 0x2e, 0xcc, 0xbb, 0xb5, 0x3f, 0x35, 0xaa, 0x61, 0xda, 0x06, 0x39, 0x51, 0x0b, 0x7d, 0xdc,
-	0xb7, 0x4c, 0xb3, 0xa7, 0xf1, 0x77, 0x5e, 0x82, 0x99, 0x70, 0x90, 0x45, 0x6b, 0x50, 0xb0, 0x31,
-	0xd1, 0x8d, 0x81, 0x16, 0xca, 0x8d, 0xf3, 0xbc, 0x93, 0xbf, 0xab, 0xdd, 0x54, 0x46, 0x92, 0x13,
-	0x82, 0xae, 0x79, 0x17, 0x16, 0x23, 0x63, 0x2c, 0x7a, 0x15, 0xb2, 0x7e, 0x7c, 0x96, 0xd8, 0x76,
-	0x4f, 0xe3, 0x61, 0x7c, 0xb0, 0x72, 0x08, 0x8b, 0x91, 0x41, 0x16, 0xbd, 0x09, 0x53, 0x36, 0x76,
-	0x86, 0x3d, 0x4e, 0xb5, 0xcc, 0xdc, 0xb9, 0x76, 0x76, 0x74, 0x1e, 0xf6, 0x88, 0x2a, 0x84, 0x94,
-	0xdb, 0x70, 0x39, 0x36, 0xca, 0xfa, 0x6c, 0x8a, 0x14, 0x60, 0x53, 0x94, 0xdf, 0x4a, 0xb0, 0x14,
-	0x1f, 0x39, 0xd1, 0xe6, 0xd8, 0x82, 0x6e, 0x9e, 0x33, 0xee, 0x06, 0x56, 0x45, 0xcb, 0x0d, 0x1b,
-	0x77, 0x30, 0x69, 0x75, 0x79, 0x08, 0xe7, 0x4e, 0xa1, 0xa0, 0x16, 0x44, 0x2f, 0x93, 0x71, 0x38,
-	0xec, 0x03, 0xdc, 0x22, 0x1a, 0x3f, 0x54, 0x87, 0xa5, 0xfc, 0x59, 0x0a, 0xa3, 0xbd, 0x75, 0xde,
-	0xa9, 0xdc, 0x82, 0x4b, 0x31, 0xb1, 0x78, 0xb2, 0x2e, 0x51, 0x1e, 0x52, 0x70, 0x64, 0x80, 0x45,
-	0x6f, 0xc3, 0x94, 0x43, 0x74, 0x32, 0x74, 0xc4, 0xce, 0xae, 0x9f, 0x19, 0x9b, 0xeb, 0x0c, 0xae,
-	0x0a, 0x31, 0xe5, 0x75, 0x40, 0x93, 0x91, 0x36, 0xa2, 0xb6, 0x92, 0xa2, 0x6a, 0xab, 0x26, 0x3c,
-	0x75, 0x4a, 0x4c, 0x45, 0xe5, 0xb1, 0xc5, 0xdd, 0x3a, 0x57, 0x48, 0x1e, 0x5b, 0xe0, 0xdf, 0x12,
-	0xb0, 0x18, 0x19, 0x5a, 0x03, 0xaf, 0x54, 0xfa, 0xb6, 0xaf, 0xf4, 0x4d, 0x00, 0x32, 0xd2, 0xf8,
-	0x49, 0xbb, 0xde, 0x3e, 0xaa, 0x9e, 0x18, 0xe1, 0x16, 0x73, 0x58, 0xf4, 0x62, 0x64, 0x89, 0xf8,
-	0xa2, 0xc5, 0x7f, 0xa0, 0x9e, 0x1d, 0xb2, 0x48, 0xe0, 0x88, 0x52, 0xef, 0xdc, 0x31, 0xc3, 0x2f,
-	0x7c, 0x79, 0xb7, 0x83, 0x1e, 0xc2, 0xa5, 0xb1, 0x88, 0xe6, 0xe9, 0x4e, 0x9d, 0x3b, 0xb0, 0x2d,
-	0x86, 0x03, 0x9b, 0xab, 0x3b, 0x18, 0x95, 0xd2, 0xe1, 0xa8, 0xf4, 0x10, 0xc0, 0x2f, 0x6c, 0xe9,
-	0x7b, 0xb3, 0xcd, 0xe1, 0xa0, 0xcd, 0x8e, 0x30, 0xad, 0xf2, 0x06, 0x7a, 0x19, 0xd2, 0xf4, 0x26,
-	0xb8, 0xa6, 0x8a, 0x70, 0x18, 0xf4, 0x48, 0x03, 0x95, 0x31, 0x87, 0x2b, 0x1f, 0xb8, 0xb7, 0x2d,
-	0xc8, 0x31, 0xc6, 0xcc, 0xf1, 0x56, 0x78, 0x0e, 0x25, 0x9e, 0xae, 0x8c, 0x9e, 0xeb, 0x07, 0x90,
-	0x66, 0xc7, 0x4f, 0xa3, 0x03, 0xa3, 0xb8, 0x45, 0x66, 0x43, 0xbf, 0xd1, 0xf7, 0x00, 0x74, 0x42,
-	0x6c, 0xa3, 0x39, 0xf4, 0x67, 0x58, 0x8d, 0xb9, 0x3f, 0x25, 0x17, 0xb8, 0x79, 0x45, 0x5c, 0xa4,
-	0x05, 0x5f, 0x36, 0x70, 0x99, 0x02, 0x1a, 0x95, 0x7d, 0x98, 0x09, 0xcb, 0xba, 0xa1, 0x98, 0x2f,
-	0x22, 0x1c, 0x8a, 0x79, 0x6e, 0x25, 0x42, 0xb1, 0x17, 0xc8, 0x93, 0x9c, 0xc8, 0x67, 0x0d, 0xe5,
-	0x87, 0x09, 0xc8, 0x07, 0x6f, 0xdf, 0xbf, 0x61, 0xb0, 0x54, 0x3e, 0x91, 0x20, 0xe3, 0xed, 0x3f,
-	0x4c, 0xe7, 0x87, 0x7e, 0x07, 0xe1, 0xe6, 0x4b, 0x04, 0x39, 0x78, 0xfe, 0xab, 0x47, 0xd2, 0xfb,
-	0xd5, 0xe3, 0x0d, 0x2f, 0x20, 0xc4, 0x16, 0xf3, 0x41, 0x6b, 0x8b, 0x8b, 0xe5, 0x06, 0xa8, 0xd7,
-	0x21, 0xeb, 0xbd, 0x61, 0x9a, 0x23, 0xbb, 0xc4, 0x87, 0x24, 0x1e, 0x92, 0x20, 0xae, 0x16, 0x20,
-	0x6d, 0x99, 0x1f, 0x09, 0x86, 0x3f, 0xa9, 0xf2, 0x86, 0x82, 0x61, 0x76, 0xcc, 0x01, 0xa0, 0x37,
-	0x60, 0xda, 0x1a, 0x36, 0x35, 0xf7, 0x7a, 0x84, 0xf8, 0xa1, 0x40, 0xee, 0x35, 0x6c, 0xf6, 0x8c,
-	0xd6, 0x3d, 0x7c, 0xe2, 0xae, 0xc6, 0x1a, 0x36, 0xef, 0xf1, 0x6b, 0xc4, 0xa7, 0x49, 0x04, 0xa7,
-	0xf9, 0xa9, 0x04, 0x19, 0xf7, 0x5d, 0xa0, 0xb7, 0x21, 0xeb, 0x79, 0x17, 0x31, 0xc5, 0x53, 0xa7,
-	0xf8, 0x25, 0x31, 0x81, 0x2f, 0x83, 0x36, 0xdd, 0xdf, 0x19, 0x8d, 0xb6, 0xd6, 0xe9, 0xe9, 0x47,
-	0xe2, 0xe7, 0xa2, 0xe5, 0x08, 0x07, 0xc4, 0x7c, 0xf4, 0xce, 0xdd, 0xad, 0x9e, 0x7e, 0xa4, 0xe6,
-	0x98, 0xd0, 0x4e, 0x9b, 0x36, 0x44, 0x1e, 0xf2, 0x8d, 0x04, 0xf2, 0xf8, 0xbb, 0xfd, 0xf6, 0xeb,
-	0x9b, 0x8c, 0x57, 0xc9, 0x88, 0x78, 0x85, 0x36, 0x60, 0xde, 0x43, 0x68, 0x8e, 0x71, 0x34, 0xd0,
-	0xc9, 0xd0, 0xc6, 0x82, 0x54, 0x43, 0xde, 0x50, 0xdd, 0x1d, 0x99, 0xdc, 0x77, 0xfa, 0x49, 0xf7,
-	0xfd, 0x71, 0x02, 0x72, 0x01, 0x8e, 0x0f, 0xfd, 0x77, 0xc0, 0x29, 0xcd, 0x44, 0x45, 0x89, 0x00,
-	0xd8, 0xff, 0xed, 0x2d, 0x6c, 0xa9, 0xc4, 0x13, 0x58, 0x2a, 0x8e, 0x4d, 0x75, 0x49, 0xc3, 0xd4,
-	0x63, 0x93, 0x86, 0xcf, 0x03, 0x22, 0x26, 0xd1, 0x7b, 0xb4, 0x0c, 0x37, 0x06, 0x47, 0x1a, 0xbf,
-	0x8c, 0xdc, 0x87, 0xc8, 0x6c, 0xe4, 0x90, 0x0d, 0xd4, 0xd8, 0xbd, 0xfc, 0x91, 0x04, 0x19, 0x8f,
-	0x7c, 0x79, 0xdc, 0xdf, 0xe4, 0x2e, 0xc2, 0x94, 0xc8, 0xbd, 0xf8, 0x8f, 0x72, 0xa2, 0x15, 0xc9,
-	0x8e, 0x2e, 0x41, 0xa6, 0x8f, 0x89, 0xce, 0x1c, 0x22, 0x8f, 0x70, 0x5e, 0xfb, 0x66, 0x13, 0x72,
-	0x81, 0x9f, 0x35, 0xd1, 0x65, 0x58, 0x2c, 0x6f, 0x57, 0xca, 0xf7, 0xb4, 0xc6, 0x7b, 0x5a, 0xe3,
-	0x41, 0xad, 0xa2, 0x1d, 0xec, 0xdf, 0xdb, 0xaf, 0xfe, 0xef, 0xbe, 0x7c, 0x61, 0x72, 0x48, 0xad,
-	0xb0, 0xb6, 0x2c, 0xa1, 0x4b, 0x30, 0x1f, 0x1e, 0xe2, 0x03, 0x89, 0xa5, 0xd4, 0x4f, 0x7e, 0xb5,
-	0x7c, 0xe1, 0xe6, 0x37, 0x12, 0xcc, 0x47, 0x64, 0xb9, 0xe8, 0x2a, 0x3c, 0x5d, 0xdd, 0xda, 0xaa,
-	0xa8, 0x5a, 0x7d, 0xbf, 0x54, 0xab, 0x6f, 0x57, 0x1b, 0x9a, 0x5a, 0xa9, 0x1f, 0xec, 0x35, 0x02,
-	0x93, 0xae, 0xc2, 0x95, 0x68, 0x48, 0xa9, 0x5c, 0xae, 0xd4, 0x1a, 0xb2, 0x84, 0x56, 0xe0, 0xa9,
-	0x18, 0xc4, 0x66, 0x55, 0x6d, 0xc8, 0x89, 0x78, 0x15, 0x6a, 0x65, 0xb7, 0x52, 0x6e, 0xc8, 0x49,
-	0x74, 0x1d, 0xd6, 0x4e, 0x43, 0x68, 0x5b, 0x55, 0xf5, 0x7e, 0xa9, 0x21, 0xa7, 0xce, 0x04, 0xd6,
-	0x2b, 0xfb, 0x77, 0x2b, 0xaa, 0x9c, 0x16, 0xfb, 0xfe, 0x65, 0x02, 0x8a, 0x71, 0xc9, 0x34, 0xd5,
-	0x55, 0xaa, 0xd5, 0xf6, 0x1e, 0xf8, 0xba, 0xca, 0xdb, 0x07, 0xfb, 0xf7, 0x26, 0x4d, 0xf0, 0x2c,
-	0x28, 0xa7, 0x01, 0x3d, 0x43, 0x5c, 0x83, 0xab, 0xa7, 0xe2, 0x84, 0x39, 0xce, 0x80, 0xa9, 0x95,
-	0x86, 0xfa, 0x40, 0x4e, 0xa2, 0x75, 0xb8, 0x79, 0x26, 0xcc, 0x1b, 0x93, 0x53, 0x68, 0x03, 0x6e,
-	0x9d, 0x8e, 0xe7, 0x06, 0x72, 0x05, 0x5c, 0x13, 0x7d, 0x2a, 0xc1, 0x62, 0x64, 0x56, 0x8e, 0xd6,
-	0x60, 0xa5, 0xa6, 0x56, 0xcb, 0x95, 0x7a, 0x5d, 0xab, 0xa9, 0xd5, 0x5a, 0xb5, 0x5e, 0xda, 0xd3,
-	0xea, 0x8d, 0x52, 0xe3, 0xa0, 0x1e, 0xb0, 0x8d, 0x02, 0xcb, 0x71, 0x20, 0xcf, 0x2e, 0xa7, 0x60,
-	0xc4, 0x0d, 0x70, 0xef, 0xe9, 0x2f, 0x24, 0xb8, 0x1c, 0x9b, 0x85, 0xa3, 0x1b, 0xf0, 0xcc, 0x61,
-	0x45, 0xdd, 0xd9, 0x7a, 0xa0, 0x1d, 0x56, 0x1b, 0x15, 0xad, 0xf2, 0x5e, 0xa3, 0xb2, 0x5f, 0xdf,
-	0xa9, 0xee, 0x4f, 0xae, 0xea, 0x3a, 0xac, 0x9d, 0x8a, 0xf4, 0x96, 0x76, 0x16, 0x70, 0x6c, 0x7d,
-	0x3f, 0x96, 0x60, 0x76, 0xcc, 0x17, 0xa2, 0x2b, 0x50, 0xbc, 0xbf, 0x53, 0xdf, 0xac, 0x6c, 0x97,
-	0x0e, 0x77, 0xaa, 0xea, 0xf8, 0x9b, 0x5d, 0x83, 0x95, 0x89, 0xd1, 0xbb, 0x07, 0xb5, 0xbd, 0x9d,
-	0x72, 0xa9, 0x51, 0x61, 0x93, 0xca, 0x12, 0xdd, 0xd8, 0x04, 0x68, 0x6f, 0xe7, 0x9d, 0xed, 0x86,
-	0x56, 0xde, 0xdb, 0xa9, 0xec, 0x37, 0xb4, 0x52, 0xa3, 0x51, 0xf2, 0x9f, 0xf3, 0xe6, 0xbd, 0xcf,
-	0xbf, 0x5a, 0x96, 0xbe, 0xf8, 0x6a, 0x59, 0xfa, 0xcb, 0x57, 0xcb, 0xd2, 0x67, 0x5f, 0x2f, 0x5f,
-	0xf8, 0xe2, 0xeb, 0xe5, 0x0b, 0x7f, 0xfa, 0x7a, 0xf9, 0xc2, 0xc3, 0xdb, 0x47, 0x06, 0xe9, 0x0e,
-	0x9b, 0xd4, 0x0b, 0x6f, 0xf8, 0xff, 0xae, 0xf4, 0xfe, 0xb5, 0x69, 0x19, 0x1b, 0xe3, 0x7f, 0xe1,
-	0x6c, 0x4e, 0x31, 0xb7, 0xfa, 0xe2, 0x3f, 0x02, 0x00, 0x00, 0xff, 0xff, 0xe3, 0x41, 0x56, 0x8d,
-	0xdd, 0x29, 0x00, 0x00,
+	// 3161 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xec, 0x5a, 0xcd, 0x6f, 0x1b, 0xc7,
+	0xd9, 0xf7, 0x92, 0x94, 0x44, 0x3e, 0x24, 0xa5, 0xd5, 0x48, 0xb2, 0x69, 0xc5, 0x91, 0xe4, 0x75,
+	0x1c, 0x3b, 0x76, 0x22, 0xbd, 0x76, 0xde, 0x37, 0x1f, 0x6f, 0xbe, 0x40, 0xd1, 0x54, 0x24, 0x59,
+	0x16, 0x99, 0x25, 0xa5, 0xc6, 0x46, 0xdb, 0xcd, 0x92, 0x1c, 0x8a, 0x1b, 0x93, 0xdc, 0xcd, 0xee,
+	0x50, 0xa1, 0xda, 0x53, 0x8b, 0x26, 0x28, 0x72, 0xca, 0xa5, 0x97, 0xa2, 0x05, 0x0a, 0x14, 0xbd,
+	0xf6, 0xdc, 0xbf, 0xa0, 0xc8, 0xa5, 0x6d, 0x8e, 0x3d, 0xa5, 0x45, 0x72, 0xeb, 0xa1, 0xb7, 0x00,
+	0x3d, 0x16, 0xf3, 0xb1, 0x5f, 0xdc, 0x5d, 0xc9, 0x76, 0xd2, 0x43, 0xd1, 0xde, 0x38, 0x33, 0xbf,
+	0xe7, 0x99, 0x99, 0x67, 0x66, 0x9e, 0x8f, 0xdf, 0x12, 0x2e, 0xb5, 0xcd, 0x01, 0x26, 0xad, 0x2e,
+	0xd9, 0xd0, 0x5b, 0x6d, 0x63, 0xe3, 0xf8, 0xd6, 0x06, 0x39, 0xb1, 0xb0, 0xb3, 0x6e, 0xd9, 0x26,
+	0x31, 0x91, 0xec, 0x8e, 0xae, 0xd3, 0xd1, 0xf5, 0xe3, 0x5b, 0xcb, 0x4f, 0x7b, 0xf8, 0xb6, 0x7d,
+	0x62, 0x11, 0x93, 0x4a, 0x3c, 0xc4, 0x27, 0x42, 0x60, 0x79, 0x25, 0x66, 0xd8, 0xb2, 0x4d, 0xb3,
+	0x1b, 0x19, 0x67, 0xd3, 0xb0, 0x61, 0xdd, 0xd6, 0x07, 0xae, 0xfc, 0xe5, 0xe8, 0xf8, 0xb1, 0xde,
+	0x37, 0x3a, 0x3a, 0x31, 0x6d, 0x01, 0x59, 0x3c, 0x32, 0x8f, 0x4c, 0xf6, 0x73, 0x83, 0xfe, 0x12,
+	0xbd, 0xab, 0x47, 0xa6, 0x79, 0xd4, 0xc7, 0x1b, 0xac, 0xd5, 0x1a, 0x75, 0x37, 0x88, 0x31, 0xc0,
+	0x0e, 0xd1, 0x07, 0x16, 0x07, 0x28, 0x7f, 0xca, 0xc1, 0x8c, 0x8a, 0x3f, 0x18, 0x61, 0x87, 0xa0,
+	0x17, 0x21, 0x83, 0xdb, 0x3d, 0xb3, 0x24, 0xad, 0x49, 0xd7, 0xf3, 0xb7, 0x9f, 0x5e, 0x9f, 0xdc,
+	0xe5, 0x7a, 0xb5, 0xdd, 0x33, 0x05, 0x78, 0xfb, 0x9c, 0xca, 0xc0, 0xe8, 0x25, 0x98, 0xea, 0xf6,
+	0x47, 0x4e, 0xaf, 0x94, 0x62, 0x52, 0x2b, 0x51, 0xa9, 0x2d, 0x3a, 0xec, 0x8b, 0x71, 0x38, 0x9d,
+	0xcc, 0x18, 0x76, 0xcd, 0x52, 0x3a, 0x69, 0xb2, 0x9d, 0x61, 0x37, 0x38, 0x19, 0x05, 0xa3, 0x0a,
+	0x80, 0x31, 0x34, 0x88, 0xd6, 0xee, 0xe9, 0xc6, 0xb0, 0x34, 0xc5, 0x44, 0x95, 0x38, 0x51, 0x83,
+	0x54, 0x28, 0xc4, 0x97, 0xcf, 0x19, 0x6e, 0x1f, 0x5d, 0xf1, 0x07, 0x23, 0x6c, 0x9f, 0x94, 0xa6,
+	0x93, 0x56, 0xfc, 0x0e, 0x1d, 0x0e, 0xac, 0x98, 0xc1, 0xd1, 0x1b, 0x90, 0x6d, 0xf7, 0x70, 0xfb,
+	0xa1, 0x46, 0xc6, 0xa5, 0x2c, 0x13, 0x5d, 0x8b, 0x8a, 0x56, 0x28, 0xa2, 0x39, 0xf6, 0x85, 0x67,
+	0xda, 0xbc, 0x07, 0xbd, 0x0a, 0xd3, 0x6d, 0x73, 0x30, 0x30, 0x48, 0x29, 0xcf, 0x84, 0x57, 0x63,
+	0x84, 0xd9, 0xb8, 0x2f, 0x2b, 0x04, 0x50, 0x0d, 0x66, 0xfb, 0x86, 0x43, 0x34, 0x67, 0xa8, 0x5b,
+	0x4e, 0xcf, 0x24, 0x4e, 0xa9, 0xc0, 0x54, 0x3c, 0x1b, 0x55, 0xb1, 0x67, 0x38, 0xa4, 0xe1, 0xc2,
+	0x7c, 0x4d, 0xc5, 0x7e, 0xb0, 0x9f, 0x2a, 0x34, 0xbb, 0x5d, 0x6c, 0x7b, 0x1a, 0x4b, 0xc5, 0x24,
+	0x85, 0x35, 0x8a, 0x73, 0x25, 0x03, 0x0a, 0xcd, 0x60, 0x3f, 0xfa, 0x2e, 0x2c, 0xf4, 0x4d, 0xbd,
+	0xe3, 0xe9, 0xd3, 0xda, 0xbd, 0xd1, 0xf0, 0x61, 0x69, 0x96, 0x69, 0xbd, 0x11, 0xb3, 0x4c, 0x53,
+	0xef, 0xb8, 0xc2, 0x15, 0x0a, 0xf5, 0x35, 0xcf, 0xf7, 0x27, 0xc7, 0x90, 0x06, 0x8b, 0xba, 0x65,
+	0xf5, 0x4f, 0x26, 0xd5, 0xcf, 0x31, 0xf5, 0x37, 0xa3, 0xea, 0xcb, 0x14, 0x9d, 0xa0, 0x1f, 0xe9,
+	0x91, 0x41, 0x74, 0x00, 0xb2, 0x65, 0x63, 0x4b, 0xb7, 0xb1, 0x66, 0xd9, 0xa6, 0x65, 0x3a, 0x7a,
+	0xbf, 0x24, 0x33, 0xe5, 0xd7, 0xa3, 0xca, 0xeb, 0x1c, 0x59, 0x17, 0x40, 0x5f, 0xf3, 0x9c, 0x15,
+	0x1e, 0xe1, 0x6a, 0xcd, 0x36, 0x76, 0x1c, 0x5f, 0xed, 0x7c, 0xb2, 0x5a, 0x86, 0x8c, 0x55, 0x1b,
+	0x1a, 0x41, 0x5b, 0x90, 0xc7, 0x63, 0x82, 0x87, 0x1d, 0xed, 0xd8, 0x24, 0xb8, 0x84, 0x98, 0xc6,
+	0x2b, 0x31, 0xcf, 0x95, 0x81, 0x0e, 0x4d, 0x82, 0x7d, 0x65, 0x80, 0xbd, 0x4e, 0xd4, 0x82, 0xa5,
+	0x63, 0x6c, 0x1b, 0xdd, 0x13, 0xa6, 0x47, 0x63, 0x23, 0x8e, 0x61, 0x0e, 0x4b, 0x0b, 0x4c, 0xe3,
+	0xf3, 0x51, 0x8d, 0x87, 0x0c, 0x4e, 0x85, 0xab, 0x2e, 0xd8, 0x57, 0xbd, 0x70, 0x1c, 0x1d, 0xa5,
+	0x37, 0xad, 0x6b, 0x0c, 0xf5, 0xbe, 0xf1, 0x03, 0xac, 0xb5, 0xfa, 0x66, 0xfb, 0x61, 0x69, 0x31,
+	0xe9, 0xa6, 0x6d, 0x09, 0xdc, 0x26, 0x85, 0x05, 0x6e, 0x5a, 0x37, 0xd8, 0xbf, 0x39, 0x03, 0x53,
+	0xc7, 0x7a, 0x7f, 0x84, 0x77, 0x33, 0xd9, 0x8c, 0x3c, 0xb5, 0x9b, 0xc9, 0xce, 0xc8, 0xd9, 0xdd,
+	0x4c, 0x36, 0x27, 0xc3, 0x6e, 0x26, 0x0b, 0x72, 0x5e, 0xb9, 0x06, 0xf9, 0x80, 0x9f, 0x42, 0x25,
+	0x98, 0x19, 0x60, 0xc7, 0xd1, 0x8f, 0x30, 0xf3, 0x6b, 0x39, 0xd5, 0x6d, 0x2a, 0xb3, 0x50, 0x08,
+	0xba, 0x26, 0xe5, 0x53, 0x09, 0xf2, 0x01, 0xa7, 0x43, 0x25, 0x8f, 0xb1, 0xcd, 0x0c, 0x22, 0x24,
+	0x45, 0x13, 0x5d, 0x81, 0x22, 0xdb, 0x8b, 0xe6, 0x8e, 0x53, 0xdf, 0x97, 0x51, 0x0b, 0xac, 0xf3,
+	0x50, 0x80, 0x56, 0x21, 0x6f, 0xdd, 0xb6, 0x3c, 0x48, 0x9a, 0x41, 0xc0, 0xba, 0x6d, 0xb9, 0x80,
+	0xcb, 0x50, 0xa0, 0x5b, 0xf7, 0x10, 0x19, 0x36, 0x49, 0x9e, 0xf6, 0x09, 0x88, 0xf2, 0xc7, 0x14,
+	0xc8, 0x93, 0xce, 0x0c, 0xbd, 0x02, 0x19, 0xea, 0xc5, 0x85, 0x9b, 0x5e, 0x5e, 0xe7, 0x2e, 0x7e,
+	0xdd, 0x75, 0xf1, 0xeb, 0x4d, 0xd7, 0xc5, 0x6f, 0x66, 0x3f, 0xfb, 0x62, 0xf5, 0xdc, 0xa7, 0x7f,
+	0x59, 0x95, 0x54, 0x26, 0x81, 0x2e, 0x52, 0x0f, 0xa6, 0x1b, 0x43, 0xcd, 0xe8, 0xb0, 0x25, 0xe7,
+	0xa8, 0x77, 0xd2, 0x8d, 0xe1, 0x4e, 0x07, 0xdd, 0x03, 0xb9, 0x6d, 0x0e, 0x1d, 0x3c, 0x74, 0x46,
+	0x8e, 0xc6, 0x63, 0x4f, 0x29, 0x3d, 0xe9, 0x5f, 0x79, 0x0c, 0x64, 0x8e, 0x4a, 0x40, 0xeb, 0x0c,
+	0xa9, 0xce, 0xb5, 0xc3, 0x1d, 0xe8, 0x6d, 0x00, 0x2f, 0x40, 0x39, 0xa5, 0xcc, 0x5a, 0xfa, 0x7a,
+	0xfe, 0xf6, 0xe5, 0x98, 0xfb, 0xe4, 0x62, 0x0e, 0xac, 0x8e, 0x4e, 0xf0, 0x66, 0x86, 0x2e, 0x58,
+	0x0d, 0x88, 0xa2, 0x67, 0x61, 0x4e, 0xb7, 0x2c, 0xcd, 0x21, 0x3a, 0xc1, 0x5a, 0xeb, 0x84, 0x60,
+	0x87, 0xb9, 0xfd, 0x82, 0x5a, 0xd4, 0x2d, 0xab, 0x41, 0x7b, 0x37, 0x69, 0x27, 0xba, 0x0a, 0xb3,
+	0xd4, 0xc3, 0x1b, 0x7a, 0x5f, 0xeb, 0x61, 0xe3, 0xa8, 0x47, 0x98, 0x77, 0x4f, 0xab, 0x45, 0xd1,
+	0xbb, 0xcd, 0x3a, 0x95, 0x0e, 0x14, 0x82, 0xce, 0x1d, 0x21, 0xc8, 0x74, 0x74, 0xa2, 0x33, 0x5b,
+	0x16, 0x54, 0xf6, 0x9b, 0xf6, 0x59, 0x3a, 0xe9, 0x09, 0x0b, 0xb1, 0xdf, 0xe8, 0x3c, 0x4c, 0x0b,
+	0xb5, 0x69, 0xa6, 0x56, 0xb4, 0xd0, 0x22, 0x4c, 0x59, 0xb6, 0x79, 0x8c, 0xd9, 0xe1, 0x65, 0x55,
+	0xde, 0x50, 0xee, 0xc3, 0x6c, 0x38, 0x0e, 0xa0, 0x59, 0x48, 0x91, 0xb1, 0x98, 0x25, 0x45, 0xc6,
+	0xe8, 0x16, 0x64, 0xa8, 0x31, 0x99, 0xb6, 0xd9, 0xb8, 0xe8, 0x27, 0xe4, 0x9b, 0x27, 0x16, 0x56,
+	0x19, 0x74, 0x37, 0x93, 0x4d, 0xc9, 0x69, 0x65, 0x0e, 0x8a, 0xa1, 0x28, 0xa1, 0x9c, 0x87, 0xc5,
+	0x38, 0x9f, 0xaf, 0x18, 0xb0, 0x18, 0xe7, 0xba, 0xd1, 0x4b, 0x90, 0xf5, 0x9c, 0xbe, 0x7b, 0x83,
+	0x22, 0xb3, 0x7b, 0x42, 0x1e, 0x96, 0xde, 0x1d, 0x7a, 0x10, 0x3d, 0x5d, 0x84, 0xfa, 0x82, 0x3a,
+	0xa3, 0x5b, 0xd6, 0xb6, 0xee, 0xf4, 0x94, 0xf7, 0xa0, 0x94, 0xe4, 0xcf, 0x03, 0x86, 0x93, 0xd8,
+	0x03, 0x70, 0x0d, 0x77, 0x1e, 0xa6, 0xbb, 0xa6, 0x3d, 0xd0, 0x09, 0x53, 0x56, 0x54, 0x45, 0x8b,
+	0x1a, 0x94, 0xfb, 0xf6, 0x34, 0xeb, 0xe6, 0x0d, 0x45, 0x83, 0x8b, 0x89, 0x2e, 0x9d, 0x8a, 0x18,
+	0xc3, 0x0e, 0xe6, 0xe6, 0x2d, 0xaa, 0xbc, 0xe1, 0x2b, 0xe2, 0x8b, 0xe5, 0x0d, 0x3a, 0xad, 0x83,
+	0x87, 0x1d, 0x6c, 0x33, 0xfd, 0x39, 0x55, 0xb4, 0x94, 0x9f, 0xa7, 0xe1, 0x7c, 0xbc, 0x5f, 0x47,
+	0x6b, 0x50, 0x18, 0xe8, 0x63, 0x8d, 0x8c, 0xc5, 0xf5, 0x93, 0xd8, 0x05, 0x80, 0x81, 0x3e, 0x6e,
+	0x8e, 0xf9, 0xdd, 0x93, 0x21, 0x4d, 0xc6, 0x4e, 0x29, 0xb5, 0x96, 0xbe, 0x5e, 0x50, 0xe9, 0x4f,
+	0x74, 0x08, 0xf3, 0x7d, 0xb3, 0xad, 0xf7, 0xb5, 0xbe, 0xee, 0x10, 0x4d, 0x84, 0x7d, 0xfe, 0x9c,
+	0x9e, 0x49, 0xf2, 0xd3, 0xb8, 0xc3, 0x0f, 0x96, 0xba, 0x20, 0xf1, 0x10, 0xe6, 0x98, 0x92, 0x3d,
+	0xdd, 0x21, 0x7c, 0x08, 0x55, 0x21, 0x3f, 0x30, 0x9c, 0x16, 0xee, 0xe9, 0xc7, 0x86, 0x69, 0x8b,
+	0x77, 0x15, 0x73, 0x7b, 0xee, 0xf9, 0x20, 0xa1, 0x2a, 0x28, 0x17, 0x38, 0x94, 0xa9, 0xd0, 0x6d,
+	0x76, 0x3d, 0xcb, 0xf4, 0x63, 0x7b, 0x96, 0xff, 0x81, 0xc5, 0x21, 0x1e, 0x13, 0xcd, 0x7f, 0xb9,
+	0xfc, 0xa6, 0xcc, 0x30, 0xe3, 0x23, 0x3a, 0xe6, 0xbd, 0x75, 0x87, 0x5e, 0x1a, 0xf4, 0x1c, 0x8b,
+	0x8d, 0x96, 0xe9, 0x60, 0x5b, 0xd3, 0x3b, 0x1d, 0x1b, 0x3b, 0x0e, 0xcb, 0xaa, 0x0a, 0xea, 0x9c,
+	0xdb, 0x5f, 0xe6, 0xdd, 0xca, 0x27, 0xec, 0x70, 0xe2, 0xa2, 0xa3, 0x6b, 0x7a, 0xc9, 0x37, 0x7d,
+	0x13, 0x16, 0x85, 0x7c, 0x27, 0x64, 0x7d, 0x9e, 0x9e, 0x5e, 0x4a, 0x4a, 0xba, 0x02, 0x56, 0x47,
+	0xae, 0x7c, 0xb2, 0xe1, 0xd3, 0x4f, 0x68, 0x78, 0x04, 0x19, 0x66, 0x96, 0x0c, 0x77, 0x37, 0xf4,
+	0xf7, 0xbf, 0xdb, 0x61, 0x7c, 0x94, 0x86, 0xf9, 0x48, 0x62, 0xe1, 0x6d, 0x4c, 0x8a, 0xdd, 0x58,
+	0x2a, 0x76, 0x63, 0xe9, 0xc7, 0xde, 0x98, 0x38, 0xed, 0xcc, 0xd9, 0xa7, 0x3d, 0xf5, 0x6d, 0x9e,
+	0xf6, 0xf4, 0x13, 0x9e, 0xf6, 0xbf, 0xf4, 0x1c, 0x7e, 0x21, 0xc1, 0x72, 0x72, 0x3a, 0x16, 0x7b,
+	0x20, 0x37, 0x61, 0xde, 0x5b, 0x8a, 0xa7, 0x9e, 0xbb, 0x47, 0xd9, 0x1b, 0x10, 0xfa, 0x13, 0x23,
+	0xde, 0x55, 0x98, 0x9d, 0xc8, 0x16, 0xf9, 0x65, 0x2e, 0x1e, 0x07, 0x97, 0xa1, 0x7c, 0x9c, 0x86,
+	0xc5, 0xb8, 0x84, 0x2e, 0xe6, 0xc5, 0xaa, 0xb0, 0xd0, 0xc1, 0x6d, 0xa3, 0xf3, 0xc4, 0x0f, 0x76,
+	0x5e, 0x88, 0xff, 0xf7, 0xbd, 0xc6, 0xdc, 0x93, 0xdf, 0x00, 0x64, 0x55, 0xec, 0x58, 0xe6, 0xd0,
+	0xc1, 0xa8, 0x02, 0x39, 0x3c, 0x6e, 0x63, 0x8b, 0xb8, 0x49, 0x6d, 0x42, 0xdd, 0x20, 0x20, 0xae,
+	0x1c, 0xad, 0x9f, 0x3d, 0x39, 0xf4, 0xbf, 0x82, 0x26, 0x48, 0x2c, 0xf8, 0x79, 0xfa, 0xed, 0x89,
+	0x32, 0x34, 0x7a, 0xd9, 0xe5, 0x09, 0xd2, 0x49, 0xd5, 0xaf, 0x48, 0xc6, 0x3d, 0x39, 0x8e, 0xa7,
+	0xd3, 0x31, 0xa2, 0x20, 0x93, 0x34, 0x1d, 0xcf, 0xd9, 0xfd, 0xe9, 0x28, 0x1a, 0xdd, 0x09, 0x31,
+	0x05, 0xd3, 0x49, 0x5b, 0x0d, 0x24, 0xd7, 0xfe, 0x56, 0x7d, 0xaa, 0xe0, 0x65, 0x97, 0x2a, 0x98,
+	0x49, 0x5a, 0xb4, 0xc8, 0x26, 0xfd, 0x45, 0x33, 0x3c, 0x7a, 0x33, 0xc0, 0x15, 0xe4, 0xd6, 0xa4,
+	0xf8, 0xec, 0xd7, 0xcb, 0x11, 0x3d, 0x69, 0x8f, 0x2c, 0xf8, 0x7f, 0x8f, 0x2c, 0x28, 0x24, 0x32,
+	0x0d, 0x22, 0x0d, 0xf4, 0x84, 0x85, 0x04, 0xaa, 0x47, 0xd8, 0x02, 0x5e, 0xdc, 0x5f, 0x3b, 0x93,
+	0x2d, 0xf0, 0x54, 0x4d, 0xd0, 0x05, 0xf5, 0x08, 0x5d, 0x30, 0x9b, 0xa4, 0x71, 0x22, 0xe7, 0xf4,
+	0x35, 0x86, 0xf9, 0x82, 0xef, 0xc5, 0xf3, 0x05, 0x89, 0x05, 0x7d, 0x4c, 0x7e, 0xe9, 0xa9, 0x8e,
+	0x21, 0x0c, 0xde, 0x4b, 0x20, 0x0c, 0xe4, 0xa4, 0xc2, 0x36, 0x2e, 0xbb, 0xf4, 0x26, 0x88, 0x63,
+	0x0c, 0x0e, 0x63, 0x18, 0x03, 0x5e, 0xda, 0x3f, 0xf7, 0x08, 0x8c, 0x81, 0xa7, 0x3a, 0x42, 0x19,
+	0x1c, 0xc6, 0x50, 0x06, 0x28, 0x59, 0xef, 0x44, 0x52, 0x14, 0xd4, 0x1b, 0x1a, 0x42, 0x6f, 0x87,
+	0x39, 0x83, 0x85, 0xd3, 0x73, 0x51, 0x1e, 0xda, 0x3d, 0x6d, 0x41, 0xd2, 0xa0, 0x9d, 0x44, 0x1a,
+	0xf0, 0xba, 0xfe, 0x85, 0x47, 0x24, 0x0d, 0x3c, 0xdd, 0xb1, 0xac, 0x41, 0x3d, 0xc2, 0x1a, 0x2c,
+	0x25, 0x5d, 0xb8, 0x89, 0x20, 0xe3, 0x5f, 0xb8, 0x44, 0xda, 0x60, 0x4a, 0x9e, 0xde, 0xcd, 0x64,
+	0xb3, 0x72, 0x8e, 0x13, 0x06, 0xbb, 0x99, 0x6c, 0x5e, 0x2e, 0x28, 0xcf, 0xd1, 0xb4, 0x66, 0xc2,
+	0xef, 0xd1, 0x22, 0x02, 0xdb, 0xb6, 0x69, 0x0b, 0x02, 0x80, 0x37, 0x94, 0xeb, 0x50, 0x08, 0xba,
+	0xb8, 0x53, 0x28, 0x86, 0x39, 0x28, 0x86, 0xbc, 0x9a, 0xf2, 0x3b, 0x09, 0x0a, 0x41, 0x7f, 0x15,
+	0x2a, 0x40, 0x73, 0xa2, 0x00, 0x0d, 0x10, 0x0f, 0xa9, 0x30, 0xf1, 0xb0, 0x0a, 0x79, 0x5a, 0x84,
+	0x4d, 0x70, 0x0a, 0xba, 0xe5, 0x71, 0x0a, 0x37, 0x60, 0x9e, 0xc5, 0x50, 0x4e, 0x4f, 0x88, 0x38,
+	0x95, 0x61, 0x71, 0x6a, 0x8e, 0x0e, 0x30, 0x63, 0xf0, 0x5a, 0x18, 0xbd, 0x00, 0x0b, 0x01, 0xac,
+	0x57, 0xdc, 0xf1, 0xf2, 0x5a, 0xf6, 0xd0, 0x65, 0x51, 0xe5, 0xfd, 0x5e, 0x82, 0xf9, 0x88, 0xbb,
+	0x8c, 0xe5, 0x0d, 0xa4, 0x6f, 0x8b, 0x37, 0x48, 0x3d, 0x39, 0x6f, 0x10, 0x2c, 0x57, 0xd3, 0xe1,
+	0x72, 0xf5, 0x1f, 0x12, 0x14, 0x43, 0x6e, 0x9b, 0x1e, 0x42, 0xdb, 0xec, 0x60, 0x51, 0x40, 0xb2,
+	0xdf, 0x34, 0x4f, 0xe9, 0x9b, 0x47, 0xa2, 0x4c, 0xa4, 0x3f, 0x29, 0xca, 0x0b, 0x44, 0x39, 0x11,
+	0x66, 0xbc, 0xda, 0x93, 0xe7, 0x02, 0xbc, 0x41, 0x65, 0x1f, 0x62, 0xce, 0x2f, 0x17, 0x54, 0xfa,
+	0x13, 0x2d, 0x8a, 0xeb, 0x27, 0x62, 0x3a, 0x6f, 0xa0, 0x57, 0x21, 0xc7, 0xbe, 0x02, 0x68, 0xa6,
+	0xe5, 0x94, 0xb2, 0x93, 0xf9, 0x0e, 0xff, 0x54, 0x20, 0xde, 0xb9, 0xd9, 0xad, 0x59, 0x8e, 0x9a,
+	0xb5, 0xc4, 0xaf, 0x40, 0x16, 0x92, 0x0b, 0x65, 0x21, 0x97, 0x20, 0x47, 0x97, 0xef, 0x58, 0x7a,
+	0x1b, 0x97, 0x80, 0xad, 0xd4, 0xef, 0x50, 0xfe, 0x90, 0x82, 0xb9, 0x89, 0xa8, 0x13, 0xbb, 0x79,
+	0xf7, 0x56, 0xa6, 0x02, 0xb4, 0xc8, 0xa3, 0x19, 0x64, 0x05, 0xe0, 0x48, 0x77, 0xb4, 0x0f, 0xf5,
+	0x21, 0xc1, 0x1d, 0x61, 0x95, 0x40, 0x0f, 0x5a, 0x86, 0x2c, 0x6d, 0x8d, 0x1c, 0xdc, 0x11, 0x0c,
+	0x8d, 0xd7, 0x46, 0x3b, 0x30, 0x8d, 0x8f, 0xf1, 0x90, 0x38, 0xa5, 0x19, 0x76, 0xf0, 0x17, 0x62,
+	0xdc, 0x13, 0x1d, 0xdf, 0x2c, 0xd1, 0xe3, 0xfe, 0xdb, 0x17, 0xab, 0x32, 0x87, 0x3f, 0x6f, 0x0e,
+	0x0c, 0x82, 0x07, 0x16, 0x39, 0x51, 0x85, 0x82, 0xb0, 0x19, 0xb2, 0x13, 0x66, 0xa0, 0x8b, 0xb0,
+	0x6c, 0xc3, 0xb4, 0x0d, 0x72, 0xc2, 0xe2, 0x6b, 0x5a, 0xf5, 0xda, 0x01, 0x86, 0xa0, 0x18, 0x64,
+	0x08, 0x18, 0xc5, 0x58, 0x50, 0x8b, 0x03, 0x3c, 0xb0, 0x4c, 0xb3, 0xaf, 0x71, 0x4f, 0x50, 0x86,
+	0xd9, 0x70, 0x18, 0xa6, 0xd4, 0xa0, 0x8d, 0x09, 0xe5, 0xd8, 0x42, 0xd9, 0x73, 0x81, 0x77, 0xf2,
+	0x97, 0xb7, 0x9b, 0xc9, 0x4a, 0x72, 0x4a, 0x10, 0x3a, 0xef, 0xc0, 0x52, 0x6c, 0x14, 0x46, 0xaf,
+	0x40, 0xce, 0x8f, 0xe0, 0xd2, 0x5a, 0xfa, 0x0c, 0xa6, 0xc6, 0x07, 0x2b, 0x87, 0xb0, 0x14, 0x1b,
+	0x86, 0xd1, 0x1b, 0x30, 0x6d, 0x63, 0x67, 0xd4, 0xe7, 0x64, 0xcc, 0xec, 0xed, 0xab, 0x67, 0xc7,
+	0xef, 0x51, 0x9f, 0xa8, 0x42, 0x48, 0xb9, 0x05, 0x17, 0x13, 0xe3, 0xb0, 0xcf, 0xb7, 0x48, 0x01,
+	0xbe, 0x45, 0xf9, 0xad, 0x04, 0xcb, 0xc9, 0xb1, 0x15, 0x6d, 0x4e, 0x2c, 0xe8, 0xc6, 0x23, 0x46,
+	0xe6, 0xc0, 0xaa, 0x68, 0x41, 0x62, 0xe3, 0x2e, 0x26, 0xed, 0x1e, 0x0f, 0xf2, 0xdc, 0x6d, 0x14,
+	0xd5, 0xa2, 0xe8, 0x65, 0x32, 0x0e, 0x87, 0xbd, 0x8f, 0xdb, 0x44, 0xe3, 0x07, 0xea, 0xb0, 0xa2,
+	0x20, 0xa7, 0x16, 0x79, 0x6f, 0x83, 0x77, 0x2a, 0x37, 0xe1, 0x42, 0x42, 0xb4, 0x8e, 0x56, 0x2e,
+	0xca, 0x03, 0x0a, 0x8e, 0x0d, 0xc1, 0xe8, 0x2d, 0x98, 0x76, 0x88, 0x4e, 0x46, 0x8e, 0xd8, 0xd9,
+	0xb5, 0x33, 0xa3, 0x77, 0x83, 0xc1, 0x55, 0x21, 0xa6, 0xbc, 0x06, 0x28, 0x1a, 0x8b, 0x63, 0xaa,
+	0x2f, 0x29, 0xae, 0xfa, 0x6a, 0xc1, 0x53, 0xa7, 0x44, 0x5d, 0x54, 0x99, 0x58, 0xdc, 0xcd, 0x47,
+	0x0a, 0xda, 0x13, 0x0b, 0xfc, 0x7b, 0x0a, 0x96, 0x62, 0x83, 0x6f, 0xe0, 0x1d, 0x4b, 0xdf, 0xf4,
+	0x1d, 0xbf, 0x01, 0x40, 0xc6, 0x1a, 0x3f, 0x69, 0x37, 0x1e, 0xc4, 0x55, 0x1c, 0x63, 0xdc, 0x6e,
+	0x8e, 0xc5, 0xc5, 0xc8, 0x11, 0xf1, 0x8b, 0xd2, 0x03, 0x81, 0x8a, 0x77, 0xc4, 0x62, 0x85, 0x53,
+	0x4a, 0x3f, 0x5e, 0x54, 0x91, 0x8f, 0xc3, 0xdd, 0x0e, 0x7a, 0x00, 0x17, 0x26, 0x62, 0x9e, 0xa7,
+	0x3b, 0xf3, 0xc8, 0xa1, 0x6f, 0x29, 0x1c, 0xfa, 0x5c, 0xdd, 0xc1, 0xb8, 0x35, 0x15, 0x8e, 0x5b,
+	0x0f, 0x00, 0xfc, 0xd2, 0x97, 0xbe, 0x37, 0xdb, 0x1c, 0x0d, 0x3b, 0xec, 0x08, 0xa7, 0x54, 0xde,
+	0xa0, 0xdf, 0x36, 0xe9, 0x4d, 0x70, 0x4d, 0x15, 0xe3, 0x30, 0xe8, 0x91, 0x06, 0x6a, 0x67, 0x0e,
+	0x57, 0xde, 0x07, 0x14, 0x65, 0x21, 0x13, 0xe6, 0x78, 0x33, 0x3c, 0x87, 0x92, 0x4c, 0x68, 0xc6,
+	0xcf, 0xf5, 0x43, 0x98, 0x62, 0xc7, 0x4f, 0xe3, 0x07, 0x23, 0xc1, 0x45, 0xee, 0x43, 0x7f, 0xa3,
+	0xef, 0x03, 0xe8, 0x84, 0xd8, 0x46, 0x6b, 0xe4, 0xcf, 0xb0, 0x96, 0x70, 0x7f, 0xca, 0x2e, 0x70,
+	0xf3, 0x92, 0xb8, 0x48, 0x8b, 0xbe, 0x6c, 0xe0, 0x32, 0x05, 0x34, 0x2a, 0xfb, 0x30, 0x1b, 0x96,
+	0x75, 0x83, 0x35, 0x5f, 0x44, 0x38, 0x58, 0xf3, 0xec, 0x8b, 0x37, 0xfc, 0x50, 0x9f, 0xe6, 0x54,
+	0x3f, 0x6b, 0x28, 0x3f, 0x4a, 0x41, 0x21, 0x78, 0xfb, 0xfe, 0x03, 0xc3, 0xa9, 0xf2, 0xb1, 0x04,
+	0x59, 0x6f, 0xff, 0x61, 0xc2, 0x3f, 0xf4, 0xa5, 0x84, 0x9b, 0x2f, 0x15, 0x64, 0xe9, 0xf9, 0x77,
+	0x91, 0xb4, 0xf7, 0x5d, 0xe4, 0x75, 0x2f, 0x20, 0x24, 0x96, 0xfb, 0x41, 0x6b, 0x8b, 0x8b, 0xe5,
+	0x06, 0xa8, 0xd7, 0x20, 0xe7, 0xbd, 0x61, 0x9a, 0x45, 0xbb, 0xd4, 0x88, 0x24, 0x1e, 0x12, 0x6f,
+	0xd2, 0xa5, 0x58, 0xe6, 0x87, 0xe2, 0x1b, 0x40, 0x5a, 0xe5, 0x0d, 0xe5, 0x67, 0x12, 0xcc, 0x4d,
+	0x78, 0x00, 0xf4, 0x3a, 0xcc, 0x58, 0xa3, 0x96, 0xe6, 0xde, 0x8f, 0x10, 0x85, 0x14, 0x48, 0xcf,
+	0x46, 0xad, 0xbe, 0xd1, 0xbe, 0x8b, 0x4f, 0xdc, 0xe5, 0x58, 0xa3, 0xd6, 0x5d, 0x7e, 0x8f, 0xf8,
+	0x3c, 0xa9, 0xc0, 0x3c, 0x68, 0x1d, 0x16, 0x44, 0xd2, 0xd7, 0xd5, 0x2c, 0xd3, 0x71, 0xb0, 0xe3,
+	0xe5, 0xf2, 0x05, 0x75, 0x9e, 0x27, 0x78, 0xdd, 0xba, 0x37, 0x40, 0xd7, 0x95, 0x75, 0x1f, 0x12,
+	0x7a, 0x0b, 0x72, 0x9e, 0x3b, 0x12, 0x4b, 0x7a, 0xea, 0x14, 0x47, 0x26, 0x16, 0xe4, 0xcb, 0xa0,
+	0x4d, 0xf7, 0xd3, 0xa5, 0xd1, 0xd1, 0xba, 0x7d, 0xfd, 0x48, 0x7c, 0x81, 0x5a, 0x89, 0xf1, 0x58,
+	0xcc, 0xa9, 0xef, 0xdc, 0xd9, 0xea, 0xeb, 0x47, 0x6a, 0x9e, 0x09, 0xed, 0x74, 0x68, 0x43, 0x24,
+	0x2e, 0x5f, 0x4b, 0x20, 0x4f, 0x3e, 0xf4, 0x6f, 0xbe, 0xbe, 0x68, 0x80, 0x4b, 0xc7, 0x04, 0x38,
+	0xb4, 0x01, 0x0b, 0x1e, 0x42, 0x73, 0x8c, 0xa3, 0xa1, 0x4e, 0x46, 0x36, 0x16, 0x3c, 0x1d, 0xf2,
+	0x86, 0x1a, 0xee, 0x48, 0x74, 0xdf, 0x53, 0x4f, 0xba, 0xef, 0x8f, 0x52, 0x90, 0x0f, 0xd0, 0x86,
+	0xe8, 0xff, 0x02, 0x5e, 0x6c, 0x36, 0x2e, 0xac, 0x04, 0xc0, 0xfe, 0xe7, 0xbc, 0xb0, 0xa5, 0x52,
+	0x4f, 0x60, 0xa9, 0x24, 0x82, 0xd6, 0xe5, 0x21, 0x33, 0x8f, 0xcd, 0x43, 0x3e, 0x0f, 0x88, 0x98,
+	0x44, 0xef, 0xd3, 0xca, 0xde, 0x18, 0x1e, 0x69, 0xfc, 0xf2, 0x72, 0xa7, 0x23, 0xb3, 0x91, 0x43,
+	0x36, 0x50, 0x67, 0xef, 0xe5, 0xc7, 0x12, 0x64, 0x3d, 0x3e, 0xe7, 0x71, 0x3f, 0xf3, 0x9d, 0x87,
+	0x69, 0x91, 0xac, 0xf1, 0xef, 0x7c, 0xa2, 0x15, 0x4b, 0xb8, 0x2e, 0x43, 0x76, 0x80, 0x89, 0xce,
+	0x3c, 0x28, 0x0f, 0x89, 0x5e, 0xfb, 0x46, 0x0b, 0xf2, 0x81, 0x2f, 0xa5, 0xe8, 0x22, 0x2c, 0x55,
+	0xb6, 0xab, 0x95, 0xbb, 0x5a, 0xf3, 0x5d, 0xad, 0x79, 0xbf, 0x5e, 0xd5, 0x0e, 0xf6, 0xef, 0xee,
+	0xd7, 0xbe, 0xb3, 0x2f, 0x9f, 0x8b, 0x0e, 0xa9, 0x55, 0xd6, 0x96, 0x25, 0x74, 0x01, 0x16, 0xc2,
+	0x43, 0x7c, 0x20, 0xb5, 0x9c, 0xf9, 0xe9, 0xaf, 0x57, 0xce, 0xdd, 0xf8, 0x5a, 0x82, 0x85, 0x98,
+	0xb4, 0x18, 0x5d, 0x86, 0xa7, 0x6b, 0x5b, 0x5b, 0x55, 0x55, 0x6b, 0xec, 0x97, 0xeb, 0x8d, 0xed,
+	0x5a, 0x53, 0x53, 0xab, 0x8d, 0x83, 0xbd, 0x66, 0x60, 0xd2, 0x35, 0xb8, 0x14, 0x0f, 0x29, 0x57,
+	0x2a, 0xd5, 0x7a, 0x53, 0x96, 0xd0, 0x2a, 0x3c, 0x95, 0x80, 0xd8, 0xac, 0xa9, 0x4d, 0x39, 0x95,
+	0xac, 0x42, 0xad, 0xee, 0x56, 0x2b, 0x4d, 0x39, 0x8d, 0xae, 0xc1, 0x95, 0xd3, 0x10, 0xda, 0x56,
+	0x4d, 0xbd, 0x57, 0x6e, 0xca, 0x99, 0x33, 0x81, 0x8d, 0xea, 0xfe, 0x9d, 0xaa, 0x2a, 0x4f, 0x89,
+	0x7d, 0xff, 0x2a, 0x05, 0xa5, 0xa4, 0xec, 0x9b, 0xea, 0x2a, 0xd7, 0xeb, 0x7b, 0xf7, 0x7d, 0x5d,
+	0x95, 0xed, 0x83, 0xfd, 0xbb, 0x51, 0x13, 0x3c, 0x0b, 0xca, 0x69, 0x40, 0xcf, 0x10, 0x57, 0xe1,
+	0xf2, 0xa9, 0x38, 0x61, 0x8e, 0x33, 0x60, 0x6a, 0xb5, 0xa9, 0xde, 0x97, 0xd3, 0x68, 0x1d, 0x6e,
+	0x9c, 0x09, 0xf3, 0xc6, 0xe4, 0x0c, 0xda, 0x80, 0x9b, 0xa7, 0xe3, 0xb9, 0x81, 0x5c, 0x01, 0xd7,
+	0x44, 0x9f, 0x48, 0xb0, 0x14, 0x9b, 0xc6, 0xa3, 0x2b, 0xb0, 0x5a, 0x57, 0x6b, 0x95, 0x6a, 0xa3,
+	0xa1, 0xd5, 0xd5, 0x5a, 0xbd, 0xd6, 0x28, 0xef, 0x69, 0x8d, 0x66, 0xb9, 0x79, 0xd0, 0x08, 0xd8,
+	0x46, 0x81, 0x95, 0x24, 0x90, 0x67, 0x97, 0x53, 0x30, 0xe2, 0x06, 0xb8, 0xf7, 0xf4, 0x97, 0x12,
+	0x5c, 0x4c, 0x4c, 0xdb, 0xd1, 0x75, 0x78, 0xe6, 0xb0, 0xaa, 0xee, 0x6c, 0xdd, 0xd7, 0x0e, 0x6b,
+	0xcd, 0xaa, 0x56, 0x7d, 0xb7, 0x59, 0xdd, 0x6f, 0xec, 0xd4, 0xf6, 0xa3, 0xab, 0xba, 0x06, 0x57,
+	0x4e, 0x45, 0x7a, 0x4b, 0x3b, 0x0b, 0x38, 0xb1, 0xbe, 0x9f, 0x48, 0x30, 0x37, 0xe1, 0x0b, 0xd1,
+	0x25, 0x28, 0xdd, 0xdb, 0x69, 0x6c, 0x56, 0xb7, 0xcb, 0x87, 0x3b, 0x35, 0x75, 0xf2, 0xcd, 0x5e,
+	0x81, 0xd5, 0xc8, 0xe8, 0x9d, 0x83, 0xfa, 0xde, 0x4e, 0xa5, 0xdc, 0xac, 0xb2, 0x49, 0x65, 0x89,
+	0x6e, 0x2c, 0x02, 0xda, 0xdb, 0x79, 0x7b, 0xbb, 0xa9, 0x55, 0xf6, 0x76, 0xaa, 0xfb, 0x4d, 0xad,
+	0xdc, 0x6c, 0x96, 0xfd, 0xe7, 0xbc, 0x79, 0xf7, 0xb3, 0x2f, 0x57, 0xa4, 0xcf, 0xbf, 0x5c, 0x91,
+	0xfe, 0xfa, 0xe5, 0x8a, 0xf4, 0xe9, 0x57, 0x2b, 0xe7, 0x3e, 0xff, 0x6a, 0xe5, 0xdc, 0x9f, 0xbf,
+	0x5a, 0x39, 0xf7, 0xe0, 0xd6, 0x91, 0x41, 0x7a, 0xa3, 0x16, 0xf5, 0xc2, 0x1b, 0xfe, 0x1f, 0x36,
+	0xdd, 0x1f, 0xba, 0x65, 0x6c, 0x4c, 0xfe, 0x2b, 0xb4, 0x35, 0xcd, 0xdc, 0xea, 0x8b, 0xff, 0x1c,
+	0x00, 0x6b, 0xb7, 0xf4, 0xe1, 0x30, 0x2a, 0x00, 0x00,
 }
 
 func (m *Request) Marshal() (dAtA []byte, err error) {
@@ -5862,6 +5896,18 @@ func (m *CheckTxResponse) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.Sender) > 0 {
+		i -= len(m.Sender)
+		copy(dAtA[i:], m.Sender)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.Sender)))
+		i--
+		dAtA[i] = 0x6a
+	}
+	if m.Priority != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.Priority))
+		i--
+		dAtA[i] = 0x60
+	}
 	if len(m.Codespace) > 0 {
 		i -= len(m.Codespace)
 		copy(dAtA[i:], m.Codespace)
@@ -6662,6 +6708,13 @@ func (m *ValidatorUpdate) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.ProofOfPossession) > 0 {
+		i -= len(m.ProofOfPossession)
+		copy(dAtA[i:], m.ProofOfPossession)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.ProofOfPossession)))
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.Power != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Power))
 		i--
@@ -7845,6 +7898,13 @@ func (m *CheckTxResponse) Size() (n int) {
 	if l > 0 {
 		n += 1 + l + sovTypes(uint64(l))
 	}
+	if m.Priority != 0 {
+		n += 1 + sovTypes(uint64(m.Priority))
+	}
+	l = len(m.Sender)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -8175,6 +8235,10 @@ func (m *ValidatorUpdate) Size() (n int) {
 	if m.Power != 0 {
 		n += 1 + sovTypes(uint64(m.Power))
 	}
+	l = len(m.ProofOfPossession)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -13244,6 +13308,57 @@ func (m *CheckTxResponse) Unmarshal(dAtA []byte) error {
 			}
 			m.Codespace = string(dAtA[iNdEx:postIndex])
 			iNdEx = postIndex
+		case 12:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Priority", wireType)
+			}
+			m.Priority = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.Priority |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+		case 13:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field Sender", wireType)
+			}
+			var stringLen uint64
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				stringLen |= uint64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			intStringLen := int(stringLen)
+			if intStringLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + intStringLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.Sender = string(dAtA[iNdEx:postIndex])
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -15340,6 +15455,40 @@ func (m *ValidatorUpdate) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ProofOfPossession", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.ProofOfPossession = append(m.ProofOfPossession[:0], dAtA[iNdEx:postIndex]...)
+			if m.ProofOfPossession == nil {
+				m.ProofOfPossession = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
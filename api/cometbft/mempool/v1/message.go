@@ -13,6 +13,27 @@ func (m *Txs) Wrap() proto.Message {
 	return mm
 }
 
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *HaveTx) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_HaveTx{HaveTx: m}
+	return mm
+}
+
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *WantTx) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_WantTx{WantTx: m}
+	return mm
+}
+
+// Wrap implements the p2p Wrapper interface and wraps a mempool message.
+func (m *NoTx) Wrap() proto.Message {
+	mm := &Message{}
+	mm.Sum = &Message_NoTx{NoTx: m}
+	return mm
+}
+
 // Unwrap implements the p2p Wrapper interface and unwraps a wrapped mempool
 // message.
 func (m *Message) Unwrap() (proto.Message, error) {
@@ -20,6 +41,15 @@ func (m *Message) Unwrap() (proto.Message, error) {
 	case *Message_Txs:
 		return m.GetTxs(), nil
 
+	case *Message_HaveTx:
+		return m.GetHaveTx(), nil
+
+	case *Message_WantTx:
+		return m.GetWantTx(), nil
+
+	case *Message_NoTx:
+		return m.GetNoTx(), nil
+
 	default:
 		return nil, fmt.Errorf("unknown message: %T", msg)
 	}
@@ -0,0 +1,167 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/stream/v1/stream_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/stream/v1/stream_service.proto", fileDescriptor_4ff18b8ae0191177)
+}
+
+var fileDescriptor_4ff18b8ae0191177 = []byte{
+	// 178 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x48, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0x2e,
+	0x29, 0x4a, 0x4d, 0xcc, 0xd5, 0x2f, 0x33, 0x84, 0xb2, 0xe2, 0xa1, 0x32, 0x7a, 0x05, 0x45, 0xf9,
+	0x25, 0xf9, 0x42, 0xd2, 0x30, 0x1d, 0x7a, 0x30, 0x1d, 0x7a, 0x10, 0x75, 0x7a, 0x65, 0x86, 0x52,
+	0x1a, 0x84, 0x8d, 0x83, 0x18, 0x63, 0x54, 0xc2, 0xc5, 0x1b, 0x0c, 0xe6, 0x07, 0x43, 0x14, 0x0a,
+	0x25, 0x73, 0xb1, 0x41, 0x04, 0x84, 0xb4, 0xf4, 0xf0, 0x58, 0xa1, 0x07, 0x51, 0x14, 0x94, 0x5a,
+	0x58, 0x9a, 0x5a, 0x5c, 0x22, 0xa5, 0x4d, 0x94, 0xda, 0xe2, 0x82, 0xfc, 0xbc, 0xe2, 0x54, 0x03,
+	0x46, 0xa7, 0xb0, 0x13, 0x8f, 0xe4, 0x18, 0x2f, 0x3c, 0x92, 0x63, 0x7c, 0xf0, 0x48, 0x8e, 0x71,
+	0xc2, 0x63, 0x39, 0x86, 0x0b, 0x8f, 0xe5, 0x18, 0x6e, 0x3c, 0x96, 0x63, 0x88, 0xb2, 0x49, 0xcf,
+	0x2c, 0xc9, 0x28, 0x4d, 0x02, 0x19, 0xa7, 0x0f, 0xf7, 0x04, 0x9c, 0x91, 0x58, 0x90, 0xa9, 0x8f,
+	0xc7, 0x6b, 0x49, 0x6c, 0x60, 0x4f, 0x19, 0x03, 0x06, 0x00, 0x61, 0x20, 0x10, 0x00, 0x4f, 0x01,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// StreamServiceClient is the client API for StreamService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type StreamServiceClient interface {
+	// Stream sends the requested data of each height, from the start height
+	// onwards. The heights already committed are read from the node's stores,
+	// after which new heights are sent as they are committed. This is a
+	// long-lived stream that is only terminated by the server if an error
+	// occurs, for example if the next height to send has been pruned. The
+	// caller is expected to reconnect using the cursor of the last response it
+	// received.
+	Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (StreamService_StreamClient, error)
+}
+
+type streamServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewStreamServiceClient(cc grpc1.ClientConn) StreamServiceClient {
+	return &streamServiceClient{cc}
+}
+
+func (c *streamServiceClient) Stream(ctx context.Context, in *StreamRequest, opts ...grpc.CallOption) (StreamService_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_StreamService_serviceDesc.Streams[0], "/cometbft.services.stream.v1.StreamService/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &streamServiceStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type StreamService_StreamClient interface {
+	Recv() (*StreamResponse, error)
+	grpc.ClientStream
+}
+
+type streamServiceStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *streamServiceStreamClient) Recv() (*StreamResponse, error) {
+	m := new(StreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// StreamServiceServer is the server API for StreamService service.
+type StreamServiceServer interface {
+	// Stream sends the requested data of each height, from the start height
+	// onwards. The heights already committed are read from the node's stores,
+	// after which new heights are sent as they are committed. This is a
+	// long-lived stream that is only terminated by the server if an error
+	// occurs, for example if the next height to send has been pruned. The
+	// caller is expected to reconnect using the cursor of the last response it
+	// received.
+	Stream(*StreamRequest, StreamService_StreamServer) error
+}
+
+// UnimplementedStreamServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedStreamServiceServer struct {
+}
+
+func (*UnimplementedStreamServiceServer) Stream(req *StreamRequest, srv StreamService_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
+
+func RegisterStreamServiceServer(s grpc1.Server, srv StreamServiceServer) {
+	s.RegisterService(&_StreamService_serviceDesc, srv)
+}
+
+func _StreamService_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(StreamServiceServer).Stream(m, &streamServiceStreamServer{stream})
+}
+
+type StreamService_StreamServer interface {
+	Send(*StreamResponse) error
+	grpc.ServerStream
+}
+
+type streamServiceStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *streamServiceStreamServer) Send(m *StreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _StreamService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.stream.v1.StreamService",
+	HandlerType: (*StreamServiceServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _StreamService_Stream_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "cometbft/services/stream/v1/stream_service.proto",
+}
@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-gogo. DO NOT EDIT.
+// source: cometbft/services/tx_status/v1/tx_status_service.proto
+
+package v1
+
+import (
+	context "context"
+	fmt "fmt"
+	grpc1 "github.com/cosmos/gogoproto/grpc"
+	proto "github.com/cosmos/gogoproto/proto"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
+
+func init() {
+	proto.RegisterFile("cometbft/services/tx_status/v1/tx_status_service.proto", fileDescriptor_f82fca94cf200dd8)
+}
+
+var fileDescriptor_f82fca94cf200dd8 = []byte{
+	// 185 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x32, 0x4b, 0xce, 0xcf, 0x4d,
+	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x4e, 0x2d, 0x2a, 0xcb, 0x4c, 0x4e, 0x2d, 0xd6, 0x2f, 0xa9,
+	0x88, 0x2f, 0x2e, 0x49, 0x2c, 0x29, 0x2d, 0xd6, 0x2f, 0x33, 0x44, 0x70, 0xe2, 0xa1, 0xf2, 0x7a,
+	0x05, 0x45, 0xf9, 0x25, 0xf9, 0x42, 0x72, 0x30, 0x7d, 0x7a, 0x30, 0x7d, 0x7a, 0x70, 0xa5, 0x7a,
+	0x65, 0x86, 0x52, 0x7a, 0xc4, 0x9a, 0x0b, 0x31, 0xcf, 0xa8, 0x93, 0x91, 0x8b, 0x3f, 0xa4, 0x22,
+	0x18, 0x2c, 0x14, 0x0c, 0xd1, 0x21, 0x54, 0xc6, 0xc5, 0xed, 0x9e, 0x5a, 0x02, 0x13, 0x15, 0x32,
+	0xd2, 0xc3, 0x6f, 0xa7, 0x1e, 0x92, 0xe2, 0xa0, 0xd4, 0xc2, 0xd2, 0xd4, 0xe2, 0x12, 0x29, 0x63,
+	0x92, 0xf4, 0x14, 0x17, 0xe4, 0xe7, 0x15, 0xa7, 0x3a, 0x45, 0x9e, 0x78, 0x24, 0xc7, 0x78, 0xe1,
+	0x91, 0x1c, 0xe3, 0x83, 0x47, 0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70,
+	0xe3, 0xb1, 0x1c, 0x43, 0x94, 0x7d, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x12, 0xc8, 0x50, 0x7d, 0xb8,
+	0x07, 0xe1, 0x8c, 0xc4, 0x82, 0x4c, 0x7d, 0xfc, 0xde, 0x4e, 0x62, 0x03, 0xfb, 0xd6, 0x18, 0x30,
+	0x00, 0x5c, 0x50, 0xe5, 0xbc, 0x77, 0x01, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// TxStatusServiceClient is the client API for TxStatusService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type TxStatusServiceClient interface {
+	// GetTxStatus returns the latest events of the lifecycle of a transaction
+	// in the mempool: received, checked, rechecked_invalid, removed and
+	// included.
+	GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error)
+}
+
+type txStatusServiceClient struct {
+	cc grpc1.ClientConn
+}
+
+func NewTxStatusServiceClient(cc grpc1.ClientConn) TxStatusServiceClient {
+	return &txStatusServiceClient{cc}
+}
+
+func (c *txStatusServiceClient) GetTxStatus(ctx context.Context, in *GetTxStatusRequest, opts ...grpc.CallOption) (*GetTxStatusResponse, error) {
+	out := new(GetTxStatusResponse)
+	err := c.cc.Invoke(ctx, "/cometbft.services.tx_status.v1.TxStatusService/GetTxStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TxStatusServiceServer is the server API for TxStatusService service.
+type TxStatusServiceServer interface {
+	// GetTxStatus returns the latest events of the lifecycle of a transaction
+	// in the mempool: received, checked, rechecked_invalid, removed and
+	// included.
+	GetTxStatus(context.Context, *GetTxStatusRequest) (*GetTxStatusResponse, error)
+}
+
+// UnimplementedTxStatusServiceServer can be embedded to have forward compatible implementations.
+type UnimplementedTxStatusServiceServer struct {
+}
+
+func (*UnimplementedTxStatusServiceServer) GetTxStatus(ctx context.Context, req *GetTxStatusRequest) (*GetTxStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTxStatus not implemented")
+}
+
+func RegisterTxStatusServiceServer(s grpc1.Server, srv TxStatusServiceServer) {
+	s.RegisterService(&_TxStatusService_serviceDesc, srv)
+}
+
+func _TxStatusService_GetTxStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTxStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TxStatusServiceServer).GetTxStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/cometbft.services.tx_status.v1.TxStatusService/GetTxStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TxStatusServiceServer).GetTxStatus(ctx, req.(*GetTxStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+var _TxStatusService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "cometbft.services.tx_status.v1.TxStatusService",
+	HandlerType: (*TxStatusServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetTxStatus",
+			Handler:    _TxStatusService_GetTxStatus_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "cometbft/services/tx_status/v1/tx_status_service.proto",
+}
@@ -139,6 +139,9 @@ func (*Message) XXX_OneofWrappers() []interface{} {
 
 // SnapshotsRequest is sent to request a snapshot.
 type SnapshotsRequest struct {
+	// If true, the snapshots of the blocks produced by CometBFT itself are
+	// advertised alongside the snapshots of the application.
+	IncludeBlockSnapshots bool `protobuf:"varint,1,opt,name=include_block_snapshots,json=includeBlockSnapshots,proto3" json:"include_block_snapshots,omitempty"`
 }
 
 func (m *SnapshotsRequest) Reset()         { *m = SnapshotsRequest{} }
@@ -174,6 +177,13 @@ func (m *SnapshotsRequest) XXX_DiscardUnknown() {
 
 var xxx_messageInfo_SnapshotsRequest proto.InternalMessageInfo
 
+func (m *SnapshotsRequest) GetIncludeBlockSnapshots() bool {
+	if m != nil {
+		return m.IncludeBlockSnapshots
+	}
+	return false
+}
+
 // SnapshotsResponse contains the snapshot metadata.
 type SnapshotsResponse struct {
 	Height   uint64 `protobuf:"varint,1,opt,name=height,proto3" json:"height,omitempty"`
@@ -400,32 +410,34 @@ func init() {
 func init() { proto.RegisterFile("cometbft/statesync/v1/types.proto", fileDescriptor_95fd383b29885bb3) }
 
 var fileDescriptor_95fd383b29885bb3 = []byte{
-	// 399 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0x6a, 0xdb, 0x40,
-	0x18, 0x94, 0xfc, 0xcf, 0x57, 0xab, 0xd8, 0x4b, 0x5b, 0x44, 0x0f, 0xa2, 0x55, 0x0b, 0xf5, 0x49,
-	0xc2, 0x2d, 0xf4, 0x01, 0xdc, 0x8b, 0x29, 0x18, 0xca, 0xb6, 0x14, 0x92, 0x4b, 0x58, 0xcb, 0x6b,
-	0x49, 0x04, 0xfd, 0xc4, 0xdf, 0xca, 0xc4, 0x0f, 0x90, 0x53, 0x2e, 0x79, 0xac, 0x1c, 0x7d, 0xcc,
-	0x29, 0x04, 0xfb, 0x45, 0x82, 0x56, 0x3f, 0x51, 0x1c, 0x27, 0x21, 0x90, 0xdb, 0xce, 0x30, 0x1e,
-	0xcf, 0x8c, 0xf8, 0xe0, 0xb3, 0x13, 0x05, 0x5c, 0x4c, 0xe7, 0xc2, 0x46, 0xc1, 0x04, 0xc7, 0x55,
-	0xe8, 0xd8, 0xcb, 0xa1, 0x2d, 0x56, 0x31, 0x47, 0x2b, 0x5e, 0x44, 0x22, 0x22, 0xef, 0x0b, 0x89,
-	0x55, 0x4a, 0xac, 0xe5, 0xd0, 0xbc, 0xae, 0x41, 0x7b, 0xc2, 0x11, 0x99, 0xcb, 0xc9, 0x7f, 0xe8,
-	0x63, 0xc8, 0x62, 0xf4, 0x22, 0x81, 0x47, 0x0b, 0x7e, 0x92, 0x70, 0x14, 0xba, 0xfa, 0x49, 0x1d,
-	0xbc, 0xf9, 0xfe, 0xcd, 0xda, 0xfb, 0x73, 0xeb, 0x6f, 0xa1, 0xa7, 0x99, 0x7c, 0xac, 0xd0, 0x1e,
-	0xee, 0x70, 0xe4, 0x00, 0x48, 0xd5, 0x17, 0xe3, 0x28, 0x44, 0xae, 0xd7, 0xa4, 0xf1, 0xe0, 0x79,
-	0xe3, 0x4c, 0x3f, 0x56, 0x68, 0x1f, 0x77, 0x49, 0xf2, 0x1b, 0x34, 0xc7, 0x4b, 0xc2, 0xe3, 0x32,
-	0x6e, 0x5d, 0xba, 0x7e, 0x79, 0xc4, 0xf5, 0x57, 0xaa, 0xbd, 0x8b, 0xda, 0x75, 0x2a, 0x98, 0x4c,
-	0xe0, 0x6d, 0xe1, 0x95, 0x47, 0x6c, 0x48, 0xb3, 0xaf, 0x4f, 0x9b, 0x95, 0xf1, 0x34, 0xa7, 0x4a,
-	0x8c, 0x9a, 0x50, 0xc7, 0x24, 0x30, 0x09, 0xf4, 0x76, 0x47, 0x32, 0xcf, 0x55, 0xe8, 0x3f, 0x28,
-	0x48, 0x3e, 0x40, 0xcb, 0xe3, 0xbe, 0xeb, 0x65, 0x9b, 0x37, 0x68, 0x8e, 0x52, 0x7e, 0x1e, 0x2d,
-	0x02, 0x26, 0xe4, 0x64, 0x1a, 0xcd, 0x51, 0xca, 0xcb, 0x7f, 0x44, 0x59, 0x5a, 0xa3, 0x39, 0x22,
-	0x04, 0x1a, 0x1e, 0x43, 0x4f, 0xa6, 0xef, 0x52, 0xf9, 0x26, 0x1f, 0xa1, 0x13, 0x70, 0xc1, 0x66,
-	0x4c, 0x30, 0xbd, 0x29, 0xf9, 0x12, 0x9b, 0xff, 0xa0, 0x5b, 0xdd, 0xe5, 0xc5, 0x39, 0xde, 0x41,
-	0xd3, 0x0f, 0x67, 0xfc, 0x34, 0x8f, 0x91, 0x01, 0xf3, 0x4c, 0x05, 0xed, 0xde, 0x42, 0xaf, 0xe3,
-	0x9b, 0xb2, 0xb2, 0x67, 0x5e, 0x2f, 0x03, 0x44, 0x87, 0x76, 0xe0, 0x23, 0xfa, 0xa1, 0x2b, 0xeb,
-	0x75, 0x68, 0x01, 0x47, 0x7f, 0x2e, 0x37, 0x86, 0xba, 0xde, 0x18, 0xea, 0xcd, 0xc6, 0x50, 0x2f,
-	0xb6, 0x86, 0xb2, 0xde, 0x1a, 0xca, 0xd5, 0xd6, 0x50, 0x0e, 0x7f, 0xba, 0xbe, 0xf0, 0x92, 0x69,
-	0xfa, 0x75, 0xed, 0xf2, 0x7e, 0xca, 0x07, 0x8b, 0x7d, 0x7b, 0xef, 0x55, 0x4d, 0x5b, 0xf2, 0xa0,
-	0x7e, 0xdc, 0x06, 0x00, 0x00, 0xff, 0xff, 0x99, 0x49, 0xe4, 0x5a, 0x75, 0x03, 0x00, 0x00,
+	// 425 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xac, 0x53, 0xcd, 0xaa, 0xd3, 0x40,
+	0x18, 0x4d, 0xee, 0x6d, 0xef, 0x2d, 0x9f, 0x8d, 0xdc, 0x0e, 0x56, 0x83, 0x8b, 0xa0, 0x51, 0xb0,
+	0xab, 0x84, 0x2a, 0xf4, 0x01, 0xea, 0xa6, 0x14, 0x0a, 0x32, 0x8a, 0xa0, 0x9b, 0x32, 0x49, 0xa7,
+	0x49, 0x68, 0xf3, 0x63, 0xbf, 0x49, 0xb1, 0x0f, 0xe0, 0xca, 0x8d, 0x8f, 0xe5, 0xb2, 0x4b, 0x57,
+	0x22, 0xed, 0x8b, 0x48, 0x26, 0xc9, 0x18, 0x6b, 0x55, 0x04, 0x77, 0x73, 0xce, 0x9c, 0x39, 0x9c,
+	0xef, 0x24, 0x1f, 0x3c, 0xf4, 0xd3, 0x98, 0x0b, 0x6f, 0x29, 0x5c, 0x14, 0x4c, 0x70, 0xdc, 0x25,
+	0xbe, 0xbb, 0x1d, 0xba, 0x62, 0x97, 0x71, 0x74, 0xb2, 0x4d, 0x2a, 0x52, 0xd2, 0xaf, 0x25, 0x8e,
+	0x92, 0x38, 0xdb, 0xa1, 0xfd, 0xf5, 0x02, 0xae, 0x67, 0x1c, 0x91, 0x05, 0x9c, 0xbc, 0x86, 0x1e,
+	0x26, 0x2c, 0xc3, 0x30, 0x15, 0x38, 0xdf, 0xf0, 0x77, 0x39, 0x47, 0x61, 0xea, 0x0f, 0xf4, 0xc1,
+	0xad, 0xa7, 0x4f, 0x9c, 0xb3, 0xcf, 0x9d, 0x97, 0xb5, 0x9e, 0x96, 0xf2, 0x89, 0x46, 0x6f, 0xf0,
+	0x84, 0x23, 0x6f, 0x80, 0x34, 0x7d, 0x31, 0x4b, 0x13, 0xe4, 0xe6, 0x85, 0x34, 0x1e, 0xfc, 0xdd,
+	0xb8, 0xd4, 0x4f, 0x34, 0xda, 0xc3, 0x53, 0x92, 0x4c, 0xc1, 0xf0, 0xc3, 0x3c, 0x59, 0xa9, 0xb8,
+	0x97, 0xd2, 0xf5, 0xd1, 0x6f, 0x5c, 0x9f, 0x17, 0xda, 0x1f, 0x51, 0xbb, 0x7e, 0x03, 0x93, 0x19,
+	0xdc, 0xae, 0xbd, 0xaa, 0x88, 0x2d, 0x69, 0xf6, 0xf8, 0xcf, 0x66, 0x2a, 0x9e, 0xe1, 0x37, 0x89,
+	0x71, 0x1b, 0x2e, 0x31, 0x8f, 0xed, 0x29, 0xdc, 0x9c, 0x96, 0x44, 0x46, 0x70, 0x2f, 0x4a, 0xfc,
+	0x75, 0xbe, 0xe0, 0x73, 0x6f, 0x9d, 0xfa, 0xab, 0xb9, 0x1a, 0x4c, 0xd6, 0xdd, 0xa1, 0xfd, 0xea,
+	0x7a, 0x5c, 0xdc, 0xaa, 0xe7, 0xf6, 0x47, 0x1d, 0x7a, 0xbf, 0x14, 0x43, 0xee, 0xc2, 0x55, 0xc8,
+	0xa3, 0x20, 0x2c, 0xbf, 0x55, 0x8b, 0x56, 0xa8, 0xe0, 0x97, 0xe9, 0x26, 0x66, 0x42, 0x56, 0x6d,
+	0xd0, 0x0a, 0x15, 0xbc, 0x4c, 0x8a, 0xb2, 0x2c, 0x83, 0x56, 0x88, 0x10, 0x68, 0x85, 0x0c, 0x43,
+	0x39, 0x75, 0x97, 0xca, 0x33, 0xb9, 0x0f, 0x9d, 0x98, 0x0b, 0xb6, 0x60, 0x82, 0x99, 0x6d, 0xc9,
+	0x2b, 0x6c, 0xbf, 0x82, 0x6e, 0xb3, 0xcf, 0x7f, 0xce, 0x71, 0x07, 0xda, 0x51, 0xb2, 0xe0, 0xef,
+	0xab, 0x18, 0x25, 0xb0, 0x3f, 0xe8, 0x60, 0xfc, 0xd4, 0xec, 0xff, 0xf1, 0x2d, 0x58, 0x39, 0x67,
+	0x35, 0x5e, 0x09, 0x88, 0x09, 0xd7, 0x71, 0x84, 0x18, 0x25, 0x81, 0x1c, 0xaf, 0x43, 0x6b, 0x38,
+	0x7e, 0xf1, 0xf9, 0x60, 0xe9, 0xfb, 0x83, 0xa5, 0x7f, 0x3b, 0x58, 0xfa, 0xa7, 0xa3, 0xa5, 0xed,
+	0x8f, 0x96, 0xf6, 0xe5, 0x68, 0x69, 0x6f, 0x47, 0x41, 0x24, 0xc2, 0xdc, 0x2b, 0xfe, 0x0a, 0x57,
+	0xed, 0x9d, 0x3a, 0xb0, 0x2c, 0x72, 0xcf, 0x6e, 0xa3, 0x77, 0x25, 0x17, 0xf1, 0xd9, 0xf7, 0x01,
+	0x00, 0x21, 0xe3, 0x0f, 0x72, 0xad, 0x03, 0x00, 0x00,
 }
 
 func (m *Message) Marshal() (dAtA []byte, err error) {
@@ -564,6 +576,16 @@ func (m *SnapshotsRequest) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.IncludeBlockSnapshots {
+		i--
+		if m.IncludeBlockSnapshots {
+			dAtA[i] = 1
+		} else {
+			dAtA[i] = 0
+		}
+		i--
+		dAtA[i] = 0x8
+	}
 	return len(dAtA) - i, nil
 }
 
@@ -789,6 +811,9 @@ func (m *SnapshotsRequest) Size() (n int) {
 	}
 	var l int
 	_ = l
+	if m.IncludeBlockSnapshots {
+		n += 2
+	}
 	return n
 }
 
@@ -1086,6 +1111,26 @@ func (m *SnapshotsRequest) Unmarshal(dAtA []byte) error {
 			return fmt.Errorf("proto: SnapshotsRequest: illegal tag %d (wire type %d)", fieldNum, wire)
 		}
 		switch fieldNum {
+		case 1:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field IncludeBlockSnapshots", wireType)
+			}
+			var v int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				v |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			m.IncludeBlockSnapshots = bool(v != 0)
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -26,6 +26,9 @@ const _ = proto.GoGoProtoPackageIsVersion3 // please upgrade the proto package
 type BlockStoreState struct {
 	Base   int64 `protobuf:"varint,1,opt,name=base,proto3" json:"base,omitempty"`
 	Height int64 `protobuf:"varint,2,opt,name=height,proto3" json:"height,omitempty"`
+	// Lowest height whose extended commit may still be stored. The extended
+	// commits can be pruned separately from the blocks.
+	ExtCommitBase int64 `protobuf:"varint,3,opt,name=ext_commit_base,json=extCommitBase,proto3" json:"ext_commit_base,omitempty"`
 }
 
 func (m *BlockStoreState) Reset()         { *m = BlockStoreState{} }
@@ -75,6 +78,13 @@ func (m *BlockStoreState) GetHeight() int64 {
 	return 0
 }
 
+func (m *BlockStoreState) GetExtCommitBase() int64 {
+	if m != nil {
+		return m.ExtCommitBase
+	}
+	return 0
+}
+
 func init() {
 	proto.RegisterType((*BlockStoreState)(nil), "cometbft.store.v1.BlockStoreState")
 }
@@ -82,18 +92,20 @@ func init() {
 func init() { proto.RegisterFile("cometbft/store/v1/types.proto", fileDescriptor_39bdcbdd79a94f5f) }
 
 var fileDescriptor_39bdcbdd79a94f5f = []byte{
-	// 170 bytes of a gzipped FileDescriptorProto
+	// 198 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xe2, 0x92, 0x4d, 0xce, 0xcf, 0x4d,
 	0x2d, 0x49, 0x4a, 0x2b, 0xd1, 0x2f, 0x2e, 0xc9, 0x2f, 0x4a, 0xd5, 0x2f, 0x33, 0xd4, 0x2f, 0xa9,
 	0x2c, 0x48, 0x2d, 0xd6, 0x2b, 0x28, 0xca, 0x2f, 0xc9, 0x17, 0x12, 0x84, 0x49, 0xeb, 0x81, 0xa5,
-	0xf5, 0xca, 0x0c, 0x95, 0x6c, 0xb9, 0xf8, 0x9d, 0x72, 0xf2, 0x93, 0xb3, 0x83, 0x41, 0x02, 0xc1,
+	0xf5, 0xca, 0x0c, 0x95, 0x52, 0xb9, 0xf8, 0x9d, 0x72, 0xf2, 0x93, 0xb3, 0x83, 0x41, 0x02, 0xc1,
 	0x25, 0x89, 0x25, 0xa9, 0x42, 0x42, 0x5c, 0x2c, 0x49, 0x89, 0xc5, 0xa9, 0x12, 0x8c, 0x0a, 0x8c,
 	0x1a, 0xcc, 0x41, 0x60, 0xb6, 0x90, 0x18, 0x17, 0x5b, 0x46, 0x6a, 0x66, 0x7a, 0x46, 0x89, 0x04,
-	0x13, 0x58, 0x14, 0xca, 0x73, 0xf2, 0x39, 0xf1, 0x48, 0x8e, 0xf1, 0xc2, 0x23, 0x39, 0xc6, 0x07,
-	0x8f, 0xe4, 0x18, 0x27, 0x3c, 0x96, 0x63, 0xb8, 0xf0, 0x58, 0x8e, 0xe1, 0xc6, 0x63, 0x39, 0x86,
-	0x28, 0xa3, 0xf4, 0xcc, 0x92, 0x8c, 0xd2, 0x24, 0xbd, 0xe4, 0xfc, 0x5c, 0x7d, 0xb8, 0xab, 0xe0,
-	0x8c, 0xc4, 0x82, 0x4c, 0x7d, 0x0c, 0xb7, 0x26, 0xb1, 0x81, 0x9d, 0x69, 0x0c, 0x08, 0x00, 0x00,
-	0xff, 0xff, 0x25, 0xd4, 0x78, 0x17, 0xc7, 0x00, 0x00, 0x00,
+	0x13, 0x58, 0x14, 0xca, 0x13, 0x52, 0xe3, 0xe2, 0x4f, 0xad, 0x28, 0x89, 0x4f, 0xce, 0xcf, 0xcd,
+	0xcd, 0x2c, 0x89, 0x07, 0x6b, 0x63, 0x06, 0x2b, 0xe0, 0x4d, 0xad, 0x28, 0x71, 0x06, 0x8b, 0x3a,
+	0x25, 0x16, 0xa7, 0x3a, 0xf9, 0x9c, 0x78, 0x24, 0xc7, 0x78, 0xe1, 0x91, 0x1c, 0xe3, 0x83, 0x47,
+	0x72, 0x8c, 0x13, 0x1e, 0xcb, 0x31, 0x5c, 0x78, 0x2c, 0xc7, 0x70, 0xe3, 0xb1, 0x1c, 0x43, 0x94,
+	0x51, 0x7a, 0x66, 0x49, 0x46, 0x69, 0x92, 0x5e, 0x72, 0x7e, 0xae, 0x3e, 0xdc, 0xf5, 0x70, 0x46,
+	0x62, 0x41, 0xa6, 0x3e, 0x86, 0x9f, 0x92, 0xd8, 0xc0, 0xde, 0x31, 0x06, 0x0c, 0x00, 0x81, 0xe6,
+	0x7d, 0xe7, 0xef, 0x00, 0x00, 0x00,
 }
 
 func (m *BlockStoreState) Marshal() (dAtA []byte, err error) {
@@ -116,6 +128,11 @@ func (m *BlockStoreState) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.ExtCommitBase != 0 {
+		i = encodeVarintTypes(dAtA, i, uint64(m.ExtCommitBase))
+		i--
+		dAtA[i] = 0x18
+	}
 	if m.Height != 0 {
 		i = encodeVarintTypes(dAtA, i, uint64(m.Height))
 		i--
@@ -152,6 +169,9 @@ func (m *BlockStoreState) Size() (n int) {
 	if m.Height != 0 {
 		n += 1 + sovTypes(uint64(m.Height))
 	}
+	if m.ExtCommitBase != 0 {
+		n += 1 + sovTypes(uint64(m.ExtCommitBase))
+	}
 	return n
 }
 
@@ -228,6 +248,25 @@ func (m *BlockStoreState) Unmarshal(dAtA []byte) error {
 					break
 				}
 			}
+		case 3:
+			if wireType != 0 {
+				return fmt.Errorf("proto: wrong wireType = %d for field ExtCommitBase", wireType)
+			}
+			m.ExtCommitBase = 0
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				m.ExtCommitBase |= int64(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
@@ -495,6 +495,25 @@ type FeatureParams struct {
 	//
 	// Cannot be set to heights lower or equal to the current blockchain height.
 	PbtsEnableHeight *types.Int64Value `protobuf:"bytes,2,opt,name=pbts_enable_height,json=pbtsEnableHeight,proto3" json:"pbts_enable_height,omitempty"`
+	// Height at which aggregated commits will be enabled.
+	//
+	// From the specified height, and for all subsequent heights, the commit of
+	// the previous block included in a block (its last commit) may carry a
+	// single aggregated signature instead of one signature per validator, if all
+	// the validators that signed it have BLS12-381 keys. Prior to this height,
+	// or when this height is set to 0, aggregated commits are considered invalid.
+	//
+	// Cannot be set to heights lower or equal to the current blockchain height.
+	AggregatedCommitEnableHeight *types.Int64Value `protobuf:"bytes,3,opt,name=aggregated_commit_enable_height,json=aggregatedCommitEnableHeight,proto3" json:"aggregated_commit_enable_height,omitempty"`
+	// Maximum size in bytes of a vote extension.
+	//
+	// Precommits with bigger vote extensions are rejected. When set to 0, the
+	// maximum size is the one supported by the protocol (1 MiB), which is also
+	// the upper bound of this parameter.
+	//
+	// As every validator has a vote extension in the extended commit of a
+	// block, this bounds the size of the extended commits stored by the nodes.
+	VoteExtensionsMaxBytes *types.Int64Value `protobuf:"bytes,4,opt,name=vote_extensions_max_bytes,json=voteExtensionsMaxBytes,proto3" json:"vote_extensions_max_bytes,omitempty"`
 }
 
 func (m *FeatureParams) Reset()         { *m = FeatureParams{} }
@@ -544,6 +563,20 @@ func (m *FeatureParams) GetPbtsEnableHeight() *types.Int64Value {
 	return nil
 }
 
+func (m *FeatureParams) GetAggregatedCommitEnableHeight() *types.Int64Value {
+	if m != nil {
+		return m.AggregatedCommitEnableHeight
+	}
+	return nil
+}
+
+func (m *FeatureParams) GetVoteExtensionsMaxBytes() *types.Int64Value {
+	if m != nil {
+		return m.VoteExtensionsMaxBytes
+	}
+	return nil
+}
+
 // ABCIParams is deprecated and its contents moved to FeatureParams
 //
 // Deprecated: Do not use.
@@ -608,53 +641,56 @@ func init() {
 func init() { proto.RegisterFile("cometbft/types/v1/params.proto", fileDescriptor_8c2f6d19461b2fe7) }
 
 var fileDescriptor_8c2f6d19461b2fe7 = []byte{
-	// 729 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x94, 0xbf, 0x4e, 0xdb, 0x50,
-	0x14, 0xc6, 0x73, 0xe3, 0x00, 0xc9, 0x09, 0x21, 0xe9, 0x55, 0xa5, 0xba, 0x20, 0x1c, 0xea, 0xa1,
-	0x42, 0x42, 0xb2, 0x05, 0xa5, 0x1d, 0x90, 0x50, 0x4b, 0x80, 0x02, 0xad, 0x68, 0x91, 0xa9, 0x18,
-	0x58, 0xac, 0xeb, 0xe4, 0xe2, 0x58, 0xc4, 0x7f, 0xe4, 0x6b, 0xa7, 0xc9, 0x5b, 0x74, 0xaa, 0x3a,
-	0x32, 0xb6, 0x6f, 0xd0, 0xbe, 0x40, 0xc5, 0xc8, 0xd8, 0x89, 0x56, 0x61, 0xe9, 0x63, 0x54, 0xbe,
-	0xb6, 0x13, 0x12, 0x42, 0x61, 0xb3, 0x7d, 0xbe, 0xdf, 0xe7, 0xef, 0x9e, 0x73, 0x6c, 0x90, 0xea,
-	0xae, 0x4d, 0x03, 0xe3, 0x24, 0x50, 0x83, 0xae, 0x47, 0x99, 0xda, 0x5e, 0x56, 0x3d, 0xe2, 0x13,
-	0x9b, 0x29, 0x9e, 0xef, 0x06, 0x2e, 0x7e, 0x90, 0xd6, 0x15, 0x5e, 0x57, 0xda, 0xcb, 0xb3, 0x0f,
-	0x4d, 0xd7, 0x74, 0x79, 0x55, 0x8d, 0xae, 0x62, 0xe1, 0xac, 0x64, 0xba, 0xae, 0xd9, 0xa2, 0x2a,
-	0xbf, 0x33, 0xc2, 0x13, 0xb5, 0x11, 0xfa, 0x24, 0xb0, 0x5c, 0xe7, 0xb6, 0xfa, 0x47, 0x9f, 0x78,
-	0x1e, 0xf5, 0x93, 0x17, 0xc9, 0x3f, 0x04, 0x28, 0x6f, 0xba, 0x0e, 0xa3, 0x0e, 0x0b, 0xd9, 0x01,
-	0x8f, 0x80, 0x57, 0x61, 0xc2, 0x68, 0xb9, 0xf5, 0x53, 0x11, 0x2d, 0xa0, 0xc5, 0xe2, 0x8a, 0xa4,
-	0xdc, 0x08, 0xa3, 0xd4, 0xa2, 0x7a, 0x2c, 0xd7, 0x62, 0x31, 0x5e, 0x87, 0x3c, 0x6d, 0x5b, 0x0d,
-	0xea, 0xd4, 0xa9, 0x98, 0xe5, 0xe0, 0x93, 0x31, 0xe0, 0x76, 0x22, 0x49, 0xd8, 0x3e, 0x82, 0x5f,
-	0x41, 0xa1, 0x4d, 0x5a, 0x56, 0x83, 0x04, 0xae, 0x2f, 0x0a, 0x9c, 0x97, 0xc7, 0xf0, 0x47, 0xa9,
-	0x26, 0x31, 0x18, 0x40, 0x78, 0x0d, 0xa6, 0xda, 0xd4, 0x67, 0x96, 0xeb, 0x88, 0x39, 0xce, 0x2f,
-	0x8c, 0xe3, 0x63, 0x45, 0x42, 0xa7, 0x00, 0x7e, 0x0e, 0x39, 0x62, 0xd4, 0x2d, 0x71, 0x82, 0x83,
-	0xf3, 0x63, 0xc0, 0x8d, 0xda, 0xe6, 0x5e, 0x4c, 0xd5, 0xb2, 0x22, 0xd2, 0xb8, 0x3c, 0x0a, 0xcd,
-	0xba, 0x4e, 0xbd, 0xe9, 0xbb, 0x4e, 0x57, 0x9c, 0xbc, 0x35, 0xf4, 0x61, 0xaa, 0x49, 0x43, 0xf7,
-	0xa1, 0x28, 0xf4, 0x09, 0x25, 0x41, 0xe8, 0x53, 0x71, 0xea, 0xd6, 0xd0, 0xaf, 0x63, 0x45, 0x1a,
-	0x3a, 0x01, 0xe4, 0x3d, 0x28, 0x5e, 0x9b, 0x03, 0x9e, 0x83, 0x82, 0x4d, 0x3a, 0xba, 0xd1, 0x0d,
-	0x28, 0xe3, 0xa3, 0x13, 0xb4, 0xbc, 0x4d, 0x3a, 0xb5, 0xe8, 0x1e, 0x3f, 0x82, 0xa9, 0xa8, 0x68,
-	0x12, 0xc6, 0x87, 0x23, 0x68, 0x93, 0x36, 0xe9, 0xec, 0x10, 0xf6, 0x26, 0x97, 0x17, 0x2a, 0x39,
-	0xf9, 0x1b, 0x82, 0x99, 0xe1, 0xd1, 0xe0, 0x25, 0xc0, 0x11, 0x41, 0x4c, 0xaa, 0x3b, 0xa1, 0xad,
-	0xf3, 0x21, 0xa7, 0xbe, 0x65, 0x9b, 0x74, 0x36, 0x4c, 0xfa, 0x2e, 0xb4, 0x79, 0x00, 0x86, 0xf7,
-	0xa1, 0x92, 0x8a, 0xd3, 0x05, 0x4c, 0x96, 0xe0, 0xb1, 0x12, 0x6f, 0xa0, 0x92, 0x6e, 0xa0, 0xb2,
-	0x95, 0x08, 0x6a, 0xf9, 0xf3, 0xcb, 0x6a, 0xe6, 0xcb, 0xef, 0x2a, 0xd2, 0x66, 0x62, 0xbf, 0xb4,
-	0x32, 0x7c, 0x14, 0x61, 0xf8, 0x28, 0xf2, 0x4b, 0x28, 0x8f, 0x6c, 0x01, 0x96, 0xa1, 0xe4, 0x85,
-	0x86, 0x7e, 0x4a, 0xbb, 0x3a, 0x6f, 0x9a, 0x88, 0x16, 0x84, 0xc5, 0x82, 0x56, 0xf4, 0x42, 0xe3,
-	0x2d, 0xed, 0x7e, 0x88, 0x1e, 0xad, 0xe5, 0xbf, 0x9f, 0x55, 0xd1, 0xdf, 0xb3, 0x2a, 0x92, 0x97,
-	0xa0, 0x34, 0xb4, 0x06, 0xb8, 0x02, 0x02, 0xf1, 0x3c, 0x7e, 0xb6, 0x9c, 0x16, 0x5d, 0x5e, 0x13,
-	0x1f, 0xc3, 0xf4, 0x2e, 0x61, 0x4d, 0xda, 0x48, 0xb4, 0x4f, 0xa1, 0xcc, 0x5b, 0xa1, 0x8f, 0xf6,
-	0xba, 0xc4, 0x1f, 0xef, 0xa7, 0x0d, 0x97, 0xa1, 0x34, 0xd0, 0x0d, 0xda, 0x5e, 0x4c, 0x55, 0x3b,
-	0x84, 0xc9, 0x9f, 0x11, 0x94, 0x47, 0x76, 0x03, 0xaf, 0x43, 0xc1, 0xf3, 0x69, 0xdd, 0xe2, 0x7b,
-	0x8c, 0xee, 0x6a, 0x61, 0x8e, 0xb7, 0x6f, 0x40, 0xe0, 0x2d, 0x28, 0xd9, 0x94, 0x31, 0x3e, 0x08,
-	0xda, 0x22, 0xdd, 0xbb, 0xa7, 0x10, 0x5b, 0x4c, 0x27, 0xd4, 0x56, 0x04, 0xc9, 0x3f, 0x11, 0x94,
-	0x86, 0x96, 0x0e, 0x37, 0x60, 0xbe, 0xed, 0x06, 0x54, 0xa7, 0x9d, 0x80, 0x3a, 0xd1, 0x9b, 0x98,
-	0x4e, 0x1d, 0x62, 0xb4, 0xa8, 0xde, 0xa4, 0x96, 0xd9, 0x0c, 0x92, 0xa8, 0x73, 0x37, 0xde, 0xb3,
-	0xe7, 0x04, 0x2f, 0x56, 0x8f, 0x48, 0x2b, 0xa4, 0xb5, 0xdc, 0xf9, 0x65, 0x15, 0x69, 0xb3, 0x91,
-	0xcf, 0x76, 0xdf, 0x66, 0x9b, 0xbb, 0xec, 0x72, 0x13, 0xfc, 0x1e, 0xb0, 0x67, 0x04, 0xa3, 0xd6,
-	0xd9, 0xfb, 0x5a, 0x57, 0x22, 0xf8, 0xba, 0xa1, 0x7c, 0x08, 0x30, 0xf8, 0x70, 0xf1, 0xc6, 0x7d,
-	0x0e, 0x21, 0xfc, 0x2f, 0xe1, 0x5a, 0x56, 0x44, 0xb5, 0x83, 0xaf, 0x3d, 0x09, 0x9d, 0xf7, 0x24,
-	0x74, 0xd1, 0x93, 0xd0, 0x9f, 0x9e, 0x84, 0x3e, 0x5d, 0x49, 0x99, 0x8b, 0x2b, 0x29, 0xf3, 0xeb,
-	0x4a, 0xca, 0x1c, 0xaf, 0x98, 0x56, 0xd0, 0x0c, 0x8d, 0xe8, 0x33, 0x56, 0xfb, 0x7f, 0xf9, 0xfe,
-	0x05, 0xf1, 0x2c, 0xf5, 0xc6, 0xbf, 0xdf, 0x98, 0xe4, 0x67, 0x7a, 0xf6, 0x2f, 0x00, 0x00, 0xff,
-	0xff, 0xd6, 0x46, 0x5b, 0x10, 0x17, 0x06, 0x00, 0x00,
+	// 776 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x95, 0xcf, 0x4f, 0xdb, 0x48,
+	0x14, 0xc7, 0x33, 0xb1, 0x81, 0x64, 0x42, 0x48, 0x76, 0xb4, 0xda, 0x35, 0xb0, 0x38, 0xac, 0x0f,
+	0x2b, 0x24, 0x24, 0x5b, 0xb0, 0xec, 0x1e, 0x90, 0x50, 0x4b, 0x80, 0x02, 0xad, 0x68, 0x91, 0xa9,
+	0x38, 0xa0, 0x4a, 0xd6, 0x38, 0x19, 0x1c, 0x8b, 0xf8, 0x87, 0x3c, 0xe3, 0x34, 0xf9, 0x27, 0xaa,
+	0x9e, 0xaa, 0x1e, 0x39, 0xb6, 0xff, 0x41, 0xfb, 0x1f, 0x70, 0xe4, 0xd8, 0x13, 0xad, 0xc2, 0xa5,
+	0x7f, 0x46, 0xe5, 0xb1, 0x9d, 0xe0, 0x10, 0xda, 0xdc, 0xc6, 0x7e, 0xdf, 0xcf, 0xf7, 0xbd, 0x79,
+	0xef, 0xc9, 0x86, 0x72, 0xc3, 0x73, 0x08, 0x33, 0xcf, 0x99, 0xc6, 0x7a, 0x3e, 0xa1, 0x5a, 0x67,
+	0x4d, 0xf3, 0x71, 0x80, 0x1d, 0xaa, 0xfa, 0x81, 0xc7, 0x3c, 0xf4, 0x5b, 0x1a, 0x57, 0x79, 0x5c,
+	0xed, 0xac, 0x2d, 0xfc, 0x6e, 0x79, 0x96, 0xc7, 0xa3, 0x5a, 0x74, 0x8a, 0x85, 0x0b, 0xb2, 0xe5,
+	0x79, 0x56, 0x9b, 0x68, 0xfc, 0xc9, 0x0c, 0xcf, 0xb5, 0x66, 0x18, 0x60, 0x66, 0x7b, 0xee, 0x43,
+	0xf1, 0xd7, 0x01, 0xf6, 0x7d, 0x12, 0x24, 0x89, 0x94, 0xcf, 0x02, 0xac, 0xec, 0x78, 0x2e, 0x25,
+	0x2e, 0x0d, 0xe9, 0x31, 0x2f, 0x01, 0x6d, 0xc0, 0x29, 0xb3, 0xed, 0x35, 0x2e, 0x24, 0xb0, 0x0c,
+	0x56, 0x4a, 0xeb, 0xb2, 0x7a, 0xaf, 0x18, 0xb5, 0x1e, 0xc5, 0x63, 0xb9, 0x1e, 0x8b, 0xd1, 0x16,
+	0x2c, 0x90, 0x8e, 0xdd, 0x24, 0x6e, 0x83, 0x48, 0x79, 0x0e, 0xfe, 0x3d, 0x06, 0xdc, 0x4b, 0x24,
+	0x09, 0x3b, 0x40, 0xd0, 0x63, 0x58, 0xec, 0xe0, 0xb6, 0xdd, 0xc4, 0xcc, 0x0b, 0x24, 0x81, 0xf3,
+	0xca, 0x18, 0xfe, 0x34, 0xd5, 0x24, 0x06, 0x43, 0x08, 0x6d, 0xc2, 0x99, 0x0e, 0x09, 0xa8, 0xed,
+	0xb9, 0x92, 0xc8, 0xf9, 0xe5, 0x71, 0x7c, 0xac, 0x48, 0xe8, 0x14, 0x40, 0xff, 0x41, 0x11, 0x9b,
+	0x0d, 0x5b, 0x9a, 0xe2, 0xe0, 0xd2, 0x18, 0x70, 0xbb, 0xbe, 0x73, 0x18, 0x53, 0xf5, 0xbc, 0x04,
+	0x74, 0x2e, 0x8f, 0x8a, 0xa6, 0x3d, 0xb7, 0xd1, 0x0a, 0x3c, 0xb7, 0x27, 0x4d, 0x3f, 0x58, 0xf4,
+	0x49, 0xaa, 0x49, 0x8b, 0x1e, 0x40, 0x51, 0xd1, 0xe7, 0x04, 0xb3, 0x30, 0x20, 0xd2, 0xcc, 0x83,
+	0x45, 0x3f, 0x89, 0x15, 0x69, 0xd1, 0x09, 0xa0, 0x1c, 0xc2, 0xd2, 0x9d, 0x39, 0xa0, 0x45, 0x58,
+	0x74, 0x70, 0xd7, 0x30, 0x7b, 0x8c, 0x50, 0x3e, 0x3a, 0x41, 0x2f, 0x38, 0xb8, 0x5b, 0x8f, 0x9e,
+	0xd1, 0x9f, 0x70, 0x26, 0x0a, 0x5a, 0x98, 0xf2, 0xe1, 0x08, 0xfa, 0xb4, 0x83, 0xbb, 0xfb, 0x98,
+	0x3e, 0x15, 0x0b, 0x42, 0x55, 0x54, 0x3e, 0x02, 0x38, 0x97, 0x1d, 0x0d, 0x5a, 0x85, 0x28, 0x22,
+	0xb0, 0x45, 0x0c, 0x37, 0x74, 0x0c, 0x3e, 0xe4, 0xd4, 0xb7, 0xe2, 0xe0, 0xee, 0xb6, 0x45, 0x9e,
+	0x87, 0x0e, 0x2f, 0x80, 0xa2, 0x23, 0x58, 0x4d, 0xc5, 0xe9, 0x02, 0x26, 0x4b, 0x30, 0xaf, 0xc6,
+	0x1b, 0xa8, 0xa6, 0x1b, 0xa8, 0xee, 0x26, 0x82, 0x7a, 0xe1, 0xea, 0xa6, 0x96, 0x7b, 0xff, 0xb5,
+	0x06, 0xf4, 0xb9, 0xd8, 0x2f, 0x8d, 0x64, 0xaf, 0x22, 0x64, 0xaf, 0xa2, 0x3c, 0x82, 0x95, 0x91,
+	0x2d, 0x40, 0x0a, 0x2c, 0xfb, 0xa1, 0x69, 0x5c, 0x90, 0x9e, 0xc1, 0x9b, 0x26, 0x81, 0x65, 0x61,
+	0xa5, 0xa8, 0x97, 0xfc, 0xd0, 0x7c, 0x46, 0x7a, 0x2f, 0xa3, 0x57, 0x9b, 0x85, 0x4f, 0x97, 0x35,
+	0xf0, 0xfd, 0xb2, 0x06, 0x94, 0x55, 0x58, 0xce, 0xac, 0x01, 0xaa, 0x42, 0x01, 0xfb, 0x3e, 0xbf,
+	0x9b, 0xa8, 0x47, 0xc7, 0x3b, 0xe2, 0x33, 0x38, 0x7b, 0x80, 0x69, 0x8b, 0x34, 0x13, 0xed, 0x3f,
+	0xb0, 0xc2, 0x5b, 0x61, 0x8c, 0xf6, 0xba, 0xcc, 0x5f, 0x1f, 0xa5, 0x0d, 0x57, 0x60, 0x79, 0xa8,
+	0x1b, 0xb6, 0xbd, 0x94, 0xaa, 0xf6, 0x31, 0x55, 0xde, 0x01, 0x58, 0x19, 0xd9, 0x0d, 0xb4, 0x05,
+	0x8b, 0x7e, 0x40, 0x1a, 0x36, 0xdf, 0x63, 0xf0, 0xab, 0x16, 0x8a, 0xbc, 0x7d, 0x43, 0x02, 0xed,
+	0xc2, 0xb2, 0x43, 0x28, 0xe5, 0x83, 0x20, 0x6d, 0xdc, 0x93, 0xf2, 0x93, 0x59, 0xcc, 0x26, 0xd4,
+	0x6e, 0x04, 0x29, 0x6f, 0x04, 0x58, 0xce, 0x2c, 0x1d, 0x6a, 0xc2, 0xa5, 0x8e, 0xc7, 0x88, 0x41,
+	0xba, 0x8c, 0xb8, 0x51, 0x26, 0x6a, 0x10, 0x17, 0x9b, 0x6d, 0x62, 0xb4, 0x88, 0x6d, 0xb5, 0x58,
+	0x52, 0xea, 0xe2, 0xbd, 0x3c, 0x87, 0x2e, 0xfb, 0x7f, 0xe3, 0x14, 0xb7, 0x43, 0x52, 0x17, 0xaf,
+	0x6e, 0x6a, 0x40, 0x5f, 0x88, 0x7c, 0xf6, 0x06, 0x36, 0x7b, 0xdc, 0xe5, 0x80, 0x9b, 0xa0, 0x17,
+	0x10, 0xf9, 0x26, 0x1b, 0xb5, 0xce, 0x4f, 0x6a, 0x5d, 0x8d, 0xe0, 0x8c, 0x61, 0x0b, 0xd6, 0xb0,
+	0x65, 0x05, 0xc4, 0xc2, 0x8c, 0x34, 0x8d, 0x86, 0xe7, 0x38, 0x36, 0x1b, 0x71, 0x17, 0x26, 0x75,
+	0xff, 0x6b, 0xe8, 0xb4, 0xc3, 0x8d, 0x32, 0x99, 0x5e, 0xc1, 0xf9, 0xd1, 0x06, 0x0d, 0x37, 0x44,
+	0x9c, 0x34, 0xc7, 0x1f, 0xd9, 0xe6, 0xa4, 0xdb, 0xa4, 0x9c, 0x40, 0x38, 0xfc, 0x00, 0xa1, 0xed,
+	0x49, 0x86, 0x21, 0xfc, 0xac, 0xd3, 0x9b, 0x79, 0x09, 0xd4, 0x8f, 0x3f, 0xf4, 0x65, 0x70, 0xd5,
+	0x97, 0xc1, 0x75, 0x5f, 0x06, 0xdf, 0xfa, 0x32, 0x78, 0x7b, 0x2b, 0xe7, 0xae, 0x6f, 0xe5, 0xdc,
+	0x97, 0x5b, 0x39, 0x77, 0xb6, 0x6e, 0xd9, 0xac, 0x15, 0x9a, 0xd1, 0xe7, 0x48, 0x1b, 0xfc, 0xad,
+	0x06, 0x07, 0xec, 0xdb, 0xda, 0xbd, 0x7f, 0x98, 0x39, 0xcd, 0x6f, 0xf6, 0xef, 0x8f, 0x01, 0x00,
+	0x25, 0x35, 0xbc, 0xba, 0xdf, 0x06, 0x00, 0x00,
 }
 
 func (this *ConsensusParams) Equal(that interface{}) bool {
@@ -900,6 +936,12 @@ func (this *FeatureParams) Equal(that interface{}) bool {
 	if !this.PbtsEnableHeight.Equal(that1.PbtsEnableHeight) {
 		return false
 	}
+	if !this.AggregatedCommitEnableHeight.Equal(that1.AggregatedCommitEnableHeight) {
+		return false
+	}
+	if !this.VoteExtensionsMaxBytes.Equal(that1.VoteExtensionsMaxBytes) {
+		return false
+	}
 	return true
 }
 func (this *ABCIParams) Equal(that interface{}) bool {
@@ -1263,6 +1305,30 @@ func (m *FeatureParams) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if m.VoteExtensionsMaxBytes != nil {
+		{
+			size, err := m.VoteExtensionsMaxBytes.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x22
+	}
+	if m.AggregatedCommitEnableHeight != nil {
+		{
+			size, err := m.AggregatedCommitEnableHeight.MarshalToSizedBuffer(dAtA[:i])
+			if err != nil {
+				return 0, err
+			}
+			i -= size
+			i = encodeVarintParams(dAtA, i, uint64(size))
+		}
+		i--
+		dAtA[i] = 0x1a
+	}
 	if m.PbtsEnableHeight != nil {
 		{
 			size, err := m.PbtsEnableHeight.MarshalToSizedBuffer(dAtA[:i])
@@ -1563,6 +1629,14 @@ func (m *FeatureParams) Size() (n int) {
 		l = m.PbtsEnableHeight.Size()
 		n += 1 + l + sovParams(uint64(l))
 	}
+	if m.AggregatedCommitEnableHeight != nil {
+		l = m.AggregatedCommitEnableHeight.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
+	if m.VoteExtensionsMaxBytes != nil {
+		l = m.VoteExtensionsMaxBytes.Size()
+		n += 1 + l + sovParams(uint64(l))
+	}
 	return n
 }
 
@@ -2557,6 +2631,78 @@ func (m *FeatureParams) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 3:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedCommitEnableHeight", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.AggregatedCommitEnableHeight == nil {
+				m.AggregatedCommitEnableHeight = &types.Int64Value{}
+			}
+			if err := m.AggregatedCommitEnableHeight.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
+		case 4:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field VoteExtensionsMaxBytes", wireType)
+			}
+			var msglen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowParams
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				msglen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if msglen < 0 {
+				return ErrInvalidLengthParams
+			}
+			postIndex := iNdEx + msglen
+			if postIndex < 0 {
+				return ErrInvalidLengthParams
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			if m.VoteExtensionsMaxBytes == nil {
+				m.VoteExtensionsMaxBytes = &types.Int64Value{}
+			}
+			if err := m.VoteExtensionsMaxBytes.Unmarshal(dAtA[iNdEx:postIndex]); err != nil {
+				return err
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipParams(dAtA[iNdEx:])
@@ -565,6 +565,10 @@ type Commit struct {
 	Round      int32       `protobuf:"varint,2,opt,name=round,proto3" json:"round,omitempty"`
 	BlockID    BlockID     `protobuf:"bytes,3,opt,name=block_id,json=blockId,proto3" json:"block_id"`
 	Signatures []CommitSig `protobuf:"bytes,4,rep,name=signatures,proto3" json:"signatures"`
+	// Aggregation of the signatures of all the non-absent CommitSigs, whose
+	// own signatures are then empty. Only set when all their validators have
+	// BLS12-381 keys, from the height at which aggregated commits are enabled.
+	AggregatedSignature []byte `protobuf:"bytes,5,opt,name=aggregated_signature,json=aggregatedSignature,proto3" json:"aggregated_signature,omitempty"`
 }
 
 func (m *Commit) Reset()         { *m = Commit{} }
@@ -628,6 +632,13 @@ func (m *Commit) GetSignatures() []CommitSig {
 	return nil
 }
 
+func (m *Commit) GetAggregatedSignature() []byte {
+	if m != nil {
+		return m.AggregatedSignature
+	}
+	return nil
+}
+
 // CommitSig is a part of the Vote included in a Commit.
 type CommitSig struct {
 	BlockIdFlag      BlockIDFlag `protobuf:"varint,1,opt,name=block_id_flag,json=blockIdFlag,proto3,enum=cometbft.types.v1.BlockIDFlag" json:"block_id_flag,omitempty"`
@@ -1206,90 +1217,91 @@ func init() {
 func init() { proto.RegisterFile("cometbft/types/v1/types.proto", fileDescriptor_8ea20b664d765b5f) }
 
 var fileDescriptor_8ea20b664d765b5f = []byte{
-	// 1314 bytes of a gzipped FileDescriptorProto
+	// 1332 bytes of a gzipped FileDescriptorProto
 	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x57, 0x4f, 0x6f, 0x1b, 0x45,
-	0x14, 0xcf, 0xda, 0xeb, 0x7f, 0xcf, 0x76, 0xe2, 0x2c, 0x11, 0x75, 0xdc, 0xd6, 0x31, 0xe6, 0x5f,
-	0x28, 0xc8, 0x6e, 0x02, 0x08, 0xb8, 0x20, 0xd5, 0x49, 0xda, 0x46, 0x34, 0x89, 0xb5, 0x76, 0x8b,
-	0x80, 0xc3, 0x6a, 0xed, 0x9d, 0xd8, 0xab, 0xda, 0x3b, 0xab, 0xdd, 0xb1, 0x49, 0xfa, 0x09, 0x50,
-	0x4f, 0x3d, 0x72, 0xe9, 0x09, 0x0e, 0x7c, 0x81, 0x1e, 0xb8, 0x22, 0x0e, 0x3d, 0xf6, 0x06, 0xa7,
-	0x82, 0x92, 0x0b, 0x1f, 0x03, 0xcd, 0x9b, 0xd9, 0xdd, 0x38, 0xb6, 0xd5, 0x88, 0x56, 0x20, 0x71,
-	0x9b, 0x79, 0xef, 0xf7, 0xde, 0xbc, 0x7d, 0xbf, 0xdf, 0x8c, 0xde, 0xc2, 0xd5, 0x2e, 0x1d, 0x12,
-	0xd6, 0x39, 0x64, 0x75, 0x76, 0xec, 0x12, 0xbf, 0x3e, 0xde, 0x10, 0x8b, 0x9a, 0xeb, 0x51, 0x46,
-	0xb5, 0xe5, 0xc0, 0x5d, 0x13, 0xd6, 0xf1, 0x46, 0xa9, 0x1c, 0x46, 0x74, 0xbd, 0x63, 0x97, 0x51,
-	0x1e, 0xe2, 0x7a, 0x94, 0x1e, 0x8a, 0x90, 0xd2, 0x1b, 0xd3, 0x19, 0xc7, 0xe6, 0xc0, 0xb6, 0x4c,
-	0x46, 0x3d, 0x09, 0x59, 0x0b, 0x21, 0x63, 0xe2, 0xf9, 0x36, 0x75, 0xce, 0x1d, 0x5b, 0x5a, 0xe9,
-	0xd1, 0x1e, 0xc5, 0x65, 0x9d, 0xaf, 0x82, 0xb0, 0x1e, 0xa5, 0xbd, 0x01, 0xa9, 0xe3, 0xae, 0x33,
-	0x3a, 0xac, 0x33, 0x7b, 0x48, 0x7c, 0x66, 0x0e, 0x5d, 0x01, 0xa8, 0x7e, 0x06, 0xf9, 0xa6, 0xe9,
-	0xb1, 0x16, 0x61, 0xb7, 0x89, 0x69, 0x11, 0x4f, 0x5b, 0x81, 0x04, 0xa3, 0xcc, 0x1c, 0x14, 0x95,
-	0x8a, 0xb2, 0x9e, 0xd7, 0xc5, 0x46, 0xd3, 0x40, 0xed, 0x9b, 0x7e, 0xbf, 0x18, 0xab, 0x28, 0xeb,
-	0x39, 0x1d, 0xd7, 0x55, 0x1b, 0x54, 0x1e, 0xca, 0x23, 0x6c, 0xc7, 0x22, 0x47, 0x41, 0x04, 0x6e,
-	0xb8, 0xb5, 0x73, 0xcc, 0x88, 0x2f, 0x43, 0xc4, 0x46, 0xfb, 0x18, 0x12, 0xf8, 0xe1, 0xc5, 0x78,
-	0x45, 0x59, 0xcf, 0x6e, 0xae, 0xd6, 0xc2, 0x66, 0x89, 0xce, 0xd4, 0xc6, 0x1b, 0xb5, 0x26, 0x07,
-	0x34, 0xd4, 0xa7, 0xcf, 0xd7, 0x16, 0x74, 0x81, 0xae, 0x0e, 0x21, 0xd5, 0x18, 0xd0, 0xee, 0xfd,
-	0xdd, 0xed, 0xb0, 0x12, 0x25, 0xaa, 0x44, 0xdb, 0x87, 0x25, 0xd7, 0xf4, 0x98, 0xe1, 0x13, 0x66,
-	0xf4, 0xf1, 0x33, 0xf0, 0xd4, 0xec, 0x66, 0xa5, 0x36, 0x45, 0x46, 0x6d, 0xe2, 0x73, 0xe5, 0x31,
-	0x79, 0xf7, 0xac, 0xb1, 0xfa, 0x97, 0x0a, 0x49, 0xd9, 0x8e, 0xcf, 0x21, 0x25, 0x1b, 0x8e, 0x27,
-	0x66, 0x37, 0xcb, 0x51, 0x4a, 0xe9, 0xe0, 0x49, 0xb7, 0xa8, 0xe3, 0x13, 0xc7, 0x1f, 0xf9, 0x32,
-	0x61, 0x10, 0xa4, 0xbd, 0x03, 0xe9, 0x6e, 0xdf, 0xb4, 0x1d, 0xc3, 0xb6, 0xb0, 0xa6, 0x4c, 0x23,
-	0x7b, 0xf2, 0x7c, 0x2d, 0xb5, 0xc5, 0x6d, 0xbb, 0xdb, 0x7a, 0x0a, 0x9d, 0xbb, 0x96, 0xf6, 0x3a,
-	0x24, 0xfb, 0xc4, 0xee, 0xf5, 0x19, 0x76, 0x26, 0xae, 0xcb, 0x9d, 0xf6, 0x29, 0xa8, 0x9c, 0xb2,
-	0xa2, 0x8a, 0x87, 0x97, 0x6a, 0x82, 0xcf, 0x5a, 0xc0, 0x67, 0xad, 0x1d, 0xf0, 0xd9, 0x48, 0xf3,
-	0x83, 0x1f, 0xfd, 0xb1, 0xa6, 0xe8, 0x18, 0xa1, 0x6d, 0x43, 0x7e, 0x60, 0xfa, 0xcc, 0xe8, 0xf0,
-	0xc6, 0xf1, 0xe3, 0x13, 0x32, 0xc5, 0x74, 0x4b, 0x64, 0x6f, 0x65, 0xed, 0x59, 0x1e, 0x26, 0x4c,
-	0x96, 0xb6, 0x0e, 0x05, 0xcc, 0xd2, 0xa5, 0xc3, 0xa1, 0xcd, 0x0c, 0x6c, 0x7d, 0x12, 0x5b, 0xbf,
-	0xc8, 0xed, 0x5b, 0x68, 0xbe, 0xcd, 0x49, 0xb8, 0x0c, 0x19, 0xcb, 0x64, 0xa6, 0x80, 0xa4, 0x10,
-	0x92, 0xe6, 0x06, 0x74, 0xbe, 0x0b, 0x4b, 0xa1, 0xa2, 0x7d, 0x01, 0x49, 0x8b, 0x2c, 0x91, 0x19,
-	0x81, 0xd7, 0x61, 0xc5, 0x21, 0x47, 0xcc, 0x38, 0x8f, 0xce, 0x20, 0x5a, 0xe3, 0xbe, 0x7b, 0x93,
-	0x11, 0x6f, 0xc3, 0x62, 0x37, 0xe8, 0xbe, 0xc0, 0x02, 0x62, 0xf3, 0xa1, 0x15, 0x61, 0xab, 0x90,
-	0x36, 0x5d, 0x57, 0x00, 0xb2, 0x08, 0x48, 0x99, 0xae, 0x8b, 0xae, 0x6b, 0xb0, 0x8c, 0xdf, 0xe8,
-	0x11, 0x7f, 0x34, 0x60, 0x32, 0x49, 0x0e, 0x31, 0x4b, 0xdc, 0xa1, 0x0b, 0x3b, 0x62, 0xdf, 0x84,
-	0x3c, 0x19, 0xdb, 0x16, 0x71, 0xba, 0x44, 0xe0, 0xf2, 0x88, 0xcb, 0x05, 0x46, 0x04, 0xbd, 0x07,
-	0x05, 0xd7, 0xa3, 0x2e, 0xf5, 0x89, 0x67, 0x98, 0x96, 0xe5, 0x11, 0xdf, 0x2f, 0x2e, 0x8a, 0x7c,
-	0x81, 0xfd, 0x86, 0x30, 0x57, 0x8b, 0xa0, 0x6e, 0x9b, 0xcc, 0xd4, 0x0a, 0x10, 0x67, 0x47, 0x7e,
-	0x51, 0xa9, 0xc4, 0xd7, 0x73, 0x3a, 0x5f, 0x56, 0x7f, 0x8e, 0x83, 0x7a, 0x8f, 0x32, 0xa2, 0x7d,
-	0x04, 0x2a, 0x67, 0x0a, 0xf5, 0xb7, 0x38, 0x53, 0xd2, 0x2d, 0xbb, 0xe7, 0x10, 0x6b, 0xcf, 0xef,
-	0xb5, 0x8f, 0x5d, 0xa2, 0x23, 0xfa, 0x8c, 0xa0, 0x62, 0x13, 0x82, 0x5a, 0x81, 0x84, 0x47, 0x47,
-	0x8e, 0x85, 0x3a, 0x4b, 0xe8, 0x62, 0xa3, 0xdd, 0x84, 0x74, 0xa8, 0x13, 0xf5, 0x85, 0x3a, 0x59,
-	0xe2, 0x3a, 0xe1, 0x32, 0x96, 0x06, 0x3d, 0xd5, 0x91, 0x72, 0x69, 0x40, 0x26, 0x7c, 0x61, 0x42,
-	0xc1, 0x5d, 0x44, 0xb3, 0x51, 0x98, 0xf6, 0x3e, 0x2c, 0x87, 0xec, 0x87, 0xed, 0x13, 0x9a, 0x2b,
-	0x84, 0x0e, 0xd9, 0xbf, 0x09, 0x61, 0x19, 0xe2, 0x19, 0x4a, 0xe1, 0x87, 0x45, 0xc2, 0xda, 0xc5,
-	0xf7, 0xe8, 0x0a, 0x64, 0x7c, 0xbb, 0xe7, 0x98, 0x6c, 0xe4, 0x11, 0xa9, 0xbd, 0xc8, 0xc0, 0xbd,
-	0xe4, 0x88, 0x11, 0x07, 0x2f, 0xba, 0xd0, 0x5a, 0x64, 0xd0, 0xea, 0xf0, 0x5a, 0xb8, 0x31, 0xa2,
-	0x2c, 0x42, 0x67, 0x5a, 0xe8, 0x6a, 0x05, 0x9e, 0xea, 0x2f, 0x0a, 0x24, 0xc5, 0xd5, 0x38, 0xc3,
-	0x83, 0x32, 0x9b, 0x87, 0xd8, 0x3c, 0x1e, 0xe2, 0x2f, 0xc5, 0x03, 0x84, 0x75, 0xfa, 0x45, 0xb5,
-	0x12, 0x5f, 0xcf, 0x6e, 0x5e, 0x99, 0x91, 0x49, 0x14, 0xd9, 0xb2, 0x7b, 0xf2, 0xee, 0x9f, 0x89,
-	0xaa, 0x3e, 0x57, 0x20, 0x13, 0xfa, 0xb5, 0x06, 0xe4, 0x83, 0xca, 0x8c, 0xc3, 0x81, 0xd9, 0x93,
-	0x72, 0x2c, 0xcf, 0x2f, 0xef, 0xe6, 0xc0, 0xec, 0xe9, 0x59, 0x59, 0x11, 0xdf, 0xcc, 0x66, 0x36,
-	0x36, 0x87, 0xd9, 0x09, 0x29, 0xc5, 0xff, 0x99, 0x94, 0x26, 0x48, 0x57, 0xcf, 0x91, 0x5e, 0x3d,
-	0x55, 0x60, 0x71, 0x87, 0x93, 0x67, 0x11, 0xeb, 0x3f, 0x65, 0xeb, 0x1b, 0xa9, 0x2f, 0x8b, 0x58,
-	0xc6, 0x14, 0x6d, 0x6f, 0xcd, 0x48, 0x39, 0x59, 0x75, 0x44, 0x9f, 0x16, 0xa4, 0x69, 0x45, 0x34,
-	0x3e, 0x89, 0xc1, 0xf2, 0x14, 0xfe, 0x7f, 0x48, 0xe7, 0xe4, 0x1d, 0x4e, 0x5c, 0xf0, 0x0e, 0x27,
-	0xe7, 0xde, 0xe1, 0x27, 0x31, 0x48, 0x37, 0xf1, 0xb5, 0x36, 0x07, 0xff, 0xca, 0x1b, 0x7c, 0x19,
-	0x32, 0x2e, 0x1d, 0x18, 0xc2, 0xa3, 0xa2, 0x27, 0xed, 0xd2, 0x81, 0x3e, 0x25, 0xb5, 0xc4, 0xab,
-	0x7a, 0xa0, 0x93, 0xaf, 0x80, 0x86, 0xd4, 0xf9, 0x5b, 0xc5, 0x20, 0x27, 0x7a, 0x21, 0x27, 0xa8,
-	0x0d, 0xde, 0x04, 0x9c, 0xc9, 0x94, 0xf3, 0x33, 0x5f, 0x58, 0xb7, 0x80, 0xea, 0x12, 0xc8, 0x43,
-	0xc4, 0xbc, 0x21, 0xc7, 0xb8, 0xd5, 0xb9, 0x2f, 0x97, 0x2e, 0x81, 0xd5, 0xef, 0x15, 0x80, 0x3b,
-	0xbc, 0xb9, 0xf8, 0xc5, 0x7c, 0xf8, 0xf1, 0xb1, 0x08, 0x63, 0xe2, 0xec, 0xb5, 0xb9, 0xc4, 0xc9,
-	0x0a, 0x72, 0xfe, 0xd9, 0xd2, 0xb7, 0x21, 0x1f, 0x09, 0xdc, 0x27, 0x41, 0x39, 0xb3, 0xb2, 0x84,
-	0x43, 0x49, 0x8b, 0x30, 0x3d, 0x37, 0x3e, 0xb3, 0xab, 0xfe, 0xaa, 0x40, 0x06, 0xab, 0xda, 0x23,
-	0xcc, 0x9c, 0x20, 0x52, 0x79, 0x09, 0x22, 0xaf, 0x02, 0x88, 0x3c, 0xbe, 0xfd, 0x80, 0x48, 0x7d,
-	0x65, 0xd0, 0xd2, 0xb2, 0x1f, 0x10, 0xed, 0x93, 0xb0, 0xeb, 0xf1, 0x17, 0x74, 0x5d, 0x3e, 0x1d,
-	0x41, 0xef, 0x2f, 0x41, 0xca, 0x19, 0x0d, 0x0d, 0x3e, 0x8c, 0xa8, 0x42, 0xb4, 0xce, 0x68, 0xd8,
-	0x3e, 0xf2, 0xab, 0xf7, 0x21, 0xd5, 0x3e, 0xc2, 0xd9, 0x9c, 0x2b, 0xd5, 0xa3, 0x54, 0x4e, 0x83,
-	0x62, 0x10, 0x4f, 0x73, 0x03, 0x0e, 0x3f, 0x1a, 0xa8, 0x7c, 0xec, 0x0b, 0x7e, 0x15, 0xf8, 0x5a,
-	0xab, 0x5f, 0x74, 0xec, 0x97, 0x03, 0xff, 0xb5, 0xdf, 0x14, 0xc8, 0x4f, 0xdc, 0x28, 0xed, 0x03,
-	0xb8, 0xd4, 0xda, 0xbd, 0xb5, 0xbf, 0xb3, 0x6d, 0xec, 0xb5, 0x6e, 0x19, 0xed, 0xaf, 0x9a, 0x3b,
-	0xc6, 0xdd, 0xfd, 0x2f, 0xf6, 0x0f, 0xbe, 0xdc, 0x2f, 0x2c, 0x94, 0x96, 0x1e, 0x3e, 0xae, 0x64,
-	0xef, 0x3a, 0xf7, 0x1d, 0xfa, 0xad, 0x33, 0x0f, 0xdd, 0xd4, 0x77, 0xee, 0x1d, 0xb4, 0x77, 0x0a,
-	0x8a, 0x40, 0x37, 0x3d, 0x32, 0xa6, 0x8c, 0x20, 0xfa, 0x3a, 0xac, 0xce, 0x40, 0x6f, 0x1d, 0xec,
-	0xed, 0xed, 0xb6, 0x0b, 0xb1, 0xd2, 0xf2, 0xc3, 0xc7, 0x95, 0x7c, 0xd3, 0x23, 0x42, 0x6a, 0x18,
-	0x51, 0x83, 0xe2, 0x74, 0xc4, 0x41, 0xf3, 0xa0, 0x75, 0xe3, 0x4e, 0xa1, 0x52, 0x2a, 0x3c, 0x7c,
-	0x5c, 0xc9, 0x05, 0x6f, 0x07, 0xc7, 0x97, 0xd2, 0xdf, 0xfd, 0x50, 0x5e, 0xf8, 0xe9, 0xc7, 0xb2,
-	0xd2, 0xb8, 0xf3, 0xf4, 0xa4, 0xac, 0x3c, 0x3b, 0x29, 0x2b, 0x7f, 0x9e, 0x94, 0x95, 0x47, 0xa7,
-	0xe5, 0x85, 0x67, 0xa7, 0xe5, 0x85, 0xdf, 0x4f, 0xcb, 0x0b, 0x5f, 0x6f, 0xf6, 0x6c, 0xd6, 0x1f,
-	0x75, 0x78, 0x6f, 0xea, 0xd1, 0x0f, 0x63, 0xb0, 0x30, 0x5d, 0xbb, 0x3e, 0xf5, 0x9b, 0xd8, 0x49,
-	0xe2, 0x9d, 0xfd, 0xf0, 0xef, 0x00, 0x00, 0x00, 0xff, 0xff, 0xdf, 0x98, 0x74, 0x84, 0x94, 0x0e,
-	0x00, 0x00,
+	0x14, 0xcf, 0xda, 0xeb, 0x7f, 0xcf, 0x76, 0xe2, 0x4c, 0x23, 0xea, 0xba, 0xad, 0x63, 0xcc, 0xbf,
+	0x50, 0x90, 0xdd, 0x04, 0x10, 0x70, 0x41, 0xaa, 0x93, 0xb4, 0x8d, 0x68, 0x12, 0x6b, 0xed, 0x16,
+	0x01, 0x87, 0xd5, 0xda, 0x3b, 0x59, 0xaf, 0x6a, 0xef, 0xac, 0x76, 0xc7, 0xc6, 0xe9, 0x27, 0x40,
+	0x3d, 0xf5, 0xc8, 0xa5, 0x27, 0x38, 0xf0, 0x05, 0x7a, 0xe0, 0xce, 0xa1, 0xc7, 0xde, 0xe0, 0x14,
+	0x50, 0x72, 0xe1, 0x0b, 0x70, 0x47, 0xf3, 0x67, 0x77, 0xed, 0xd8, 0x56, 0x0b, 0xad, 0x40, 0xe2,
+	0x36, 0xf3, 0xde, 0xef, 0xbd, 0x79, 0xef, 0xfd, 0x7e, 0xb3, 0x9a, 0x85, 0xab, 0x5d, 0x32, 0xc0,
+	0xb4, 0x73, 0x44, 0xeb, 0xf4, 0xd8, 0xc5, 0x7e, 0x7d, 0xb4, 0x29, 0x16, 0x35, 0xd7, 0x23, 0x94,
+	0xa0, 0xd5, 0xc0, 0x5d, 0x13, 0xd6, 0xd1, 0x66, 0xa9, 0x1c, 0x46, 0x74, 0xbd, 0x63, 0x97, 0x12,
+	0x16, 0xe2, 0x7a, 0x84, 0x1c, 0x89, 0x90, 0xd2, 0xeb, 0xb3, 0x19, 0x47, 0x46, 0xdf, 0x36, 0x0d,
+	0x4a, 0x3c, 0x09, 0x59, 0x0f, 0x21, 0x23, 0xec, 0xf9, 0x36, 0x71, 0xce, 0x1d, 0x5b, 0x5a, 0xb3,
+	0x88, 0x45, 0xf8, 0xb2, 0xce, 0x56, 0x41, 0x98, 0x45, 0x88, 0xd5, 0xc7, 0x75, 0xbe, 0xeb, 0x0c,
+	0x8f, 0xea, 0xd4, 0x1e, 0x60, 0x9f, 0x1a, 0x03, 0x57, 0x00, 0xaa, 0x9f, 0x42, 0xbe, 0x69, 0x78,
+	0xb4, 0x85, 0xe9, 0x6d, 0x6c, 0x98, 0xd8, 0x43, 0x6b, 0x90, 0xa0, 0x84, 0x1a, 0xfd, 0xa2, 0x52,
+	0x51, 0x36, 0xf2, 0x9a, 0xd8, 0x20, 0x04, 0x6a, 0xcf, 0xf0, 0x7b, 0xc5, 0x58, 0x45, 0xd9, 0xc8,
+	0x69, 0x7c, 0x5d, 0xb5, 0x41, 0x65, 0xa1, 0x2c, 0xc2, 0x76, 0x4c, 0x3c, 0x0e, 0x22, 0xf8, 0x86,
+	0x59, 0x3b, 0xc7, 0x14, 0xfb, 0x32, 0x44, 0x6c, 0xd0, 0x47, 0x90, 0xe0, 0x8d, 0x17, 0xe3, 0x15,
+	0x65, 0x23, 0xbb, 0x75, 0xa9, 0x16, 0x0e, 0x4b, 0x4c, 0xa6, 0x36, 0xda, 0xac, 0x35, 0x19, 0xa0,
+	0xa1, 0x3e, 0x3d, 0x59, 0x5f, 0xd2, 0x04, 0xba, 0x3a, 0x80, 0x54, 0xa3, 0x4f, 0xba, 0xf7, 0xf7,
+	0x76, 0xc2, 0x4a, 0x94, 0xa8, 0x12, 0x74, 0x00, 0x2b, 0xae, 0xe1, 0x51, 0xdd, 0xc7, 0x54, 0xef,
+	0xf1, 0x36, 0xf8, 0xa9, 0xd9, 0xad, 0x4a, 0x6d, 0x86, 0x8c, 0xda, 0x54, 0xbb, 0xf2, 0x98, 0xbc,
+	0x3b, 0x69, 0xac, 0xfe, 0xa1, 0x42, 0x52, 0x8e, 0xe3, 0x33, 0x48, 0xc9, 0x81, 0xf3, 0x13, 0xb3,
+	0x5b, 0xe5, 0x28, 0xa5, 0x74, 0xb0, 0xa4, 0xdb, 0xc4, 0xf1, 0xb1, 0xe3, 0x0f, 0x7d, 0x99, 0x30,
+	0x08, 0x42, 0x6f, 0x43, 0xba, 0xdb, 0x33, 0x6c, 0x47, 0xb7, 0x4d, 0x5e, 0x53, 0xa6, 0x91, 0x3d,
+	0x3d, 0x59, 0x4f, 0x6d, 0x33, 0xdb, 0xde, 0x8e, 0x96, 0xe2, 0xce, 0x3d, 0x13, 0xbd, 0x06, 0xc9,
+	0x1e, 0xb6, 0xad, 0x1e, 0xe5, 0x93, 0x89, 0x6b, 0x72, 0x87, 0x3e, 0x01, 0x95, 0x51, 0x56, 0x54,
+	0xf9, 0xe1, 0xa5, 0x9a, 0xe0, 0xb3, 0x16, 0xf0, 0x59, 0x6b, 0x07, 0x7c, 0x36, 0xd2, 0xec, 0xe0,
+	0x47, 0xbf, 0xad, 0x2b, 0x1a, 0x8f, 0x40, 0x3b, 0x90, 0xef, 0x1b, 0x3e, 0xd5, 0x3b, 0x6c, 0x70,
+	0xec, 0xf8, 0x84, 0x4c, 0x31, 0x3b, 0x12, 0x39, 0x5b, 0x59, 0x7b, 0x96, 0x85, 0x09, 0x93, 0x89,
+	0x36, 0xa0, 0xc0, 0xb3, 0x74, 0xc9, 0x60, 0x60, 0x53, 0x9d, 0x8f, 0x3e, 0xc9, 0x47, 0xbf, 0xcc,
+	0xec, 0xdb, 0xdc, 0x7c, 0x9b, 0x91, 0x70, 0x19, 0x32, 0xa6, 0x41, 0x0d, 0x01, 0x49, 0x71, 0x48,
+	0x9a, 0x19, 0xb8, 0xf3, 0x1d, 0x58, 0x09, 0x15, 0xed, 0x0b, 0x48, 0x5a, 0x64, 0x89, 0xcc, 0x1c,
+	0x78, 0x1d, 0xd6, 0x1c, 0x3c, 0xa6, 0xfa, 0x79, 0x74, 0x86, 0xa3, 0x11, 0xf3, 0xdd, 0x9b, 0x8e,
+	0x78, 0x0b, 0x96, 0xbb, 0xc1, 0xf4, 0x05, 0x16, 0x38, 0x36, 0x1f, 0x5a, 0x39, 0xec, 0x12, 0xa4,
+	0x0d, 0xd7, 0x15, 0x80, 0x2c, 0x07, 0xa4, 0x0c, 0xd7, 0xe5, 0xae, 0x6b, 0xb0, 0xca, 0x7b, 0xf4,
+	0xb0, 0x3f, 0xec, 0x53, 0x99, 0x24, 0xc7, 0x31, 0x2b, 0xcc, 0xa1, 0x09, 0x3b, 0xc7, 0xbe, 0x01,
+	0x79, 0x3c, 0xb2, 0x4d, 0xec, 0x74, 0xb1, 0xc0, 0xe5, 0x39, 0x2e, 0x17, 0x18, 0x39, 0xe8, 0x5d,
+	0x28, 0xb8, 0x1e, 0x71, 0x89, 0x8f, 0x3d, 0xdd, 0x30, 0x4d, 0x0f, 0xfb, 0x7e, 0x71, 0x59, 0xe4,
+	0x0b, 0xec, 0x37, 0x84, 0xb9, 0x5a, 0x04, 0x75, 0xc7, 0xa0, 0x06, 0x2a, 0x40, 0x9c, 0x8e, 0xfd,
+	0xa2, 0x52, 0x89, 0x6f, 0xe4, 0x34, 0xb6, 0xac, 0xfe, 0x14, 0x07, 0xf5, 0x1e, 0xa1, 0x18, 0x7d,
+	0x08, 0x2a, 0x63, 0x8a, 0xeb, 0x6f, 0x79, 0xae, 0xa4, 0x5b, 0xb6, 0xe5, 0x60, 0x73, 0xdf, 0xb7,
+	0xda, 0xc7, 0x2e, 0xd6, 0x38, 0x7a, 0x42, 0x50, 0xb1, 0x29, 0x41, 0xad, 0x41, 0xc2, 0x23, 0x43,
+	0xc7, 0xe4, 0x3a, 0x4b, 0x68, 0x62, 0x83, 0x6e, 0x42, 0x3a, 0xd4, 0x89, 0xfa, 0x5c, 0x9d, 0xac,
+	0x30, 0x9d, 0x30, 0x19, 0x4b, 0x83, 0x96, 0xea, 0x48, 0xb9, 0x34, 0x20, 0x13, 0x7e, 0x61, 0x8a,
+	0x89, 0xbf, 0xa1, 0xd9, 0x28, 0x0c, 0xbd, 0x07, 0xab, 0x21, 0xfb, 0xe1, 0xf8, 0x84, 0xe6, 0x0a,
+	0xa1, 0x43, 0xce, 0x6f, 0x4a, 0x58, 0xba, 0xf8, 0x0c, 0xa5, 0x78, 0x63, 0x91, 0xb0, 0xf6, 0x98,
+	0x15, 0x5d, 0x81, 0x8c, 0x6f, 0x5b, 0x8e, 0x41, 0x87, 0x1e, 0x96, 0xda, 0x8b, 0x0c, 0xcc, 0x8b,
+	0xc7, 0x14, 0x3b, 0xfc, 0xa2, 0x0b, 0xad, 0x45, 0x06, 0x54, 0x87, 0x0b, 0xe1, 0x46, 0x8f, 0xb2,
+	0x08, 0x9d, 0xa1, 0xd0, 0xd5, 0x0a, 0x3c, 0xd5, 0x3f, 0x15, 0x48, 0x8a, 0xab, 0x31, 0xc1, 0x83,
+	0x32, 0x9f, 0x87, 0xd8, 0x22, 0x1e, 0xe2, 0x2f, 0xc5, 0x03, 0x84, 0x75, 0xfa, 0x45, 0xb5, 0x12,
+	0xdf, 0xc8, 0x6e, 0x5d, 0x99, 0x93, 0x49, 0x14, 0xd9, 0xb2, 0x2d, 0x79, 0xf7, 0x27, 0xa2, 0xd0,
+	0x26, 0xac, 0x19, 0x96, 0xe5, 0x61, 0xcb, 0xa0, 0xd8, 0x9c, 0x68, 0x3b, 0xc1, 0xdb, 0xbe, 0x10,
+	0xf9, 0xa2, 0xbe, 0x4f, 0x14, 0xc8, 0x84, 0x29, 0x51, 0x03, 0xf2, 0x41, 0x33, 0xfa, 0x51, 0xdf,
+	0xb0, 0xa4, 0x82, 0xcb, 0x8b, 0x3b, 0xba, 0xd9, 0x37, 0x2c, 0x2d, 0x2b, 0x9b, 0x60, 0x9b, 0xf9,
+	0x62, 0x88, 0x2d, 0x10, 0xc3, 0x94, 0xfa, 0xe2, 0xff, 0x4c, 0x7d, 0x53, 0x3a, 0x51, 0xcf, 0xe9,
+	0xa4, 0x7a, 0xa6, 0xc0, 0xf2, 0x2e, 0xe3, 0xdb, 0xc4, 0xe6, 0x7f, 0x4a, 0xf0, 0xd7, 0x52, 0x92,
+	0xe6, 0x24, 0x35, 0x01, 0xd3, 0x6f, 0xce, 0x49, 0x39, 0x5d, 0x75, 0xc4, 0x38, 0x0a, 0xd2, 0x84,
+	0x2c, 0xfa, 0xd5, 0x27, 0x31, 0x58, 0x9d, 0xc1, 0xff, 0x0f, 0xe9, 0x9c, 0xbe, 0xf6, 0x89, 0x17,
+	0xbc, 0xf6, 0xc9, 0x85, 0xd7, 0xfe, 0x49, 0x0c, 0xd2, 0x4d, 0xfe, 0x81, 0x37, 0xfa, 0xff, 0xca,
+	0x67, 0xfb, 0x32, 0x64, 0x5c, 0xd2, 0xd7, 0x85, 0x47, 0xe5, 0x9e, 0xb4, 0x4b, 0xfa, 0xda, 0x8c,
+	0xd4, 0x12, 0xaf, 0xea, 0x9b, 0x9e, 0x7c, 0x05, 0x34, 0xa4, 0xce, 0xdf, 0x2a, 0x0a, 0x39, 0x31,
+	0x0b, 0xf9, 0xe8, 0xda, 0x64, 0x43, 0x60, 0xab, 0xa2, 0x72, 0xfe, 0x99, 0x18, 0xd6, 0x2d, 0xa0,
+	0x5a, 0xb2, 0x17, 0x86, 0x88, 0x27, 0x4a, 0x31, 0xb6, 0x30, 0x44, 0x48, 0x59, 0x93, 0xc0, 0xea,
+	0x77, 0x0a, 0xc0, 0x1d, 0x36, 0x5c, 0xde, 0x31, 0x7b, 0x2f, 0xf9, 0xbc, 0x08, 0x7d, 0xea, 0xec,
+	0xf5, 0x85, 0xc4, 0xc9, 0x0a, 0x72, 0xfe, 0x64, 0xe9, 0x3b, 0x90, 0x8f, 0x04, 0xee, 0xe3, 0xa0,
+	0x9c, 0x79, 0x59, 0xc2, 0x77, 0x4c, 0x0b, 0x53, 0x2d, 0x37, 0x9a, 0xd8, 0x55, 0x7f, 0x56, 0x20,
+	0xc3, 0xab, 0xda, 0xc7, 0xd4, 0x98, 0x22, 0x52, 0x79, 0x09, 0x22, 0xaf, 0x02, 0x88, 0x3c, 0xbe,
+	0xfd, 0x00, 0x4b, 0x7d, 0x65, 0xb8, 0xa5, 0x65, 0x3f, 0xc0, 0xe8, 0xe3, 0x70, 0xea, 0xf1, 0xe7,
+	0x4c, 0x5d, 0x7e, 0x3a, 0x82, 0xd9, 0x5f, 0x84, 0x94, 0x33, 0x1c, 0xe8, 0xec, 0xfd, 0xa2, 0x0a,
+	0xd1, 0x3a, 0xc3, 0x41, 0x7b, 0xec, 0x57, 0xef, 0x43, 0xaa, 0x3d, 0xe6, 0xcf, 0x79, 0xa6, 0x54,
+	0x8f, 0x10, 0xf9, 0x80, 0x14, 0x6f, 0xf7, 0x34, 0x33, 0xf0, 0xf7, 0x12, 0x02, 0x95, 0xbd, 0x14,
+	0x83, 0xbf, 0x0b, 0xb6, 0x46, 0xf5, 0x17, 0xfd, 0x53, 0x90, 0xff, 0x08, 0xd7, 0x7e, 0x51, 0x20,
+	0x3f, 0x75, 0xa3, 0xd0, 0xfb, 0x70, 0xb1, 0xb5, 0x77, 0xeb, 0x60, 0x77, 0x47, 0xdf, 0x6f, 0xdd,
+	0xd2, 0xdb, 0x5f, 0x36, 0x77, 0xf5, 0xbb, 0x07, 0x9f, 0x1f, 0x1c, 0x7e, 0x71, 0x50, 0x58, 0x2a,
+	0xad, 0x3c, 0x7c, 0x5c, 0xc9, 0xde, 0x75, 0xee, 0x3b, 0xe4, 0x1b, 0x67, 0x11, 0xba, 0xa9, 0xed,
+	0xde, 0x3b, 0x6c, 0xef, 0x16, 0x14, 0x81, 0x6e, 0x7a, 0x78, 0x44, 0x28, 0xe6, 0xe8, 0xeb, 0x70,
+	0x69, 0x0e, 0x7a, 0xfb, 0x70, 0x7f, 0x7f, 0xaf, 0x5d, 0x88, 0x95, 0x56, 0x1f, 0x3e, 0xae, 0xe4,
+	0x9b, 0x1e, 0x16, 0x52, 0xe3, 0x11, 0x35, 0x28, 0xce, 0x46, 0x1c, 0x36, 0x0f, 0x5b, 0x37, 0xee,
+	0x14, 0x2a, 0xa5, 0xc2, 0xc3, 0xc7, 0x95, 0x5c, 0xf0, 0xed, 0x60, 0xf8, 0x52, 0xfa, 0xdb, 0xef,
+	0xcb, 0x4b, 0x3f, 0xfe, 0x50, 0x56, 0x1a, 0x77, 0x9e, 0x9e, 0x96, 0x95, 0x67, 0xa7, 0x65, 0xe5,
+	0xf7, 0xd3, 0xb2, 0xf2, 0xe8, 0xac, 0xbc, 0xf4, 0xec, 0xac, 0xbc, 0xf4, 0xeb, 0x59, 0x79, 0xe9,
+	0xab, 0x2d, 0xcb, 0xa6, 0xbd, 0x61, 0x87, 0xcd, 0xa6, 0x1e, 0xfd, 0x63, 0x06, 0x0b, 0xc3, 0xb5,
+	0xeb, 0x33, 0x7f, 0x96, 0x9d, 0x24, 0xbf, 0xb3, 0x1f, 0xfc, 0x35, 0x00, 0xe4, 0x06, 0xde, 0xde,
+	0xc7, 0x0e, 0x00, 0x00,
 }
 
 func (m *PartSetHeader) Marshal() (dAtA []byte, err error) {
@@ -1679,6 +1691,13 @@ func (m *Commit) MarshalToSizedBuffer(dAtA []byte) (int, error) {
 	_ = i
 	var l int
 	_ = l
+	if len(m.AggregatedSignature) > 0 {
+		i -= len(m.AggregatedSignature)
+		copy(dAtA[i:], m.AggregatedSignature)
+		i = encodeVarintTypes(dAtA, i, uint64(len(m.AggregatedSignature)))
+		i--
+		dAtA[i] = 0x2a
+	}
 	if len(m.Signatures) > 0 {
 		for iNdEx := len(m.Signatures) - 1; iNdEx >= 0; iNdEx-- {
 			{
@@ -2345,6 +2364,10 @@ func (m *Commit) Size() (n int) {
 			n += 1 + l + sovTypes(uint64(l))
 		}
 	}
+	l = len(m.AggregatedSignature)
+	if l > 0 {
+		n += 1 + l + sovTypes(uint64(l))
+	}
 	return n
 }
 
@@ -3938,6 +3961,40 @@ func (m *Commit) Unmarshal(dAtA []byte) error {
 				return err
 			}
 			iNdEx = postIndex
+		case 5:
+			if wireType != 2 {
+				return fmt.Errorf("proto: wrong wireType = %d for field AggregatedSignature", wireType)
+			}
+			var byteLen int
+			for shift := uint(0); ; shift += 7 {
+				if shift >= 64 {
+					return ErrIntOverflowTypes
+				}
+				if iNdEx >= l {
+					return io.ErrUnexpectedEOF
+				}
+				b := dAtA[iNdEx]
+				iNdEx++
+				byteLen |= int(b&0x7F) << shift
+				if b < 0x80 {
+					break
+				}
+			}
+			if byteLen < 0 {
+				return ErrInvalidLengthTypes
+			}
+			postIndex := iNdEx + byteLen
+			if postIndex < 0 {
+				return ErrInvalidLengthTypes
+			}
+			if postIndex > l {
+				return io.ErrUnexpectedEOF
+			}
+			m.AggregatedSignature = append(m.AggregatedSignature[:0], dAtA[iNdEx:postIndex]...)
+			if m.AggregatedSignature == nil {
+				m.AggregatedSignature = []byte{}
+			}
+			iNdEx = postIndex
 		default:
 			iNdEx = preIndex
 			skippy, err := skipTypes(dAtA[iNdEx:])
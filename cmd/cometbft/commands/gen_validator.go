@@ -7,6 +7,7 @@ import (
 
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
 )
 
 // GenValidatorCmd allows the generation of a keypair for a
@@ -15,15 +16,26 @@ var GenValidatorCmd = &cobra.Command{
 	Use:     "gen-validator",
 	Aliases: []string{"gen_validator"},
 	Short:   "Generate new validator keypair",
-	Run:     genValidator,
+	RunE:    genValidator,
 }
 
-func genValidator(*cobra.Command, []string) {
-	pv := privval.GenFilePV("", "")
+var keyType string
+
+func init() {
+	GenValidatorCmd.Flags().StringVarP(&keyType, "key-type", "k", types.ABCIPubKeyTypeEd25519,
+		"type of the validator key (ed25519, secp256k1 or bls12_381)")
+}
+
+func genValidator(*cobra.Command, []string) error {
+	pv, err := privval.GenFilePVWithKeyType("", "", keyType)
+	if err != nil {
+		return fmt.Errorf("can't generate validator key: %w", err)
+	}
 	jsbz, err := cmtjson.Marshal(pv)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf(`%v
 `, string(jsbz))
+	return nil
 }
@@ -21,6 +21,11 @@ var InitFilesCmd = &cobra.Command{
 	RunE:  initFiles,
 }
 
+func init() {
+	InitFilesCmd.Flags().StringVarP(&keyType, "key-type", "k", types.ABCIPubKeyTypeEd25519,
+		"type of the validator key (ed25519, secp256k1 or bls12_381)")
+}
+
 func initFiles(*cobra.Command, []string) error {
 	return initFilesWithConfig(config)
 }
@@ -35,7 +40,11 @@ func initFilesWithConfig(config *cfg.Config) error {
 		logger.Info("Found private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
 	} else {
-		pv = privval.GenFilePV(privValKeyFile, privValStateFile)
+		var err error
+		pv, err = privval.GenFilePVWithKeyType(privValKeyFile, privValStateFile, keyType)
+		if err != nil {
+			return fmt.Errorf("can't generate private validator: %w", err)
+		}
 		pv.Save()
 		logger.Info("Generated private validator", "keyFile", privValKeyFile,
 			"stateFile", privValStateFile)
@@ -65,10 +74,18 @@ func initFilesWithConfig(config *cfg.Config) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		if pubKey.Type() != types.ABCIPubKeyTypeEd25519 {
+			genDoc.ConsensusParams.Validator.PubKeyTypes = []string{pubKey.Type()}
+		}
+		proof, err := pv.ProofOfPossession()
+		if err != nil {
+			return fmt.Errorf("can't prove possession of the private key: %w", err)
+		}
 		genDoc.Validators = []types.GenesisValidator{{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   10,
+			Address:           pubKey.Address(),
+			PubKey:            pubKey,
+			Power:             10,
+			ProofOfPossession: proof,
 		}}
 
 		if err := genDoc.SaveAs(genFile); err != nil {
@@ -44,14 +44,29 @@ either or both arguments.
 
 Note: This operation requires ABCI Responses. Do not set DiscardABCIResponses to true if you
 want to use this command.
+
+With --numeric-index, the tooling instead builds the numeric index of the kv tx indexer
+from the events it already indexed, and ignores the heights. Tx indexes created before
+the numeric index existed must be migrated this way, for range queries over numeric
+event attributes to be served from the numeric index.
 	`,
 	Example: `
 	cometbft reindex-event
 	cometbft reindex-event --start-height 2
 	cometbft reindex-event --end-height 10
 	cometbft reindex-event --start-height 2 --end-height 10
+	cometbft reindex-event --numeric-index
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if numericIndex {
+			if err := migrateNumericIndex(cmd, config); err != nil {
+				fmt.Println(reindexFailed, err)
+				return
+			}
+			fmt.Println("numeric index migration finished")
+			return
+		}
+
 		bs, ss, err := loadStateAndBlockStore(config)
 		if err != nil {
 			fmt.Println(reindexFailed, err)
@@ -92,13 +107,35 @@ want to use this command.
 }
 
 var (
-	startHeight int64
-	endHeight   int64
+	startHeight  int64
+	endHeight    int64
+	numericIndex bool
 )
 
 func init() {
 	ReIndexEventCmd.Flags().Int64Var(&startHeight, "start-height", 0, "the block height would like to start for re-index")
 	ReIndexEventCmd.Flags().Int64Var(&endHeight, "end-height", 0, "the block height would like to finish for re-index")
+	ReIndexEventCmd.Flags().BoolVar(&numericIndex, "numeric-index", false,
+		"build the numeric index of the kv tx indexer from its indexed events, instead of re-indexing events")
+}
+
+// migrateNumericIndex builds the numeric index of the kv tx indexer.
+func migrateNumericIndex(cmd *cobra.Command, cfg *cmtcfg.Config) error {
+	if strings.ToLower(cfg.TxIndex.Indexer) != "kv" {
+		return fmt.Errorf("the numeric index is only supported by the kv event sink, found: %s", cfg.TxIndex.Indexer)
+	}
+	store, err := dbm.NewDB("tx_index", dbm.BackendType(cfg.DBBackend), cfg.DBDir())
+	if err != nil {
+		return err
+	}
+	defer store.Close()
+
+	added, err := kv.NewTxIndex(store).MigrateNumericIndex(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("numeric index migration failed after adding %d entries: %w", added, err)
+	}
+	fmt.Printf("added %d entries to the numeric index\n", added)
+	return nil
 }
 
 func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, txindex.TxIndexer, error) {
@@ -122,6 +159,9 @@ func loadEventSinks(cfg *cmtcfg.Config, chainID string) (indexer.BlockIndexer, t
 		}
 
 		txIndexer := kv.NewTxIndex(store)
+		if err := txIndexer.InitNumericIndex(); err != nil {
+			return nil, nil, err
+		}
 		blockIndexer := blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")))
 		return blockIndexer, txIndexer, nil
 	default:
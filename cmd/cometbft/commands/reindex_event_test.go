@@ -188,3 +188,15 @@ func TestReIndexEvent(t *testing.T) {
 		}
 	}
 }
+
+func TestMigrateNumericIndex(t *testing.T) {
+	cmd := setupReIndexEventCmd()
+
+	cfg := cmtcfg.TestConfig()
+	cfg.DBPath = t.TempDir()
+	cfg.TxIndex.Indexer = "psql"
+	require.Error(t, migrateNumericIndex(cmd, cfg))
+
+	cfg.TxIndex.Indexer = "kv"
+	require.NoError(t, migrateNumericIndex(cmd, cfg))
+}
@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 
@@ -74,8 +75,7 @@ func resetPrivValidator(cmd *cobra.Command, _ []string) (err error) {
 		return err
 	}
 
-	resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
-	return nil
+	return resetFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile(), logger)
 }
 
 // resetAll removes address book files plus all data, and resets the privValdiator data.
@@ -97,8 +97,7 @@ func resetAll(dbDir, addrBookFile, privValKeyFile, privValStateFile string, logg
 	}
 
 	// recreate the dbDir since the privVal state needs to live there
-	resetFilePV(privValKeyFile, privValStateFile, logger)
-	return nil
+	return resetFilePV(privValKeyFile, privValStateFile, logger)
 }
 
 // resetState removes address book files plus all databases.
@@ -155,10 +154,12 @@ func resetState(dbDir string, logger log.Logger) error {
 	return nil
 }
 
-func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
+func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) error {
 	if _, err := os.Stat(privValKeyFile); err == nil {
 		pv := privval.LoadFilePVEmptyState(privValKeyFile, privValStateFile)
-		pv.Reset()
+		if err := pv.Reset(); err != nil {
+			return fmt.Errorf("can't reset private validator: %w", err)
+		}
 		logger.Info(
 			"Reset private validator file to genesis state",
 			"keyFile", privValKeyFile,
@@ -173,6 +174,7 @@ func resetFilePV(privValKeyFile, privValStateFile string, logger log.Logger) {
 			"stateFile", privValStateFile,
 		)
 	}
+	return nil
 }
 
 func removeAddrBook(addrBookFile string, logger log.Logger) {
@@ -16,6 +16,9 @@ import (
 var (
 	config = cfg.DefaultConfig()
 	logger = log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	// logFilter filters the log events by level, and can be changed when the
+	// config is reloaded.
+	logFilter *log.ReloadableFilter
 )
 
 func init() {
@@ -83,10 +86,12 @@ var RootCmd = &cobra.Command{
 			logger = log.NewTMJSONLogger(log.NewSyncWriter(os.Stdout))
 		}
 
-		logger, err = cmtflags.ParseLogLevel(config.LogLevel, logger, cfg.DefaultLogLevel)
+		logLevelOptions, err := cmtflags.ParseLogLevelOptions(config.LogLevel, cfg.DefaultLogLevel)
 		if err != nil {
 			return err
 		}
+		logFilter = log.NewReloadableFilter(logger, logLevelOptions...)
+		logger = logFilter
 
 		if viper.GetBool(cli.TraceFlag) {
 			logger = log.NewTracingLogger(logger)
@@ -3,9 +3,14 @@ package commands
 import (
 	"encoding/hex"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
+	cfg "github.com/cometbft/cometbft/config"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	nm "github.com/cometbft/cometbft/node"
 )
@@ -98,6 +103,10 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 			if err != nil {
 				return fmt.Errorf("failed to create node: %w", err)
 			}
+			n.SetLogFilter(logFilter)
+			n.SetConfigLoader(func() (*cfg.Config, error) {
+				return reloadConfig(cmd)
+			})
 
 			if err := n.Start(); err != nil {
 				return fmt.Errorf("failed to start node: %w", err)
@@ -114,6 +123,9 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 				}
 			})
 
+			// Reload the config upon receiving SIGHUP.
+			trapReloadSignal(n)
+
 			// Run forever.
 			select {}
 		},
@@ -122,3 +134,32 @@ func NewRunNodeCmd(nodeProvider nm.Provider) *cobra.Command {
 	AddNodeFlags(cmd)
 	return cmd
 }
+
+// reloadConfig reads the config file again, and returns the configuration
+// with the flags and environment variables applied as on startup.
+func reloadConfig(cmd *cobra.Command) (*cfg.Config, error) {
+	if err := viper.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	conf, err := ParseConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if len(genesisHash) != 0 {
+		conf.Storage.GenesisHash = hex.EncodeToString(genesisHash)
+	}
+	return conf, nil
+}
+
+func trapReloadSignal(n *nm.Node) {
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, syscall.SIGHUP)
+	go func() {
+		for range c {
+			logger.Info("SIGHUP received, reloading config")
+			if _, _, err := n.ReloadConfig(); err != nil {
+				logger.Error("Failed to reload config", "err", err)
+			}
+		}
+	}()
+}
@@ -148,11 +148,16 @@ func testnetFiles(*cobra.Command, []string) error {
 		if err != nil {
 			return fmt.Errorf("can't get pubkey: %w", err)
 		}
+		proof, err := pv.ProofOfPossession()
+		if err != nil {
+			return fmt.Errorf("can't prove possession of the private key: %w", err)
+		}
 		genVals[i] = types.GenesisValidator{
-			Address: pubKey.Address(),
-			PubKey:  pubKey,
-			Power:   1,
-			Name:    nodeDirName,
+			Address:           pubKey.Address(),
+			PubKey:            pubKey,
+			Power:             1,
+			Name:              nodeDirName,
+			ProofOfPossession: proof,
 		}
 	}
 
@@ -0,0 +1,160 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cometbft/cometbft/internal/consensus"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+var (
+	walFile       string
+	walDumpHeight int64
+	walDumpRound  int32
+	walTruncateAt int64
+)
+
+func init() {
+	WALCmd.PersistentFlags().StringVar(&walFile, "wal-file", "",
+		"path to the head of the WAL (default: the consensus.wal_file of the config)")
+
+	walDumpCmd.Flags().Int64Var(&walDumpHeight, "height", 0, "only dump the messages of this height (0 means all)")
+	walDumpCmd.Flags().Int32Var(&walDumpRound, "round", -1, "only dump the messages of this round (-1 means all)")
+
+	walTruncateCmd.Flags().Int64Var(&walTruncateAt, "height", 0,
+		"remove the messages following the end of this height")
+	_ = walTruncateCmd.MarkFlagRequired("height")
+
+	WALCmd.AddCommand(walDumpCmd, walVerifyCmd, walRepairCmd, walTruncateCmd)
+}
+
+// WALCmd is the root of the commands inspecting and fixing the consensus WAL
+// of a stopped node.
+var WALCmd = &cobra.Command{
+	Use:   "wal",
+	Short: "inspect and fix the consensus write-ahead log",
+	Long: `
+The wal commands read the consensus write-ahead log (WAL), which is made of the
+head file (consensus.wal_file) and its rotated files. The node must be stopped.
+`,
+}
+
+var walDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "print the messages of the WAL as JSON lines",
+	Long: `
+Prints the messages of the WAL, from the oldest to the newest, as one JSON object
+per line. With --height and --round, only the messages of the given height and
+round are printed. The EndHeightMessage of a height belongs to that height and
+has no round.
+`,
+	Example: `
+	cometbft wal dump
+	cometbft wal dump --height 10 --round 1
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out := cmd.OutOrStdout()
+		return consensus.ReadWAL(walFilePath(), func(msg *consensus.TimedWALMessage) error {
+			if walDumpHeight > 0 {
+				if h, ok := consensus.WALMessageHeight(msg.Msg); !ok || h != walDumpHeight {
+					return nil
+				}
+			}
+			if walDumpRound >= 0 {
+				if r, ok := consensus.WALMessageRound(msg.Msg); !ok || r != walDumpRound {
+					return nil
+				}
+			}
+			bz, err := cmtjson.Marshal(msg)
+			if err != nil {
+				return fmt.Errorf("failed to marshal msg: %w", err)
+			}
+			_, err = fmt.Fprintln(out, string(bz))
+			return err
+		})
+	},
+}
+
+var walVerifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "check the checksums and the encoding of the messages of the WAL",
+	Long: `
+Decodes all the messages of the WAL and reports the first corrupted one. The
+command fails if the WAL is corrupted.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		res, err := consensus.VerifyWAL(walFilePath())
+		if err != nil {
+			return err
+		}
+		out := cmd.OutOrStdout()
+		fmt.Fprintf(out, "Files: %d\nMessages: %d\nLast end height: %d\n",
+			len(res.Files), res.Messages, res.LastEndHeight)
+		if res.CorruptedAt != nil {
+			return fmt.Errorf("WAL is corrupted at %v: %s", res.CorruptedAt, res.Corruption)
+		}
+		fmt.Fprintln(out, "WAL is not corrupted")
+		return nil
+	},
+}
+
+var walRepairCmd = &cobra.Command{
+	Use:   "repair",
+	Short: "remove the corrupted messages at the end of the WAL head",
+	Long: `
+Backs up the head of the WAL to <head>.CORRUPTED, then rewrites the head with the
+messages preceding the first corrupted one, as the node does when it finds the
+WAL corrupted on start. The rotated files are left untouched.
+`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		head := walFilePath()
+		if !cmtos.FileExists(head) {
+			return fmt.Errorf("WAL file %s does not exist", head)
+		}
+		corrupted := head + ".CORRUPTED"
+		if err := cmtos.CopyFile(head, corrupted); err != nil {
+			return fmt.Errorf("failed to back up WAL file: %w", err)
+		}
+		if err := consensus.RepairWALFile(corrupted, head); err != nil {
+			return fmt.Errorf("failed to repair WAL file: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Repaired %s (backup: %s)\n", head, corrupted)
+		return nil
+	},
+}
+
+var walTruncateCmd = &cobra.Command{
+	Use:   "truncate",
+	Short: "remove the messages following the end of a height from the WAL",
+	Long: `
+Removes the messages following the EndHeightMessage of the given height, so that
+on restart the node replays the WAL from the next height. This is needed after
+rolling back the state of the node, for its WAL to match its state. The rotated
+files following the end of the height are removed.
+`,
+	Example: `
+	cometbft rollback
+	cometbft wal truncate --height 9
+	`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if walTruncateAt < 0 {
+			return errors.New("height can't be negative")
+		}
+		if err := consensus.TruncateWAL(walFilePath(), walTruncateAt); err != nil {
+			return fmt.Errorf("failed to truncate WAL: %w", err)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Truncated WAL after the end of height %d\n", walTruncateAt)
+		return nil
+	},
+}
+
+func walFilePath() string {
+	if walFile != "" {
+		return walFile
+	}
+	return config.Consensus.WalFile()
+}
@@ -0,0 +1,49 @@
+package commands
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/internal/consensus"
+	"github.com/cometbft/cometbft/internal/test"
+)
+
+func TestWALCmd(t *testing.T) {
+	walBody, err := consensus.WALWithNBlocks(t, 3, test.ResetTestRoot(t.Name()))
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "wal")
+	require.NoError(t, os.WriteFile(file, walBody, 0o600))
+
+	run := func(args ...string) (string, error) {
+		out := new(bytes.Buffer)
+		WALCmd.SetOut(out)
+		WALCmd.SetArgs(append(args, "--wal-file", file))
+		err := WALCmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("dump", "--height", "2")
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	require.NotEmpty(t, lines)
+	require.Contains(t, lines[len(lines)-1], `"tendermint/wal/EndHeightMessage","value":{"height":"2"}`)
+
+	out, err = run("verify")
+	require.NoError(t, err)
+	require.Contains(t, out, "Last end height: 2")
+
+	_, err = run("truncate", "--height", "1")
+	require.NoError(t, err)
+	out, err = run("verify")
+	require.NoError(t, err)
+	require.Contains(t, out, "Last end height: 1")
+
+	_, err = run("repair")
+	require.NoError(t, err)
+	require.FileExists(t, file+".CORRUPTED")
+}
@@ -55,3 +55,9 @@ endif
 ifeq (pebbledb,$(findstring pebbledb,$(COMETBFT_BUILD_OPTIONS)))
   BUILD_TAGS += pebbledb
 endif
+
+# handle bls12381
+ifeq (bls12381,$(findstring bls12381,$(COMETBFT_BUILD_OPTIONS)))
+  CGO_ENABLED=1
+  BUILD_TAGS += bls12381
+endif
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"time"
 
+	cmtstrings "github.com/cometbft/cometbft/internal/strings"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
 	"github.com/cometbft/cometbft/version"
 )
@@ -48,8 +50,12 @@ const (
 	v1 = "v1"
 	v2 = "v2"
 
-	MempoolTypeFlood = "flood"
-	MempoolTypeNop   = "nop"
+	MempoolTypeFlood    = "flood"
+	MempoolTypeNop      = "nop"
+	MempoolTypePriority = "priority"
+
+	P2PTransportTCP  = "tcp"
+	P2PTransportQUIC = "quic"
 )
 
 // NOTE: Most of the structs & relevant comments + the
@@ -246,16 +252,51 @@ type BaseConfig struct {
 	// Path to the JSON file containing the last sign state of a validator
 	PrivValidatorState string `mapstructure:"priv_validator_state_file"`
 
+	// Connection string of a PostgreSQL database in which to keep the last
+	// sign state of the validator instead of PrivValidatorState, so that the
+	// nodes sharing the validator key and the database never sign
+	// conflicting votes or proposals.
+	PrivValidatorStatePsqlConn string `mapstructure:"priv_validator_state_psql_conn"`
+
 	// TCP or UNIX socket address for CometBFT to listen on for
-	// connections from an external PrivValidator process
+	// connections from an external PrivValidator process, or the address of
+	// a remote signer serving the gRPC PrivValidatorAPI, prefixed with
+	// grpc:// (e.g. grpc://127.0.0.1:26659)
 	PrivValidatorListenAddr string `mapstructure:"priv_validator_laddr"`
 
+	// Paths to the certificate and private key used by CometBFT to
+	// authenticate with a gRPC remote signer, and to the certificate of the
+	// CA used to verify the remote signer's certificate. Either all or none
+	// of them must be set. If none is set, PrivValidatorInsecure must be true.
+	PrivValidatorClientCertificate string `mapstructure:"priv_validator_client_certificate_file"`
+	PrivValidatorClientKey         string `mapstructure:"priv_validator_client_key_file"`
+	PrivValidatorRootCA            string `mapstructure:"priv_validator_root_ca_file"`
+
+	// If true, CometBFT connects to a gRPC remote signer without encryption
+	// nor authentication when the files above are not set. Only meant for
+	// testing: anyone able to reach the remote signer can make it sign.
+	PrivValidatorInsecure bool `mapstructure:"priv_validator_insecure"`
+
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `mapstructure:"node_key_file"`
 
 	// Mechanism to connect to the ABCI application: socket | grpc
 	ABCI string `mapstructure:"abci"`
 
+	// If true, the mempool, query and snapshot connections to a remote ABCI
+	// application are re-established when the application restarts, instead
+	// of stopping the node. The consensus connection is never re-established.
+	ABCIReconnect bool `mapstructure:"abci_reconnect"`
+
+	// Bounds of the delay between two attempts to re-establish a connection
+	// to the ABCI application. The delay doubles after each failed attempt.
+	ABCIReconnectMinBackoff time.Duration `mapstructure:"abci_reconnect_min_backoff"`
+	ABCIReconnectMaxBackoff time.Duration `mapstructure:"abci_reconnect_max_backoff"`
+
+	// Number of parallel connections used to serve the queries to a remote
+	// ABCI application.
+	ABCIQueryConnections int `mapstructure:"abci_query_connections"`
+
 	// If true, query the ABCI app on connecting to a new peer
 	// so the app can decide if we should keep the connection or not
 	FilterPeers bool `mapstructure:"filter_peers"` // false
@@ -264,19 +305,23 @@ type BaseConfig struct {
 // DefaultBaseConfig returns a default base configuration for a CometBFT node.
 func DefaultBaseConfig() BaseConfig {
 	return BaseConfig{
-		Version:            version.CMTSemVer,
-		Genesis:            defaultGenesisJSONPath,
-		PrivValidatorKey:   defaultPrivValKeyPath,
-		PrivValidatorState: defaultPrivValStatePath,
-		NodeKey:            defaultNodeKeyPath,
-		Moniker:            defaultMoniker,
-		ProxyApp:           "tcp://127.0.0.1:26658",
-		ABCI:               "socket",
-		LogLevel:           DefaultLogLevel,
-		LogFormat:          LogFormatPlain,
-		FilterPeers:        false,
-		DBBackend:          "goleveldb",
-		DBPath:             DefaultDataDir,
+		Version:                 version.CMTSemVer,
+		Genesis:                 defaultGenesisJSONPath,
+		PrivValidatorKey:        defaultPrivValKeyPath,
+		PrivValidatorState:      defaultPrivValStatePath,
+		NodeKey:                 defaultNodeKeyPath,
+		Moniker:                 defaultMoniker,
+		ProxyApp:                "tcp://127.0.0.1:26658",
+		ABCI:                    "socket",
+		ABCIReconnect:           false,
+		ABCIReconnectMinBackoff: 100 * time.Millisecond,
+		ABCIReconnectMaxBackoff: 10 * time.Second,
+		ABCIQueryConnections:    1,
+		LogLevel:                DefaultLogLevel,
+		LogFormat:               LogFormatPlain,
+		FilterPeers:             false,
+		DBBackend:               "goleveldb",
+		DBPath:                  DefaultDataDir,
 	}
 }
 
@@ -303,6 +348,32 @@ func (cfg BaseConfig) PrivValidatorStateFile() string {
 	return rootify(cfg.PrivValidatorState, cfg.RootDir)
 }
 
+// PrivValidatorClientCertificateFile returns the full path to the
+// certificate used to authenticate with a gRPC remote signer.
+func (cfg BaseConfig) PrivValidatorClientCertificateFile() string {
+	return rootify(cfg.PrivValidatorClientCertificate, cfg.RootDir)
+}
+
+// PrivValidatorClientKeyFile returns the full path to the private key used
+// to authenticate with a gRPC remote signer.
+func (cfg BaseConfig) PrivValidatorClientKeyFile() string {
+	return rootify(cfg.PrivValidatorClientKey, cfg.RootDir)
+}
+
+// PrivValidatorRootCAFile returns the full path to the certificate of the CA
+// used to verify a gRPC remote signer.
+func (cfg BaseConfig) PrivValidatorRootCAFile() string {
+	return rootify(cfg.PrivValidatorRootCA, cfg.RootDir)
+}
+
+// ArePrivValidatorClientAndRootCAKeysSet returns true if the certificates
+// and key used to connect to a gRPC remote signer over mutual TLS are set.
+func (cfg BaseConfig) ArePrivValidatorClientAndRootCAKeysSet() bool {
+	return cfg.PrivValidatorClientCertificate != "" &&
+		cfg.PrivValidatorClientKey != "" &&
+		cfg.PrivValidatorRootCA != ""
+}
+
 // NodeKeyFile returns the full path to the node_key.json file.
 func (cfg BaseConfig) NodeKeyFile() string {
 	return rootify(cfg.NodeKey, cfg.RootDir)
@@ -327,6 +398,31 @@ func (cfg BaseConfig) ValidateBasic() error {
 	default:
 		return errors.New("unknown log_format (must be 'plain' or 'json')")
 	}
+
+	if cfg.PrivValidatorStatePsqlConn != "" && cfg.PrivValidatorListenAddr != "" {
+		return errors.New("priv_validator_state_psql_conn cannot be used with priv_validator_laddr")
+	}
+
+	tlsFiles := 0
+	for _, file := range []string{cfg.PrivValidatorClientCertificate, cfg.PrivValidatorClientKey, cfg.PrivValidatorRootCA} {
+		if file != "" {
+			tlsFiles++
+		}
+	}
+	if tlsFiles != 0 && tlsFiles != 3 {
+		return errors.New("priv_validator_client_certificate_file, priv_validator_client_key_file " +
+			"and priv_validator_root_ca_file must either all be set or all be empty")
+	}
+
+	if cfg.ABCIReconnectMinBackoff <= 0 {
+		return errors.New("abci_reconnect_min_backoff must be positive")
+	}
+	if cfg.ABCIReconnectMaxBackoff < cfg.ABCIReconnectMinBackoff {
+		return errors.New("abci_reconnect_max_backoff can't be lower than abci_reconnect_min_backoff")
+	}
+	if cfg.ABCIQueryConnections < 1 {
+		return errors.New("abci_query_connections must be at least 1")
+	}
 	return nil
 }
 
@@ -546,6 +642,14 @@ type GRPCConfig struct {
 	// If no height is provided, the block results of the latest height are returned
 	BlockResultsService *GRPCBlockResultsServiceConfig `mapstructure:"block_results_service"`
 
+	// The gRPC stream service streams the blocks, block results and events of
+	// each height from a given height onwards
+	StreamService *GRPCStreamServiceConfig `mapstructure:"stream_service"`
+
+	// The gRPC tx status service provides the lifecycle of the transactions
+	// submitted to the mempool
+	TxStatusService *GRPCTxStatusServiceConfig `mapstructure:"tx_status_service"`
+
 	// The "privileged" section provides configuration for the gRPC server
 	// dedicated to privileged clients.
 	Privileged *GRPCPrivilegedConfig `mapstructure:"privileged"`
@@ -557,6 +661,8 @@ func DefaultGRPCConfig() *GRPCConfig {
 		VersionService:      DefaultGRPCVersionServiceConfig(),
 		BlockService:        DefaultGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		StreamService:       DefaultGRPCStreamServiceConfig(),
+		TxStatusService:     DefaultGRPCTxStatusServiceConfig(),
 		Privileged:          DefaultGRPCPrivilegedConfig(),
 	}
 }
@@ -567,6 +673,8 @@ func TestGRPCConfig() *GRPCConfig {
 		VersionService:      TestGRPCVersionServiceConfig(),
 		BlockService:        TestGRPCBlockServiceConfig(),
 		BlockResultsService: DefaultGRPCBlockResultsServiceConfig(),
+		StreamService:       TestGRPCStreamServiceConfig(),
+		TxStatusService:     TestGRPCTxStatusServiceConfig(),
 		Privileged:          TestGRPCPrivilegedConfig(),
 	}
 }
@@ -626,6 +734,38 @@ func TestGRPCBlockServiceConfig() *GRPCBlockServiceConfig {
 	}
 }
 
+type GRPCStreamServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCStreamServiceConfig() *GRPCStreamServiceConfig {
+	return &GRPCStreamServiceConfig{
+		Enabled: true,
+	}
+}
+
+func TestGRPCStreamServiceConfig() *GRPCStreamServiceConfig {
+	return &GRPCStreamServiceConfig{
+		Enabled: true,
+	}
+}
+
+type GRPCTxStatusServiceConfig struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
+func DefaultGRPCTxStatusServiceConfig() *GRPCTxStatusServiceConfig {
+	return &GRPCTxStatusServiceConfig{
+		Enabled: true,
+	}
+}
+
+func TestGRPCTxStatusServiceConfig() *GRPCTxStatusServiceConfig {
+	return &GRPCTxStatusServiceConfig{
+		Enabled: true,
+	}
+}
+
 //-----------------------------------------------------------------------------
 // GRPCPrivilegedConfig
 
@@ -681,6 +821,10 @@ type P2PConfig struct { //nolint: maligned
 	// Address to listen for incoming connections
 	ListenAddress string `mapstructure:"laddr"`
 
+	// Transport used to connect to peers: "tcp" or "quic". All the peers of
+	// a node must use the same transport.
+	Transport string `mapstructure:"transport"`
+
 	// Address to advertise to peers for them to dial
 	ExternalAddress string `mapstructure:"external_address"`
 
@@ -722,6 +866,10 @@ type P2PConfig struct { //nolint: maligned
 	// Rate at which packets can be received, in bytes/second
 	RecvRate int64 `mapstructure:"recv_rate"`
 
+	// Overrides of the priority, send queue capacity and rates of specific
+	// channels
+	Channels []P2PChannelConfig `mapstructure:"channels"`
+
 	// Set true to enable the peer-exchange reactor
 	PexReactor bool `mapstructure:"pex"`
 
@@ -738,6 +886,22 @@ type P2PConfig struct { //nolint: maligned
 	// Toggle to disable guard against peers connecting from the same ip.
 	AllowDuplicateIP bool `mapstructure:"allow_duplicate_ip"`
 
+	// Comma separated list of CIDR ranges peers must belong to. Empty means
+	// peers can connect from any address not in DeniedCIDRs
+	AllowedCIDRs string `mapstructure:"allowed_cidrs"`
+
+	// Comma separated list of CIDR ranges peers must not belong to
+	DeniedCIDRs string `mapstructure:"denied_cidrs"`
+
+	// Maximum number of connections with peers in the same subnet. Zero means
+	// unlimited
+	MaxConnsPerSubnet int `mapstructure:"max_conns_per_subnet"`
+
+	// Length of the prefix of the subnets of IPv4 and IPv6 peers, used by
+	// MaxConnsPerSubnet
+	IPv4SubnetPrefixLen int `mapstructure:"ipv4_subnet_prefix_len"`
+	IPv6SubnetPrefixLen int `mapstructure:"ipv6_subnet_prefix_len"`
+
 	// Peer connection configuration.
 	HandshakeTimeout time.Duration `mapstructure:"handshake_timeout"`
 	DialTimeout      time.Duration `mapstructure:"dial_timeout"`
@@ -750,10 +914,50 @@ type P2PConfig struct { //nolint: maligned
 	TestFuzzConfig *FuzzConnConfig `mapstructure:"test_fuzz_config"`
 }
 
+// P2PChannelConfig overrides the defaults set by the reactors for a p2p
+// channel. Zero values keep the defaults.
+type P2PChannelConfig struct {
+	// ID of the channel, e.g. 0x30 for the mempool
+	ID byte `mapstructure:"id"`
+
+	// Priority of the channel, relative to the other channels
+	Priority int `mapstructure:"priority"`
+
+	// Maximum number of messages waiting to be sent to a peer
+	SendQueueCapacity int `mapstructure:"send_queue_capacity"`
+
+	// Rate at which the messages of the channel can be sent to a peer, in
+	// bytes/second. Zero means only send_rate applies
+	SendRate int64 `mapstructure:"send_rate"`
+
+	// Rate at which the messages of the channel can be received from a peer,
+	// in bytes/second. Zero means only recv_rate applies
+	RecvRate int64 `mapstructure:"recv_rate"`
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg P2PChannelConfig) ValidateBasic() error {
+	if cfg.Priority < 0 {
+		return cmterrors.ErrNegativeField{Field: "priority"}
+	}
+	if cfg.SendQueueCapacity < 0 {
+		return cmterrors.ErrNegativeField{Field: "send_queue_capacity"}
+	}
+	if cfg.SendRate < 0 {
+		return cmterrors.ErrNegativeField{Field: "send_rate"}
+	}
+	if cfg.RecvRate < 0 {
+		return cmterrors.ErrNegativeField{Field: "recv_rate"}
+	}
+	return nil
+}
+
 // DefaultP2PConfig returns a default configuration for the peer-to-peer layer.
 func DefaultP2PConfig() *P2PConfig {
 	return &P2PConfig{
 		ListenAddress:                "tcp://0.0.0.0:26656",
+		Transport:                    P2PTransportTCP,
 		ExternalAddress:              "",
 		AddrBook:                     defaultAddrBookPath,
 		AddrBookStrict:               true,
@@ -767,6 +971,9 @@ func DefaultP2PConfig() *P2PConfig {
 		PexReactor:                   true,
 		SeedMode:                     false,
 		AllowDuplicateIP:             false,
+		MaxConnsPerSubnet:            0,
+		IPv4SubnetPrefixLen:          24,
+		IPv6SubnetPrefixLen:          48,
 		HandshakeTimeout:             20 * time.Second,
 		DialTimeout:                  3 * time.Second,
 		TestDialFail:                 false,
@@ -792,6 +999,12 @@ func (cfg *P2PConfig) AddrBookFile() string {
 // ValidateBasic performs basic validation (checking param bounds, etc.) and
 // returns an error if any check fails.
 func (cfg *P2PConfig) ValidateBasic() error {
+	switch cfg.Transport {
+	case P2PTransportTCP, P2PTransportQUIC:
+	case "": // allow empty string to be backwards compatible
+	default:
+		return fmt.Errorf("unknown p2p transport: %q", cfg.Transport)
+	}
 	if cfg.MaxNumInboundPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_num_inbound_peers"}
 	}
@@ -813,6 +1026,30 @@ func (cfg *P2PConfig) ValidateBasic() error {
 	if cfg.RecvRate < 0 {
 		return cmterrors.ErrNegativeField{Field: "recv_rate"}
 	}
+	seen := make(map[byte]struct{}, len(cfg.Channels))
+	for _, ch := range cfg.Channels {
+		if _, ok := seen[ch.ID]; ok {
+			return fmt.Errorf("duplicate channel %#x in channels", ch.ID)
+		}
+		seen[ch.ID] = struct{}{}
+		if err := ch.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid channel %#x: %w", ch.ID, err)
+		}
+	}
+	for _, cidr := range cmtstrings.SplitAndTrimEmpty(cfg.AllowedCIDRs+","+cfg.DeniedCIDRs, ",", " ") {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+	}
+	if cfg.MaxConnsPerSubnet < 0 {
+		return cmterrors.ErrNegativeField{Field: "max_conns_per_subnet"}
+	}
+	if cfg.IPv4SubnetPrefixLen < 0 || cfg.IPv4SubnetPrefixLen > 32 {
+		return errors.New("ipv4_subnet_prefix_len must be between 0 and 32")
+	}
+	if cfg.IPv6SubnetPrefixLen < 0 || cfg.IPv6SubnetPrefixLen > 128 {
+		return errors.New("ipv6_subnet_prefix_len must be between 0 and 128")
+	}
 	return nil
 }
 
@@ -849,9 +1086,12 @@ type MempoolConfig struct {
 	// The type of mempool for this node to use.
 	//
 	//  Possible types:
-	//  - "flood" : concurrent linked list mempool with flooding gossip protocol
-	//  (default)
-	//  - "nop"   : nop-mempool (short for no operation; the ABCI app is
+	//  - "flood"    : concurrent linked list mempool with flooding gossip
+	//  protocol (default)
+	//  - "priority" : mempool ordering txs by the priority assigned by the app
+	//  in CheckTx, evicting lower priority txs when full; uses the same
+	//  flooding gossip protocol as "flood"
+	//  - "nop"      : nop-mempool (short for no operation; the ABCI app is
 	//  responsible for storing, disseminating and proposing txs).
 	//  "create_empty_blocks=false" is not supported.
 	Type string `mapstructure:"type"`
@@ -874,7 +1114,9 @@ type MempoolConfig struct {
 	// WalPath (default: "") configures the location of the Write Ahead Log
 	// (WAL) for the mempool. The WAL is disabled by default. To enable, set
 	// WalPath to where you want the WAL to be written (e.g.
-	// "data/mempool.wal").
+	// "data/mempool.wal"). When enabled, the transactions in the mempool are
+	// restored from the WAL after a restart, provided they are still valid
+	// for the application. Only supported by the "flood" mempool.
 	WalPath string `mapstructure:"wal_dir"`
 	// Maximum number of transactions in the mempool
 	Size int `mapstructure:"size"`
@@ -891,6 +1133,17 @@ type MempoolConfig struct {
 	// Maximum size of a single transaction
 	// NOTE: the max size of a tx transmitted over the network is {max_tx_bytes}.
 	MaxTxBytes int `mapstructure:"max_tx_bytes"`
+	// Number of txs whose lifecycle (received, checked, removed, included) is
+	// kept for the tx_status RPC. The least recently updated txs are
+	// forgotten first. 0 disables the tracking.
+	TxStatusHistorySize int `mapstructure:"tx_status_history_size"`
+	// TTLDuration, if non-zero, defines the maximum amount of time a tx can
+	// exist in the mempool. Expired txs are removed on the next Update.
+	TTLDuration time.Duration `mapstructure:"ttl-duration"`
+	// TTLNumBlocks, if non-zero, defines the maximum number of blocks a tx
+	// can exist in the mempool for. Expired txs are removed on the next
+	// Update.
+	TTLNumBlocks int64 `mapstructure:"ttl-num-blocks"`
 	// Experimental parameters to limit gossiping txs to up to the specified number of peers.
 	// We use two independent upper values for persistent and non-persistent peers.
 	// Unconditional peers are not affected by this feature.
@@ -905,6 +1158,11 @@ type MempoolConfig struct {
 	// performance results using the default P2P configuration.
 	ExperimentalMaxGossipConnectionsToPersistentPeers    int `mapstructure:"experimental_max_gossip_connections_to_persistent_peers"`
 	ExperimentalMaxGossipConnectionsToNonPersistentPeers int `mapstructure:"experimental_max_gossip_connections_to_non_persistent_peers"`
+	// ExperimentalTxAnnouncements (default: false) enables announcing txs by
+	// their hash to the peers that also enabled it, instead of sending the
+	// whole txs. Those peers request only the txs they haven't seen yet. The
+	// other peers keep receiving the whole txs.
+	ExperimentalTxAnnouncements bool `mapstructure:"experimental_tx_announcements"`
 }
 
 // DefaultMempoolConfig returns a default configuration for the CometBFT mempool.
@@ -916,12 +1174,16 @@ func DefaultMempoolConfig() *MempoolConfig {
 		WalPath:   "",
 		// Each signature verification takes .5ms, Size reduced until we implement
 		// ABCI Recheck
-		Size:        5000,
-		MaxTxsBytes: 1024 * 1024 * 1024, // 1GB
-		CacheSize:   10000,
-		MaxTxBytes:  1024 * 1024, // 1MB
+		Size:                5000,
+		MaxTxsBytes:         1024 * 1024 * 1024, // 1GB
+		CacheSize:           10000,
+		MaxTxBytes:          1024 * 1024, // 1MB
+		TxStatusHistorySize: 10000,
+		TTLDuration:         0 * time.Second,
+		TTLNumBlocks:        0,
 		ExperimentalMaxGossipConnectionsToNonPersistentPeers: 0,
 		ExperimentalMaxGossipConnectionsToPersistentPeers:    0,
+		ExperimentalTxAnnouncements:                          false,
 	}
 }
 
@@ -946,11 +1208,14 @@ func (cfg *MempoolConfig) WalEnabled() bool {
 // returns an error if any check fails.
 func (cfg *MempoolConfig) ValidateBasic() error {
 	switch cfg.Type {
-	case MempoolTypeFlood, MempoolTypeNop:
+	case MempoolTypeFlood, MempoolTypePriority, MempoolTypeNop:
 	case "": // allow empty string to be backwards compatible
 	default:
 		return fmt.Errorf("unknown mempool type: %q", cfg.Type)
 	}
+	if cfg.Type == MempoolTypePriority && cfg.WalEnabled() {
+		return errors.New("wal_dir is not supported by the priority mempool")
+	}
 	if cfg.Size < 0 {
 		return cmterrors.ErrNegativeField{Field: "size"}
 	}
@@ -963,6 +1228,15 @@ func (cfg *MempoolConfig) ValidateBasic() error {
 	if cfg.MaxTxBytes < 0 {
 		return cmterrors.ErrNegativeField{Field: "max_tx_bytes"}
 	}
+	if cfg.TxStatusHistorySize < 0 {
+		return cmterrors.ErrNegativeField{Field: "tx_status_history_size"}
+	}
+	if cfg.TTLDuration < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl-duration"}
+	}
+	if cfg.TTLNumBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "ttl-num-blocks"}
+	}
 	if cfg.ExperimentalMaxGossipConnectionsToPersistentPeers < 0 {
 		return cmterrors.ErrNegativeField{Field: "experimental_max_gossip_connections_to_persistent_peers"}
 	}
@@ -986,6 +1260,18 @@ type StateSyncConfig struct {
 	DiscoveryTime       time.Duration `mapstructure:"discovery_time"`
 	ChunkRequestTimeout time.Duration `mapstructure:"chunk_request_timeout"`
 	ChunkFetchers       int32         `mapstructure:"chunk_fetchers"`
+
+	// Number of most recent blocks included, along with their validator sets
+	// and consensus params, in the block snapshots served by CometBFT
+	// alongside each of the two most recent application snapshots. 0
+	// disables block snapshots.
+	SnapshotNumBlocks int64 `mapstructure:"snapshot_num_blocks"`
+	// If true, restore the block snapshot at the height of the restored
+	// application snapshot, if a peer serves one, so that the node can serve
+	// the restored blocks to other nodes. The consensus params of the restored
+	// blocks are not restored, since only their block size limits are
+	// verified against the block headers.
+	RestoreBlockSnapshots bool `mapstructure:"restore_block_snapshots"`
 }
 
 func (cfg *StateSyncConfig) TrustHashBytes() []byte {
@@ -1000,10 +1286,12 @@ func (cfg *StateSyncConfig) TrustHashBytes() []byte {
 // DefaultStateSyncConfig returns a default configuration for the state sync service.
 func DefaultStateSyncConfig() *StateSyncConfig {
 	return &StateSyncConfig{
-		TrustPeriod:         168 * time.Hour,
-		DiscoveryTime:       15 * time.Second,
-		ChunkRequestTimeout: 10 * time.Second,
-		ChunkFetchers:       4,
+		TrustPeriod:           168 * time.Hour,
+		DiscoveryTime:         15 * time.Second,
+		ChunkRequestTimeout:   10 * time.Second,
+		ChunkFetchers:         4,
+		SnapshotNumBlocks:     0,
+		RestoreBlockSnapshots: false,
 	}
 }
 
@@ -1014,6 +1302,10 @@ func TestStateSyncConfig() *StateSyncConfig {
 
 // ValidateBasic performs basic validation.
 func (cfg *StateSyncConfig) ValidateBasic() error {
+	if cfg.SnapshotNumBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "snapshot_num_blocks"}
+	}
+
 	if cfg.Enable {
 		if len(cfg.RPCServers) == 0 {
 			return cmterrors.ErrRequiredField{Field: "rpc_servers"}
@@ -1125,6 +1417,19 @@ type ConsensusConfig struct {
 	// Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 	SkipTimeoutCommit bool `mapstructure:"skip_timeout_commit"`
 
+	// Compute the propose, prevote and precommit timeouts from the latencies
+	// observed in the latest rounds instead of using the static timeouts
+	// above. The *_delta timeouts are still added for each round.
+	AdaptiveTimeouts bool `mapstructure:"adaptive_timeouts"`
+	// Number of latest rounds whose latencies are used to compute the timeouts
+	AdaptiveTimeoutWindow int `mapstructure:"adaptive_timeout_window"`
+	// Bounds of the adaptive timeout_propose
+	AdaptiveTimeoutProposeMin time.Duration `mapstructure:"adaptive_timeout_propose_min"`
+	AdaptiveTimeoutProposeMax time.Duration `mapstructure:"adaptive_timeout_propose_max"`
+	// Bounds of the adaptive timeout_prevote and timeout_precommit
+	AdaptiveTimeoutVoteMin time.Duration `mapstructure:"adaptive_timeout_vote_min"`
+	AdaptiveTimeoutVoteMax time.Duration `mapstructure:"adaptive_timeout_vote_max"`
+
 	// EmptyBlocks mode and possible interval between empty blocks
 	CreateEmptyBlocks         bool          `mapstructure:"create_empty_blocks"`
 	CreateEmptyBlocksInterval time.Duration `mapstructure:"create_empty_blocks_interval"`
@@ -1149,6 +1454,12 @@ func DefaultConsensusConfig() *ConsensusConfig {
 		TimeoutPrecommitDelta:            500 * time.Millisecond,
 		TimeoutCommit:                    1000 * time.Millisecond,
 		SkipTimeoutCommit:                false,
+		AdaptiveTimeouts:                 false,
+		AdaptiveTimeoutWindow:            100,
+		AdaptiveTimeoutProposeMin:        1000 * time.Millisecond,
+		AdaptiveTimeoutProposeMax:        10000 * time.Millisecond,
+		AdaptiveTimeoutVoteMin:           500 * time.Millisecond,
+		AdaptiveTimeoutVoteMax:           5000 * time.Millisecond,
 		CreateEmptyBlocks:                true,
 		CreateEmptyBlocksInterval:        0 * time.Second,
 		PeerGossipSleepDuration:          100 * time.Millisecond,
@@ -1245,6 +1556,23 @@ func (cfg *ConsensusConfig) ValidateBasic() error {
 	if cfg.TimeoutCommit < 0 {
 		return cmterrors.ErrNegativeField{Field: "timeout_commit"}
 	}
+	if cfg.AdaptiveTimeouts {
+		if cfg.AdaptiveTimeoutWindow <= 0 {
+			return errors.New("adaptive_timeout_window must be positive")
+		}
+		if cfg.AdaptiveTimeoutProposeMin < 0 {
+			return cmterrors.ErrNegativeField{Field: "adaptive_timeout_propose_min"}
+		}
+		if cfg.AdaptiveTimeoutProposeMax < cfg.AdaptiveTimeoutProposeMin {
+			return errors.New("adaptive_timeout_propose_max can't be less than adaptive_timeout_propose_min")
+		}
+		if cfg.AdaptiveTimeoutVoteMin < 0 {
+			return cmterrors.ErrNegativeField{Field: "adaptive_timeout_vote_min"}
+		}
+		if cfg.AdaptiveTimeoutVoteMax < cfg.AdaptiveTimeoutVoteMin {
+			return errors.New("adaptive_timeout_vote_max can't be less than adaptive_timeout_vote_min")
+		}
+	}
 	if cfg.CreateEmptyBlocksInterval < 0 {
 		return cmterrors.ErrNegativeField{Field: "create_empty_blocks_interval"}
 	}
@@ -1450,21 +1778,28 @@ func getDefaultMoniker() string {
 type PruningConfig struct {
 	// The time period between automated background pruning operations.
 	Interval time.Duration `mapstructure:"interval"`
+	// Number of latest heights whose extended commits (with the vote
+	// extensions) are kept. The extended commits of the lower heights are
+	// pruned even if their blocks are kept. 0 keeps the extended commits of
+	// all the blocks.
+	ExtendedCommitRetainBlocks int64 `mapstructure:"extended_commit_retain_blocks"`
 	// Data companion-related pruning configuration.
 	DataCompanion *DataCompanionPruningConfig `mapstructure:"data_companion"`
 }
 
 func DefaultPruningConfig() *PruningConfig {
 	return &PruningConfig{
-		Interval:      DefaultPruningInterval,
-		DataCompanion: DefaultDataCompanionPruningConfig(),
+		Interval:                   DefaultPruningInterval,
+		ExtendedCommitRetainBlocks: 0,
+		DataCompanion:              DefaultDataCompanionPruningConfig(),
 	}
 }
 
 func TestPruningConfig() *PruningConfig {
 	return &PruningConfig{
-		Interval:      DefaultPruningInterval,
-		DataCompanion: TestDataCompanionPruningConfig(),
+		Interval:                   DefaultPruningInterval,
+		ExtendedCommitRetainBlocks: 0,
+		DataCompanion:              TestDataCompanionPruningConfig(),
 	}
 }
 
@@ -1472,6 +1807,9 @@ func (cfg *PruningConfig) ValidateBasic() error {
 	if cfg.Interval <= 0 {
 		return errors.New("interval must be > 0")
 	}
+	if cfg.ExtendedCommitRetainBlocks < 0 {
+		return cmterrors.ErrNegativeField{Field: "extended_commit_retain_blocks"}
+	}
 	if err := cfg.DataCompanion.ValidateBasic(); err != nil {
 		return fmt.Errorf("error in [data_companion] section: %w", err)
 	}
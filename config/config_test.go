@@ -68,6 +68,33 @@ func TestBaseConfigValidateBasic(t *testing.T) {
 	// tamper with log format
 	cfg.LogFormat = "invalid"
 	require.Error(t, cfg.ValidateBasic())
+	cfg.LogFormat = config.LogFormatPlain
+
+	// the last sign state is only kept in a database by a local signer
+	cfg.PrivValidatorStatePsqlConn = "postgresql://localhost/db"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.PrivValidatorListenAddr = "tcp://127.0.0.1:26659"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorStatePsqlConn = ""
+	cfg.PrivValidatorListenAddr = ""
+
+	// the remote signer TLS files must all be set, or none of them
+	cfg.PrivValidatorClientCertificate = "client.crt"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.PrivValidatorClientKey = "client.key"
+	cfg.PrivValidatorRootCA = "ca.crt"
+	require.NoError(t, cfg.ValidateBasic())
+	require.True(t, cfg.ArePrivValidatorClientAndRootCAKeysSet())
+
+	// the reconnection backoff must be positive and ordered
+	cfg.ABCIReconnectMinBackoff = 0
+	require.Error(t, cfg.ValidateBasic())
+	cfg.ABCIReconnectMinBackoff = cfg.ABCIReconnectMaxBackoff + time.Second
+	require.Error(t, cfg.ValidateBasic())
+	cfg.ABCIReconnectMinBackoff = time.Second
+
+	cfg.ABCIQueryConnections = 0
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestRPCConfigValidateBasic(t *testing.T) {
@@ -108,6 +135,35 @@ func TestP2PConfigValidateBasic(t *testing.T) {
 		require.Error(t, cfg.ValidateBasic())
 		reflect.ValueOf(cfg).Elem().FieldByName(fieldName).SetInt(0)
 	}
+
+	cfg.Transport = config.P2PTransportQUIC
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.Transport = "udp"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.Transport = config.P2PTransportTCP
+
+	cfg.Channels = []config.P2PChannelConfig{{ID: 0x30, Priority: 1, SendRate: 1024}, {ID: 0x20}}
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.Channels[1].RecvRate = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.Channels[1] = config.P2PChannelConfig{ID: 0x30}
+	require.Error(t, cfg.ValidateBasic(), "duplicate channel")
+	cfg.Channels = nil
+
+	cfg.AllowedCIDRs = "10.0.0.0/8, 2001:db8::/32"
+	cfg.DeniedCIDRs = "10.1.0.0/16"
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.DeniedCIDRs = "10.1.0.0"
+	require.Error(t, cfg.ValidateBasic())
+	cfg.DeniedCIDRs = ""
+	cfg.MaxConnsPerSubnet = -1
+	require.Error(t, cfg.ValidateBasic())
+	cfg.MaxConnsPerSubnet = 2
+	cfg.IPv4SubnetPrefixLen = 33
+	require.Error(t, cfg.ValidateBasic())
+	cfg.IPv4SubnetPrefixLen = 24
+	cfg.IPv6SubnetPrefixLen = 129
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestMempoolConfigValidateBasic(t *testing.T) {
@@ -119,6 +175,8 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 		"MaxTxsBytes",
 		"CacheSize",
 		"MaxTxBytes",
+		"TTLDuration",
+		"TTLNumBlocks",
 	}
 
 	for _, fieldName := range fieldsToTest {
@@ -129,6 +187,12 @@ func TestMempoolConfigValidateBasic(t *testing.T) {
 
 	reflect.ValueOf(cfg).Elem().FieldByName("Type").SetString("invalid")
 	require.Error(t, cfg.ValidateBasic())
+
+	// the priority mempool has no WAL
+	cfg.Type = config.MempoolTypePriority
+	require.NoError(t, cfg.ValidateBasic())
+	cfg.WalPath = "data/mempool.wal"
+	require.Error(t, cfg.ValidateBasic())
 }
 
 func TestStateSyncConfigValidateBasic(t *testing.T) {
@@ -173,6 +237,18 @@ func TestConsensusConfig_ValidateBasic(t *testing.T) {
 		"PeerQueryMaj23SleepDuration":          {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = time.Second }, false},
 		"PeerQueryMaj23SleepDuration negative": {func(c *config.ConsensusConfig) { c.PeerQueryMaj23SleepDuration = -1 }, true},
 		"DoubleSignCheckHeight negative":       {func(c *config.ConsensusConfig) { c.DoubleSignCheckHeight = -1 }, true},
+		"AdaptiveTimeouts":                     {func(c *config.ConsensusConfig) { c.AdaptiveTimeouts = true }, false},
+		"AdaptiveTimeoutWindow zero":           {func(c *config.ConsensusConfig) { c.AdaptiveTimeouts = true; c.AdaptiveTimeoutWindow = 0 }, true},
+		"AdaptiveTimeoutProposeMin negative":   {func(c *config.ConsensusConfig) { c.AdaptiveTimeouts = true; c.AdaptiveTimeoutProposeMin = -1 }, true},
+		"AdaptiveTimeoutProposeMax < min": {func(c *config.ConsensusConfig) {
+			c.AdaptiveTimeouts = true
+			c.AdaptiveTimeoutProposeMax = time.Millisecond
+		}, true},
+		"AdaptiveTimeoutVoteMin negative": {func(c *config.ConsensusConfig) { c.AdaptiveTimeouts = true; c.AdaptiveTimeoutVoteMin = -1 }, true},
+		"AdaptiveTimeoutVoteMax < min": {func(c *config.ConsensusConfig) {
+			c.AdaptiveTimeouts = true
+			c.AdaptiveTimeoutVoteMax = time.Millisecond
+		}, true},
 	}
 	for desc, tc := range testcases {
 		tc := tc // appease linter
@@ -198,3 +274,19 @@ func TestInstrumentationConfigValidateBasic(t *testing.T) {
 	cfg.MaxOpenConnections = -1
 	require.Error(t, cfg.ValidateBasic())
 }
+
+func TestDiff(t *testing.T) {
+	a, b := config.DefaultConfig(), config.DefaultConfig()
+	assert.Empty(t, config.Diff(a, b))
+
+	b.LogLevel = "debug"
+	b.P2P.PersistentPeers = "id@1.2.3.4:26656"
+	b.RPC.CORSAllowedOrigins = []string{"*"}
+	b.Consensus.TimeoutPropose = 0
+	assert.Equal(t, []string{
+		"log_level",
+		"rpc.cors_allowed_origins",
+		"p2p.persistent_peers",
+		"consensus.timeout_propose",
+	}, config.Diff(a, b))
+}
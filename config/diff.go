@@ -0,0 +1,53 @@
+package config
+
+import (
+	"reflect"
+	"strings"
+)
+
+// Diff returns the keys of the options which differ between the two
+// configurations, as in the config file, e.g. "log_level" or "p2p.laddr".
+func Diff(a, b *Config) []string {
+	keys := make([]string, 0)
+	av, bv := reflect.ValueOf(a).Elem(), reflect.ValueOf(b).Elem()
+	for i := 0; i < av.NumField(); i++ {
+		field := av.Type().Field(i)
+		name, squash := mapstructureKey(field)
+		switch {
+		case squash:
+			keys = diffFields(av.Field(i), bv.Field(i), "", keys)
+		case av.Field(i).IsNil() || bv.Field(i).IsNil():
+			if av.Field(i).IsNil() != bv.Field(i).IsNil() {
+				keys = append(keys, name)
+			}
+		default:
+			keys = diffFields(av.Field(i).Elem(), bv.Field(i).Elem(), name+".", keys)
+		}
+	}
+	return keys
+}
+
+// diffFields appends the keys of the fields which differ between the two
+// structs to keys.
+func diffFields(a, b reflect.Value, prefix string, keys []string) []string {
+	for i := 0; i < a.NumField(); i++ {
+		field := a.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if !reflect.DeepEqual(a.Field(i).Interface(), b.Field(i).Interface()) {
+			name, _ := mapstructureKey(field)
+			keys = append(keys, prefix+name)
+		}
+	}
+	return keys
+}
+
+func mapstructureKey(field reflect.StructField) (name string, squash bool) {
+	tag := field.Tag.Get("mapstructure")
+	name, opts, _ := strings.Cut(tag, ",")
+	if name == "" {
+		name = strings.ToLower(field.Name)
+	}
+	return name, opts == "squash"
+}
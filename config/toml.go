@@ -136,16 +136,52 @@ priv_validator_key_file = "{{ js .BaseConfig.PrivValidatorKey }}"
 # Path to the JSON file containing the last sign state of a validator
 priv_validator_state_file = "{{ js .BaseConfig.PrivValidatorState }}"
 
+# Connection string of a PostgreSQL database in which to keep the last sign
+# state of the validator instead of priv_validator_state_file, e.g.
+# "postgresql://<user>:<password>@<host>:<port>/<db>?<opts>". The nodes
+# sharing the validator key and the database never sign conflicting votes or
+# proposals, so that one of them can take over when another fails.
+priv_validator_state_psql_conn = "{{ .BaseConfig.PrivValidatorStatePsqlConn }}"
+
 # TCP or UNIX socket address for CometBFT to listen on for
-# connections from an external PrivValidator process
+# connections from an external PrivValidator process, or the address of a
+# remote signer serving the gRPC PrivValidatorAPI, prefixed with grpc://
+# (e.g. "grpc://127.0.0.1:26659")
 priv_validator_laddr = "{{ .BaseConfig.PrivValidatorListenAddr }}"
 
+# Paths to the certificate and private key used to authenticate with a gRPC
+# remote signer, and to the certificate of the CA used to verify the remote
+# signer's certificate. Either all or none of them must be set. If none is
+# set, priv_validator_insecure must be true.
+priv_validator_client_certificate_file = "{{ js .BaseConfig.PrivValidatorClientCertificate }}"
+priv_validator_client_key_file = "{{ js .BaseConfig.PrivValidatorClientKey }}"
+priv_validator_root_ca_file = "{{ js .BaseConfig.PrivValidatorRootCA }}"
+
+# If true, connect to a gRPC remote signer without encryption nor
+# authentication when the files above are not set. Only meant for testing:
+# anyone able to reach the remote signer can make it sign.
+priv_validator_insecure = {{ .BaseConfig.PrivValidatorInsecure }}
+
 # Path to the JSON file containing the private key to use for node authentication in the p2p protocol
 node_key_file = "{{ js .BaseConfig.NodeKey }}"
 
 # Mechanism to connect to the ABCI application: socket | grpc
 abci = "{{ .BaseConfig.ABCI }}"
 
+# If true, the mempool, query and snapshot connections to a remote ABCI
+# application are re-established when the application restarts, instead of
+# stopping the node. The consensus connection is never re-established.
+abci_reconnect = {{ .BaseConfig.ABCIReconnect }}
+
+# Bounds of the delay between two attempts to re-establish a connection to
+# the ABCI application. The delay doubles after each failed attempt.
+abci_reconnect_min_backoff = "{{ .BaseConfig.ABCIReconnectMinBackoff }}"
+abci_reconnect_max_backoff = "{{ .BaseConfig.ABCIReconnectMaxBackoff }}"
+
+# Number of parallel connections used to serve the queries to a remote ABCI
+# application
+abci_query_connections = {{ .BaseConfig.ABCIQueryConnections }}
+
 # If true, query the ABCI app on connecting to a new peer
 # so the app can decide if we should keep the connection or not
 filter_peers = {{ .BaseConfig.FilterPeers }}
@@ -291,6 +327,17 @@ enabled = {{ .GRPC.BlockService.Enabled }}
 [grpc.block_results_service]
 enabled = {{ .GRPC.BlockResultsService.Enabled }}
 
+# The gRPC stream service streams the blocks, block results and events of each
+# height, from a given height or cursor onwards. Heights that are already
+# committed are read from the node's stores, subject to pruning.
+[grpc.stream_service]
+enabled = {{ .GRPC.StreamService.Enabled }}
+
+# The gRPC tx status service returns the lifecycle of a transaction submitted
+# to the mempool (see [mempool] tx_status_history_size).
+[grpc.tx_status_service]
+enabled = {{ .GRPC.TxStatusService.Enabled }}
+
 #
 # Configuration for privileged gRPC endpoints, which should **never** be exposed
 # to the public internet.
@@ -320,6 +367,18 @@ enabled = {{ .GRPC.Privileged.PruningService.Enabled }}
 # Address to listen for incoming connections
 laddr = "{{ .P2P.ListenAddress }}"
 
+# Transport used to connect to peers:
+#
+# 1) "tcp" - (default) multiplexes the channels of the reactors on an
+#    authenticated and encrypted TCP connection.
+# 2) "quic" - sends the messages of every channel on a separate QUIC stream
+#    over UDP, on the port of laddr, so that a channel sending large messages
+#    (e.g. block parts) does not delay the others (e.g. votes). The node key
+#    must be an ed25519 key.
+#
+# All the peers of a node must use the same transport.
+transport = "{{ .P2P.Transport }}"
+
 # Address to advertise to peers for them to dial. If empty, will use the same
 # port as the laddr, and will introspect on the listener to figure out the
 # address. IP and port are required. Example: 159.89.10.97:26656
@@ -362,6 +421,19 @@ send_rate = {{ .P2P.SendRate }}
 # Rate at which packets can be received, in bytes/second
 recv_rate = {{ .P2P.RecvRate }}
 
+# Overrides of the priority, send queue capacity and rates of specific
+# channels, e.g. to keep mempool gossip from starving consensus traffic.
+# Zero values keep the defaults. The send and receive rates of a channel, in
+# bytes/second, apply on top of send_rate and recv_rate; note that throttling
+# the receiving of a channel delays the messages of the other channels too,
+# unless the QUIC transport is used.
+#
+# Example:
+#   channels = [
+#     { id = 0x30, priority = 1, send_queue_capacity = 50, send_rate = 1024000, recv_rate = 1024000 },
+#   ]
+channels = [{{ range .P2P.Channels }}{ id = {{ printf "%#x" .ID }}, priority = {{ .Priority }}, send_queue_capacity = {{ .SendQueueCapacity }}, send_rate = {{ .SendRate }}, recv_rate = {{ .RecvRate }} }, {{end}}]
+
 # Set true to enable the peer-exchange reactor
 pex = {{ .P2P.PexReactor }}
 
@@ -377,6 +449,26 @@ private_peer_ids = "{{ .P2P.PrivatePeerIDs }}"
 # Toggle to disable guard against peers connecting from the same ip.
 allow_duplicate_ip = {{ .P2P.AllowDuplicateIP }}
 
+# Comma separated list of CIDR ranges, e.g. "10.0.0.0/8,2001:db8::/32", peers
+# must belong to. Empty means peers can connect from any address not in
+# denied_cidrs. Applies to inbound and outbound connections, including those
+# with persistent and unconditional peers. The CIDR ranges and
+# max_conns_per_subnet can be changed at runtime with the unsafe
+# /unsafe_set_ip_filter RPC endpoint.
+allowed_cidrs = "{{ .P2P.AllowedCIDRs }}"
+
+# Comma separated list of CIDR ranges peers must not belong to. Takes
+# precedence over allowed_cidrs.
+denied_cidrs = "{{ .P2P.DeniedCIDRs }}"
+
+# Maximum number of connections with peers in the same subnet, as defined by
+# ipv4_subnet_prefix_len and ipv6_subnet_prefix_len. Limits how much of the
+# peers of the node an attacker in a single hosting range can control.
+# 0 means unlimited.
+max_conns_per_subnet = {{ .P2P.MaxConnsPerSubnet }}
+ipv4_subnet_prefix_len = {{ .P2P.IPv4SubnetPrefixLen }}
+ipv6_subnet_prefix_len = {{ .P2P.IPv6SubnetPrefixLen }}
+
 # Peer connection configuration.
 handshake_timeout = "{{ .P2P.HandshakeTimeout }}"
 dial_timeout = "{{ .P2P.DialTimeout }}"
@@ -389,12 +481,15 @@ dial_timeout = "{{ .P2P.DialTimeout }}"
 # The type of mempool for this node to use.
 #
 #  Possible types:
-#  - "flood" : concurrent linked list mempool with flooding gossip protocol
+#  - "flood"    : concurrent linked list mempool with flooding gossip protocol
 #  (default)
-#  - "nop"   : nop-mempool (short for no operation; the ABCI app is responsible
-#  for storing, disseminating and proposing txs). "create_empty_blocks=false" is
-#  not supported.
-type = "flood"
+#  - "priority" : mempool ordering txs by the priority assigned by the app in
+#  CheckTx, evicting lower priority txs when full; uses the same flooding gossip
+#  protocol as "flood"
+#  - "nop"      : nop-mempool (short for no operation; the ABCI app is
+#  responsible for storing, disseminating and proposing txs).
+#  "create_empty_blocks=false" is not supported.
+type = "{{ .Mempool.Type }}"
 
 # recheck (default: true) defines whether CometBFT should recheck the
 # validity for all remaining transaction in the mempool after a block.
@@ -413,7 +508,9 @@ broadcast = {{ .Mempool.Broadcast }}
 # wal_dir (default: "") configures the location of the Write Ahead Log
 # (WAL) for the mempool. The WAL is disabled by default. To enable, set
 # wal_dir to where you want the WAL to be written (e.g.
-# "data/mempool.wal").
+# "data/mempool.wal"). When enabled, the transactions in the mempool are
+# restored from the WAL after a restart, provided they are still valid for the
+# application. Only supported by the "flood" mempool.
 wal_dir = "{{ js .Mempool.WalPath }}"
 
 # Maximum number of transactions in the mempool
@@ -436,6 +533,23 @@ keep-invalid-txs-in-cache = {{ .Mempool.KeepInvalidTxsInCache }}
 # NOTE: the max size of a tx transmitted over the network is {max_tx_bytes}.
 max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 
+# Number of transactions whose lifecycle (received, checked, removed, included)
+# is kept for the tx_status RPC. The least recently updated transactions are
+# forgotten first. 0 disables the tracking.
+tx_status_history_size = {{ .Mempool.TxStatusHistorySize }}
+
+# ttl-duration, if non-zero, defines the maximum amount of time a transaction
+# can exist in the mempool. Expired transactions are removed from the mempool,
+# and from the cache so that they can be submitted again, after the next block
+# is committed.
+ttl-duration = "{{ .Mempool.TTLDuration }}"
+
+# ttl-num-blocks, if non-zero, defines the maximum number of blocks a
+# transaction can exist in the mempool for. Expired transactions are removed
+# from the mempool, and from the cache so that they can be submitted again,
+# after the next block is committed.
+ttl-num-blocks = {{ .Mempool.TTLNumBlocks }}
+
 # Experimental parameters to limit gossiping txs to up to the specified number of peers.
 # We use two independent upper values for persistent and non-persistent peers.
 # Unconditional peers are not affected by this feature.
@@ -451,6 +565,12 @@ max_tx_bytes = {{ .Mempool.MaxTxBytes }}
 experimental_max_gossip_connections_to_persistent_peers = {{ .Mempool.ExperimentalMaxGossipConnectionsToPersistentPeers }}
 experimental_max_gossip_connections_to_non_persistent_peers = {{ .Mempool.ExperimentalMaxGossipConnectionsToNonPersistentPeers }}
 
+# Experimental parameter to announce txs by their hash to the peers that also
+# enabled it, instead of sending the whole txs. Those peers request only the txs
+# they haven't seen yet, which saves bandwidth on nodes with many peers. The
+# other peers keep receiving the whole txs.
+experimental_tx_announcements = {{ .Mempool.ExperimentalTxAnnouncements }}
+
 #######################################################
 ###         State Sync Configuration Options        ###
 #######################################################
@@ -487,6 +607,19 @@ chunk_request_timeout = "{{ .StateSync.ChunkRequestTimeout }}"
 # The number of concurrent chunk fetchers to run (default: 1).
 chunk_fetchers = "{{ .StateSync.ChunkFetchers }}"
 
+# Number of most recent blocks included, along with their validator sets and
+# consensus params, in the block snapshots served by CometBFT alongside the
+# two most recent snapshots of the application. State syncing nodes restoring
+# a block snapshot are able to serve these blocks to other nodes. 0 disables
+# block snapshots.
+snapshot_num_blocks = {{ .StateSync.SnapshotNumBlocks }}
+
+# If true, restore the block snapshot at the height of the restored
+# application snapshot, if any peer serves one. The consensus params of the
+# restored blocks are not restored, since only their block size limits are
+# verified against the block headers.
+restore_block_snapshots = {{ .StateSync.RestoreBlockSnapshots }}
+
 #######################################################
 ###       Block Sync Configuration Options          ###
 #######################################################
@@ -533,6 +666,19 @@ double_sign_check_height = {{ .Consensus.DoubleSignCheckHeight }}
 # Make progress as soon as we have all the precommits (as if TimeoutCommit = 0)
 skip_timeout_commit = {{ .Consensus.SkipTimeoutCommit }}
 
+# Compute the propose, prevote and precommit timeouts from the latencies
+# observed in the latest rounds instead of using the static timeouts above.
+# The *_delta timeouts are still added for each round.
+adaptive_timeouts = {{ .Consensus.AdaptiveTimeouts }}
+# Number of latest rounds whose latencies are used to compute the timeouts
+adaptive_timeout_window = {{ .Consensus.AdaptiveTimeoutWindow }}
+# Bounds of the adaptive timeout_propose
+adaptive_timeout_propose_min = "{{ .Consensus.AdaptiveTimeoutProposeMin }}"
+adaptive_timeout_propose_max = "{{ .Consensus.AdaptiveTimeoutProposeMax }}"
+# Bounds of the adaptive timeout_prevote and timeout_precommit
+adaptive_timeout_vote_min = "{{ .Consensus.AdaptiveTimeoutVoteMin }}"
+adaptive_timeout_vote_max = "{{ .Consensus.AdaptiveTimeoutVoteMax }}"
+
 # EmptyBlocks mode and possible interval between empty blocks
 create_empty_blocks = {{ .Consensus.CreateEmptyBlocks }}
 create_empty_blocks_interval = "{{ .Consensus.CreateEmptyBlocksInterval }}"
@@ -571,6 +717,13 @@ compaction_interval = "{{ .Storage.CompactionInterval }}"
 # The time period between automated background pruning operations.
 interval = "{{ .Storage.Pruning.Interval }}"
 
+# Number of latest heights whose extended commits (with the vote extensions)
+# are kept. The extended commits of the lower heights are pruned even if their
+# blocks are kept, so the node can't serve these blocks to the peers doing
+# block sync when vote extensions are enabled. 0 keeps the extended commits of
+# all the blocks.
+extended_commit_retain_blocks = {{ .Storage.Pruning.ExtendedCommitRetainBlocks }}
+
 #
 # Storage pruning configuration relating only to the data companion.
 #
@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -82,3 +83,22 @@ func assertValidConfig(t *testing.T, configFile string) {
 		assert.Contains(t, configFile, e)
 	}
 }
+
+func TestWriteConfigFileP2PChannels(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.P2P.Channels = []config.P2PChannelConfig{
+		{ID: 0x30, Priority: 1, SendQueueCapacity: 50, SendRate: 1024000, RecvRate: 512000},
+		{ID: 0x22, Priority: 10},
+	}
+
+	path := filepath.Join(t.TempDir(), config.DefaultConfigFileName)
+	config.WriteConfigFile(path, cfg)
+
+	v := viper.New()
+	v.SetConfigFile(path)
+	require.NoError(t, v.ReadInConfig())
+	parsed := config.DefaultConfig()
+	require.NoError(t, v.Unmarshal(parsed))
+	assert.Equal(t, cfg.P2P.Channels, parsed.P2P.Channels)
+	require.NoError(t, parsed.P2P.ValidateBasic())
+}
@@ -0,0 +1,208 @@
+// Package bls12381 implements BLS signatures over the BLS12-381 curve.
+//
+// Public keys are points of G1 (48 bytes compressed) and signatures are points
+// of G2 (96 bytes compressed), following the proof-of-possession ciphersuite
+// of the IETF BLS signature draft. Signatures can be aggregated, so that many
+// signatures, over the same or different messages, can be verified at once
+// from a single 96-byte signature.
+//
+// The cryptographic operations are implemented with blst, which requires cgo,
+// and are only available when building with the bls12381 build tag. Without
+// it, Enabled is false and generating keys, signing and verifying fail.
+package bls12381
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+const (
+	PrivKeyName = "cometbft/PrivKeyBls12_381"
+	PubKeyName  = "cometbft/PubKeyBls12_381"
+
+	KeyType = "bls12_381"
+
+	// PrivKeySize is the size, in bytes, of private keys.
+	PrivKeySize = 32
+	// PubKeySize is the size, in bytes, of compressed public keys.
+	PubKeySize = 48
+	// SignatureLength is the size, in bytes, of compressed signatures.
+	SignatureLength = 96
+)
+
+// ErrDisabled is returned when the package is used without having been built
+// with the bls12381 build tag.
+var ErrDisabled = errors.New("bls12381 is disabled: build with the bls12381 tag to enable it")
+
+// dst is the domain separation tag of the proof-of-possession ciphersuite.
+var dst = []byte("BLS_SIG_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+// popDST is the domain separation tag of the proofs of possession, so that
+// they can't be mistaken for signatures of a message.
+var popDST = []byte("BLS_POP_BLS12381G2_XMD:SHA-256_SSWU_RO_POP_")
+
+func init() {
+	cmtjson.RegisterType(PubKey{}, PubKeyName)
+	cmtjson.RegisterType(PrivKey{}, PrivKeyName)
+}
+
+var _ crypto.PrivKey = PrivKey{}
+
+// PrivKey implements crypto.PrivKey. It is the big-endian encoding of the
+// secret scalar.
+type PrivKey []byte
+
+// GenPrivKey generates a new private key using OS randomness.
+func GenPrivKey() (PrivKey, error) {
+	ikm := crypto.CRandBytes(32)
+	return genPrivKey(ikm)
+}
+
+// GenPrivKeyFromSecret deterministically generates a private key from secret.
+//
+// NOTE: secret should be the output of a KDF like bcrypt, if it's derived
+// from user input.
+func GenPrivKeyFromSecret(secret []byte) (PrivKey, error) {
+	ikm := sha256.Sum256(secret)
+	return genPrivKey(ikm[:])
+}
+
+// Bytes returns the private key bytes.
+func (privKey PrivKey) Bytes() []byte {
+	return []byte(privKey)
+}
+
+// Sign produces a signature of msg.
+func (privKey PrivKey) Sign(msg []byte) ([]byte, error) {
+	return sign(privKey, msg)
+}
+
+// ProvePossession returns a proof that the holder of the public key of
+// privKey knows privKey, that is a signature of the public key itself.
+func (privKey PrivKey) ProvePossession() ([]byte, error) {
+	pubKey, err := pubKeyFromPrivKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	return signWithDST(privKey, pubKey, popDST)
+}
+
+// PubKey returns the public key corresponding to the private key. It panics
+// if the package is disabled or if the private key is malformed.
+func (privKey PrivKey) PubKey() crypto.PubKey {
+	pubKey, err := pubKeyFromPrivKey(privKey)
+	if err != nil {
+		panic(fmt.Sprintf("bls12381: %v", err))
+	}
+	return pubKey
+}
+
+// Equals - you probably don't need to use this.
+// Runs in constant time based on length of the keys.
+func (privKey PrivKey) Equals(other crypto.PrivKey) bool {
+	if otherBLS, ok := other.(PrivKey); ok {
+		return subtle.ConstantTimeCompare(privKey[:], otherBLS[:]) == 1
+	}
+	return false
+}
+
+func (PrivKey) Type() string {
+	return KeyType
+}
+
+// -------------------------------------
+
+var _ crypto.PubKey = PubKey{}
+
+// PubKey implements crypto.PubKey. It is the compressed encoding of a point
+// of G1.
+type PubKey []byte
+
+// Address is the SHA256-20 of the raw pubkey bytes.
+func (pubKey PubKey) Address() crypto.Address {
+	if len(pubKey) != PubKeySize {
+		panic("pubkey is incorrect size")
+	}
+	return crypto.Address(tmhash.SumTruncated(pubKey))
+}
+
+// Bytes returns the PubKey byte format.
+func (pubKey PubKey) Bytes() []byte {
+	return []byte(pubKey)
+}
+
+// VerifySignature reports whether sig is a valid signature of msg by pubKey.
+// It always returns false if the package is disabled.
+func (pubKey PubKey) VerifySignature(msg []byte, sig []byte) bool {
+	if len(pubKey) != PubKeySize || len(sig) != SignatureLength {
+		return false
+	}
+	return verify(pubKey, msg, sig)
+}
+
+// VerifyProofOfPossession reports whether proof is a valid proof of
+// possession of pubKey (see PrivKey.ProvePossession). It always returns false
+// if the package is disabled.
+func (pubKey PubKey) VerifyProofOfPossession(proof []byte) bool {
+	if len(pubKey) != PubKeySize || len(proof) != SignatureLength {
+		return false
+	}
+	return verifyWithDST(pubKey, pubKey, proof, popDST)
+}
+
+func (pubKey PubKey) String() string {
+	return fmt.Sprintf("PubKeyBls12_381{%X}", []byte(pubKey))
+}
+
+func (PubKey) Type() string {
+	return KeyType
+}
+
+func (pubKey PubKey) Equals(other crypto.PubKey) bool {
+	if otherBLS, ok := other.(PubKey); ok {
+		return bytes.Equal(pubKey[:], otherBLS[:])
+	}
+	return false
+}
+
+// -------------------------------------
+
+// AggregateSignatures aggregates the given signatures into a single signature.
+func AggregateSignatures(sigs [][]byte) ([]byte, error) {
+	if len(sigs) == 0 {
+		return nil, errors.New("no signatures to aggregate")
+	}
+	for i, sig := range sigs {
+		if len(sig) != SignatureLength {
+			return nil, fmt.Errorf("signature #%d has size %d, want %d", i, len(sig), SignatureLength)
+		}
+	}
+	return aggregateSignatures(sigs)
+}
+
+// VerifyAggregateSignature reports whether sig is the aggregation of valid
+// signatures of msgs[i] by pubKeys[i], for all i. Messages don't need to be
+// distinct.
+//
+// NOTE: as with any aggregation of signatures of the same message, this is
+// only secure if the holders of the public keys have proven they own the
+// corresponding private keys, to prevent rogue key attacks. See
+// PubKey.VerifyProofOfPossession.
+func VerifyAggregateSignature(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	if len(pubKeys) == 0 || len(pubKeys) != len(msgs) || len(sig) != SignatureLength {
+		return false
+	}
+	for _, pubKey := range pubKeys {
+		if len(pubKey) != PubKeySize {
+			return false
+		}
+	}
+	return verifyAggregate(pubKeys, msgs, sig)
+}
@@ -0,0 +1,97 @@
+//go:build bls12381
+// +build bls12381
+
+package bls12381
+
+import (
+	"errors"
+
+	blst "github.com/supranational/blst/bindings/go"
+)
+
+// Enabled reports whether the package was built with the bls12381 build tag.
+const Enabled = true
+
+func genPrivKey(ikm []byte) (PrivKey, error) {
+	sk := blst.KeyGen(ikm)
+	if sk == nil {
+		return nil, errors.New("failed to generate private key")
+	}
+	defer sk.Zeroize()
+	return PrivKey(sk.Serialize()), nil
+}
+
+func secretKey(privKey PrivKey) (*blst.SecretKey, error) {
+	if len(privKey) != PrivKeySize {
+		return nil, errors.New("private key is incorrect size")
+	}
+	sk := new(blst.SecretKey).Deserialize(privKey)
+	if sk == nil || !sk.Valid() {
+		return nil, errors.New("invalid private key")
+	}
+	return sk, nil
+}
+
+func pubKeyFromPrivKey(privKey PrivKey) (PubKey, error) {
+	sk, err := secretKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	defer sk.Zeroize()
+	return PubKey(new(blst.P1Affine).From(sk).Compress()), nil
+}
+
+func sign(privKey PrivKey, msg []byte) ([]byte, error) {
+	return signWithDST(privKey, msg, dst)
+}
+
+func signWithDST(privKey PrivKey, msg, dst []byte) ([]byte, error) {
+	sk, err := secretKey(privKey)
+	if err != nil {
+		return nil, err
+	}
+	defer sk.Zeroize()
+	return new(blst.P2Affine).Sign(sk, msg, dst).Compress(), nil
+}
+
+func verify(pubKey PubKey, msg, sig []byte) bool {
+	return verifyWithDST(pubKey, msg, sig, dst)
+}
+
+func verifyWithDST(pubKey PubKey, msg, sig, dst []byte) bool {
+	pk := new(blst.P1Affine).Uncompress(pubKey)
+	if pk == nil {
+		return false
+	}
+	s := new(blst.P2Affine).Uncompress(sig)
+	if s == nil {
+		return false
+	}
+	return s.Verify(true, pk, true, msg, dst)
+}
+
+func aggregateSignatures(sigs [][]byte) ([]byte, error) {
+	agg := new(blst.P2Aggregate)
+	if !agg.AggregateCompressed(sigs, true) {
+		return nil, errors.New("invalid signature")
+	}
+	return agg.ToAffine().Compress(), nil
+}
+
+func verifyAggregate(pubKeys []PubKey, msgs [][]byte, sig []byte) bool {
+	pks := make([]*blst.P1Affine, len(pubKeys))
+	for i, pubKey := range pubKeys {
+		if pks[i] = new(blst.P1Affine).Uncompress(pubKey); pks[i] == nil {
+			return false
+		}
+	}
+	s := new(blst.P2Affine).Uncompress(sig)
+	if s == nil {
+		return false
+	}
+	blstMsgs := make([]blst.Message, len(msgs))
+	for i, msg := range msgs {
+		blstMsgs[i] = msg
+	}
+	return s.AggregateVerify(true, pks, true, blstMsgs, dst)
+}
@@ -0,0 +1,39 @@
+//go:build !bls12381
+// +build !bls12381
+
+package bls12381
+
+// Enabled reports whether the package was built with the bls12381 build tag.
+const Enabled = false
+
+func genPrivKey([]byte) (PrivKey, error) {
+	return nil, ErrDisabled
+}
+
+func pubKeyFromPrivKey(PrivKey) (PubKey, error) {
+	return nil, ErrDisabled
+}
+
+func sign(PrivKey, []byte) ([]byte, error) {
+	return nil, ErrDisabled
+}
+
+func signWithDST(PrivKey, []byte, []byte) ([]byte, error) {
+	return nil, ErrDisabled
+}
+
+func verify(PubKey, []byte, []byte) bool {
+	return false
+}
+
+func verifyWithDST(PubKey, []byte, []byte, []byte) bool {
+	return false
+}
+
+func aggregateSignatures([][]byte) ([]byte, error) {
+	return nil, ErrDisabled
+}
+
+func verifyAggregate([]PubKey, [][]byte, []byte) bool {
+	return false
+}
@@ -0,0 +1,147 @@
+package bls12381_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+func TestDisabled(t *testing.T) {
+	if bls12381.Enabled {
+		t.Skip("bls12381 is enabled")
+	}
+
+	_, err := bls12381.GenPrivKey()
+	require.ErrorIs(t, err, bls12381.ErrDisabled)
+	_, err = bls12381.AggregateSignatures([][]byte{make([]byte, bls12381.SignatureLength)})
+	require.ErrorIs(t, err, bls12381.ErrDisabled)
+	assert.False(t, bls12381.PubKey(make([]byte, bls12381.PubKeySize)).VerifySignature(
+		[]byte("msg"), make([]byte, bls12381.SignatureLength)))
+}
+
+func TestSignAndValidateBLS12381(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey()
+	require.Len(t, pubKey.Bytes(), bls12381.PubKeySize)
+
+	msg := crypto.CRandBytes(128)
+	sig, err := privKey.Sign(msg)
+	require.NoError(t, err)
+	require.Len(t, sig, bls12381.SignatureLength)
+
+	assert.True(t, pubKey.VerifySignature(msg, sig))
+	assert.False(t, pubKey.VerifySignature(msg[1:], sig))
+
+	// Mutate the signature, just one bit.
+	sig[7] ^= byte(0x01)
+	assert.False(t, pubKey.VerifySignature(msg, sig))
+}
+
+func TestGenPrivKeyFromSecret(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	privKey1, err := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	require.NoError(t, err)
+	privKey2, err := bls12381.GenPrivKeyFromSecret([]byte("secret"))
+	require.NoError(t, err)
+	privKey3, err := bls12381.GenPrivKeyFromSecret([]byte("other secret"))
+	require.NoError(t, err)
+
+	assert.True(t, privKey1.Equals(privKey2))
+	assert.False(t, privKey1.Equals(privKey3))
+	assert.True(t, privKey1.PubKey().Equals(privKey2.PubKey()))
+}
+
+func TestProofOfPossession(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	pubKey := privKey.PubKey().(bls12381.PubKey)
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+	require.Len(t, proof, bls12381.SignatureLength)
+	assert.True(t, pubKey.VerifyProofOfPossession(proof))
+
+	// A signature of the public key is not a proof of possession.
+	sig, err := privKey.Sign(pubKey)
+	require.NoError(t, err)
+	assert.False(t, pubKey.VerifyProofOfPossession(sig))
+
+	// Neither is the proof of another key.
+	otherKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	otherProof, err := otherKey.ProvePossession()
+	require.NoError(t, err)
+	assert.False(t, pubKey.VerifyProofOfPossession(otherProof))
+}
+
+func TestAggregateSignatures(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	var (
+		pubKeys []bls12381.PubKey
+		msgs    [][]byte
+		sigs    [][]byte
+	)
+	for i := 0; i < 4; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+
+		// Half of the messages are the same.
+		msg := []byte("same")
+		if i%2 == 0 {
+			msg = crypto.CRandBytes(32)
+		}
+		sig, err := privKey.Sign(msg)
+		require.NoError(t, err)
+
+		pubKeys = append(pubKeys, privKey.PubKey().(bls12381.PubKey))
+		msgs = append(msgs, msg)
+		sigs = append(sigs, sig)
+	}
+
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	require.NoError(t, err)
+	require.Len(t, aggSig, bls12381.SignatureLength)
+	assert.True(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, aggSig))
+
+	// A missing signer, or a signer of a different message, is detected.
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys[1:], msgs[1:], aggSig))
+	msgs[0] = []byte("other")
+	assert.False(t, bls12381.VerifyAggregateSignature(pubKeys, msgs, aggSig))
+
+	_, err = bls12381.AggregateSignatures([][]byte{sigs[0][1:]})
+	require.Error(t, err)
+}
+
+func TestJSON(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+
+	bz, err := cmtjson.Marshal(privKey)
+	require.NoError(t, err)
+	var decoded crypto.PrivKey
+	require.NoError(t, cmtjson.Unmarshal(bz, &decoded))
+	assert.True(t, privKey.Equals(decoded))
+}
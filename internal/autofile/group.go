@@ -196,6 +196,13 @@ func (g *Group) MinIndex() int {
 	return g.minIndex
 }
 
+// FilePath returns the path of the file with the given index in the group.
+func (g *Group) FilePath(index int) string {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+	return filePathForIndex(g.Head.Path, index, g.maxIndex)
+}
+
 // Write writes the contents of p into the current head of the group. It
 // returns the number of bytes written. If nn < len(p), it also returns an
 // error explaining why the write is short.
@@ -326,6 +333,23 @@ func (g *Group) RotateFile() {
 	g.maxIndex++
 }
 
+// RemoveRotatedFiles removes all the files of the group except for the head,
+// which becomes the only file of the group. It is used to discard the content
+// of the group written before the last call to RotateFile.
+func (g *Group) RemoveRotatedFiles() error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	for index := g.minIndex; index < g.maxIndex; index++ {
+		path := filePathForIndex(g.Head.Path, index, g.maxIndex)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	g.minIndex = g.maxIndex
+	return nil
+}
+
 // NewReader returns a new group reader.
 // CONTRACT: Caller must close the returned GroupReader.
 func (g *Group) NewReader(index int) (*GroupReader, error) {
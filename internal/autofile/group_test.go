@@ -175,6 +175,42 @@ func TestRotateFile(t *testing.T) {
 	destroyTestGroup(t, g)
 }
 
+func TestRemoveRotatedFiles(t *testing.T) {
+	g := createTestGroupWithHeadSizeLimit(t, 0)
+
+	for i := 0; i < 3; i++ {
+		err := g.WriteLine("Line")
+		require.NoError(t, err)
+		g.RotateFile()
+	}
+	err := g.WriteLine("Head")
+	require.NoError(t, err)
+	err = g.FlushAndSync()
+	require.NoError(t, err)
+	assert.Equal(t, 3, g.MaxIndex())
+
+	err = g.RemoveRotatedFiles()
+	require.NoError(t, err)
+	assert.Equal(t, 3, g.MinIndex())
+	assert.Equal(t, 3, g.MaxIndex())
+
+	// Only the head is left.
+	files, err := os.ReadDir(g.Dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	assert.Equal(t, filepath.Base(g.Head.Path), files[0].Name())
+
+	gr, err := g.NewReader(g.MinIndex())
+	require.NoError(t, err)
+	defer gr.Close()
+	read, err := io.ReadAll(gr)
+	require.NoError(t, err)
+	assert.Equal(t, "Head\n", string(read))
+
+	// Cleanup
+	destroyTestGroup(t, g)
+}
+
 func TestWrite(t *testing.T) {
 	g := createTestGroupWithHeadSizeLimit(t, 0)
 
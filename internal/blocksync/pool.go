@@ -54,6 +54,8 @@ const (
 var (
 	requestInterval = 10 * time.Millisecond // timeout between requests
 	peerTimeout     = 15 * time.Second      // not const so we can override with tests
+
+	errPeerTimeout = errors.New("peer did not send us anything")
 )
 
 /*
@@ -563,7 +565,7 @@ func (peer *bpPeer) onTimeout() {
 	peer.pool.mtx.Lock()
 	defer peer.pool.mtx.Unlock()
 
-	err := errors.New("peer did not send us anything")
+	err := errPeerTimeout
 	peer.pool.sendError(err, peer.id)
 	peer.logger.Error("SendTimeout", "reason", err, "timeout", peerTimeout)
 	peer.didTimeout = true
@@ -1,6 +1,7 @@
 package blocksync
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 	"sync"
@@ -222,8 +223,13 @@ func (bcR *Reactor) respondToPeer(msg *bcproto.BlockRequest, src p2p.Peer) (queu
 	if state.ConsensusParams.Feature.VoteExtensionsEnabled(msg.Height) {
 		extCommit = bcR.store.LoadBlockExtendedCommit(msg.Height)
 		if extCommit == nil {
-			bcR.Logger.Error("found block in store with no extended commit", "block", block)
-			return false
+			// The extended commits can be pruned before the blocks.
+			bcR.Logger.Info("Peer asking for a block whose extended commit we don't have",
+				"src", src, "height", msg.Height)
+			return src.TrySend(p2p.Envelope{
+				ChannelID: BlocksyncChannel,
+				Message:   &bcproto.NoBlockResponse{Height: msg.Height},
+			})
 		}
 	}
 
@@ -350,6 +356,9 @@ func (bcR *Reactor) poolRoutine(stateSynced bool) {
 			case err := <-bcR.errorsCh:
 				peer := bcR.Switch.Peers().Get(err.peerID)
 				if peer != nil {
+					if errors.Is(err.err, errPeerTimeout) {
+						bcR.Switch.ReportPeer(peer, p2p.PeerBehaviorRequestTimeout)
+					}
 					bcR.Switch.StopPeerForError(peer, err)
 				}
 
@@ -482,6 +491,9 @@ FOR_LOOP:
 			// TODO(sergio): Should we also validate against the extended commit?
 			err = state.Validators.VerifyCommitLight(
 				chainID, firstID, first.Height, second.LastCommit)
+			// If the commit does not match the first block, either the first
+			// block or the second block's commit is wrong.
+			commitMismatch := err != nil
 
 			if err == nil {
 				// validate the block before we persist it
@@ -498,17 +510,27 @@ FOR_LOOP:
 			if err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
 				peerID := bcR.pool.RemovePeerAndRedoAllPeerRequests(first.Height)
+				peerID2 := bcR.pool.RemovePeerAndRedoAllPeerRequests(second.Height)
+				// The culprit is only certain if the first block is invalid
+				// on its own, or if the same peer sent both blocks.
+				behavior := p2p.PeerBehaviorBadMessage
+				if commitMismatch && peerID != peerID2 {
+					behavior = p2p.PeerBehaviorSuspectBlock
+				}
 				peer := bcR.Switch.Peers().Get(peerID)
 				if peer != nil {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
+					bcR.Switch.ReportPeer(peer, behavior)
 					bcR.Switch.StopPeerForError(peer, ErrReactorValidation{Err: err})
 				}
-				peerID2 := bcR.pool.RemovePeerAndRedoAllPeerRequests(second.Height)
 				peer2 := bcR.Switch.Peers().Get(peerID2)
 				if peer2 != nil && peer2 != peer {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
+					if commitMismatch {
+						bcR.Switch.ReportPeer(peer2, p2p.PeerBehaviorSuspectBlock)
+					}
 					bcR.Switch.StopPeerForError(peer2, ErrReactorValidation{Err: err})
 				}
 				continue FOR_LOOP
@@ -0,0 +1,192 @@
+package consensus
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	cfg "github.com/cometbft/cometbft/config"
+)
+
+const (
+	// The adaptive timeouts are this quantile of the measured latencies
+	// times the margin.
+	adaptiveTimeoutQuantile = 0.95
+	adaptiveTimeoutMargin   = 1.5
+	// The static timeouts are used until this many latencies were measured.
+	adaptiveTimeoutMinSamples = 10
+)
+
+// timeoutStep is a step of a round whose timeout is adaptive.
+type timeoutStep int
+
+const (
+	timeoutStepPropose timeoutStep = iota
+	timeoutStepPrevote
+	timeoutStepPrecommit
+	numTimeoutSteps
+)
+
+func (s timeoutStep) String() string {
+	switch s {
+	case timeoutStepPropose:
+		return "propose"
+	case timeoutStepPrevote:
+		return "prevote"
+	case timeoutStepPrecommit:
+		return "precommit"
+	default:
+		return "unknown"
+	}
+}
+
+// adaptiveTimeouts computes the timeouts of the propose, prevote and
+// precommit steps from the latencies measured in the latest rounds:
+//
+//   - propose: from entering the propose step to having the complete
+//     proposal, i.e. entering the prevote step;
+//   - prevote: from entering the prevote wait step, i.e. receiving +2/3
+//     prevotes for anything, to entering the precommit step;
+//   - precommit: from entering the precommit wait step, i.e. receiving +2/3
+//     precommits for anything, to receiving +2/3 precommits for a block or
+//     nil, or the timeout.
+//
+// The vote latencies are thus measured over the same interval as the
+// timeouts they bound, and the rounds without a wait step are not measured.
+//
+// When a timeout expires before the measured event, the sample is only a
+// lower bound of the latency. Each timeout is the 95th percentile of the
+// latencies, or the median of all the samples if greater, times the margin:
+// the timeouts increase when most rounds time out, i.e. when they are too
+// short, but not because of a minority of rounds timing out, e.g. those of
+// an offline proposer, which would otherwise ratchet the timeouts up to their
+// upper bound.
+//
+// It is not thread-safe, the State calls it with its mutex held.
+type adaptiveTimeouts struct {
+	config  *cfg.ConsensusConfig
+	windows [numTimeoutSteps]*latencyWindow
+
+	// Start of the measurements of the steps of the current round. A zero
+	// time means that the latency of the step was measured or can't be.
+	height int64
+	round  int32
+	starts [numTimeoutSteps]time.Time
+}
+
+func newAdaptiveTimeouts(config *cfg.ConsensusConfig) *adaptiveTimeouts {
+	at := &adaptiveTimeouts{config: config}
+	for i := range at.windows {
+		at.windows[i] = newLatencyWindow(config.AdaptiveTimeoutWindow)
+	}
+	return at
+}
+
+// start starts measuring the latency of a step of the given round.
+func (at *adaptiveTimeouts) start(step timeoutStep, height int64, round int32, now time.Time) {
+	if at.height != height || at.round != round {
+		at.height, at.round = height, round
+		at.starts = [numTimeoutSteps]time.Time{}
+	}
+	at.starts[step] = now
+}
+
+// observe ends the measurement of the latency of a step of the given round,
+// if it was started, and returns the latency. If the timeout of the step
+// expired, the sample is recorded as such, but no latency is returned.
+func (at *adaptiveTimeouts) observe(step timeoutStep, height int64, round int32, now time.Time) (time.Duration, bool) {
+	if at.height != height || at.round != round || at.starts[step].IsZero() {
+		return 0, false
+	}
+	latency := now.Sub(at.starts[step])
+	at.starts[step] = time.Time{}
+	if latency < 0 {
+		return 0, false
+	}
+	timedOut := latency >= at.timeout(step, round)
+	at.windows[step].add(latency, timedOut)
+	return latency, !timedOut
+}
+
+// timeout returns the timeout of a step in the given round.
+func (at *adaptiveTimeouts) timeout(step timeoutStep, round int32) time.Duration {
+	var static, delta, lower, upper time.Duration
+	switch step {
+	case timeoutStepPropose:
+		static, delta = at.config.TimeoutPropose, at.config.TimeoutProposeDelta
+		lower, upper = at.config.AdaptiveTimeoutProposeMin, at.config.AdaptiveTimeoutProposeMax
+	case timeoutStepPrevote:
+		static, delta = at.config.TimeoutPrevote, at.config.TimeoutPrevoteDelta
+		lower, upper = at.config.AdaptiveTimeoutVoteMin, at.config.AdaptiveTimeoutVoteMax
+	case timeoutStepPrecommit:
+		static, delta = at.config.TimeoutPrecommit, at.config.TimeoutPrecommitDelta
+		lower, upper = at.config.AdaptiveTimeoutVoteMin, at.config.AdaptiveTimeoutVoteMax
+	}
+
+	base := static
+	w := at.windows[step]
+	if w.len() >= min(adaptiveTimeoutMinSamples, w.size()) {
+		latency, _ := w.quantile(adaptiveTimeoutQuantile, false)
+		if median, _ := w.quantile(0.5, true); median > latency {
+			latency = median
+		}
+		base = time.Duration(float64(latency) * adaptiveTimeoutMargin)
+		base = max(lower, min(upper, base))
+	}
+	return base + delta*time.Duration(round)
+}
+
+// latencyWindow keeps the latest latencies in a ring buffer.
+type latencyWindow struct {
+	samples []latencySample
+	next    int
+	full    bool
+}
+
+// latencySample is a measured latency, or a lower bound of the latency if the
+// timeout expired before the measured event.
+type latencySample struct {
+	latency  time.Duration
+	timedOut bool
+}
+
+func newLatencyWindow(size int) *latencyWindow {
+	return &latencyWindow{samples: make([]latencySample, size)}
+}
+
+func (w *latencyWindow) add(latency time.Duration, timedOut bool) {
+	w.samples[w.next] = latencySample{latency: latency, timedOut: timedOut}
+	w.next = (w.next + 1) % len(w.samples)
+	if w.next == 0 {
+		w.full = true
+	}
+}
+
+func (w *latencyWindow) size() int {
+	return len(w.samples)
+}
+
+func (w *latencyWindow) len() int {
+	if w.full {
+		return len(w.samples)
+	}
+	return w.next
+}
+
+// quantile returns the q-quantile of the latencies, using the nearest-rank
+// method, including the timed-out samples if timedOut is true. It returns
+// false if there is no such latency.
+func (w *latencyWindow) quantile(q float64, timedOut bool) (time.Duration, bool) {
+	sorted := make([]time.Duration, 0, w.len())
+	for _, s := range w.samples[:w.len()] {
+		if timedOut || !s.timedOut {
+			sorted = append(sorted, s.latency)
+		}
+	}
+	if len(sorted) == 0 {
+		return 0, false
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	i := int(math.Ceil(q*float64(len(sorted)))) - 1
+	return sorted[max(0, min(len(sorted)-1, i))], true
+}
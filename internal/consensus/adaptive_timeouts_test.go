@@ -0,0 +1,143 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cfg "github.com/cometbft/cometbft/config"
+)
+
+func TestLatencyWindow(t *testing.T) {
+	w := newLatencyWindow(4)
+	_, ok := w.quantile(0.95, true)
+	assert.False(t, ok)
+	for i := 1; i <= 3; i++ {
+		w.add(time.Duration(i)*time.Second, false)
+	}
+	assert.Equal(t, 3, w.len())
+	quantile := func(q float64, timedOut bool) time.Duration {
+		latency, ok := w.quantile(q, timedOut)
+		require.True(t, ok)
+		return latency
+	}
+	assert.Equal(t, 3*time.Second, quantile(0.95, false))
+	assert.Equal(t, 2*time.Second, quantile(0.5, false))
+	assert.Equal(t, time.Second, quantile(0, false))
+
+	// the timed-out samples are only included if requested
+	w.add(time.Minute, true)
+	assert.Equal(t, 3*time.Second, quantile(0.95, false))
+	assert.Equal(t, time.Minute, quantile(0.95, true))
+
+	// the oldest latencies are replaced
+	for i := 0; i < 4; i++ {
+		w.add(100*time.Millisecond, true)
+	}
+	assert.Equal(t, 4, w.len())
+	assert.Equal(t, 100*time.Millisecond, quantile(0.95, true))
+	_, ok = w.quantile(0.95, false)
+	assert.False(t, ok)
+}
+
+func TestAdaptiveTimeouts(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutWindow = 20
+	at := newAdaptiveTimeouts(config)
+
+	now := time.Now()
+	measure := func(step timeoutStep, height int64, round int32, latency time.Duration) {
+		at.start(step, height, round, now)
+		_, ok := at.observe(step, height, round, now.Add(latency))
+		require.True(t, ok)
+	}
+
+	// the static timeouts are used until enough latencies are measured
+	for h := int64(1); h < adaptiveTimeoutMinSamples; h++ {
+		measure(timeoutStepPropose, h, 0, 2*time.Second)
+	}
+	assert.Equal(t, config.Propose(0), at.timeout(timeoutStepPropose, 0))
+	assert.Equal(t, config.Propose(2), at.timeout(timeoutStepPropose, 2))
+
+	measure(timeoutStepPropose, adaptiveTimeoutMinSamples, 0, 2*time.Second)
+	assert.Equal(t, 3*time.Second, at.timeout(timeoutStepPropose, 0))
+	assert.Equal(t, 3*time.Second+2*config.TimeoutProposeDelta, at.timeout(timeoutStepPropose, 2))
+
+	// the timeouts are bounded, even by the latencies measured in late rounds
+	for h := int64(1); h <= 20; h++ {
+		measure(timeoutStepPrevote, h, 0, time.Millisecond)
+		measure(timeoutStepPrecommit, h, 1000, time.Minute)
+	}
+	assert.Equal(t, config.AdaptiveTimeoutVoteMin, at.timeout(timeoutStepPrevote, 0))
+	assert.Equal(t, config.AdaptiveTimeoutVoteMax, at.timeout(timeoutStepPrecommit, 0))
+
+	// only the latencies of the current round are measured, once
+	at.start(timeoutStepPrevote, 30, 1, now)
+	_, ok := at.observe(timeoutStepPrevote, 30, 0, now)
+	assert.False(t, ok)
+	_, ok = at.observe(timeoutStepPrevote, 30, 1, now)
+	assert.True(t, ok)
+	_, ok = at.observe(timeoutStepPrevote, 30, 1, now)
+	assert.False(t, ok)
+	at.start(timeoutStepPrevote, 30, 2, now)
+	_, ok = at.observe(timeoutStepPrecommit, 30, 2, now)
+	assert.False(t, ok)
+}
+
+func TestAdaptiveTimeoutsOfflineProposer(t *testing.T) {
+	config := cfg.DefaultConsensusConfig()
+	config.AdaptiveTimeouts = true
+	config.AdaptiveTimeoutWindow = 20
+	at := newAdaptiveTimeouts(config)
+
+	// one of four proposers is offline: its rounds time out, and the
+	// proposal of the next round is received in time
+	now := time.Now()
+	latency := 800 * time.Millisecond
+	for h := int64(1); h <= 100; h++ {
+		round := int32(0)
+		if h%4 == 0 {
+			at.start(timeoutStepPropose, h, round, now)
+			_, ok := at.observe(timeoutStepPropose, h, round, now.Add(at.timeout(timeoutStepPropose, round)))
+			require.False(t, ok)
+			round++
+		}
+		at.start(timeoutStepPropose, h, round, now)
+		_, ok := at.observe(timeoutStepPropose, h, round, now.Add(latency))
+		require.True(t, ok)
+	}
+	assert.Equal(t, time.Duration(float64(latency)*adaptiveTimeoutMargin), at.timeout(timeoutStepPropose, 0))
+	assert.Less(t, at.timeout(timeoutStepPropose, 0), config.AdaptiveTimeoutProposeMax)
+}
+
+func TestSimulatorAdaptiveTimeouts(t *testing.T) {
+	// the static timeout_propose is much shorter than the message delays,
+	// so the proposals are only received in later rounds
+	config := cfg.TestConsensusConfig()
+	config.TimeoutPropose = 20 * time.Millisecond
+	config.TimeoutProposeDelta = 20 * time.Millisecond
+	link := SimLink{MinDelay: 100 * time.Millisecond, MaxDelay: 200 * time.Millisecond}
+
+	heights := func(adaptive bool) int64 {
+		config := *config
+		config.AdaptiveTimeouts = adaptive
+		config.AdaptiveTimeoutWindow = 20
+		config.AdaptiveTimeoutProposeMin = 10 * time.Millisecond
+		config.AdaptiveTimeoutProposeMax = 2 * time.Second
+		config.AdaptiveTimeoutVoteMin = 10 * time.Millisecond
+		sim := newTestSimulator(t, SimulatorConfig{Seed: 5, Consensus: &config, DefaultLink: link})
+		sim.RunFor(time.Minute)
+		if adaptive {
+			// the proposals are waited for long enough
+			assert.Greater(t, sim.State(0).adaptiveTimeouts.timeout(timeoutStepPropose, 0), link.MaxDelay)
+		}
+		return sim.Height(0)
+	}
+
+	static := heights(false)
+	adaptive := heights(true)
+	assert.Greater(t, adaptive, 2*static)
+}
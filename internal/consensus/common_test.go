@@ -508,7 +508,9 @@ func loadPrivValidator(config *cfg.Config) *privval.FilePV {
 	ensureDir(filepath.Dir(privValidatorKeyFile))
 	privValidatorStateFile := config.PrivValidatorStateFile()
 	privValidator := privval.LoadOrGenFilePV(privValidatorKeyFile, privValidatorStateFile)
-	privValidator.Reset()
+	if err := privValidator.Reset(); err != nil {
+		panic(err)
+	}
 	return privValidator
 }
 
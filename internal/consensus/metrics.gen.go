@@ -206,6 +206,20 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 
 			Buckets: []float64{-1.5, -1.0, -0.5, -0.2, 0, 0.2, 0.5, 1.0, 1.5, 2.0, 2.5, 4.0, 8.0},
 		}, append(labels, "is_timely")).With(labelsAndValues...),
+		AdaptiveTimeout: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "adaptive_timeout_seconds",
+			Help:      "Latest timeout in seconds computed by the adaptive timeouts for each step.",
+		}, append(labels, "step")).With(labelsAndValues...),
+		AdaptiveTimeoutLatency: prometheus.NewHistogramFrom(stdprometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "adaptive_timeout_latency_seconds",
+			Help:      "Latencies in seconds measured by the adaptive timeouts for each step.",
+
+			Buckets: stdprometheus.ExponentialBucketsRange(0.01, 100, 10),
+		}, append(labels, "step")).With(labelsAndValues...),
 	}
 }
 
@@ -242,5 +256,7 @@ func NopMetrics() *Metrics {
 		RoundVotingPowerPercent:     discard.NewGauge(),
 		LateVotes:                   discard.NewCounter(),
 		ProposalTimestampDifference: discard.NewHistogram(),
+		AdaptiveTimeout:             discard.NewGauge(),
+		AdaptiveTimeoutLatency:      discard.NewHistogram(),
 	}
 }
@@ -140,6 +140,17 @@ type Metrics struct {
 	// parameter SynchronyParams.MessageDelay, used by the PBTS algorithm.
 	// metrics:Difference in seconds between the local time when a proposal message is received and the timestamp in the proposal message.
 	ProposalTimestampDifference metrics.Histogram `metrics_bucketsizes:"-1.5, -1.0, -0.5, -0.2, 0, 0.2, 0.5, 1.0, 1.5, 2.0, 2.5, 4.0, 8.0" metrics_labels:"is_timely"`
+
+	// AdaptiveTimeout is the latest timeout in seconds scheduled for the
+	// propose, prevote and precommit steps when adaptive timeouts are
+	// enabled.
+	// metrics:Latest timeout in seconds computed by the adaptive timeouts for each step.
+	AdaptiveTimeout metrics.Gauge `metrics_name:"adaptive_timeout_seconds" metrics_labels:"step"`
+
+	// AdaptiveTimeoutLatency is the histogram of the latencies measured by
+	// the adaptive timeouts for each step.
+	// metrics:Latencies in seconds measured by the adaptive timeouts for each step.
+	AdaptiveTimeoutLatency metrics.Histogram `metrics_name:"adaptive_timeout_latency_seconds" metrics_bucketsizes:"0.01, 100, 10" metrics_buckettype:"exprange" metrics_labels:"step"`
 }
 
 func (m *Metrics) MarkProposalProcessed(accepted bool) {
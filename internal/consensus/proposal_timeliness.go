@@ -0,0 +1,51 @@
+package consensus
+
+import (
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+// proposalTimelinessHistorySize is the number of proposals whose timeliness
+// is kept by the State.
+const proposalTimelinessHistorySize = 1000
+
+// proposalTimelinessQueueSize is the number of verdicts waiting to be
+// published; the new ones are not published while the queue is full.
+const proposalTimelinessQueueSize = 100
+
+// proposalTimelinessHistory keeps the PBTS verdicts on the latest proposals
+// received by the node, so operators can find the proposers whose clocks are
+// skewed.
+type proposalTimelinessHistory struct {
+	mtx     cmtsync.Mutex
+	records []types.EventDataProposalTimeliness // ring buffer
+	next    int
+	full    bool
+}
+
+func newProposalTimelinessHistory(size int) *proposalTimelinessHistory {
+	return &proposalTimelinessHistory{records: make([]types.EventDataProposalTimeliness, size)}
+}
+
+// Add adds a verdict, evicting the oldest one if the history is full.
+func (h *proposalTimelinessHistory) Add(t types.EventDataProposalTimeliness) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.records[h.next] = t
+	h.next = (h.next + 1) % len(h.records)
+	if h.next == 0 {
+		h.full = true
+	}
+}
+
+// List returns the verdicts, from the oldest to the newest.
+func (h *proposalTimelinessHistory) List() []types.EventDataProposalTimeliness {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if !h.full {
+		return append([]types.EventDataProposalTimeliness(nil), h.records[:h.next]...)
+	}
+	list := make([]types.EventDataProposalTimeliness, 0, len(h.records))
+	list = append(list, h.records[h.next:]...)
+	return append(list, h.records[:h.next]...)
+}
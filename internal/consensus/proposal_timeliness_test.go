@@ -0,0 +1,70 @@
+package consensus
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestProposalTimelinessHistory(t *testing.T) {
+	h := newProposalTimelinessHistory(3)
+	assert.Empty(t, h.List())
+
+	for height := int64(1); height <= 5; height++ {
+		h.Add(types.EventDataProposalTimeliness{Height: height})
+		list := h.List()
+		require.Len(t, list, min(int(height), 3))
+		assert.Equal(t, height, list[len(list)-1].Height)
+		assert.Equal(t, max(1, height-2), list[0].Height)
+	}
+}
+
+func TestStateProposalTimeliness(t *testing.T) {
+	params := types.DefaultConsensusParams()
+	params.Feature.PbtsEnableHeight = 1
+	params.Synchrony.Precision = 500 * time.Millisecond
+	params.Synchrony.MessageDelay = 2 * time.Second
+
+	// the clock of node 0 is 3s ahead of the others
+	sim := newTestSimulator(t, SimulatorConfig{
+		Seed:            5,
+		ConsensusParams: params,
+		ClockOffsets:    []time.Duration{3 * time.Second},
+		DefaultLink:     SimLink{MinDelay: 10 * time.Millisecond, MaxDelay: 50 * time.Millisecond},
+	})
+	sub, err := sim.EventBus(1).Subscribe(context.Background(), "test", types.EventQueryProposalTimeliness, 100)
+	require.NoError(t, err)
+	require.NoError(t, sim.RunUntilHeight(6, 5*time.Minute))
+
+	skewed := sim.State(0).privValidatorPubKey.Address()
+	var timely, untimely int
+	for _, pt := range sim.State(1).GetProposalTimeliness() {
+		assert.Equal(t, pt.ReceiveTime.Sub(pt.Timestamp), pt.Difference)
+		assert.Equal(t, pt.Timely, pt.LowerMargin >= 0 && pt.UpperMargin >= 0)
+		if bytes.Equal(pt.Proposer, skewed) {
+			assert.False(t, pt.Timely)
+			assert.Less(t, pt.Difference, -2*time.Second)
+			assert.Negative(t, pt.LowerMargin)
+			untimely++
+		} else {
+			assert.True(t, pt.Timely)
+			timely++
+		}
+	}
+	assert.Positive(t, timely)
+	assert.Positive(t, untimely)
+
+	select {
+	case msg := <-sub.Out():
+		_, ok := msg.Data().(types.EventDataProposalTimeliness)
+		assert.True(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("no ProposalTimeliness event")
+	}
+}
@@ -800,7 +800,12 @@ OUTER_LOOP:
 				ec = conR.conS.blockStore.LoadBlockExtendedCommit(prs.Height)
 			} else {
 				c := conR.conS.blockStore.LoadBlockCommit(prs.Height)
-				if c == nil {
+				if c != nil && c.IsAggregated() {
+					// The votes can't be recovered from an aggregated commit,
+					// try with the commit we've seen instead.
+					c = conR.conS.blockStore.LoadSeenCommit(prs.Height)
+				}
+				if c == nil || c.IsAggregated() {
 					continue
 				}
 				ec = c.WrappedExtendedCommit()
@@ -1010,14 +1015,20 @@ func (conR *Reactor) peerStatsRoutine() {
 			}
 			switch msg.Msg.(type) {
 			case *VoteMessage:
+				conR.Switch.ReportPeer(peer, p2p.PeerBehaviorUsefulVote)
 				if numVotes := ps.RecordVote(); numVotes%votesToContributeToBecomeGoodPeer == 0 {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
 			case *BlockPartMessage:
+				conR.Switch.ReportPeer(peer, p2p.PeerBehaviorUsefulBlockPart)
 				if numParts := ps.RecordBlockPart(); numParts%blocksToContributeToBecomeGoodPeer == 0 {
 					conR.Switch.MarkPeerAsGood(peer)
 				}
 			}
+		case msg := <-conR.conS.invalidMsgQueue:
+			if peer := conR.Switch.Peers().Get(msg.PeerID); peer != nil {
+				conR.Switch.ReportPeer(peer, p2p.PeerBehaviorInvalidVote)
+			}
 		case <-conR.conS.Quit():
 			return
 
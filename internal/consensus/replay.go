@@ -308,6 +308,12 @@ func (h *Handshaker) ReplayBlocks(
 		}
 		validatorSet := types.NewValidatorSet(validators)
 		nextVals := types.TM2PB.ValidatorUpdates(validatorSet)
+		// Pass the proofs of possession along, so that the application can
+		// return the validators as they are.
+		for _, val := range h.genDoc.Validators {
+			idx, _ := validatorSet.GetByAddress(val.PubKey.Address())
+			nextVals[idx].ProofOfPossession = val.ProofOfPossession
+		}
 		pbparams := h.genDoc.ConsensusParams.ToProto()
 		req := &abci.InitChainRequest{
 			Time:            h.genDoc.GenesisTime,
@@ -337,6 +343,11 @@ func (h *Handshaker) ReplayBlocks(
 				if err != nil {
 					return nil, err
 				}
+				for i, val := range vals {
+					if err := types.VerifyProofOfPossession(val.PubKey, res.Validators[i].ProofOfPossession); err != nil {
+						return nil, fmt.Errorf("validator %v returned by InitChain: %w", val, err)
+					}
+				}
 				state.Validators = types.NewValidatorSet(vals)
 				state.NextValidators = types.NewValidatorSet(vals).CopyIncrementProposerPriority(1)
 			} else if len(h.genDoc.Validators) == 0 {
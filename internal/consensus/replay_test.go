@@ -1216,6 +1216,15 @@ func (bs *mockBlockStore) PruneBlocks(height int64, _ sm.State) (uint64, int64,
 	return pruned, evidencePoint, nil
 }
 
+func (bs *mockBlockStore) PruneExtendedCommits(height int64) (uint64, error) {
+	pruned := uint64(0)
+	for i := int64(0); i < height-1; i++ {
+		bs.extCommits[i] = nil
+		pruned++
+	}
+	return pruned, nil
+}
+
 func (bs *mockBlockStore) DeleteLatestBlock() error { return nil }
 func (bs *mockBlockStore) Close() error             { return nil }
 
@@ -0,0 +1,580 @@
+package consensus
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// AnyNode matches all the nodes in the rules of the network of a Simulator.
+const AnyNode = -1
+
+// SimulatorConfig is the configuration of a Simulator.
+type SimulatorConfig struct {
+	// Voting powers of the validators. There is one node per validator.
+	VotingPowers []int64
+	// Seed of the keys of the validators and of the network. Two simulations
+	// with the same config and the same actions produce the same execution.
+	Seed int64
+	// Consensus config of the nodes. Defaults to DefaultConsensusConfig.
+	Consensus *cfg.ConsensusConfig
+	// Consensus params of the chain. Defaults to DefaultConsensusParams.
+	ConsensusParams *types.ConsensusParams
+	// Time of the genesis, at which the simulation starts.
+	GenesisTime time.Time
+	// Offsets of the clocks of the nodes from the time of the simulation.
+	ClockOffsets []time.Duration
+	// Link over which the messages are sent, unless overridden with SetLink.
+	DefaultLink SimLink
+	// Interval at which the nodes send their peers the messages they miss,
+	// e.g. because they were dropped. Defaults to 100ms.
+	GossipInterval time.Duration
+	Logger         log.Logger
+}
+
+// SimLink describes how the messages sent from one node to another are
+// delivered.
+type SimLink struct {
+	// The messages are delayed by a duration drawn uniformly from
+	// [MinDelay, MaxDelay], so they may be reordered.
+	MinDelay time.Duration
+	MaxDelay time.Duration
+	// Probability that a message is dropped. The dropped messages are sent
+	// again on the next gossip.
+	DropRate float64
+}
+
+// Simulator runs a network of validators, each with its own consensus State,
+// in a single goroutine. The time of the simulation is virtual: the
+// timeouts of the nodes and the delivery of their messages are events,
+// executed in the order of their virtual time without waiting. Each node
+// reads its local time from a clock with a configurable offset.
+//
+// The nodes exchange proposals, block parts and votes over a scripted
+// network, on which the messages can be delayed, dropped, reordered and
+// partitioned, per link and per channel. The randomness of the network comes
+// from the seed of the config, so a simulation is reproducible.
+//
+// Instead of the reactor, the nodes gossip the messages of the height of a
+// peer which the peer did not accept yet, every GossipInterval.
+type Simulator struct {
+	config SimulatorConfig
+	rnd    *rand.Rand
+	now    time.Time
+	events simEventQueue
+	seq    uint64
+
+	nodes      []*simNode
+	links      []simLinkRule
+	partitions map[int]int // node -> partition, nil if healed
+}
+
+type simNode struct {
+	index     int
+	cs        *State
+	clock     *simClock
+	ticker    *simTicker
+	proxyApp  proxy.AppConns
+	eventBus  *types.EventBus
+	peerID    p2p.ID
+	log       map[int64][]Message // messages the node has, per height
+	known     map[Message]struct{}
+	delivered map[Message]Message // clone -> original, during a delivery
+}
+
+type simLinkRule struct {
+	from, to int
+	chID     byte
+	link     SimLink
+}
+
+// NewSimulator creates the nodes of a simulation. Call Start to start it.
+func NewSimulator(config SimulatorConfig) (*Simulator, error) {
+	if len(config.VotingPowers) == 0 {
+		return nil, errors.New("no validators")
+	}
+	if len(config.ClockOffsets) > len(config.VotingPowers) {
+		return nil, errors.New("more clock offsets than validators")
+	}
+	if config.Consensus == nil {
+		config.Consensus = cfg.DefaultConsensusConfig()
+	}
+	if config.ConsensusParams == nil {
+		config.ConsensusParams = types.DefaultConsensusParams()
+	}
+	if config.GenesisTime.IsZero() {
+		config.GenesisTime = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	}
+	if config.GossipInterval <= 0 {
+		config.GossipInterval = 100 * time.Millisecond
+	}
+	if config.Logger == nil {
+		config.Logger = log.NewNopLogger()
+	}
+
+	s := &Simulator{
+		config: config,
+		rnd:    rand.New(rand.NewSource(config.Seed)), //nolint:gosec
+		now:    config.GenesisTime,
+	}
+
+	privVals := make([]types.PrivValidator, len(config.VotingPowers))
+	genDoc := &types.GenesisDoc{
+		ChainID:         fmt.Sprintf("sim-%d", config.Seed),
+		GenesisTime:     config.GenesisTime,
+		ConsensusParams: config.ConsensusParams,
+	}
+	for i, power := range config.VotingPowers {
+		privKey := ed25519.GenPrivKeyFromSecret([]byte(fmt.Sprintf("sim/%d/%d", config.Seed, i)))
+		privVals[i] = types.NewMockPVWithParams(privKey, false, false)
+		genDoc.Validators = append(genDoc.Validators, types.GenesisValidator{
+			Address: privKey.PubKey().Address(),
+			PubKey:  privKey.PubKey(),
+			Power:   power,
+		})
+	}
+	if err := genDoc.ValidateAndComplete(); err != nil {
+		return nil, fmt.Errorf("invalid genesis: %w", err)
+	}
+	state, err := sm.MakeGenesisState(genDoc)
+	if err != nil {
+		return nil, err
+	}
+	state.Version.Consensus.App = kvstore.AppVersion
+
+	for i := range config.VotingPowers {
+		n, err := s.newNode(i, state.Copy(), privVals[i])
+		if err != nil {
+			s.stopNodes()
+			return nil, err
+		}
+		s.nodes = append(s.nodes, n)
+	}
+	return s, nil
+}
+
+func (s *Simulator) newNode(index int, state sm.State, privVal types.PrivValidator) (*simNode, error) {
+	logger := s.config.Logger.With("validator", index)
+	n := &simNode{
+		index:  index,
+		clock:  &simClock{sim: s},
+		peerID: p2p.ID(fmt.Sprintf("%040x", index)),
+		log:    make(map[int64][]Message),
+		known:  make(map[Message]struct{}),
+	}
+	if index < len(s.config.ClockOffsets) {
+		n.clock.offset = s.config.ClockOffsets[index]
+	}
+	n.ticker = &simTicker{sim: s, node: n}
+
+	stateDB := dbm.NewMemDB()
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+	if err := stateStore.Save(state); err != nil {
+		return nil, err
+	}
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+
+	n.proxyApp = proxy.NewAppConns(proxy.NewLocalClientCreator(kvstore.NewInMemoryApplication()), proxy.NopMetrics())
+	n.proxyApp.SetLogger(logger.With("module", "proxy"))
+	if err := n.proxyApp.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start proxy app connections: %w", err)
+	}
+	n.eventBus = types.NewEventBus()
+	n.eventBus.SetLogger(logger.With("module", "events"))
+	if err := n.eventBus.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start event bus: %w", err)
+	}
+
+	mempool := emptyMempool{}
+	evpool := sm.EmptyEvidencePool{}
+	blockExec := sm.NewBlockExecutor(stateStore, logger, n.proxyApp.Consensus(), mempool, evpool, blockStore,
+		sm.BlockExecutorWithTimeSource(n.clock))
+	config := *s.config.Consensus
+	n.cs = NewState(&config, state, blockExec, blockStore, mempool, evpool, StateTimeSource(n.clock))
+	n.cs.SetLogger(logger.With("module", "consensus"))
+	n.cs.SetEventBus(n.eventBus)
+	n.cs.SetTimeoutTicker(n.ticker)
+	n.cs.SetPrivValidator(privVal)
+	return n, nil
+}
+
+// Start schedules the first round of the nodes.
+func (s *Simulator) Start() {
+	for _, n := range s.nodes {
+		n.cs.scheduleRound0(n.cs.GetRoundState())
+		s.schedule(s.now.Add(s.config.GossipInterval), &simEvent{kind: simEventGossip, node: n})
+	}
+}
+
+// Stop stops the applications and the event buses of the nodes.
+func (s *Simulator) Stop() {
+	s.stopNodes()
+}
+
+func (s *Simulator) stopNodes() {
+	for _, n := range s.nodes {
+		_ = n.proxyApp.Stop()
+		_ = n.eventBus.Stop()
+	}
+}
+
+// Now returns the time of the simulation.
+func (s *Simulator) Now() time.Time {
+	return s.now
+}
+
+// State returns the consensus state of a node.
+func (s *Simulator) State(node int) *State {
+	return s.nodes[node].cs
+}
+
+// EventBus returns the event bus of a node.
+func (s *Simulator) EventBus(node int) *types.EventBus {
+	return s.nodes[node].eventBus
+}
+
+// Height returns the height of the last block committed by a node.
+func (s *Simulator) Height(node int) int64 {
+	return s.nodes[node].cs.blockStore.Height()
+}
+
+// SetClockOffset sets the offset of the clock of a node from the time of the
+// simulation.
+func (s *Simulator) SetClockOffset(node int, offset time.Duration) {
+	s.nodes[node].clock.offset = offset
+}
+
+// SetLink sets the link over which the messages sent from a node to another
+// on a channel are delivered. from and to can be AnyNode, and chID can be 0
+// to match all the channels. The last link set matching a message is used.
+func (s *Simulator) SetLink(from, to int, chID byte, link SimLink) {
+	s.links = append(s.links, simLinkRule{from: from, to: to, chID: chID, link: link})
+}
+
+// Partition splits the network: the nodes can only communicate with the
+// nodes of the same group. The nodes not in a group are isolated.
+func (s *Simulator) Partition(groups ...[]int) {
+	s.partitions = make(map[int]int)
+	for i, group := range groups {
+		for _, node := range group {
+			s.partitions[node] = i
+		}
+	}
+}
+
+// Heal removes the partitions of the network.
+func (s *Simulator) Heal() {
+	s.partitions = nil
+}
+
+// Step executes the next event. It returns false if there is none.
+func (s *Simulator) Step() bool {
+	if s.events.Len() == 0 {
+		return false
+	}
+	ev := heap.Pop(&s.events).(*simEvent)
+	s.now = ev.at
+	switch ev.kind {
+	case simEventTimeout:
+		if ev.gen == ev.node.ticker.gen {
+			ev.node.cs.handleTimeout(ev.ti, *ev.node.cs.GetRoundState())
+		}
+	case simEventDeliver:
+		s.deliver(ev.from, ev.node, ev.msg)
+	case simEventGossip:
+		s.gossip(ev.node)
+		s.schedule(s.now.Add(s.config.GossipInterval), ev)
+	}
+	s.flush(ev.node)
+	return true
+}
+
+// RunFor executes the events of the next d of the simulation.
+func (s *Simulator) RunFor(d time.Duration) {
+	end := s.now.Add(d)
+	for s.events.Len() > 0 && !s.events[0].at.After(end) {
+		s.Step()
+	}
+	s.now = end
+}
+
+// RunUntil executes the events until cond returns true, and returns an error
+// if it didn't within timeout of the simulation.
+func (s *Simulator) RunUntil(cond func() bool, timeout time.Duration) error {
+	end := s.now.Add(timeout)
+	for !cond() {
+		if s.events.Len() == 0 || s.events[0].at.After(end) {
+			return fmt.Errorf("condition not met after %v", timeout)
+		}
+		s.Step()
+	}
+	return nil
+}
+
+// RunUntilHeight executes the events until all the nodes committed the given
+// height, and returns an error if they didn't within timeout of the
+// simulation.
+func (s *Simulator) RunUntilHeight(height int64, timeout time.Duration) error {
+	return s.RunUntil(func() bool {
+		for i := range s.nodes {
+			if s.Height(i) < height {
+				return false
+			}
+		}
+		return true
+	}, timeout)
+}
+
+// flush processes the messages of the node to itself, and broadcasts them.
+func (s *Simulator) flush(n *simNode) {
+	for {
+		s.drainStats(n)
+		select {
+		case mi := <-n.cs.internalMsgQueue:
+			s.addToLog(n, mi.Msg)
+			for _, peer := range s.nodes {
+				if peer != n {
+					s.send(n, peer, mi.Msg)
+				}
+			}
+			n.cs.handleMsg(mi)
+		default:
+			return
+		}
+	}
+}
+
+// drainStats logs the messages from peers the node accepted, and publishes
+// the PBTS verdicts of the node. The invalid messages are discarded.
+func (s *Simulator) drainStats(n *simNode) {
+	for {
+		select {
+		case mi := <-n.cs.statsMsgQueue:
+			if orig, ok := n.delivered[mi.Msg]; ok {
+				s.addToLog(n, orig)
+			}
+		case t := <-n.cs.timelinessQueue:
+			n.cs.publishProposalTimeliness(t)
+		case <-n.cs.invalidMsgQueue:
+		default:
+			return
+		}
+	}
+}
+
+func (s *Simulator) addToLog(n *simNode, msg Message) {
+	height, _ := WALMessageHeight(msgInfo{Msg: msg})
+	n.log[height] = append(n.log[height], msg)
+	n.known[msg] = struct{}{}
+}
+
+// deliver passes a copy of a message to the node, as the reactor would. If
+// the node doesn't accept it, the message will be sent again.
+func (s *Simulator) deliver(from, n *simNode, msg Message) {
+	if !s.connected(from.index, n.index) {
+		delete(n.known, msg)
+		return
+	}
+	clone, err := cloneMessage(msg)
+	if err != nil {
+		panic(fmt.Sprintf("failed to copy %T: %v", msg, err))
+	}
+	n.delivered = map[Message]Message{clone: msg}
+	defer func() { n.delivered = nil }()
+
+	delete(n.known, msg)
+	n.cs.handleMsg(msgInfo{Msg: clone, PeerID: from.peerID, ReceiveTime: n.clock.Now()})
+	s.drainStats(n)
+	if m, ok := clone.(*ProposalMessage); ok && n.cs.Proposal == m.Proposal {
+		s.addToLog(n, msg)
+	}
+}
+
+// gossip sends each peer the messages of its height it doesn't have.
+func (s *Simulator) gossip(n *simNode) {
+	for _, peer := range s.nodes {
+		if peer == n {
+			continue
+		}
+		for _, msg := range n.log[peer.cs.Height] {
+			if _, ok := peer.known[msg]; !ok {
+				s.send(n, peer, msg)
+			}
+		}
+	}
+}
+
+func (s *Simulator) send(from, to *simNode, msg Message) {
+	link := s.link(from.index, to.index, msgChannel(msg))
+	if link.DropRate > 0 && s.rnd.Float64() < link.DropRate {
+		return
+	}
+	delay := link.MinDelay
+	if link.MaxDelay > link.MinDelay {
+		delay += time.Duration(s.rnd.Int63n(int64(link.MaxDelay - link.MinDelay + 1)))
+	}
+	to.known[msg] = struct{}{}
+	s.schedule(s.now.Add(delay), &simEvent{kind: simEventDeliver, from: from, node: to, msg: msg})
+}
+
+func (s *Simulator) link(from, to int, chID byte) SimLink {
+	for i := len(s.links) - 1; i >= 0; i-- {
+		r := s.links[i]
+		if (r.from == AnyNode || r.from == from) && (r.to == AnyNode || r.to == to) &&
+			(r.chID == 0 || r.chID == chID) {
+			return r.link
+		}
+	}
+	return s.config.DefaultLink
+}
+
+func (s *Simulator) connected(a, b int) bool {
+	if s.partitions == nil {
+		return true
+	}
+	pa, okA := s.partitions[a]
+	pb, okB := s.partitions[b]
+	return okA && okB && pa == pb
+}
+
+func (s *Simulator) schedule(at time.Time, ev *simEvent) {
+	if at.Before(s.now) {
+		at = s.now
+	}
+	s.seq++
+	ev.at, ev.seq = at, s.seq
+	heap.Push(&s.events, ev)
+}
+
+// msgChannel returns the channel on which the reactor sends a message.
+func msgChannel(msg Message) byte {
+	if _, ok := msg.(*VoteMessage); ok {
+		return VoteChannel
+	}
+	return DataChannel
+}
+
+// cloneMessage copies a message by encoding and decoding it, so that the
+// nodes don't share it.
+func cloneMessage(msg Message) (Message, error) {
+	pb, err := MsgToWrappedProto(msg)
+	if err != nil {
+		return nil, err
+	}
+	inner, err := pb.Unwrap()
+	if err != nil {
+		return nil, err
+	}
+	return MsgFromProto(inner)
+}
+
+//-----------------------------------------------------------------------------
+
+// simClock is the local clock of a node of a Simulator.
+type simClock struct {
+	sim    *Simulator
+	offset time.Duration
+}
+
+var _ cmttime.Source = (*simClock)(nil)
+
+func (c *simClock) Now() time.Time {
+	return c.sim.now.Add(c.offset)
+}
+
+// simTicker is a TimeoutTicker firing the timeouts as events of a Simulator.
+// Like timeoutTicker, it only keeps the latest timeout, and ignores the
+// timeouts for earlier heights, rounds and steps.
+type simTicker struct {
+	sim  *Simulator
+	node *simNode
+	ti   timeoutInfo
+	gen  uint64
+}
+
+var _ TimeoutTicker = (*simTicker)(nil)
+
+func (*simTicker) Start() error             { return nil }
+func (*simTicker) Stop() error              { return nil }
+func (*simTicker) Chan() <-chan timeoutInfo { return nil }
+func (*simTicker) SetLogger(log.Logger)     {}
+
+func (t *simTicker) ScheduleTimeout(newti timeoutInfo) {
+	ti := t.ti
+	if newti.Height < ti.Height {
+		return
+	} else if newti.Height == ti.Height {
+		if newti.Round < ti.Round {
+			return
+		} else if newti.Round == ti.Round && ti.Step > 0 && newti.Step <= ti.Step {
+			return
+		}
+	}
+	t.ti = newti
+	t.gen++
+	t.sim.schedule(t.sim.now.Add(newti.Duration), &simEvent{
+		kind: simEventTimeout,
+		node: t.node,
+		ti:   newti,
+		gen:  t.gen,
+	})
+}
+
+//-----------------------------------------------------------------------------
+
+type simEventKind int
+
+const (
+	simEventTimeout simEventKind = iota
+	simEventDeliver
+	simEventGossip
+)
+
+type simEvent struct {
+	at   time.Time
+	seq  uint64 // orders the events scheduled at the same time
+	kind simEventKind
+	node *simNode
+
+	ti  timeoutInfo // simEventTimeout
+	gen uint64
+
+	from *simNode // simEventDeliver
+	msg  Message
+}
+
+type simEventQueue []*simEvent
+
+func (q simEventQueue) Len() int { return len(q) }
+
+func (q simEventQueue) Less(i, j int) bool {
+	if q[i].at.Equal(q[j].at) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].at.Before(q[j].at)
+}
+
+func (q simEventQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *simEventQueue) Push(x any) { *q = append(*q, x.(*simEvent)) }
+
+func (q *simEventQueue) Pop() any {
+	old := *q
+	ev := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return ev
+}
@@ -0,0 +1,122 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/types"
+)
+
+func newTestSimulator(t *testing.T, config SimulatorConfig) *Simulator {
+	t.Helper()
+	if config.VotingPowers == nil {
+		config.VotingPowers = []int64{10, 10, 10, 10}
+	}
+	sim, err := NewSimulator(config)
+	require.NoError(t, err)
+	t.Cleanup(sim.Stop)
+	sim.Start()
+	return sim
+}
+
+func TestSimulatorCommits(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{
+		Seed:        1,
+		DefaultLink: SimLink{MinDelay: 5 * time.Millisecond, MaxDelay: 50 * time.Millisecond},
+	})
+	require.NoError(t, sim.RunUntilHeight(5, time.Minute))
+
+	// all the nodes committed the same blocks
+	for h := int64(1); h <= 5; h++ {
+		hash := sim.State(0).blockStore.LoadBlockMeta(h).BlockID.Hash
+		for i := 1; i < 4; i++ {
+			assert.Equal(t, hash, sim.State(i).blockStore.LoadBlockMeta(h).BlockID.Hash)
+		}
+	}
+}
+
+func TestSimulatorDeterministic(t *testing.T) {
+	run := func(seed int64) (time.Time, []byte) {
+		sim := newTestSimulator(t, SimulatorConfig{
+			Seed: seed,
+			DefaultLink: SimLink{
+				MinDelay: time.Millisecond,
+				MaxDelay: 800 * time.Millisecond,
+				DropRate: 0.2,
+			},
+		})
+		require.NoError(t, sim.RunUntilHeight(3, 5*time.Minute))
+		return sim.Now(), sim.State(0).blockStore.LoadBlockMeta(3).BlockID.Hash
+	}
+
+	end1, hash1 := run(7)
+	end2, hash2 := run(7)
+	assert.Equal(t, end1, end2)
+	assert.Equal(t, hash1, hash2)
+}
+
+func TestSimulatorPartition(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{
+		Seed:        2,
+		DefaultLink: SimLink{MinDelay: 10 * time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	})
+	require.NoError(t, sim.RunUntilHeight(2, time.Minute))
+
+	// no partition has +2/3 of the voting power
+	sim.Partition([]int{0, 1}, []int{2, 3})
+	height := sim.Height(0)
+	sim.RunFor(time.Minute)
+	for i := 0; i < 4; i++ {
+		assert.LessOrEqual(t, sim.Height(i), height+1, "node %d", i)
+	}
+
+	sim.Heal()
+	require.NoError(t, sim.RunUntilHeight(height+3, 5*time.Minute))
+}
+
+func TestSimulatorVoteChannel(t *testing.T) {
+	sim := newTestSimulator(t, SimulatorConfig{Seed: 3})
+
+	// node 3 doesn't receive the votes, so it can only commit by catching up
+	// once the votes are delivered again
+	sim.SetLink(AnyNode, 3, VoteChannel, SimLink{DropRate: 1})
+	require.NoError(t, sim.RunUntil(func() bool { return sim.Height(0) >= 3 }, time.Minute))
+	assert.EqualValues(t, 0, sim.Height(3))
+
+	sim.SetLink(AnyNode, 3, VoteChannel, SimLink{})
+	require.NoError(t, sim.RunUntilHeight(4, time.Minute))
+}
+
+func TestSimulatorPBTSSkewedClocks(t *testing.T) {
+	params := types.DefaultConsensusParams()
+	params.Feature.PbtsEnableHeight = 1
+	params.Synchrony.Precision = 500 * time.Millisecond
+	params.Synchrony.MessageDelay = 2 * time.Second
+
+	// the clock of node 0 is far ahead of the others, so its proposals are
+	// not timely and it must wait for the others to catch up with the time of
+	// its blocks
+	sim := newTestSimulator(t, SimulatorConfig{
+		Seed:            4,
+		ConsensusParams: params,
+		ClockOffsets:    []time.Duration{10 * time.Second, 0, 100 * time.Millisecond, -300 * time.Millisecond},
+		DefaultLink:     SimLink{MinDelay: 10 * time.Millisecond, MaxDelay: 200 * time.Millisecond},
+	})
+	require.NoError(t, sim.RunUntilHeight(8, 5*time.Minute))
+
+	// the proposals of node 0 are rejected, so the blocks it should have
+	// proposed are committed in a later round
+	addr0 := sim.State(0).privValidatorPubKey.Address()
+	laterRounds := 0
+	for h := int64(1); h < 8; h++ {
+		block, _ := sim.State(1).blockStore.LoadBlock(h)
+		assert.NotEqual(t, addr0, block.ProposerAddress, "height %d", h)
+		if sim.State(1).blockStore.LoadBlockCommit(h).Round > 0 {
+			laterRounds++
+		}
+	}
+	assert.Positive(t, laterRounds)
+}
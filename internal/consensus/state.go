@@ -106,6 +106,8 @@ type State struct {
 	// information about about added votes and block parts are written on this channel
 	// so statistics can be computed by reactor
 	statsMsgQueue chan msgInfo
+	// invalid messages from peers, used by the reactor to lower their score.
+	invalidMsgQueue chan msgInfo
 
 	// we use eventBus to trigger msg broadcasts in the reactor,
 	// and to notify external subscribers, eg. through a websocket
@@ -135,6 +137,19 @@ type State struct {
 	// for reporting metrics
 	metrics *Metrics
 
+	// local clock, used to time the steps, proposals and votes
+	timeSource cmttime.Source
+
+	// PBTS verdicts on the latest proposals, for diagnostics. The new ones
+	// are published by publishTimelinessRoutine, so that the subscribers
+	// never block the receiveRoutine.
+	timeliness      *proposalTimelinessHistory
+	timelinessQueue chan types.EventDataProposalTimeliness
+
+	// computes the timeouts from the latencies of the latest rounds, if
+	// enabled by config.AdaptiveTimeouts
+	adaptiveTimeouts *adaptiveTimeouts
+
 	// offline state sync height indicating to which height the node synced offline
 	offlineStateSyncHeight int64
 }
@@ -161,12 +176,19 @@ func NewState(
 		internalMsgQueue: make(chan msgInfo, msgQueueSize),
 		timeoutTicker:    NewTimeoutTicker(),
 		statsMsgQueue:    make(chan msgInfo, msgQueueSize),
+		invalidMsgQueue:  make(chan msgInfo, msgQueueSize),
 		done:             make(chan struct{}),
 		doWALCatchup:     true,
 		wal:              nilWAL{},
 		evpool:           evpool,
 		evsw:             cmtevents.NewEventSwitch(),
 		metrics:          NopMetrics(),
+		timeSource:       cmttime.DefaultSource{},
+		timeliness:       newProposalTimelinessHistory(proposalTimelinessHistorySize),
+		timelinessQueue:  make(chan types.EventDataProposalTimeliness, proposalTimelinessQueueSize),
+	}
+	if config.AdaptiveTimeouts {
+		cs.adaptiveTimeouts = newAdaptiveTimeouts(config)
 	}
 	for _, option := range options {
 		option(cs)
@@ -216,6 +238,12 @@ func StateMetrics(metrics *Metrics) StateOption {
 	return func(cs *State) { cs.metrics = metrics }
 }
 
+// StateTimeSource sets the local clock. It may be useful to overwrite for
+// testing, e.g. with a virtual clock.
+func StateTimeSource(source cmttime.Source) StateOption {
+	return func(cs *State) { cs.timeSource = source }
+}
+
 // OfflineStateSyncHeight indicates the height at which the node
 // statesync offline - before booting sets the metrics.
 func OfflineStateSyncHeight(height int64) StateOption {
@@ -265,6 +293,12 @@ func (cs *State) GetRoundStateSimpleJSON() ([]byte, error) {
 	return cmtjson.Marshal(cs.RoundState.RoundStateSimple())
 }
 
+// GetProposalTimeliness returns the PBTS verdicts on the latest proposals
+// received by the node, from the oldest to the newest.
+func (cs *State) GetProposalTimeliness() []types.EventDataProposalTimeliness {
+	return cs.timeliness.List()
+}
+
 // GetValidators returns a copy of the current validators.
 func (cs *State) GetValidators() (int64, []*types.Validator) {
 	cs.mtx.RLock()
@@ -388,6 +422,7 @@ func (cs *State) OnStart() error {
 
 	// now start the receiveRoutine
 	go cs.receiveRoutine(0)
+	go cs.publishTimelinessRoutine()
 
 	// schedule the first round!
 	// use GetRoundState so we don't race the receiveRoutine for access
@@ -408,6 +443,7 @@ func (cs *State) startRoutines(maxSteps int) {
 	}
 
 	go cs.receiveRoutine(maxSteps)
+	go cs.publishTimelinessRoutine()
 }
 
 // loadWalFile loads WAL data from file. It overwrites cs.wal.
@@ -482,9 +518,9 @@ func (cs *State) AddVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 // SetProposal inputs a proposal.
 func (cs *State) SetProposal(proposal *types.Proposal, peerID p2p.ID) error {
 	if peerID == "" {
-		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, "", cmttime.Now()}
+		cs.internalMsgQueue <- msgInfo{&ProposalMessage{proposal}, "", cs.timeSource.Now()}
 	} else {
-		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID, cmttime.Now()}
+		cs.peerMsgQueue <- msgInfo{&ProposalMessage{proposal}, peerID, cs.timeSource.Now()}
 	}
 
 	// TODO: wait for event?!
@@ -549,7 +585,7 @@ func (cs *State) updateRoundStep(round int32, step cstypes.RoundStepType) {
 // enterNewRound(height, 0) at cs.StartTime.
 func (cs *State) scheduleRound0(rs *cstypes.RoundState) {
 	// cs.Logger.Info("scheduleRound0", "now", cmttime.Now(), "startTime", cs.StartTime)
-	sleepDuration := rs.StartTime.Sub(cmttime.Now())
+	sleepDuration := rs.StartTime.Sub(cs.timeSource.Now())
 	cs.scheduleTimeout(sleepDuration, rs.Height, 0, cstypes.RoundStepNewHeight)
 }
 
@@ -558,6 +594,44 @@ func (cs *State) scheduleTimeout(duration time.Duration, height int64, round int
 	cs.timeoutTicker.ScheduleTimeout(timeoutInfo{duration, height, round, step})
 }
 
+// stepTimeout returns the timeout of the propose, prevote or precommit step
+// of the given round, either static or adaptive.
+func (cs *State) stepTimeout(step timeoutStep, round int32) time.Duration {
+	if cs.adaptiveTimeouts == nil {
+		switch step {
+		case timeoutStepPropose:
+			return cs.config.Propose(round)
+		case timeoutStepPrevote:
+			return cs.config.Prevote(round)
+		default:
+			return cs.config.Precommit(round)
+		}
+	}
+	timeout := cs.adaptiveTimeouts.timeout(step, round)
+	cs.metrics.AdaptiveTimeout.With("step", step.String()).Set(timeout.Seconds())
+	return timeout
+}
+
+// startStepLatency starts measuring the latency of a step for the adaptive
+// timeouts.
+func (cs *State) startStepLatency(step timeoutStep, height int64, round int32) {
+	if cs.adaptiveTimeouts == nil || cs.replayMode {
+		return
+	}
+	cs.adaptiveTimeouts.start(step, height, round, cs.timeSource.Now())
+}
+
+// observeStepLatency ends the measurement of the latency of a step for the
+// adaptive timeouts, if it was started.
+func (cs *State) observeStepLatency(step timeoutStep, height int64, round int32) {
+	if cs.adaptiveTimeouts == nil || cs.replayMode {
+		return
+	}
+	if latency, ok := cs.adaptiveTimeouts.observe(step, height, round, cs.timeSource.Now()); ok {
+		cs.metrics.AdaptiveTimeoutLatency.With("step", step.String()).Observe(latency.Seconds())
+	}
+}
+
 // send a msg into the receiveRoutine regarding our own proposal, block part, or vote.
 func (cs *State) sendInternalMessage(mi msgInfo) {
 	select {
@@ -629,6 +703,14 @@ func (cs *State) votesFromSeenCommit(state sm.State) (*types.VoteSet, error) {
 		return nil, fmt.Errorf("heights don't match in votesFromSeenCommit %v!=%v",
 			commit.Height, state.LastBlockHeight)
 	}
+	if commit.IsAggregated() {
+		// The votes can't be recovered from an aggregated commit, which happens
+		// if the block was received via blocksync. Start with an empty vote
+		// set, filled in with the precommits gossiped by our peers, even late
+		// ones (see addVote); we don't propose until it has +2/3 of them.
+		return types.NewVoteSet(state.ChainID, state.LastBlockHeight, commit.Round,
+			types.PrecommitType, state.LastValidators), nil
+	}
 	vs := commit.ToVoteSet(state.ChainID, state.LastValidators)
 	if !vs.HasTwoThirdsMajority() {
 		return nil, ErrCommitQuorumNotMet
@@ -719,7 +801,7 @@ func (cs *State) updateToState(state sm.State) {
 		// to be gathered for the first block.
 		// And alternative solution that relies on clocks:
 		// cs.StartTime = state.LastBlockTime.Add(timeoutCommit)
-		cs.StartTime = cs.config.Commit(cmttime.Now())
+		cs.StartTime = cs.config.Commit(cs.timeSource.Now())
 	} else {
 		cs.StartTime = cs.config.Commit(cs.CommitTime)
 	}
@@ -736,7 +818,8 @@ func (cs *State) updateToState(state sm.State) {
 	cs.ValidBlock = nil
 	cs.ValidBlockParts = nil
 	if state.ConsensusParams.Feature.VoteExtensionsEnabled(height) {
-		cs.Votes = cstypes.NewExtendedHeightVoteSet(state.ChainID, height, validators)
+		cs.Votes = cstypes.NewExtendedHeightVoteSet(state.ChainID, height, validators,
+			state.ConsensusParams.Feature.MaxVoteExtensionBytes())
 	} else {
 		cs.Votes = cstypes.NewHeightVoteSet(state.ChainID, height, validators)
 	}
@@ -926,13 +1009,17 @@ func (cs *State) handleMsg(mi msgInfo) {
 			cs.statsMsgQueue <- mi
 		}
 
-		// if err == ErrAddingVote {
-		// TODO: punish peer
-		// We probably don't want to stop the peer here. The vote does not
-		// necessarily comes from a malicious peer but can be just broadcasted by
-		// a typical peer.
+		// We don't stop the peer here, but lower its score. Peers only
+		// gossip the votes they could add, but the vote could still come
+		// from a typical peer, e.g. one running a different version.
 		// https://github.com/tendermint/tendermint/issues/1281
-		// }
+		if errors.Is(err, ErrAddingVote) && peerID != "" {
+			select {
+			case cs.invalidMsgQueue <- mi:
+			default:
+				// Never block consensus to report a peer.
+			}
+		}
 
 		// NOTE: the vote is broadcast to peers by the reactor listening
 		// for vote events
@@ -999,6 +1086,7 @@ func (cs *State) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 			cs.Logger.Error("failed publishing timeout wait", "err", err)
 		}
 
+		cs.observeStepLatency(timeoutStepPrecommit, ti.Height, ti.Round)
 		cs.enterPrecommit(ti.Height, ti.Round)
 		cs.enterNewRound(ti.Height, ti.Round+1)
 
@@ -1024,7 +1112,7 @@ func (cs *State) handleTxsAvailable() {
 		}
 
 		// +1ms to ensure RoundStepNewRound timeout always happens after RoundStepNewHeight
-		timeoutCommit := cs.StartTime.Sub(cmttime.Now()) + 1*time.Millisecond
+		timeoutCommit := cs.StartTime.Sub(cs.timeSource.Now()) + 1*time.Millisecond
 		cs.scheduleTimeout(timeoutCommit, cs.Height, 0, cstypes.RoundStepNewRound)
 
 	case cstypes.RoundStepNewRound: // after timeoutCommit
@@ -1055,7 +1143,7 @@ func (cs *State) enterNewRound(height int64, round int32) {
 		return
 	}
 
-	if now := cmttime.Now(); cs.StartTime.After(now) {
+	if now := cs.timeSource.Now(); cs.StartTime.After(now) {
 		logger.Debug("need to set a buffer and log message here for sanity", "start_time", cs.StartTime, "now", now)
 	}
 
@@ -1147,7 +1235,7 @@ func (cs *State) enterPropose(height int64, round int32) {
 	// If this validator is the proposer of this round, and the previous block time is later than
 	// our local clock time, wait to propose until our local clock time has passed the block time.
 	if cs.isPBTSEnabled(height) && cs.privValidatorPubKey != nil && cs.isProposer(cs.privValidatorPubKey.Address()) {
-		proposerWaitTime := proposerWaitTime(cmttime.DefaultSource{}, cs.state.LastBlockTime)
+		proposerWaitTime := proposerWaitTime(cs.timeSource, cs.state.LastBlockTime)
 		if proposerWaitTime > 0 {
 			cs.scheduleTimeout(proposerWaitTime, height, round, cstypes.RoundStepNewRound)
 			return
@@ -1169,8 +1257,13 @@ func (cs *State) enterPropose(height int64, round int32) {
 		}
 	}()
 
+	// Our own proposals arrive immediately, so they are not measured.
+	if cs.privValidatorPubKey == nil || !cs.isProposer(cs.privValidatorPubKey.Address()) {
+		cs.startStepLatency(timeoutStepPropose, height, round)
+	}
+
 	// If we don't get the proposal and all block parts quick enough, enterPrevote
-	cs.scheduleTimeout(cs.config.Propose(round), height, round, cstypes.RoundStepPropose)
+	cs.scheduleTimeout(cs.stepTimeout(timeoutStepPropose, round), height, round, cstypes.RoundStepPropose)
 
 	// Nothing more to do if we're not a validator
 	if cs.privValidator == nil {
@@ -1219,7 +1312,11 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 		// Create a new proposal block from state/txs from the mempool.
 		var err error
 		block, err = cs.createProposalBlock(context.TODO())
-		if err != nil {
+		if errors.Is(err, ErrProposalWithoutPreviousCommit) {
+			cs.Logger.Info("not proposing until +2/3 precommits for the previous block are received",
+				"height", height, "round", round, "last_commit", cs.LastCommit.StringShort())
+			return
+		} else if err != nil {
 			cs.Logger.Error("unable to create proposal block", "error", err)
 			return
 		} else if block == nil {
@@ -1247,7 +1344,7 @@ func (cs *State) defaultDecideProposal(height int64, round int32) {
 		proposal.Signature = p.Signature
 
 		// send proposal and block parts on internal msg queue
-		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, "", cmttime.Now()})
+		cs.sendInternalMessage(msgInfo{&ProposalMessage{proposal}, "", cs.timeSource.Now()})
 
 		for i := 0; i < int(blockParts.Total()); i++ {
 			part := blockParts.GetPart(i)
@@ -1299,7 +1396,10 @@ func (cs *State) createProposalBlock(ctx context.Context) (*types.Block, error)
 		// Make the commit from LastCommit
 		lastExtCommit = cs.LastCommit.MakeExtendedCommit(cs.state.ConsensusParams.Feature)
 
-	default: // This shouldn't happen.
+	default:
+		// This happens if the last block was received via blocksync with an
+		// aggregated commit, until +2/3 of its precommits are received from
+		// our peers. We don't propose until then.
 		return nil, ErrProposalWithoutPreviousCommit
 	}
 
@@ -1344,6 +1444,8 @@ func (cs *State) enterPrevote(height int64, round int32) {
 
 	logger.Debug("entering prevote step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.observeStepLatency(timeoutStepPropose, height, round)
+
 	// Sign and broadcast vote as necessary
 	cs.doPrevote(height, round)
 
@@ -1580,7 +1682,8 @@ func (cs *State) enterPrevoteWait(height int64, round int32) {
 	}()
 
 	// Wait for some more prevotes; enterPrecommit
-	cs.scheduleTimeout(cs.config.Prevote(round), height, round, cstypes.RoundStepPrevoteWait)
+	cs.startStepLatency(timeoutStepPrevote, height, round)
+	cs.scheduleTimeout(cs.stepTimeout(timeoutStepPrevote, round), height, round, cstypes.RoundStepPrevoteWait)
 }
 
 // Enter: `timeoutPrevote` after any +2/3 prevotes.
@@ -1601,6 +1704,8 @@ func (cs *State) enterPrecommit(height int64, round int32) {
 
 	logger.Debug("entering precommit step", "current", log.NewLazySprintf("%v/%v/%v", cs.Height, cs.Round, cs.Step))
 
+	cs.observeStepLatency(timeoutStepPrevote, height, round)
+
 	defer func() {
 		// Done enterPrecommit:
 		cs.updateRoundStep(round, cstypes.RoundStepPrecommit)
@@ -1718,7 +1823,8 @@ func (cs *State) enterPrecommitWait(height int64, round int32) {
 	}()
 
 	// wait for some more precommits; enterNewRound
-	cs.scheduleTimeout(cs.config.Precommit(round), height, round, cstypes.RoundStepPrecommitWait)
+	cs.startStepLatency(timeoutStepPrecommit, height, round)
+	cs.scheduleTimeout(cs.stepTimeout(timeoutStepPrecommit, round), height, round, cstypes.RoundStepPrecommitWait)
 }
 
 // Enter: +2/3 precommits for block.
@@ -1740,7 +1846,7 @@ func (cs *State) enterCommit(height int64, commitRound int32) {
 		// keep cs.Round the same, commitRound points to the right Precommits set.
 		cs.updateRoundStep(cs.Round, cstypes.RoundStepCommit)
 		cs.CommitRound = commitRound
-		cs.CommitTime = cmttime.Now()
+		cs.CommitTime = cs.timeSource.Now()
 		cs.newStep()
 
 		// Maybe finalize immediately.
@@ -2065,6 +2171,7 @@ func (cs *State) defaultSetProposal(proposal *types.Proposal, recvTime time.Time
 	cs.Proposal = proposal
 	cs.ProposalReceiveTime = recvTime
 	cs.calculateProposalTimestampDifferenceMetric()
+	cs.recordProposalTimeliness(pubKey.Address())
 	// We don't update cs.ProposalBlockParts if it is already set.
 	// This happens if we're already in cstypes.RoundStepCommit or if there is a valid block in the current round.
 	// TODO: We can check if Proposal is for a different block as this is a sign of misbehavior!
@@ -2268,8 +2375,10 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 	// A precommit for the previous height?
 	// These come in while we wait timeoutCommit
 	if vote.Height+1 == cs.Height && vote.Type == types.PrecommitType {
-		if cs.Step != cstypes.RoundStepNewHeight {
-			// Late precommit at prior height is ignored
+		// Late precommits at prior height are ignored, unless we still need
+		// them to propose, i.e. if the last block was received via blocksync
+		// with an aggregated commit, from which the votes can't be recovered.
+		if cs.Step != cstypes.RoundStepNewHeight && (cs.LastCommit == nil || cs.LastCommit.HasTwoThirdsMajority()) {
 			cs.Logger.Debug("precommit vote came in after commit timeout and has been ignored", "vote", vote)
 			return added, err
 		}
@@ -2291,7 +2400,7 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 		cs.evsw.FireEvent(types.EventVote, vote)
 
 		// if we can skip timeoutCommit and have all the votes now,
-		if cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
+		if cs.Step == cstypes.RoundStepNewHeight && cs.config.SkipTimeoutCommit && cs.LastCommit.HasAll() {
 			// go straight to new round (skip timeout commit)
 			// cs.scheduleTimeout(time.Duration(0), cs.Height, 0, cstypes.RoundStepNewHeight)
 			cs.enterNewRound(cs.Height, 0)
@@ -2440,6 +2549,8 @@ func (cs *State) addVote(vote *types.Vote, peerID p2p.ID) (added bool, err error
 
 		blockID, ok := precommits.TwoThirdsMajority()
 		if ok {
+			cs.observeStepLatency(timeoutStepPrecommit, height, vote.Round)
+
 			// Executed as TwoThirdsMajority could be from a higher round
 			cs.enterNewRound(height, vote.Round)
 			cs.enterPrecommit(height, vote.Round)
@@ -2518,9 +2629,9 @@ func (cs *State) signVote(
 
 func (cs *State) voteTime(height int64) time.Time {
 	if cs.isPBTSEnabled(height) {
-		return cmttime.Now()
+		return cs.timeSource.Now()
 	}
-	now := cmttime.Now()
+	now := cs.timeSource.Now()
 	minVoteTime := now
 
 	// Minimum time increment between blocks
@@ -2716,6 +2827,54 @@ func (cs *State) calculateProposalTimestampDifferenceMetric() {
 	}
 }
 
+// recordProposalTimeliness adds the PBTS verdict on the proposal for a new
+// block to the timeliness history, and queues it for publication.
+func (cs *State) recordProposalTimeliness(proposer types.Address) {
+	if cs.replayMode || cs.Proposal.POLRound != -1 || !cs.isPBTSEnabled(cs.Proposal.Height) {
+		return
+	}
+	sp := cs.state.ConsensusParams.Synchrony.InRound(cs.Proposal.Round)
+	difference := cs.ProposalReceiveTime.Sub(cs.Proposal.Timestamp)
+	t := types.EventDataProposalTimeliness{
+		Height:       cs.Proposal.Height,
+		Round:        cs.Proposal.Round,
+		Proposer:     proposer,
+		Timestamp:    cs.Proposal.Timestamp,
+		ReceiveTime:  cs.ProposalReceiveTime,
+		Difference:   difference,
+		Precision:    sp.Precision,
+		MessageDelay: sp.MessageDelay,
+		LowerMargin:  difference + sp.Precision,
+		UpperMargin:  sp.MessageDelay + sp.Precision - difference,
+		Timely:       cs.Proposal.IsTimely(cs.ProposalReceiveTime, sp),
+	}
+	cs.timeliness.Add(t)
+	select {
+	case cs.timelinessQueue <- t:
+	default:
+		cs.Logger.Debug("proposal timeliness queue is full; not publishing", "height", t.Height, "round", t.Round)
+	}
+}
+
+// publishTimelinessRoutine publishes the verdicts queued by
+// recordProposalTimeliness, outside of the receiveRoutine.
+func (cs *State) publishTimelinessRoutine() {
+	for {
+		select {
+		case t := <-cs.timelinessQueue:
+			cs.publishProposalTimeliness(t)
+		case <-cs.Quit():
+			return
+		}
+	}
+}
+
+func (cs *State) publishProposalTimeliness(t types.EventDataProposalTimeliness) {
+	if err := cs.eventBus.PublishEventProposalTimeliness(t); err != nil {
+		cs.Logger.Error("failed publishing proposal timeliness", "err", err)
+	}
+}
+
 // proposerWaitTime determines how long the proposer should wait to propose its next block.
 // If the result is zero, a block can be proposed immediately.
 //
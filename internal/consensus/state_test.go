@@ -16,6 +16,7 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cstypes "github.com/cometbft/cometbft/internal/consensus/types"
 	"github.com/cometbft/cometbft/internal/protoio"
@@ -142,6 +143,58 @@ func TestStateProposerSelection2(t *testing.T) {
 	}
 }
 
+// Test that a validator restarting after blocksync, whose last block was
+// received with an aggregated commit, does not propose until it receives +2/3
+// of the precommits for the last block, even after the commit timeout.
+func TestStateProposeAfterBlocksyncWithAggregatedCommit(t *testing.T) {
+	cs1, vss := randStateWithAppWithHeight(1, kvstore.NewInMemoryApplication(), 0)
+	chainID := cs1.state.ChainID
+
+	// Store the first block with an aggregated commit, as blocksync does.
+	block, err := cs1.createProposalBlock(context.Background())
+	require.NoError(t, err)
+	blockParts, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: blockParts.Header()}
+	pv, err := vss[0].GetPubKey()
+	require.NoError(t, err)
+	cs1.blockStore.SaveBlock(block, blockParts, &types.Commit{
+		Height:  1,
+		BlockID: blockID,
+		Signatures: []types.CommitSig{{
+			BlockIDFlag:      types.BlockIDFlagCommit,
+			ValidatorAddress: pv.Address(),
+			Timestamp:        block.Time,
+		}},
+		AggregatedSignature: make([]byte, bls12381.SignatureLength),
+	})
+
+	state := cs1.state.Copy()
+	state.LastBlockHeight = 1
+	state.LastBlockID = blockID
+	state.LastBlockTime = block.Time
+	state.LastValidators = state.Validators.Copy()
+	cs1.reconstructLastCommit(state)
+	cs1.updateToState(state)
+	require.NotNil(t, cs1.LastCommit)
+	require.False(t, cs1.LastCommit.HasTwoThirdsMajority())
+
+	newRoundCh := subscribe(cs1.eventBus, types.EventQueryNewRound)
+	proposalCh := subscribe(cs1.eventBus, types.EventQueryCompleteProposal)
+
+	// The validator is the proposer, but can't propose in the first round.
+	startTestRound(cs1, 2, 0)
+	ensureNewRound(newRoundCh, 2, 0)
+
+	// It receives its precommit for the last block late, and proposes in the
+	// next round.
+	vss[0].Height = 1
+	signAddVotes(cs1, types.PrecommitType, chainID, blockID, false, vss[0])
+	ensureNewRound(newRoundCh, 2, 1)
+	ensureNewProposal(proposalCh, 2, 1)
+	assert.True(t, cs1.GetRoundState().LastCommit.HasTwoThirdsMajority())
+}
+
 // a non-validator should timeout into the prevote round.
 func TestStateEnterProposeNoPrivValidator(t *testing.T) {
 	cs, _ := randState(1)
@@ -40,6 +40,7 @@ type HeightVoteSet struct {
 	height            int64
 	valSet            *types.ValidatorSet
 	extensionsEnabled bool
+	maxExtensionBytes int
 
 	mtx               sync.Mutex
 	round             int32                  // max tracked round
@@ -56,10 +57,13 @@ func NewHeightVoteSet(chainID string, height int64, valSet *types.ValidatorSet)
 	return hvs
 }
 
-func NewExtendedHeightVoteSet(chainID string, height int64, valSet *types.ValidatorSet) *HeightVoteSet {
+// NewExtendedHeightVoteSet returns a HeightVoteSet whose precommits must
+// have vote extensions of at most maxExtensionBytes.
+func NewExtendedHeightVoteSet(chainID string, height int64, valSet *types.ValidatorSet, maxExtensionBytes int) *HeightVoteSet {
 	hvs := &HeightVoteSet{
 		chainID:           chainID,
 		extensionsEnabled: true,
+		maxExtensionBytes: maxExtensionBytes,
 	}
 	hvs.Reset(height, valSet)
 	return hvs
@@ -115,7 +119,8 @@ func (hvs *HeightVoteSet) addRound(round int32) {
 	prevotes := types.NewVoteSet(hvs.chainID, hvs.height, round, types.PrevoteType, hvs.valSet)
 	var precommits *types.VoteSet
 	if hvs.extensionsEnabled {
-		precommits = types.NewExtendedVoteSet(hvs.chainID, hvs.height, round, types.PrecommitType, hvs.valSet)
+		precommits = types.NewExtendedVoteSetWithMaxExtensionBytes(hvs.chainID, hvs.height, round,
+			types.PrecommitType, hvs.valSet, hvs.maxExtensionBytes)
 	} else {
 		precommits = types.NewVoteSet(hvs.chainID, hvs.height, round, types.PrecommitType, hvs.valSet)
 	}
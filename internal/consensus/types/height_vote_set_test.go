@@ -26,7 +26,7 @@ func TestMain(m *testing.M) {
 func TestPeerCatchupRounds(t *testing.T) {
 	valSet, privVals := types.RandValidatorSet(10, 1)
 
-	hvs := NewExtendedHeightVoteSet(test.DefaultTestChainID, 1, valSet)
+	hvs := NewExtendedHeightVoteSet(test.DefaultTestChainID, 1, valSet, types.MaxVoteExtensionSize)
 
 	vote999_0 := makeVoteHR(999, privVals)
 	added, err := hvs.AddVote(vote999_0, "peer1", true)
@@ -58,7 +58,7 @@ func TestPeerCatchupRounds(t *testing.T) {
 func TestInconsistentExtensionData(t *testing.T) {
 	valSet, privVals := types.RandValidatorSet(10, 1)
 
-	hvsE := NewExtendedHeightVoteSet(test.DefaultTestChainID, 1, valSet)
+	hvsE := NewExtendedHeightVoteSet(test.DefaultTestChainID, 1, valSet, types.MaxVoteExtensionSize)
 	voteNoExt := makeVoteHR(20, privVals)
 	voteNoExt.Extension, voteNoExt.ExtensionSignature = nil, nil
 	require.Panics(t, func() {
@@ -0,0 +1,273 @@
+package consensus
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	auto "github.com/cometbft/cometbft/internal/autofile"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/types"
+)
+
+// Functions to inspect and fix the WAL of a stopped node, used by the
+// `cometbft wal` commands.
+
+// WALPosition is the position of a message in the files of a WAL group.
+type WALPosition struct {
+	File   string `json:"file"`
+	Offset int64  `json:"offset"`
+}
+
+func (p WALPosition) String() string {
+	return fmt.Sprintf("%s:%d", p.File, p.Offset)
+}
+
+// WALVerifyResult is the result of VerifyWAL.
+type WALVerifyResult struct {
+	// Files of the group, from the oldest to the head.
+	Files []string `json:"files"`
+	// Number of messages decoded before the end of the WAL or the corruption.
+	Messages int `json:"messages"`
+	// Height of the last EndHeightMessage, or -1 if there is none.
+	LastEndHeight int64 `json:"last_end_height"`
+	// Position and error of the first corrupted message, if any. The
+	// messages following it can't be decoded.
+	CorruptedAt *WALPosition `json:"corrupted_at,omitempty"`
+	Corruption  string       `json:"corruption,omitempty"`
+}
+
+// ReadWAL decodes the messages of the WAL group with head walFile, from the
+// oldest file to the head, and calls fn with each of them. It stops at the
+// first error returned by fn or by the decoder, which is a
+// DataCorruptionError if the WAL is corrupted.
+func ReadWAL(walFile string, fn func(msg *TimedWALMessage) error) error {
+	sc, err := openWALScanner(walFile)
+	if err != nil {
+		return err
+	}
+	defer sc.Close()
+
+	for {
+		msg, _, err := sc.next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(msg); err != nil {
+			return err
+		}
+	}
+}
+
+// VerifyWAL decodes all the messages of the WAL group with head walFile,
+// checking their checksums, and reports the first corrupted message. An
+// error is only returned if the WAL could not be read.
+func VerifyWAL(walFile string) (*WALVerifyResult, error) {
+	sc, err := openWALScanner(walFile)
+	if err != nil {
+		return nil, err
+	}
+	defer sc.Close()
+
+	res := &WALVerifyResult{Files: sc.files, LastEndHeight: -1}
+	for {
+		msg, pos, err := sc.next()
+		if errors.Is(err, io.EOF) {
+			return res, nil
+		}
+		if IsDataCorruptionError(err) {
+			res.CorruptedAt = &pos
+			res.Corruption = err.Error()
+			return res, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		res.Messages++
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			res.LastEndHeight = m.Height
+		}
+	}
+}
+
+// RepairWALFile decodes the messages of the WAL file src until the first
+// error, and writes them to dst. See State.OnStart, which does the same when
+// the WAL is found corrupted.
+func RepairWALFile(src, dst string) error {
+	return repairWalFile(src, dst)
+}
+
+// TruncateWAL removes the messages following the EndHeightMessage of the
+// given height from the WAL group with head walFile, so that the node
+// replays the WAL from height+1 on restart, e.g. after its state was rolled
+// back. The files following the one containing the EndHeightMessage are
+// removed, and the latter becomes the head of the group.
+//
+// The node must be stopped.
+func TruncateWAL(walFile string, height int64) error {
+	sc, err := openWALScanner(walFile)
+	if err != nil {
+		return err
+	}
+
+	var end *WALPosition
+	for end == nil {
+		msg, _, err := sc.next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			sc.Close()
+			return err
+		}
+		if m, ok := msg.Msg.(EndHeightMessage); ok && m.Height == height {
+			pos := sc.position(sc.rd.n)
+			end = &pos
+		}
+	}
+	files := sc.files
+	sc.Close()
+	if end == nil {
+		return fmt.Errorf("end of height %d not found in WAL", height)
+	}
+
+	if err := os.Truncate(end.File, end.Offset); err != nil {
+		return err
+	}
+	head := files[len(files)-1]
+	if end.File == head {
+		return nil
+	}
+	for i := len(files) - 1; files[i] != end.File; i-- {
+		if err := os.Remove(files[i]); err != nil {
+			return err
+		}
+	}
+	return os.Rename(end.File, head)
+}
+
+// WALMessageHeight returns the height of a WAL message, if it has one.
+func WALMessageHeight(msg WALMessage) (int64, bool) {
+	switch m := msg.(type) {
+	case EndHeightMessage:
+		return m.Height, true
+	case timeoutInfo:
+		return m.Height, true
+	case types.EventDataRoundState:
+		return m.Height, true
+	case msgInfo:
+		switch mm := m.Msg.(type) {
+		case *ProposalMessage:
+			return mm.Proposal.Height, true
+		case *BlockPartMessage:
+			return mm.Height, true
+		case *VoteMessage:
+			return mm.Vote.Height, true
+		}
+	}
+	return 0, false
+}
+
+// WALMessageRound returns the round of a WAL message, if it has one.
+func WALMessageRound(msg WALMessage) (int32, bool) {
+	switch m := msg.(type) {
+	case timeoutInfo:
+		return m.Round, true
+	case types.EventDataRoundState:
+		return m.Round, true
+	case msgInfo:
+		switch mm := m.Msg.(type) {
+		case *ProposalMessage:
+			return mm.Proposal.Round, true
+		case *BlockPartMessage:
+			return mm.Round, true
+		case *VoteMessage:
+			return mm.Vote.Round, true
+		}
+	}
+	return 0, false
+}
+
+// walScanner decodes the messages of all the files of a WAL group, keeping
+// track of their position.
+type walScanner struct {
+	group *auto.Group
+	gr    *auto.GroupReader
+	rd    *countingReader
+	dec   *WALDecoder
+	files []string
+	sizes []int64
+}
+
+func openWALScanner(walFile string) (*walScanner, error) {
+	// OpenGroup would create the head if it didn't exist.
+	if !cmtos.FileExists(walFile) {
+		return nil, fmt.Errorf("WAL file %s does not exist", walFile)
+	}
+	group, err := auto.OpenGroup(walFile)
+	if err != nil {
+		return nil, err
+	}
+
+	sc := &walScanner{group: group}
+	for i := group.MinIndex(); i <= group.MaxIndex(); i++ {
+		file := group.FilePath(i)
+		info, err := os.Stat(file)
+		if err != nil {
+			sc.Close()
+			return nil, err
+		}
+		sc.files = append(sc.files, file)
+		sc.sizes = append(sc.sizes, info.Size())
+	}
+
+	sc.gr, err = group.NewReader(group.MinIndex())
+	if err != nil {
+		sc.Close()
+		return nil, err
+	}
+	sc.rd = &countingReader{rd: sc.gr}
+	sc.dec = NewWALDecoder(sc.rd)
+	return sc, nil
+}
+
+// next decodes the next message and returns it with its position.
+func (sc *walScanner) next() (*TimedWALMessage, WALPosition, error) {
+	pos := sc.position(sc.rd.n)
+	msg, err := sc.dec.Decode()
+	return msg, pos, err
+}
+
+// position converts an offset in the whole group to a position in one of its
+// files.
+func (sc *walScanner) position(offset int64) WALPosition {
+	for i, size := range sc.sizes {
+		if offset < size || i == len(sc.sizes)-1 {
+			return WALPosition{File: sc.files[i], Offset: offset}
+		}
+		offset -= size
+	}
+	return WALPosition{}
+}
+
+func (sc *walScanner) Close() {
+	if sc.gr != nil {
+		sc.gr.Close()
+	}
+	_ = sc.group.Head.Close()
+}
+
+type countingReader struct {
+	rd io.Reader
+	n  int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.rd.Read(p)
+	r.n += int64(n)
+	return n, err
+}
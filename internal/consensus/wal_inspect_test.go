@@ -0,0 +1,108 @@
+package consensus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// splitWAL writes data to a WAL group of three files, cutting it in the
+// middle of messages, and returns the path of the head.
+func splitWAL(t *testing.T, data []byte) string {
+	t.Helper()
+	walFile := filepath.Join(t.TempDir(), "wal")
+	third := len(data) / 3
+	require.NoError(t, os.WriteFile(walFile+".000", data[:third], 0o600))
+	require.NoError(t, os.WriteFile(walFile+".001", data[third:2*third], 0o600))
+	require.NoError(t, os.WriteFile(walFile, data[2*third:], 0o600))
+	return walFile
+}
+
+func readWALEndHeights(t *testing.T, walFile string) []int64 {
+	t.Helper()
+	heights := make([]int64, 0)
+	err := ReadWAL(walFile, func(msg *TimedWALMessage) error {
+		if m, ok := msg.Msg.(EndHeightMessage); ok {
+			heights = append(heights, m.Height)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return heights
+}
+
+func TestReadWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6, getConfig(t))
+	require.NoError(t, err)
+	walFile := splitWAL(t, walBody)
+
+	assert.Equal(t, []int64{0, 1, 2, 3, 4, 5}, readWALEndHeights(t, walFile))
+
+	rounds := 0
+	err = ReadWAL(walFile, func(msg *TimedWALMessage) error {
+		h, ok := WALMessageHeight(msg.Msg)
+		require.True(t, ok, "no height for %T", msg.Msg)
+		require.LessOrEqual(t, h, int64(6))
+		if _, ok := WALMessageRound(msg.Msg); ok {
+			rounds++
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Positive(t, rounds)
+
+	err = ReadWAL(filepath.Join(t.TempDir(), "wal"), func(*TimedWALMessage) error { return nil })
+	require.Error(t, err)
+}
+
+func TestVerifyWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6, getConfig(t))
+	require.NoError(t, err)
+	walFile := splitWAL(t, walBody)
+
+	res, err := VerifyWAL(walFile)
+	require.NoError(t, err)
+	assert.Len(t, res.Files, 3)
+	assert.Positive(t, res.Messages)
+	assert.EqualValues(t, 5, res.LastEndHeight)
+	assert.Nil(t, res.CorruptedAt)
+
+	// corrupt a message in the second file
+	data, err := os.ReadFile(walFile + ".001")
+	require.NoError(t, err)
+	data[len(data)/2] ^= 0xff
+	require.NoError(t, os.WriteFile(walFile+".001", data, 0o600))
+
+	res, err = VerifyWAL(walFile)
+	require.NoError(t, err)
+	require.NotNil(t, res.CorruptedAt)
+	assert.NotEmpty(t, res.Corruption)
+	assert.Less(t, res.LastEndHeight, int64(5))
+}
+
+func TestTruncateWAL(t *testing.T) {
+	walBody, err := WALWithNBlocks(t, 6, getConfig(t))
+	require.NoError(t, err)
+
+	for _, height := range []int64{0, 3, 5} {
+		walFile := splitWAL(t, walBody)
+
+		require.NoError(t, TruncateWAL(walFile, height))
+
+		expected := make([]int64, 0)
+		for h := int64(0); h <= height; h++ {
+			expected = append(expected, h)
+		}
+		assert.Equal(t, expected, readWALEndHeights(t, walFile), "height %d", height)
+
+		res, err := VerifyWAL(walFile)
+		require.NoError(t, err)
+		assert.Nil(t, res.CorruptedAt)
+	}
+
+	walFile := splitWAL(t, walBody)
+	require.Error(t, TruncateWAL(walFile, 6))
+}
@@ -1,7 +1,6 @@
 package state
 
 import (
-	"bytes"
 	"context"
 	"fmt"
 
@@ -45,6 +44,9 @@ type BlockExecutor struct {
 	logger log.Logger
 
 	metrics *Metrics
+
+	// local clock of the proposer, used for the time of the blocks with PBTS
+	timeSource cmttime.Source
 }
 
 type BlockExecutorOption func(executor *BlockExecutor)
@@ -61,6 +63,15 @@ func BlockExecutorWithMetrics(metrics *Metrics) BlockExecutorOption {
 	}
 }
 
+// BlockExecutorWithTimeSource sets the clock used for the time of the
+// proposed blocks when PBTS is enabled. It may be useful to overwrite for
+// testing, e.g. with a virtual clock.
+func BlockExecutorWithTimeSource(timeSource cmttime.Source) BlockExecutorOption {
+	return func(blockExec *BlockExecutor) {
+		blockExec.timeSource = timeSource
+	}
+}
+
 // NewBlockExecutor returns a new BlockExecutor with a NopEventBus.
 // Call SetEventBus to provide one.
 func NewBlockExecutor(
@@ -81,6 +92,7 @@ func NewBlockExecutor(
 		logger:     logger,
 		metrics:    NopMetrics(),
 		blockStore: blockStore,
+		timeSource: cmttime.DefaultSource{},
 	}
 
 	for _, option := range options {
@@ -124,7 +136,8 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	evidence, evSize := blockExec.evpool.PendingEvidence(state.ConsensusParams.Evidence.MaxBytes)
 
 	// Fetch a limited amount of valid txs
-	maxDataBytes := types.MaxDataBytes(maxBytes, evSize, state.Validators.Size())
+	maxDataBytes := types.MaxDataBytesForKeyTypes(
+		maxBytes, evSize, state.Validators.Size(), state.ConsensusParams.Validator.PubKeyTypes)
 	maxReapBytes := maxDataBytes
 	if emptyMaxBytes {
 		maxReapBytes = -1
@@ -132,7 +145,17 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxReapBytes, maxGas)
 	commit := lastExtCommit.ToCommit()
-	block := state.MakeBlock(height, txs, commit, evidence, proposerAddr)
+	if height > state.InitialHeight && state.ConsensusParams.Feature.AggregatedCommitEnabled(height-1) {
+		// Fall back to the non-aggregated commit if, for instance, some of the
+		// validators don't have BLS12-381 keys.
+		aggCommit, err := commit.Aggregate(state.LastValidators)
+		if err != nil {
+			blockExec.logger.Debug("Could not aggregate last commit", "height", height-1, "err", err)
+		} else {
+			commit = aggCommit
+		}
+	}
+	block := state.makeBlock(height, txs, commit, evidence, proposerAddr, blockExec.timeSource)
 	rpp, err := blockExec.proxyApp.PrepareProposal(
 		ctx,
 		&abci.PrepareProposalRequest{
@@ -163,7 +186,7 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 		return nil, err
 	}
 
-	return state.MakeBlock(height, txl, commit, evidence, proposerAddr), nil
+	return state.makeBlock(height, txl, commit, evidence, proposerAddr, blockExec.timeSource), nil
 }
 
 func (blockExec *BlockExecutor) ProcessProposal(
@@ -269,7 +292,7 @@ func (blockExec *BlockExecutor) applyBlock(state State, blockID types.BlockID, b
 	fail.Fail() // XXX
 
 	// validate the validator updates and convert to CometBFT types
-	err = validateValidatorUpdates(abciResponse.ValidatorUpdates, state.ConsensusParams.Validator)
+	err = validateValidatorUpdates(abciResponse.ValidatorUpdates, state.ConsensusParams.Validator, state.NextValidators)
 	if err != nil {
 		return state, fmt.Errorf("error in validator updates: %w", err)
 	}
@@ -353,6 +376,11 @@ func (blockExec *BlockExecutor) ExtendVote(
 	if err != nil {
 		panic(fmt.Errorf("ExtendVote call failed: %w", err))
 	}
+	// The other validators would reject the vote.
+	if maxBytes := state.ConsensusParams.Feature.MaxVoteExtensionBytes(); len(resp.VoteExtension) > maxBytes {
+		return nil, fmt.Errorf("%w: the application returned %d bytes (max: %d)",
+			types.ErrVoteExtensionTooBig, len(resp.VoteExtension), maxBytes)
+	}
 	return resp.VoteExtension, nil
 }
 
@@ -518,57 +546,21 @@ func BuildExtendedCommitInfo(ec *types.ExtendedCommit, valSet *types.ValidatorSe
 		return abci.ExtendedCommitInfo{}
 	}
 
-	var (
-		ecSize    = ec.Size()
-		valSetLen = len(valSet.Validators)
-	)
-
-	// Ensure that the size of the validator set in the extended commit matches
-	// the size of the validator set in the state store.
-	if ecSize != valSetLen {
-		panic(fmt.Errorf(
-			"extended commit size (%d) does not match validator set length (%d) at height %d\n\n%v\n\n%v",
-			ecSize, valSetLen, ec.Height, ec.ExtendedSignatures, valSet.Validators,
-		))
-	}
-
-	votes := make([]abci.ExtendedVoteInfo, ecSize)
-	for i, val := range valSet.Validators {
-		ecs := ec.ExtendedSignatures[i]
-
-		// Absent signatures have empty validator addresses, but otherwise we
-		// expect the validator addresses to be the same.
-		if ecs.BlockIDFlag != types.BlockIDFlagAbsent && !bytes.Equal(ecs.ValidatorAddress, val.Address) {
-			panic(fmt.Errorf("validator address of extended commit signature in position %d (%s) does not match the corresponding validator's at height %d (%s)",
-				i, ecs.ValidatorAddress, ec.Height, val.Address,
-			))
-		}
-
-		// Check if vote extensions were enabled during the commit's height: ec.Height.
-		// ec is the commit from the previous height, so if extensions were enabled
-		// during that height, we ensure they are present and deliver the data to
-		// the proposer. If they were not enabled during this previous height, we
-		// will not deliver extension data.
-		if err := ecs.EnsureExtension(fp.VoteExtensionsEnabled(ec.Height)); err != nil {
-			panic(fmt.Errorf("commit at height %d has problems with vote extension data; err %w", ec.Height, err))
-		}
-
-		votes[i] = abci.ExtendedVoteInfo{
-			Validator:          types.TM2PB.Validator(val),
-			BlockIdFlag:        cmtproto.BlockIDFlag(ecs.BlockIDFlag),
-			VoteExtension:      ecs.Extension,
-			ExtensionSignature: ecs.ExtensionSignature,
-		}
-	}
-
-	return abci.ExtendedCommitInfo{
-		Round: ec.Round,
-		Votes: votes,
+	// Check if vote extensions were enabled during the commit's height: ec.Height.
+	// ec is the commit from the previous height, so if extensions were enabled
+	// during that height, we ensure they are present and deliver the data to
+	// the proposer. If they were not enabled during this previous height, we
+	// will not deliver extension data.
+	eci, err := ec.ExtendedCommitInfo(valSet, fp.VoteExtensionsEnabled(ec.Height))
+	if err != nil {
+		panic(err)
 	}
+	return eci
 }
 
 func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 	params types.ValidatorParams,
+	vals *types.ValidatorSet,
 ) error {
 	for _, valUpdate := range abciUpdates {
 		if valUpdate.GetPower() < 0 {
@@ -589,6 +581,13 @@ func validateValidatorUpdates(abciUpdates []abci.ValidatorUpdate,
 			return fmt.Errorf("validator %v is using pubkey %s, which is unsupported for consensus",
 				valUpdate, pk.Type())
 		}
+
+		// The key of a new validator must come with a proof of possession.
+		if !vals.HasAddress(pk.Address()) {
+			if err := types.VerifyProofOfPossession(pk, valUpdate.ProofOfPossession); err != nil {
+				return fmt.Errorf("validator %v: %w", valUpdate, err)
+			}
+		}
 	}
 	return nil
 }
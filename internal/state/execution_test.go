@@ -11,20 +11,25 @@ import (
 	"github.com/stretchr/testify/require"
 
 	dbm "github.com/cometbft/cometbft-db"
+	abcicli "github.com/cometbft/cometbft/abci/client"
 	abciclientmocks "github.com/cometbft/cometbft/abci/client/mocks"
 	abci "github.com/cometbft/cometbft/abci/types"
 	abcimocks "github.com/cometbft/cometbft/abci/types/mocks"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
+	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
 	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/state/mocks"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/internal/test"
 	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/mempool"
 	mpmocks "github.com/cometbft/cometbft/mempool/mocks"
 	"github.com/cometbft/cometbft/proxy"
 	pmocks "github.com/cometbft/cometbft/proxy/mocks"
@@ -78,6 +83,73 @@ func TestApplyBlock(t *testing.T) {
 	assert.EqualValues(t, 1, state.Version.Consensus.App, "App version wasn't updated")
 }
 
+// TestApplyBlockMempoolConnDown ensures a block is committed while the
+// mempool connection to the application is reconnecting.
+func TestApplyBlockMempoolConnDown(t *testing.T) {
+	app := &testApp{}
+	cc := proxy.NewLocalClientCreator(app)
+	proxyApp := proxy.NewAppConns(cc, proxy.NopMetrics())
+	err := proxyApp.Start()
+	require.NoError(t, err)
+	defer proxyApp.Stop() //nolint:errcheck // ignore for tests
+
+	first := abcicli.NewLocalClient(nil, app)
+	var conns int
+	mempoolClient := abcicli.NewReconnectingClient(
+		func() (abcicli.Client, error) {
+			conns++
+			if conns == 1 {
+				return first, nil
+			}
+			return nil, errors.New("application unreachable")
+		},
+		true,
+		abcicli.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	mp := mempool.NewCListMempool(config.TestMempoolConfig(),
+		proxy.NewAppConnMempool(mempoolClient, proxy.NopMetrics()), 0)
+	require.NoError(t, mempoolClient.Start())
+	defer mempoolClient.Stop() //nolint:errcheck // ignore for tests
+
+	// The tx left in the mempool is rechecked after the block is committed.
+	_, err = mp.CheckTx(types.Tx("tx"))
+	require.NoError(t, err)
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, 1, mp.Size())
+
+	// The mempool connection breaks.
+	require.NoError(t, first.Stop())
+	require.Eventually(t, func() bool {
+		_, err := mempoolClient.Echo(context.Background(), "hello")
+		return errors.Is(err, abcicli.ErrClientReconnecting)
+	}, 5*time.Second, 10*time.Millisecond)
+
+	state, stateDB, _ := makeState(1, 1, chainID)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{
+		DiscardABCIResponses: false,
+	})
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	blockExec := sm.NewBlockExecutor(stateStore, log.TestingLogger(), proxyApp.Consensus(),
+		mp, sm.EmptyEvidencePool{}, blockStore)
+
+	block := makeBlock(state, 1, new(types.Commit))
+	bps, err := block.MakePartSet(testPartSize)
+	require.NoError(t, err)
+	blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: bps.Header()}
+
+	state, err = blockExec.ApplyBlock(state, blockID, block)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, state.LastBlockHeight)
+	assert.Equal(t, 1, mp.Size())
+
+	// New txs are rejected until the connection is re-established, and can
+	// be submitted again.
+	_, err = mp.CheckTx(types.Tx("tx2"))
+	require.Error(t, err)
+	_, err = mp.CheckTx(types.Tx("tx2"))
+	require.NotErrorIs(t, err, mempool.ErrTxInCache)
+}
+
 // TestFinalizeBlockDecidedLastCommit ensures we correctly send the
 // DecidedLastCommit to the application. The test ensures that the
 // DecidedLastCommit properly reflects which validators signed the preceding
@@ -452,12 +524,20 @@ func TestValidateValidatorUpdates(t *testing.T) {
 	require.NoError(t, err)
 
 	defaultValidatorParams := types.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeEd25519}}
+	vals := types.NewValidatorSet([]*types.Validator{types.NewValidator(pubkey1, 10)})
+
+	blsPubKey := bls12381.PubKey(cmtrand.Bytes(bls12381.PubKeySize))
+	blsPk, err := cryptoenc.PubKeyToProto(blsPubKey)
+	require.NoError(t, err)
+	blsValidatorParams := types.ValidatorParams{PubKeyTypes: []string{types.ABCIPubKeyTypeBls12381}}
+	blsVals := types.NewValidatorSet([]*types.Validator{types.NewValidator(blsPubKey, 10)})
 
 	testCases := []struct {
 		name string
 
 		abciUpdates     []abci.ValidatorUpdate
 		validatorParams types.ValidatorParams
+		vals            *types.ValidatorSet
 
 		shouldErr bool
 	}{
@@ -465,32 +545,57 @@ func TestValidateValidatorUpdates(t *testing.T) {
 			"adding a validator is OK",
 			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 20}},
 			defaultValidatorParams,
+			vals,
 			false,
 		},
 		{
 			"updating a validator is OK",
 			[]abci.ValidatorUpdate{{PubKey: pk1, Power: 20}},
 			defaultValidatorParams,
+			vals,
 			false,
 		},
 		{
 			"removing a validator is OK",
 			[]abci.ValidatorUpdate{{PubKey: pk2, Power: 0}},
 			defaultValidatorParams,
+			vals,
 			false,
 		},
 		{
 			"adding a validator with negative power results in error",
 			[]abci.ValidatorUpdate{{PubKey: pk2, Power: -100}},
 			defaultValidatorParams,
+			vals,
+			true,
+		},
+		{
+			"adding a BLS12-381 validator without a proof of possession results in error",
+			[]abci.ValidatorUpdate{{PubKey: blsPk, Power: 20}},
+			blsValidatorParams,
+			vals,
 			true,
 		},
+		{
+			"adding a BLS12-381 validator with an invalid proof of possession results in error",
+			[]abci.ValidatorUpdate{{PubKey: blsPk, Power: 20, ProofOfPossession: cmtrand.Bytes(bls12381.SignatureLength)}},
+			blsValidatorParams,
+			vals,
+			true,
+		},
+		{
+			"updating a BLS12-381 validator is OK",
+			[]abci.ValidatorUpdate{{PubKey: blsPk, Power: 20}},
+			blsValidatorParams,
+			blsVals,
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
 		tc := tc
 		t.Run(tc.name, func(t *testing.T) {
-			err := sm.ValidateValidatorUpdates(tc.abciUpdates, tc.validatorParams)
+			err := sm.ValidateValidatorUpdates(tc.abciUpdates, tc.validatorParams, tc.vals)
 			if tc.shouldErr {
 				require.Error(t, err)
 			} else {
@@ -33,8 +33,8 @@ func UpdateState(
 
 // ValidateValidatorUpdates is an alias for validateValidatorUpdates exported
 // from execution.go, exclusively and explicitly for testing.
-func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params types.ValidatorParams) error {
-	return validateValidatorUpdates(abciUpdates, params)
+func ValidateValidatorUpdates(abciUpdates []abci.ValidatorUpdate, params types.ValidatorParams, vals *types.ValidatorSet) error {
+	return validateValidatorUpdates(abciUpdates, params, vals)
 }
 
 // SaveValidatorsInfo is an alias for the private saveValidatorsInfo method in
@@ -27,7 +27,11 @@ func IndexerFromConfig(cfg *config.Config, dbProvider config.DBProvider, chainID
 			return nil, nil, err
 		}
 
-		return kv.NewTxIndex(store), blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")), blockidxkv.WithCompaction(cfg.Storage.Compact, cfg.Storage.CompactionInterval)), nil
+		txIndexer := kv.NewTxIndex(store)
+		if err := txIndexer.InitNumericIndex(); err != nil {
+			return nil, nil, fmt.Errorf("initializing the numeric index of the kv indexer: %w", err)
+		}
+		return txIndexer, blockidxkv.New(dbm.NewPrefixDB(store, []byte("block_events")), blockidxkv.WithCompaction(cfg.Storage.Compact, cfg.Storage.CompactionInterval)), nil
 
 	case "psql":
 		conn := cfg.TxIndex.PsqlConn
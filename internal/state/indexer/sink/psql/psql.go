@@ -4,7 +4,6 @@ package psql
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"strconv"
 	"strings"
@@ -16,6 +15,7 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/internal/pubsub/query"
 	"github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/internal/state/txindex"
 	"github.com/cometbft/cometbft/types"
 )
 
@@ -251,24 +251,117 @@ func (es *EventSink) IndexTxEvents(txrs []*abci.TxResult) error {
 	return nil
 }
 
-// SearchBlockEvents is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) SearchBlockEvents(_ context.Context, _ *query.Query) ([]int64, error) {
-	return nil, errors.New("block search is not supported via the postgres event sink")
+// SearchBlockEvents returns the heights of the blocks whose events match q,
+// in ascending order. It is part of the indexer.EventSink interface.
+func (es *EventSink) SearchBlockEvents(ctx context.Context, q *query.Query) ([]int64, error) {
+	var b queryBuilder
+	chainID := b.arg(es.chainID)
+	conds, err := b.conditionsSQL(q.Syntax(), "block_id = "+tableBlocks+".rowid AND tx_id IS NULL")
+	if err != nil {
+		return nil, fmt.Errorf("translating query %q: %w", q, err)
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT height FROM `+tableBlocks+`
+  WHERE chain_id = `+chainID+` AND `+conds+`
+  ORDER BY height;
+`, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	defer rows.Close()
+
+	var heights []int64
+	for rows.Next() {
+		var height int64
+		if err := rows.Scan(&height); err != nil {
+			return nil, fmt.Errorf("scanning block height: %w", err)
+		}
+		heights = append(heights, height)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching blocks: %w", err)
+	}
+	return heights, nil
 }
 
-// SearchTxEvents is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) SearchTxEvents(_ context.Context, _ *query.Query) ([]*abci.TxResult, error) {
-	return nil, errors.New("tx search is not supported via the postgres event sink")
+// SearchTxEvents returns the results of the transactions whose events match
+// q, ordered by height and index. It is part of the indexer.EventSink
+// interface.
+func (es *EventSink) SearchTxEvents(ctx context.Context, q *query.Query) ([]*abci.TxResult, error) {
+	var b queryBuilder
+	chainID := b.arg(es.chainID)
+	conds, err := b.conditionsSQL(q.Syntax(), "tx_id = "+tableTxResults+".rowid")
+	if err != nil {
+		return nil, fmt.Errorf("translating query %q: %w", q, err)
+	}
+
+	rows, err := es.store.QueryContext(ctx, `
+SELECT tx_result FROM `+tableTxResults+`
+  JOIN `+tableBlocks+` ON (`+tableBlocks+`.rowid = `+tableTxResults+`.block_id)
+  WHERE chain_id = `+chainID+` AND `+conds+`
+  ORDER BY height, index;
+`, b.args...)
+	if err != nil {
+		return nil, fmt.Errorf("searching txs: %w", err)
+	}
+	defer rows.Close()
+
+	var results []*abci.TxResult
+	for rows.Next() {
+		var resultData []byte
+		if err := rows.Scan(&resultData); err != nil {
+			return nil, fmt.Errorf("scanning tx_result: %w", err)
+		}
+		txr := new(abci.TxResult)
+		if err := proto.Unmarshal(resultData, txr); err != nil {
+			return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+		}
+		results = append(results, txr)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("searching txs: %w", err)
+	}
+	return results, nil
 }
 
-// GetTxByHash is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) GetTxByHash(_ []byte) (*abci.TxResult, error) {
-	return nil, errors.New("getTxByHash is not supported via the postgres event sink")
+// GetTxByHash returns the result of the transaction with the given hash, or
+// nil if the transaction is not indexed. It is part of the indexer.EventSink
+// interface.
+func (es *EventSink) GetTxByHash(hash []byte) (*abci.TxResult, error) {
+	if len(hash) == 0 {
+		return nil, txindex.ErrorEmptyHash
+	}
+
+	var resultData []byte
+	err := es.store.QueryRow(`
+SELECT tx_result FROM `+tableTxResults+`
+  JOIN `+tableBlocks+` ON (`+tableBlocks+`.rowid = `+tableTxResults+`.block_id)
+  WHERE tx_hash = $1 AND chain_id = $2;
+`, fmt.Sprintf("%X", hash), es.chainID).Scan(&resultData)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("looking up tx_result: %w", err)
+	}
+
+	txr := new(abci.TxResult)
+	if err := proto.Unmarshal(resultData, txr); err != nil {
+		return nil, fmt.Errorf("unmarshaling tx_result: %w", err)
+	}
+	return txr, nil
 }
 
-// HasBlock is not implemented by this sink, and reports an error for all queries.
-func (es *EventSink) HasBlock(_ int64) (bool, error) {
-	return false, errors.New("hasBlock is not supported via the postgres event sink")
+// HasBlock reports whether the events of the block at the given height have
+// been indexed. It is part of the indexer.EventSink interface.
+func (es *EventSink) HasBlock(height int64) (bool, error) {
+	var exists bool
+	if err := es.store.QueryRow(`
+SELECT EXISTS(SELECT 1 FROM `+tableBlocks+` WHERE height = $1 AND chain_id = $2);
+`, height, es.chainID).Scan(&exists); err != nil {
+		return false, fmt.Errorf("looking up block: %w", err)
+	}
+	return exists, nil
 }
 
 // Stop closes the underlying PostgreSQL database.
@@ -21,6 +21,7 @@ import (
 	_ "github.com/lib/pq"
 
 	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/pubsub/query"
 	"github.com/cometbft/cometbft/internal/state/txindex"
 	tmlog "github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/types"
@@ -147,13 +148,27 @@ func TestIndexing(t *testing.T) {
 		verifyBlock(t, 1)
 		verifyBlock(t, 2)
 
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(1) })
-		verifyNotImplemented(t, "hasBlock", func() (bool, error) { return indexer.HasBlock(2) })
-
-		verifyNotImplemented(t, "block search", func() (bool, error) {
-			v, err := indexer.SearchBlockEvents(context.Background(), nil)
-			return v != nil, err
-		})
+		ok, err := indexer.HasBlock(1)
+		require.NoError(t, err)
+		assert.True(t, ok)
+		ok, err = indexer.HasBlock(2)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		for q, want := range map[string][]int64{
+			"block.height = 1": {1},
+			"block.height > 1": nil,
+			"thingy.whatzit = 'O.O' AND block.height <= 1": {1},
+			"end_event.foo > 50":                           {1},
+			"end_event.foo < 50":                           nil,
+			"begin_event.proposer CONTAINS 'AA0'":          {1},
+			"begin_event EXISTS":                           {1},
+			"missing.key EXISTS":                           nil,
+		} {
+			heights, err := indexer.SearchBlockEvents(context.Background(), query.MustCompile(q))
+			require.NoError(t, err, q)
+			assert.Equal(t, want, heights, q)
+		}
 
 		require.NoError(t, verifyTimeStamp(tableBlocks))
 
@@ -186,20 +201,65 @@ func TestIndexing(t *testing.T) {
 		require.NoError(t, verifyTimeStamp(tableTxResults))
 		require.NoError(t, verifyTimeStamp(viewTxEvents))
 
-		verifyNotImplemented(t, "getTxByHash", func() (bool, error) {
-			txr, err := indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
-			return txr != nil, err
-		})
-		verifyNotImplemented(t, "tx search", func() (bool, error) {
-			txr, err := indexer.SearchTxEvents(context.Background(), nil)
-			return txr != nil, err
-		})
+		txr, err = indexer.GetTxByHash(types.Tx(txResult.Tx).Hash())
+		require.NoError(t, err)
+		assert.Equal(t, txResult, txr)
+		txr, err = indexer.GetTxByHash(types.Tx("missing").Hash())
+		require.NoError(t, err)
+		assert.Nil(t, txr)
+
+		hash := fmt.Sprintf("%x", types.Tx(txResult.Tx).Hash())
+		for q, want := range map[string][]*abci.TxResult{
+			"tx.hash = '" + hash + "'":                       {txResult},
+			"account.owner = 'Ivan' AND account.number >= 1": nil, // not in the same event
+			"account.owner = 'Yulieta' AND tx.height = 1":    {txResult},
+			"account.number < 1":                             nil,
+			"account.owner = 'Vlad'":                         nil,
+			"tx.height > 1":                                  nil,
+		} {
+			txrs, err := indexer.SearchTxEvents(context.Background(), query.MustCompile(q))
+			require.NoError(t, err, q)
+			assert.Equal(t, want, txrs, q)
+		}
 
 		// try to insert the duplicate tx events.
 		err = indexer.IndexTxEvents([]*abci.TxResult{txResult})
 		require.NoError(t, err)
 	})
 
+	t.Run("IndexTxEventsSameEvent", func(t *testing.T) {
+		indexer := &EventSink{store: testDB(), chainID: chainID}
+
+		txResult := txResultWithEvents([]abci.Event{
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "sender", Value: "Ana", Index: true},
+				{Key: "amount", Value: "10", Index: true},
+			}},
+			{Type: "transfer", Attributes: []abci.EventAttribute{
+				{Key: "sender", Value: "Ivan", Index: true},
+				{Key: "amount", Value: "20", Index: true},
+			}},
+		})
+		txResult.Height = 2
+		require.NoError(t, indexer.IndexBlockEvents(types.EventDataNewBlockEvents{Height: 2}))
+		require.NoError(t, indexer.IndexTxEvents([]*abci.TxResult{txResult}))
+
+		// As with the kv indexer, the conditions must be matched by a single
+		// event, except those on the reserved keys.
+		for q, want := range map[string][]*abci.TxResult{
+			"transfer.sender = 'Ana' AND transfer.amount = 10":                    {txResult},
+			"transfer.sender = 'Ivan' AND transfer.amount > 15":                   {txResult},
+			"transfer.sender = 'Ana' AND transfer.amount = 20":                    nil,
+			"transfer.sender = 'Ana' AND transfer.sender = 'Ivan'":                nil,
+			"transfer.sender = 'Ivan' AND transfer.amount = 20 AND tx.height = 2": {txResult},
+			"transfer EXISTS AND transfer.amount = 20":                            {txResult},
+		} {
+			txrs, err := indexer.SearchTxEvents(context.Background(), query.MustCompile(q))
+			require.NoError(t, err, q)
+			assert.Equal(t, want, txrs, q)
+		}
+	})
+
 	t.Run("IndexerService", func(t *testing.T) {
 		indexer := &EventSink{store: testDB(), chainID: chainID}
 
@@ -360,20 +420,6 @@ SELECT type, height, chain_id FROM `+viewBlockEvents+`
 	}
 }
 
-// verifyNotImplemented calls f and verifies that it returns both a
-// false-valued flag and a non-nil error whose string matching the expected
-// "not supported" message with label prefixed.
-func verifyNotImplemented(t *testing.T, label string, f func() (bool, error)) {
-	t.Helper()
-	t.Logf("Verifying that %q reports it is not implemented", label)
-
-	want := label + " is not supported via the postgres event sink"
-	ok, err := f()
-	assert.False(t, ok)
-	require.Error(t, err)
-	assert.Equal(t, want, err.Error())
-}
-
 // waitForInterrupt blocks until a SIGINT is received by the process.
 func waitForInterrupt() {
 	ch := make(chan os.Signal, 1)
@@ -0,0 +1,145 @@
+package psql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cometbft/cometbft/internal/pubsub/query/syntax"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// numberPattern matches the attribute values that can be compared with a
+	// number argument.
+	numberPattern = `^-?[0-9]+(\.[0-9]+)?$`
+
+	// datePattern and timePattern match the attribute values that can be
+	// compared with DATE and TIME arguments, using the same formats as the
+	// query package.
+	datePattern = `^[0-9]{4}-[0-9]{2}-[0-9]{2}$`
+	timePattern = `^[0-9]{4}-[0-9]{2}-[0-9]{2}T[0-9]{2}:[0-9]{2}:[0-9]{2}(\.[0-9]+)?(Z|[+-][0-9]{2}:[0-9]{2})$`
+)
+
+var sqlOperators = map[syntax.Token]string{
+	syntax.TEq:  "=",
+	syntax.TLt:  "<",
+	syntax.TLeq: "<=",
+	syntax.TGt:  ">",
+	syntax.TGeq: ">=",
+}
+
+// queryBuilder accumulates the arguments of a parameterized SQL query.
+type queryBuilder struct {
+	args []any
+}
+
+// arg adds v to the arguments of the query, and returns the placeholder to
+// refer to it.
+func (b *queryBuilder) arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// reservedKeys are the keys of the attributes indexed by the sink itself,
+// each in an event of its own.
+var reservedKeys = map[string]bool{
+	types.BlockHeightKey: true,
+	types.TxHashKey:      true,
+	types.TxHeightKey:    true,
+}
+
+// conditionsSQL translates the conditions of a query into a SQL expression
+// selecting the blocks or transactions with events matching them, where scope
+// is an expression restricting the events to those of the block or
+// transaction being matched. As with the kv indexer, the conditions on the
+// reserved keys (heights and hashes) are matched on their own, while all the
+// other conditions must be matched by the attributes of a single event.
+//
+// A query with no conditions matches everything.
+func (b *queryBuilder) conditionsSQL(conds syntax.Query, scope string) (string, error) {
+	var clauses, eventClauses []string
+	for _, c := range conds {
+		expr, err := b.conditionSQL(c)
+		if err != nil {
+			return "", err
+		}
+		if reservedKeys[c.Tag] {
+			clauses = append(clauses, fmt.Sprintf(`EXISTS (
+  SELECT 1 FROM event_attributes WHERE %s AND %s
+)`, scope, expr))
+			continue
+		}
+		eventClauses = append(eventClauses, fmt.Sprintf(`EXISTS (
+    SELECT 1 FROM events LEFT JOIN attributes ON (events.rowid = attributes.event_id)
+    WHERE events.rowid = e.rowid AND %s
+  )`, expr))
+	}
+	if len(eventClauses) > 0 {
+		clauses = append(clauses, fmt.Sprintf(`EXISTS (
+  SELECT 1 FROM events AS e WHERE %s AND %s
+)`, scope, strings.Join(eventClauses, " AND ")))
+	}
+	if len(clauses) == 0 {
+		return "TRUE", nil
+	}
+	return strings.Join(clauses, " AND "), nil
+}
+
+// conditionSQL translates a single query condition into a SQL expression over
+// the columns of the event_attributes view. The semantics match those of the
+// kv indexer: a condition on a composite key is satisfied if any of the
+// attributes with that key has a value matching the condition, and an EXISTS
+// condition on a bare event type is satisfied by any event of that type.
+func (b *queryBuilder) conditionSQL(c syntax.Condition) (string, error) {
+	tag := b.arg(c.Tag)
+	if c.Op == syntax.TExists {
+		return fmt.Sprintf("(composite_key = %[1]s OR type = %[1]s)", tag), nil
+	}
+	if c.Arg == nil {
+		return "", fmt.Errorf("missing argument for %v in condition %q", c.Op, c)
+	}
+	if c.Op == syntax.TContains {
+		if c.Arg.Type != syntax.TString {
+			return "", fmt.Errorf("invalid argument type %v for %v in condition %q", c.Arg.Type, c.Op, c)
+		}
+		return fmt.Sprintf("composite_key = %s AND strpos(value, %s) > 0", tag, b.arg(c.Arg.Value())), nil
+	}
+
+	op, ok := sqlOperators[c.Op]
+	if !ok {
+		return "", fmt.Errorf("unsupported operator %v in condition %q", c.Op, c)
+	}
+	switch c.Arg.Type {
+	case syntax.TString:
+		if c.Op != syntax.TEq {
+			return "", fmt.Errorf("invalid argument type %v for %v in condition %q", c.Arg.Type, c.Op, c)
+		}
+		value := c.Arg.Value()
+		if c.Tag == types.TxHashKey {
+			// Transaction hashes are indexed as upper-case hex strings.
+			value = strings.ToUpper(value)
+		}
+		return fmt.Sprintf("composite_key = %s AND value = %s", tag, b.arg(value)), nil
+	case syntax.TNumber:
+		if c.Arg.Number() == nil {
+			return "", fmt.Errorf("invalid number in condition %q", c)
+		}
+		return b.castCondition(tag, op, numberPattern, "numeric", c.Arg.Value()), nil
+	case syntax.TDate:
+		return b.castCondition(tag, op, datePattern, "date", c.Arg.Value()), nil
+	case syntax.TTime:
+		return b.castCondition(tag, op, timePattern, "timestamptz", c.Arg.Value()), nil
+	default:
+		return "", fmt.Errorf("unsupported argument type %v in condition %q", c.Arg.Type, c)
+	}
+}
+
+// castCondition returns an expression comparing the attribute values, cast to
+// sqlType, with arg. Values that don't match pattern, and therefore cannot be
+// cast, never satisfy the condition.
+func (b *queryBuilder) castCondition(tag, op, pattern, sqlType, arg string) string {
+	return fmt.Sprintf(
+		"composite_key = %[1]s AND CASE WHEN value ~ %[2]s THEN value::%[3]s %[4]s %[5]s::%[3]s ELSE FALSE END",
+		tag, b.arg(pattern), sqlType, op, b.arg(arg))
+}
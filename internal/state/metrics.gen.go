@@ -70,6 +70,12 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "block_store_base_height",
 			Help:      "BlockStoreBaseHeight shows the first height at which a block is available",
 		}, labels).With(labelsAndValues...),
+		ExtendedCommitBaseHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "extended_commit_base_height",
+			Help:      "ExtendedCommitBaseHeight shows the first height at which an extended commit may be available",
+		}, labels).With(labelsAndValues...),
 		ABCIResultsBaseHeight: prometheus.NewGaugeFrom(stdprometheus.GaugeOpts{
 			Namespace: namespace,
 			Subsystem: MetricsSubsystem,
@@ -110,6 +116,7 @@ func NopMetrics() *Metrics {
 		PruningServiceBlockIndexerRetainHeight: discard.NewGauge(),
 		ApplicationBlockRetainHeight:           discard.NewGauge(),
 		BlockStoreBaseHeight:                   discard.NewGauge(),
+		ExtendedCommitBaseHeight:               discard.NewGauge(),
 		ABCIResultsBaseHeight:                  discard.NewGauge(),
 		TxIndexerBaseHeight:                    discard.NewGauge(),
 		BlockIndexerBaseHeight:                 discard.NewGauge(),
@@ -51,6 +51,10 @@ type Metrics struct {
 	// a block is available
 	BlockStoreBaseHeight metrics.Gauge
 
+	// ExtendedCommitBaseHeight shows the first height at which
+	// an extended commit may be available
+	ExtendedCommitBaseHeight metrics.Gauge
+
 	// ABCIResultsBaseHeight shows the first height at which
 	// abci results are available
 	ABCIResultsBaseHeight metrics.Gauge
@@ -519,6 +519,24 @@ func (_m *Store) SaveFinalizeBlockResponse(height int64, res *v1.FinalizeBlockRe
 	return r0
 }
 
+// SaveValidators provides a mock function with given fields: height, vals
+func (_m *Store) SaveValidators(height int64, vals *types.ValidatorSet) error {
+	ret := _m.Called(height, vals)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveValidators")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(int64, *types.ValidatorSet) error); ok {
+		r0 = rf(height, vals)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetOfflineStateSyncHeight provides a mock function with given fields: height
 func (_m *Store) SetOfflineStateSyncHeight(height int64) error {
 	ret := _m.Called(height)
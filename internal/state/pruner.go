@@ -38,6 +38,9 @@ type Pruner struct {
 	interval     time.Duration
 	observer     PrunerObserver
 	metrics      *Metrics
+	// Number of latest extended commits to keep, 0 to prune them with the
+	// blocks only
+	extCommitRetainBlocks int64
 
 	// Preserve the number of state entries pruned.
 	// Used to calculated correctly when to trigger compactions
@@ -45,10 +48,11 @@ type Pruner struct {
 }
 
 type prunerConfig struct {
-	dcEnabled bool
-	interval  time.Duration
-	observer  PrunerObserver
-	metrics   *Metrics
+	dcEnabled             bool
+	interval              time.Duration
+	observer              PrunerObserver
+	metrics               *Metrics
+	extCommitRetainBlocks int64
 }
 
 func defaultPrunerConfig() *prunerConfig {
@@ -88,6 +92,13 @@ func WithPrunerMetrics(metrics *Metrics) PrunerOption {
 	}
 }
 
+// WithPrunerExtendedCommitRetainBlocks makes the pruner remove the extended
+// commits of the blocks below the latest n ones, even if the blocks are
+// retained. By default, the extended commits are pruned with their blocks.
+func WithPrunerExtendedCommitRetainBlocks(n int64) PrunerOption {
+	return func(p *prunerConfig) { p.extCommitRetainBlocks = n }
+}
+
 // NewPruner creates a service that controls background pruning of node data.
 //
 // Assumes that the initial application and data companion retain heights have
@@ -114,6 +125,8 @@ func NewPruner(
 		observer:     cfg.observer,
 		metrics:      cfg.metrics,
 		dcEnabled:    cfg.dcEnabled,
+
+		extCommitRetainBlocks: cfg.extCommitRetainBlocks,
 	}
 	p.BaseService = *service.NewBaseService(logger, "Pruner", p)
 	return p
@@ -125,6 +138,9 @@ func (p *Pruner) SetObserver(obs PrunerObserver) {
 
 func (p *Pruner) OnStart() error {
 	go p.pruneBlocks()
+	if p.extCommitRetainBlocks > 0 {
+		go p.pruneExtendedCommits()
+	}
 	// We only care about pruning ABCI results if the data companion has been
 	// enabled.
 	if p.dcEnabled {
@@ -349,6 +365,34 @@ func (p *Pruner) pruneBlocks() {
 	}
 }
 
+func (p *Pruner) pruneExtendedCommits() {
+	p.logger.Info("Started pruning extended commits", "interval", p.interval.String(),
+		"retainBlocks", p.extCommitRetainBlocks)
+	for {
+		select {
+		case <-p.Quit():
+			return
+		default:
+			p.pruneExtendedCommitsToRetainHeight()
+			time.Sleep(p.interval)
+		}
+	}
+}
+
+func (p *Pruner) pruneExtendedCommitsToRetainHeight() {
+	targetRetainHeight := p.bs.Height() - p.extCommitRetainBlocks + 1
+	if targetRetainHeight <= 1 {
+		return
+	}
+	pruned, err := p.bs.PruneExtendedCommits(targetRetainHeight)
+	if err != nil {
+		p.logger.Error("Failed to prune extended commits", "err", err, "targetRetainHeight", targetRetainHeight)
+	} else if pruned > 0 {
+		p.metrics.ExtendedCommitBaseHeight.Set(float64(targetRetainHeight))
+		p.logger.Debug("Pruned extended commits", "count", pruned, "newRetainHeight", targetRetainHeight)
+	}
+}
+
 func (p *Pruner) pruneIndexesRoutine() {
 	p.logger.Info("Index pruner started", "interval", p.interval.String())
 	lastTxIndexerRetainHeight := int64(0)
@@ -28,6 +28,7 @@ type BlockStore interface {
 	SaveBlockWithExtendedCommit(block *types.Block, blockParts *types.PartSet, seenCommit *types.ExtendedCommit)
 
 	PruneBlocks(height int64, state State) (uint64, int64, error)
+	PruneExtendedCommits(height int64) (uint64, error)
 
 	LoadBlockByHash(hash []byte) (*types.Block, *types.BlockMeta)
 	LoadBlockMetaByHash(hash []byte) *types.BlockMeta
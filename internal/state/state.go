@@ -236,6 +236,19 @@ func (state State) MakeBlock(
 	lastCommit *types.Commit,
 	evidence []types.Evidence,
 	proposerAddress []byte,
+) *types.Block {
+	return state.makeBlock(height, txs, lastCommit, evidence, proposerAddress, cmttime.DefaultSource{})
+}
+
+// makeBlock is MakeBlock, reading the local time of the proposer from
+// timeSource if PBTS is enabled.
+func (state State) makeBlock(
+	height int64,
+	txs []types.Tx,
+	lastCommit *types.Commit,
+	evidence []types.Evidence,
+	proposerAddress []byte,
+	timeSource cmttime.Source,
 ) *types.Block {
 	// Build base block with block data.
 	block := types.MakeBlock(height, txs, lastCommit, evidence)
@@ -244,7 +257,7 @@ func (state State) MakeBlock(
 	var timestamp time.Time
 	switch {
 	case state.ConsensusParams.Feature.PbtsEnabled(height):
-		timestamp = cmttime.Now()
+		timestamp = timeSource.Now()
 	case height == state.InitialHeight:
 		timestamp = state.LastBlockTime // genesis time
 	default:
@@ -82,6 +82,9 @@ type Store interface {
 	SaveFinalizeBlockResponse(height int64, res *abci.FinalizeBlockResponse) error
 	// Bootstrap is used for bootstrapping state when not starting from a initial height.
 	Bootstrap(state State) error
+	// SaveValidators saves the validator set at a height below the
+	// bootstrapped state, e.g. restored from a block snapshot.
+	SaveValidators(height int64, vals *types.ValidatorSet) error
 	// PruneStates takes the height from which to start pruning and which height stop at
 	PruneStates(fromHeight, toHeight, evidenceThresholdHeight int64, previouslyPrunedStates uint64) (uint64, error)
 	// PruneABCIResponses will prune all ABCI responses below the given height.
@@ -320,6 +323,19 @@ func (store dbStore) Bootstrap(state State) error {
 	return batch.Close()
 }
 
+// SaveValidators saves the validator set at the given height. It is saved in
+// full, as if it had changed at this height, since the state at the previous
+// heights may be missing.
+func (store dbStore) SaveValidators(height int64, vals *types.ValidatorSet) error {
+	batch := store.db.NewBatch()
+	defer batch.Close()
+
+	if err := store.saveValidatorsInfo(height, height, vals, batch); err != nil {
+		return err
+	}
+	return batch.WriteSync()
+}
+
 // PruneStates deletes states between the given heights (including from, excluding to). It is not
 // guaranteed to delete all states, since the last checkpointed state and states being pointed to by
 // e.g. `LastHeightChanged` must remain. The state at to must also exist.
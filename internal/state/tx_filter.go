@@ -12,9 +12,10 @@ func TxPreCheck(state State) mempl.PreCheckFunc {
 	if maxBytes == -1 {
 		maxBytes = int64(types.MaxBlockSizeBytes)
 	}
-	maxDataBytes := types.MaxDataBytesNoEvidence(
+	maxDataBytes := types.MaxDataBytesNoEvidenceForKeyTypes(
 		maxBytes,
 		state.Validators.Size(),
+		state.ConsensusParams.Validator.PubKeyTypes,
 	)
 	return mempl.PreCheckMaxBytes(maxDataBytes)
 }
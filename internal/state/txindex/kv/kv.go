@@ -47,6 +47,10 @@ type TxIndex struct {
 	compact            bool
 	compactionInterval int64
 	lastPruned         int64
+
+	// numericIndex is true if all indexed txs have entries in the numeric
+	// index, which then serves the numeric range queries.
+	numericIndex bool
 }
 
 type IndexerOption func(*TxIndex)
@@ -194,7 +198,8 @@ func (txi *TxIndex) getIndexerRetainHeight() (int64, error) {
 	return height, nil
 }
 
-// NewTxIndex creates new KV indexer.
+// NewTxIndex creates new KV indexer. Range queries are not served from the
+// numeric index until InitNumericIndex or MigrateNumericIndex is called.
 func NewTxIndex(store dbm.DB, options ...IndexerOption) *TxIndex {
 	txIndex := &TxIndex{
 		store: store,
@@ -257,6 +262,10 @@ func (txi *TxIndex) AddBatch(b *txindex.Batch) error {
 		if err != nil {
 			return err
 		}
+		_, err = setNumericEvent(storeBatch, types.TxHeightKey, strconv.FormatInt(result.Height, 10), result, 0, hash)
+		if err != nil {
+			return err
+		}
 
 		rawBytes, err := proto.Marshal(result)
 		if err != nil {
@@ -282,6 +291,10 @@ func (txi *TxIndex) deleteResult(result *abci.TxResult, batch dbm.Batch) error {
 	if err != nil {
 		return err
 	}
+	err = txi.deleteNumericEvents(batch, types.TxHeightKey, strconv.FormatInt(result.Height, 10), result)
+	if err != nil {
+		return err
+	}
 	err = batch.Delete(hash)
 	if err != nil {
 		return err
@@ -328,6 +341,10 @@ func (txi *TxIndex) Index(result *abci.TxResult) error {
 	if err != nil {
 		return err
 	}
+	_, err = setNumericEvent(b, types.TxHeightKey, strconv.FormatInt(result.Height, 10), result, 0, hash)
+	if err != nil {
+		return err
+	}
 
 	rawBytes, err := proto.Marshal(result)
 	if err != nil {
@@ -368,6 +385,9 @@ func (txi *TxIndex) deleteEvents(result *abci.TxResult, batch dbm.Batch) error {
 						return err
 					}
 				}
+				if err := txi.deleteNumericEvents(batch, compositeTag, attr.Value, result); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -398,6 +418,10 @@ func (txi *TxIndex) indexEvents(result *abci.TxResult, hash []byte, store dbm.Ba
 				if err != nil {
 					return err
 				}
+				_, err = setNumericEvent(store, compositeTag, attr.Value, result, txi.eventSeq, hash)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -474,6 +498,19 @@ func (txi *TxIndex) Search(ctx context.Context, q *query.Query) ([]*abci.TxResul
 			if qr.Key == types.TxHeightKey && !heightInfo.onlyHeightRange {
 				continue
 			}
+			if txi.useNumericIndex(qr) {
+				filteredHashes, err = txi.matchNumericRange(ctx, qr, filteredHashes, !hashesInitialized, heightInfo)
+				if err != nil {
+					return nil, fmt.Errorf("error searching the numeric index: %w", err)
+				}
+				if !hashesInitialized {
+					hashesInitialized = true
+					if len(filteredHashes) == 0 {
+						break
+					}
+				}
+				continue
+			}
 			if !hashesInitialized {
 				filteredHashes = txi.matchRange(ctx, qr, startKey(qr.Key), filteredHashes, true, heightInfo)
 				hashesInitialized = true
@@ -75,6 +75,7 @@ func TestTxIndex_Prune(t *testing.T) {
 		blockidxkv.LastBlockIndexerRetainHeightKey,
 		TxIndexerRetainHeightKey,
 		blockidxkv.BlockIndexerRetainHeightKey,
+		NumericIndexKey,
 	}
 
 	tx := types.Tx("HELLO WORLD")
@@ -135,7 +136,7 @@ func TestTxIndex_Prune(t *testing.T) {
 
 	keys3 := GetKeys(indexer)
 	assert.True(t, isEqualSets(setDiff(keys2, keys1), setDiff(keys3, metaKeys)))
-	assert.True(t, emptyIntersection(keys1, keys3))
+	assert.True(t, emptyIntersection(setDiff(keys1, metaKeys), keys3))
 
 	loadedTxResult2, err := indexer.Get(hash2)
 	require.NoError(t, err)
@@ -0,0 +1,477 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/google/orderedcode"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	idxutil "github.com/cometbft/cometbft/internal/indexer"
+	"github.com/cometbft/cometbft/internal/state/indexer"
+	"github.com/cometbft/cometbft/types"
+)
+
+// The numeric index is a secondary index over the attributes whose values are
+// numbers, and over the tx heights. Its keys are ordered by
+// (composite key, numeric value, height), so that range queries are served by
+// seeking to the lower bound and stopping at the upper bound, instead of
+// iterating over every value of the composite key.
+//
+// Keys are encoded with orderedcode as:
+//
+//	numericIndexPrefix, composite key, numeric value, raw value, height, index, event sequence
+//
+// and values are the tx hashes. The numeric value is encoded by
+// appendNumericValue so that the byte order of the keys is the numeric order
+// of the values.
+
+const numericIndexPrefix = "numeric_index"
+
+// Classes of numeric values, in increasing order.
+const (
+	numericClassNegInf int64 = iota
+	numericClassNeg
+	numericClassZero
+	numericClassPos
+	numericClassPosInf
+)
+
+// NumericIndexKey is set once all indexed txs have entries in the numeric
+// index. Until then, range queries iterate over the attribute values.
+var NumericIndexKey = []byte("TxIndexerNumericIndexKey")
+
+// numericIndexBatchSize is the number of numeric index entries written per
+// batch when migrating an existing index.
+const numericIndexBatchSize = 1000
+
+// parseNumericValue parses an attribute value the same way range queries do.
+// It returns nil if the value is not a number.
+func parseNumericValue(value string) *big.Float {
+	if v, ok := new(big.Int).SetString(value, 10); ok {
+		return new(big.Float).SetInt(v)
+	}
+	vF, _, err := big.ParseFloat(value, 10, 125, big.ToNearestEven)
+	if err != nil {
+		return nil
+	}
+	return vF
+}
+
+// appendNumericValue appends an encoding of v that preserves the numeric order
+// of the values. Finite non-zero values are encoded as their exponent followed
+// by the bytes of their mantissa, both in decreasing order for negative
+// values.
+func appendNumericValue(buf []byte, v *big.Float) ([]byte, error) {
+	switch {
+	case v.IsInf() && v.Sign() < 0:
+		return orderedcode.Append(buf, numericClassNegInf)
+	case v.IsInf():
+		return orderedcode.Append(buf, numericClassPosInf)
+	case v.Sign() == 0:
+		return orderedcode.Append(buf, numericClassZero)
+	}
+
+	mant := new(big.Float)
+	exp := int64(v.MantExp(mant))
+	mant.Abs(mant)
+	// Shift the mantissa, in [0.5, 1), so that its most significant bit is
+	// the most significant bit of the first byte. Trailing zero bytes are
+	// trimmed so that equal values with different precisions are encoded the
+	// same way.
+	bits := (mant.Prec() + 7) / 8 * 8
+	mantInt, _ := mant.SetMantExp(mant, int(bits)).Int(nil)
+	mantBz := bytes.TrimRight(mantInt.Bytes(), "\x00")
+
+	if v.Sign() < 0 {
+		return orderedcode.Append(buf, numericClassNeg, orderedcode.Decr(exp), orderedcode.Decr(string(mantBz)))
+	}
+	return orderedcode.Append(buf, numericClassPos, exp, string(mantBz))
+}
+
+// skipNumericValue parses a value encoded by appendNumericValue and returns
+// the remainder of the key.
+func skipNumericValue(key string) (string, error) {
+	var class int64
+	remaining, err := orderedcode.Parse(key, &class)
+	if err != nil {
+		return "", err
+	}
+	var (
+		exp  int64
+		mant string
+	)
+	switch class {
+	case numericClassNegInf, numericClassZero, numericClassPosInf:
+		return remaining, nil
+	case numericClassNeg:
+		return orderedcode.Parse(remaining, orderedcode.Decr(&exp), orderedcode.Decr(&mant))
+	case numericClassPos:
+		return orderedcode.Parse(remaining, &exp, &mant)
+	default:
+		return "", fmt.Errorf("unknown numeric value class %d", class)
+	}
+}
+
+func numericIndexCompositePrefix(compositeKey string) ([]byte, error) {
+	return orderedcode.Append(nil, numericIndexPrefix, compositeKey)
+}
+
+func keyForNumericEvent(compositeKey string, value *big.Float, rawValue string, height int64, index uint32, eventSeq int64) ([]byte, error) {
+	key, err := numericIndexCompositePrefix(compositeKey)
+	if err != nil {
+		return nil, err
+	}
+	key, err = appendNumericValue(key, value)
+	if err != nil {
+		return nil, err
+	}
+	return orderedcode.Append(key, rawValue, height, int64(index), eventSeq)
+}
+
+// parseNumericEventKey returns the raw value, height and event sequence of a
+// numeric index key, given the length of its composite key prefix.
+func parseNumericEventKey(key []byte, prefixLen int) (rawValue string, height int64, eventSeq int64, err error) {
+	remaining, err := skipNumericValue(string(key[prefixLen:]))
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to parse numeric index key: %w", err)
+	}
+	var index int64
+	remaining, err = orderedcode.Parse(remaining, &rawValue, &height, &index, &eventSeq)
+	if err != nil {
+		return "", 0, 0, fmt.Errorf("failed to parse numeric index key: %w", err)
+	}
+	if len(remaining) != 0 {
+		return "", 0, 0, fmt.Errorf("unexpected remainder in numeric index key: %s", remaining)
+	}
+	return rawValue, height, eventSeq, nil
+}
+
+// setNumericEvent indexes the given attribute value in the numeric index, if
+// it is a number. It returns true if the value was indexed.
+func setNumericEvent(batch dbm.Batch, compositeKey, value string, result *abci.TxResult, eventSeq int64, hash []byte) (bool, error) {
+	v := parseNumericValue(value)
+	if v == nil {
+		return false, nil
+	}
+	key, err := keyForNumericEvent(compositeKey, v, value, result.Height, result.Index, eventSeq)
+	if err != nil {
+		return false, err
+	}
+	return true, batch.Set(key, hash)
+}
+
+// deleteNumericEvents deletes the numeric index entries of the given attribute
+// value for the given tx, whatever their event sequence.
+func (txi *TxIndex) deleteNumericEvents(batch dbm.Batch, compositeKey, value string, result *abci.TxResult) error {
+	v := parseNumericValue(value)
+	if v == nil {
+		return nil
+	}
+	startKey, err := keyForNumericEvent(compositeKey, v, value, result.Height, result.Index, 0)
+	if err != nil {
+		return err
+	}
+	endKey, err := keyForNumericEvent(compositeKey, v, value, result.Height, result.Index, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+	it, err := txi.store.Iterator(startKey, endKey)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if err := batch.Delete(it.Key()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+// numericRangeKeys returns the iteration bounds over the numeric index for the
+// given query range. The bounds are inclusive of the range's bounds, whether
+// the range includes them or not: CheckBounds is applied to every entry.
+func numericRangeKeys(qr indexer.QueryRange) (start, end []byte, err error) {
+	prefix, err := numericIndexCompositePrefix(qr.Key)
+	if err != nil {
+		return nil, nil, err
+	}
+	start, end = prefix, prefixEnd(prefix)
+	if lower, ok := qr.LowerBound.(*big.Float); ok {
+		if start, err = appendNumericValue(append([]byte(nil), prefix...), lower); err != nil {
+			return nil, nil, err
+		}
+	}
+	if upper, ok := qr.UpperBound.(*big.Float); ok {
+		upperKey, err := appendNumericValue(append([]byte(nil), prefix...), upper)
+		if err != nil {
+			return nil, nil, err
+		}
+		end = prefixEnd(upperKey)
+	}
+	return start, end, nil
+}
+
+// matchNumericRange is the equivalent of matchRange for numeric query ranges,
+// served from the numeric index.
+//
+// NOTE: filteredHashes may be empty if no previous condition has matched.
+func (txi *TxIndex) matchNumericRange(
+	ctx context.Context,
+	qr indexer.QueryRange,
+	filteredHashes map[string][]byte,
+	firstRun bool,
+	heightInfo HeightInfo,
+) (map[string][]byte, error) {
+	// A previous match was attempted but resulted in no matches, so we return
+	// no matches (assuming AND operand).
+	if !firstRun && len(filteredHashes) == 0 {
+		return filteredHashes, nil
+	}
+
+	prefix, err := numericIndexCompositePrefix(qr.Key)
+	if err != nil {
+		return nil, err
+	}
+	start, end, err := numericRangeKeys(qr)
+	if err != nil {
+		return nil, err
+	}
+	it, err := txi.store.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	tmpHashes := make(map[string][]byte)
+
+LOOP:
+	for ; it.Valid(); it.Next() {
+		rawValue, keyHeight, eventSeq, err := parseNumericEventKey(it.Key(), len(prefix))
+		if err != nil {
+			txi.log.Error("failure to parse numeric index key:", err)
+			continue
+		}
+		if qr.Key != types.TxHeightKey {
+			withinBounds, err := checkHeightConditions(heightInfo, keyHeight)
+			if err != nil {
+				txi.log.Error("failure checking for height bounds:", err)
+				continue
+			}
+			if !withinBounds {
+				continue
+			}
+		}
+
+		// Compare integers as such, like matchRange does.
+		var v interface{}
+		if vInt, ok := new(big.Int).SetString(rawValue, 10); ok {
+			v = vInt
+		} else {
+			v = parseNumericValue(rawValue)
+		}
+		withinBounds, err := idxutil.CheckBounds(qr, v)
+		if err != nil {
+			txi.log.Error("failed to parse bounds:", err)
+		} else if withinBounds {
+			tmpHashes[string(it.Value())+strconv.FormatInt(eventSeq, 10)] = it.Value()
+		}
+
+		// Potentially exit early.
+		select {
+		case <-ctx.Done():
+			break LOOP
+		default:
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	if len(tmpHashes) == 0 || firstRun {
+		return tmpHashes, nil
+	}
+
+	// Remove/reduce matches in filteredHashes that were not found in this
+	// match (tmpHashes).
+REMOVE_LOOP:
+	for k, v := range filteredHashes {
+		tmpHash := tmpHashes[k]
+		if tmpHash == nil || !bytes.Equal(tmpHash, v) {
+			delete(filteredHashes, k)
+
+			// Potentially exit early.
+			select {
+			case <-ctx.Done():
+				break REMOVE_LOOP
+			default:
+			}
+		}
+	}
+
+	return filteredHashes, nil
+}
+
+// useNumericIndex returns true if the given query range can be served from the
+// numeric index.
+func (txi *TxIndex) useNumericIndex(qr indexer.QueryRange) bool {
+	if !txi.numericIndex {
+		return false
+	}
+	_, ok := qr.AnyBound().(*big.Float)
+	return ok
+}
+
+// MigrateNumericIndex builds the numeric index from the existing event and
+// height keys, for tx indexes created before the numeric index existed. Once
+// done, range queries are served from the numeric index. It returns the number
+// of entries added to the numeric index.
+//
+// It must not run concurrently with the indexing of new txs.
+func (txi *TxIndex) MigrateNumericIndex(ctx context.Context) (int, error) {
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	batch := txi.store.NewBatch()
+	defer func() { batch.Close() }()
+
+	numPrefix, err := orderedcode.Append(nil, numericIndexPrefix)
+	if err != nil {
+		return 0, err
+	}
+	added, pending := 0, 0
+	for ; it.Valid(); it.Next() {
+		select {
+		case <-ctx.Done():
+			return added, ctx.Err()
+		default:
+		}
+
+		key := it.Key()
+		if bytes.HasPrefix(key, numPrefix) {
+			continue
+		}
+		compositeKey, value, result, eventSeq, ok := parseEventKey(key, it.Value())
+		if !ok {
+			continue
+		}
+		indexed, err := setNumericEvent(batch, compositeKey, value, result, eventSeq, it.Value())
+		if err != nil {
+			return added, err
+		}
+		if !indexed {
+			continue
+		}
+		pending++
+
+		if pending == numericIndexBatchSize {
+			if err := batch.WriteSync(); err != nil {
+				return added, fmt.Errorf("failed to flush numeric index batch: %w", err)
+			}
+			batch.Close()
+			batch = txi.store.NewBatch()
+			added += pending
+			pending = 0
+		}
+	}
+	if err := it.Error(); err != nil {
+		return added, err
+	}
+
+	if err := batch.Set(NumericIndexKey, []byte{1}); err != nil {
+		return added, err
+	}
+	if err := batch.WriteSync(); err != nil {
+		return added, fmt.Errorf("failed to flush numeric index batch: %w", err)
+	}
+	added += pending
+	txi.numericIndex = true
+	return added, nil
+}
+
+// parseEventKey parses an event or height key, as created by keyForEvent and
+// keyForHeight, returning ok=false for any other key: tx results indexed by
+// hash, retain heights, or the keys of the block indexer sharing the same DB.
+func parseEventKey(key, value []byte) (compositeKey, eventValue string, result *abci.TxResult, eventSeq int64, ok bool) {
+	if len(value) != len(types.Tx(nil).Hash()) || !isTagKey(key) {
+		return "", "", nil, 0, false
+	}
+	compositeKey, _, _ = strings.Cut(string(key), tagKeySeparator)
+	if !strings.Contains(compositeKey, ".") || strings.ContainsRune(compositeKey, 0) {
+		return "", "", nil, 0, false
+	}
+
+	height, err := extractHeightFromKey(key)
+	if err != nil {
+		return "", "", nil, 0, false
+	}
+	parts := strings.Split(string(key), tagKeySeparator)
+	indexStr, _, _ := strings.Cut(parts[len(parts)-1], eventSeqSeparator)
+	index, err := strconv.ParseUint(indexStr, 10, 32)
+	if err != nil {
+		return "", "", nil, 0, false
+	}
+	eventSeq, err = strconv.ParseInt(extractEventSeqFromKey(key), 10, 64)
+	if err != nil {
+		return "", "", nil, 0, false
+	}
+
+	result = &abci.TxResult{Height: height, Index: uint32(index)}
+	return compositeKey, extractValueFromKey(key), result, eventSeq, true
+}
+
+// prefixEnd returns the smallest key greater than all the keys with the given
+// prefix.
+func prefixEnd(prefix []byte) []byte {
+	end := append([]byte(nil), prefix...)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return end[:i+1]
+		}
+	}
+	return nil
+}
+
+// InitNumericIndex enables the numeric index if it was built, or if the tx
+// index is empty so that it will be built as txs are indexed. Otherwise, the
+// index has to be migrated with MigrateNumericIndex.
+//
+// It must be called before indexing any tx.
+func (txi *TxIndex) InitNumericIndex() error {
+	has, err := txi.store.Has(NumericIndexKey)
+	if err != nil {
+		return err
+	}
+	if has {
+		txi.numericIndex = true
+		return nil
+	}
+
+	it, err := txi.store.Iterator(nil, nil)
+	if err != nil {
+		return err
+	}
+	empty := !it.Valid()
+	if err := it.Close(); err != nil {
+		return err
+	}
+	if !empty {
+		return nil
+	}
+	if err := txi.store.SetSync(NumericIndexKey, []byte{1}); err != nil {
+		return err
+	}
+	txi.numericIndex = true
+	return nil
+}
@@ -0,0 +1,224 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/google/orderedcode"
+	"github.com/stretchr/testify/require"
+
+	db "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/pubsub/query"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestNumericValueEncodingOrder(t *testing.T) {
+	// In increasing numeric order.
+	values := []string{
+		"-1e30",
+		"-18446744073709551617",
+		"-100.5",
+		"-100",
+		"-1",
+		"-0.75",
+		"-0.5",
+		"0",
+		"0.0001",
+		"0.5",
+		"0.75",
+		"1",
+		"1.5",
+		"2",
+		"100",
+		"100.25",
+		"18446744073709551616",
+		"18446744073709551617",
+		"1e30",
+	}
+	encode := func(value string) []byte {
+		v := parseNumericValue(value)
+		require.NotNil(t, v, value)
+		bz, err := appendNumericValue(nil, v)
+		require.NoError(t, err)
+		return bz
+	}
+	for i := 1; i < len(values); i++ {
+		require.Negative(t, bytes.Compare(encode(values[i-1]), encode(values[i])),
+			"%s should be encoded before %s", values[i-1], values[i])
+	}
+
+	// Equal values are encoded the same way, whatever their precision.
+	require.Equal(t, encode("1"), encode("1.0"))
+	require.Equal(t, encode("-100"), encode("-100.000"))
+	require.Equal(t, encode("0"), encode("-0"))
+
+	require.Nil(t, parseNumericValue("abc"))
+}
+
+func TestNumericEventKey(t *testing.T) {
+	v := parseNumericValue("-12.5")
+	key, err := keyForNumericEvent("transfer.amount", v, "-12.5", 10, 2, 7)
+	require.NoError(t, err)
+
+	prefix, err := numericIndexCompositePrefix("transfer.amount")
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(key, prefix))
+
+	rawValue, height, eventSeq, err := parseNumericEventKey(key, len(prefix))
+	require.NoError(t, err)
+	require.Equal(t, "-12.5", rawValue)
+	require.EqualValues(t, 10, height)
+	require.EqualValues(t, 7, eventSeq)
+}
+
+// numericRangeQueries are run against indexes with and without the numeric
+// index, which must return the same results.
+var numericRangeQueries = []string{
+	"transfer.amount > 1000",
+	"transfer.amount >= 1000",
+	"transfer.amount < 1000",
+	"transfer.amount <= 1000.5",
+	"transfer.amount > 10.5 AND transfer.amount < 2000",
+	"transfer.amount > 1000 AND tx.height > 3",
+	"transfer.amount >= 0 AND tx.height >= 2 AND tx.height <= 4",
+	"transfer.amount > 100 AND transfer.sender = 'addr2'",
+	"transfer.amount > 18446744073709551615",
+	"tx.height > 2",
+	"tx.height >= 2 AND tx.height < 5",
+	"tx.height <= 1",
+}
+
+func indexNumericTxs(t *testing.T, indexer *TxIndex) {
+	t.Helper()
+	amounts := []string{"-5", "0", "10.5", "999", "1000", "1000.5", "1500", "18446744073709551616", "abc", "2000"}
+	for i, amount := range amounts {
+		txResult := &abci.TxResult{
+			Height: int64(i/2 + 1),
+			Index:  uint32(i % 2),
+			Tx:     types.Tx(fmt.Sprintf("tx%d", i)),
+			Result: abci.ExecTxResult{
+				Events: []abci.Event{
+					{Type: "transfer", Attributes: []abci.EventAttribute{
+						{Key: "amount", Value: amount, Index: true},
+						{Key: "sender", Value: fmt.Sprintf("addr%d", i%3), Index: true},
+					}},
+				},
+			},
+		}
+		require.NoError(t, indexer.Index(txResult))
+	}
+}
+
+func searchHashes(t *testing.T, indexer *TxIndex, q string) []string {
+	t.Helper()
+	results, err := indexer.Search(context.Background(), query.MustCompile(q))
+	require.NoError(t, err)
+	hashes := make([]string, len(results))
+	for i, res := range results {
+		hashes[i] = fmt.Sprintf("%X", types.Tx(res.Tx).Hash())
+	}
+	sort.Strings(hashes)
+	return hashes
+}
+
+func TestTxSearchNumericIndex(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+	indexer.SetLogger(log.TestingLogger())
+	// The numeric index is only enabled, and recorded in the store, on init.
+	require.False(t, indexer.numericIndex)
+	require.Empty(t, getKeys(indexer))
+	require.NoError(t, indexer.InitNumericIndex())
+	require.True(t, indexer.numericIndex)
+	indexNumericTxs(t, indexer)
+
+	for _, q := range numericRangeQueries {
+		t.Run(q, func(t *testing.T) {
+			indexed := searchHashes(t, indexer, q)
+			indexer.numericIndex = false
+			scanned := searchHashes(t, indexer, q)
+			indexer.numericIndex = true
+			require.Equal(t, scanned, indexed)
+		})
+	}
+
+	require.Len(t, searchHashes(t, indexer, "transfer.amount > 1000"), 4)
+	require.Len(t, searchHashes(t, indexer, "transfer.amount > 1000 AND tx.height > 4"), 1)
+}
+
+func TestTxIndexNumericIndexPrune(t *testing.T) {
+	indexer := NewTxIndex(db.NewMemDB())
+	indexer.SetLogger(log.TestingLogger())
+	require.NoError(t, indexer.InitNumericIndex())
+	indexNumericTxs(t, indexer)
+
+	_, _, err := indexer.Prune(3)
+	require.NoError(t, err)
+
+	// Only the entries of heights 3 and above remain in the numeric index.
+	prefix, err := orderedcode.Append(nil, numericIndexPrefix)
+	require.NoError(t, err)
+	for _, key := range getKeys(indexer) {
+		if !bytes.HasPrefix(key, prefix) {
+			continue
+		}
+		compositePrefix, err := numericIndexCompositePrefix(types.TxHeightKey)
+		require.NoError(t, err)
+		if !bytes.HasPrefix(key, compositePrefix) {
+			compositePrefix, err = numericIndexCompositePrefix("transfer.amount")
+			require.NoError(t, err)
+		}
+		_, height, _, err := parseNumericEventKey(key, len(compositePrefix))
+		require.NoError(t, err)
+		require.GreaterOrEqual(t, height, int64(3))
+	}
+	require.Len(t, searchHashes(t, indexer, "transfer.amount > 1000"), 4)
+	require.Len(t, searchHashes(t, indexer, "transfer.amount <= 1000"), 1)
+}
+
+func TestMigrateNumericIndex(t *testing.T) {
+	store := db.NewMemDB()
+	indexer := NewTxIndex(store)
+	indexer.SetLogger(log.TestingLogger())
+	require.NoError(t, indexer.InitNumericIndex())
+	indexNumericTxs(t, indexer)
+
+	// Turn the index into one created before the numeric index existed.
+	prefix, err := orderedcode.Append(nil, numericIndexPrefix)
+	require.NoError(t, err)
+	numericKeys := 0
+	for _, key := range getKeys(indexer) {
+		if bytes.HasPrefix(key, prefix) {
+			require.NoError(t, store.Delete(key))
+			numericKeys++
+		}
+	}
+	require.NoError(t, store.Delete(NumericIndexKey))
+
+	indexer = NewTxIndex(store)
+	indexer.SetLogger(log.TestingLogger())
+	require.NoError(t, indexer.InitNumericIndex())
+	require.False(t, indexer.numericIndex)
+	expected := make(map[string][]string, len(numericRangeQueries))
+	for _, q := range numericRangeQueries {
+		expected[q] = searchHashes(t, indexer, q)
+	}
+
+	added, err := indexer.MigrateNumericIndex(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, numericKeys, added)
+	require.True(t, indexer.numericIndex)
+
+	// The numeric index is used after a restart.
+	indexer = NewTxIndex(store)
+	indexer.SetLogger(log.TestingLogger())
+	require.NoError(t, indexer.InitNumericIndex())
+	require.True(t, indexer.numericIndex)
+	for _, q := range numericRangeQueries {
+		require.Equal(t, expected[q], searchHashes(t, indexer, q), q)
+	}
+}
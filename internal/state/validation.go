@@ -90,6 +90,10 @@ func validateBlock(state State, block *types.Block) error {
 			return errors.New("initial block can't have LastCommit signatures")
 		}
 	} else {
+		if block.LastCommit.IsAggregated() && !state.ConsensusParams.Feature.AggregatedCommitEnabled(block.Height-1) {
+			return fmt.Errorf("aggregated LastCommit at height %d, but aggregated commits are not enabled",
+				block.Height-1)
+		}
 		// LastCommit.Signatures length is checked in VerifyCommit.
 		if err := state.LastValidators.VerifyCommit(
 			state.ChainID, state.LastBlockID, block.Height-1, block.LastCommit); err != nil {
@@ -0,0 +1,333 @@
+package statesync
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"time"
+
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	"github.com/cometbft/cometbft/internal/protoio"
+	sm "github.com/cometbft/cometbft/internal/state"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// BlockSnapshotFormat is the format of the block snapshots produced by
+	// CometBFT, which are advertised alongside the snapshots of the
+	// application. Applications must not use this format.
+	BlockSnapshotFormat = uint32(math.MaxUint32)
+
+	// blockSnapshotChunkSize is the size of the chunks of block snapshots.
+	blockSnapshotChunkSize = 4 * 1024 * 1024
+	// recentBlockSnapshots is the number of block snapshots advertised, and
+	// kept in memory, for the most recent application snapshots.
+	recentBlockSnapshots = 2
+	// maxBlockSnapshotEntrySize is the maximum size of an entry of a block
+	// snapshot.
+	maxBlockSnapshotEntrySize = 2 * types.MaxBlockSizeBytes
+	// blockSnapshotInterval is how often the block snapshots are produced at
+	// the heights of the most recent application snapshots.
+	blockSnapshotInterval = 10 * time.Second
+)
+
+// errNoConsensusParams is returned when the consensus params of a block are
+// unknown, e.g. because it was restored from a block snapshot.
+var errNoConsensusParams = errors.New("consensus params not found")
+
+// blockSnapshot is a block snapshot produced by the local node.
+type blockSnapshot struct {
+	snapshot *snapshot
+	data     []byte
+}
+
+// chunk returns the chunk with the given index, or nil if there is none.
+func (s *blockSnapshot) chunk(index uint32) []byte {
+	start := int(index) * blockSnapshotChunkSize
+	if start >= len(s.data) {
+		return nil
+	}
+	end := start + blockSnapshotChunkSize
+	if end > len(s.data) {
+		end = len(s.data)
+	}
+	return s.data[start:end]
+}
+
+// blockSnapshotter produces the block snapshots of the most recent blocks, at
+// the heights of the application snapshots, so that state syncing nodes can
+// restore them and serve them to other nodes.
+type blockSnapshotter struct {
+	blockStore sm.BlockStore
+	stateStore sm.Store
+	numBlocks  int64
+
+	mtx       cmtsync.Mutex
+	snapshots map[uint64]*blockSnapshot
+}
+
+func newBlockSnapshotter(blockStore sm.BlockStore, stateStore sm.Store, numBlocks int64) *blockSnapshotter {
+	return &blockSnapshotter{
+		blockStore: blockStore,
+		stateStore: stateStore,
+		numBlocks:  numBlocks,
+		snapshots:  make(map[uint64]*blockSnapshot),
+	}
+}
+
+// Snapshot returns the block snapshot at the given height, producing it if
+// needed. Only the block snapshots of the recentBlockSnapshots most recent
+// heights produced are kept. Producing a block snapshot loads numBlocks blocks
+// from the stores, so it must not be done on behalf of peers: the block
+// snapshots are produced in the background, and Cached returns them.
+func (bs *blockSnapshotter) Snapshot(height uint64) (*snapshot, error) {
+	if s, ok := bs.Cached(height); ok {
+		return s, nil
+	}
+
+	data, err := bs.produce(int64(height))
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(data)
+	s := &blockSnapshot{
+		snapshot: &snapshot{
+			Height: height,
+			Format: BlockSnapshotFormat,
+			Chunks: uint32((len(data) + blockSnapshotChunkSize - 1) / blockSnapshotChunkSize),
+			Hash:   hash[:],
+		},
+		data: data,
+	}
+
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+	bs.snapshots[height] = s
+	if len(bs.snapshots) > recentBlockSnapshots {
+		heights := make([]uint64, 0, len(bs.snapshots))
+		for h := range bs.snapshots {
+			heights = append(heights, h)
+		}
+		sort.Slice(heights, func(i, j int) bool { return heights[i] < heights[j] })
+		for _, h := range heights[:len(heights)-recentBlockSnapshots] {
+			delete(bs.snapshots, h)
+		}
+	}
+	return s.snapshot, nil
+}
+
+// Cached returns the block snapshot at the given height if it was already
+// produced.
+func (bs *blockSnapshotter) Cached(height uint64) (*snapshot, bool) {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	s, ok := bs.snapshots[height]
+	if !ok {
+		return nil, false
+	}
+	return s.snapshot, true
+}
+
+// Chunk returns a chunk of the block snapshot at the given height, or nil if
+// there is no such snapshot or chunk.
+func (bs *blockSnapshotter) Chunk(height uint64, index uint32) []byte {
+	bs.mtx.Lock()
+	defer bs.mtx.Unlock()
+
+	s, ok := bs.snapshots[height]
+	if !ok {
+		return nil
+	}
+	return s.chunk(index)
+}
+
+// produce returns the contents of the block snapshot at the given height,
+// i.e. the length-delimited entries of the numBlocks blocks up to the
+// height, or less if the older blocks were pruned or restored from a block
+// snapshot, in which case their consensus params are unknown.
+func (bs *blockSnapshotter) produce(height int64) ([]byte, error) {
+	if height > bs.blockStore.Height() {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	base := height - bs.numBlocks + 1
+	if storeBase := bs.blockStore.Base(); base < storeBase {
+		base = storeBase
+	}
+	if base < 1 || base > height {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+
+	// Load the entries downwards, stopping at the first block whose
+	// consensus params are unknown.
+	entries := make([]*ssproto.BlockSnapshotEntry, 0, height-base+1)
+	for h := height; h >= base; h-- {
+		entry, err := bs.entry(h)
+		if err != nil {
+			if h < height && errors.Is(err, errNoConsensusParams) {
+				break
+			}
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+
+	buf := new(bytes.Buffer)
+	w := protoio.NewDelimitedWriter(buf)
+	for i := len(entries) - 1; i >= 0; i-- {
+		if _, err := w.WriteMsg(entries[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (bs *blockSnapshotter) entry(height int64) (*ssproto.BlockSnapshotEntry, error) {
+	block, _ := bs.blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block at height %d not found", height)
+	}
+	pbBlock, err := block.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	vals, err := bs.stateStore.LoadValidators(height)
+	if err != nil {
+		return nil, err
+	}
+	pbVals, err := vals.ToProto()
+	if err != nil {
+		return nil, err
+	}
+	params, err := bs.stateStore.LoadConsensusParams(height)
+	if err != nil {
+		return nil, fmt.Errorf("%w at height %d: %v", errNoConsensusParams, height, err)
+	}
+	pbParams := params.ToProto()
+
+	return &ssproto.BlockSnapshotEntry{
+		Block:           pbBlock,
+		Validators:      pbVals,
+		ConsensusParams: &pbParams,
+	}, nil
+}
+
+//-------------------------------------------------------------------------------
+
+// blockSnapshotEntry is a verified entry of a block snapshot.
+type blockSnapshotEntry struct {
+	block      *types.Block
+	validators *types.ValidatorSet
+	params     types.ConsensusParams
+}
+
+// verifyBlockSnapshot decodes the contents of a block snapshot and verifies
+// them against the trusted state and commit at the height of the snapshot:
+// the last block must be the one committed by the commit, and every other
+// block the one referred to by the next block. The validator sets and
+// consensus params must match the hashes of the block headers; note that the
+// hash of the consensus params only covers the block size limits, so the
+// consensus params are not restored.
+func verifyBlockSnapshot(data []byte, state sm.State, commit *types.Commit) ([]*blockSnapshotEntry, error) {
+	var entries []*blockSnapshotEntry
+	r := protoio.NewDelimitedReader(bytes.NewReader(data), maxBlockSnapshotEntrySize)
+	for {
+		var pbEntry ssproto.BlockSnapshotEntry
+		if _, err := r.ReadMsg(&pbEntry); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("invalid block snapshot entry: %w", err)
+		}
+		if pbEntry.Block == nil || pbEntry.Validators == nil || pbEntry.ConsensusParams == nil {
+			return nil, errors.New("incomplete block snapshot entry")
+		}
+		block, err := types.BlockFromProto(pbEntry.Block)
+		if err != nil {
+			return nil, fmt.Errorf("invalid block: %w", err)
+		}
+		vals, err := types.ValidatorSetFromProto(pbEntry.Validators)
+		if err != nil {
+			return nil, fmt.Errorf("invalid validator set at height %d: %w", block.Height, err)
+		}
+		entries = append(entries, &blockSnapshotEntry{
+			block:      block,
+			validators: vals,
+			params:     types.ConsensusParamsFromProto(*pbEntry.ConsensusParams),
+		})
+	}
+	if len(entries) == 0 {
+		return nil, errors.New("empty block snapshot")
+	}
+
+	// Verify the blocks from the trusted commit downwards.
+	trustedID := commit.BlockID
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		height := state.LastBlockHeight - int64(len(entries)-1-i)
+		block := entry.block
+		if block.Height != height {
+			return nil, fmt.Errorf("expected block at height %d, got %d", height, block.Height)
+		}
+		if block.ChainID != state.ChainID {
+			return nil, fmt.Errorf("block at height %d has chain ID %q, expected %q", height, block.ChainID, state.ChainID)
+		}
+		if err := block.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid block at height %d: %w", height, err)
+		}
+		if !bytes.Equal(block.Hash(), trustedID.Hash) {
+			return nil, fmt.Errorf("block at height %d does not match the trusted block %X", height, trustedID.Hash)
+		}
+		if !bytes.Equal(entry.validators.Hash(), block.ValidatorsHash) {
+			return nil, fmt.Errorf("validator set at height %d does not match the block header", height)
+		}
+		if !bytes.Equal(entry.params.Hash(), block.ConsensusHash) {
+			return nil, fmt.Errorf("consensus params at height %d do not match the block header", height)
+		}
+		if err := entry.params.ValidateBasic(); err != nil {
+			return nil, fmt.Errorf("invalid consensus params at height %d: %w", height, err)
+		}
+		trustedID = block.LastBlockID
+	}
+	return entries, nil
+}
+
+// restoreBlockSnapshot saves the verified blocks and validator sets of a block
+// snapshot to the block store and state store. The block store must be empty.
+// The consensus params are not saved, since only the block size limits were
+// verified against the block headers.
+func restoreBlockSnapshot(
+	entries []*blockSnapshotEntry,
+	commit *types.Commit,
+	blockStore sm.BlockStore,
+	stateStore sm.Store,
+) error {
+	if blockStore.Height() != 0 {
+		return fmt.Errorf("block store is not empty (height %d)", blockStore.Height())
+	}
+	for i, entry := range entries {
+		partSet, err := entry.block.MakePartSet(types.BlockPartSizeBytes)
+		if err != nil {
+			return err
+		}
+		// The commit of a block is the last commit of the next block.
+		seenCommit := commit
+		if i < len(entries)-1 {
+			seenCommit = entries[i+1].block.LastCommit
+		}
+		if !seenCommit.BlockID.PartSetHeader.Equals(partSet.Header()) {
+			return fmt.Errorf("block parts at height %d do not match the commit", entry.block.Height)
+		}
+		blockStore.SaveBlock(entry.block, partSet, seenCommit)
+
+		if err := stateStore.SaveValidators(entry.block.Height, entry.validators); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,368 @@
+package statesync
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	abci "github.com/cometbft/cometbft/abci/types"
+	ssproto "github.com/cometbft/cometbft/api/cometbft/statesync/v1"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/protoio"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p"
+	proxymocks "github.com/cometbft/cometbft/proxy/mocks"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// testBlockChain is a chain of blocks, with the state after each block.
+type testBlockChain struct {
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	states     map[int64]sm.State
+}
+
+func newTestBlockChain(t *testing.T, numBlocks int64) *testBlockChain {
+	t.Helper()
+	valSet, _ := types.RandValidatorSet(2, 10)
+	genVals := make([]types.GenesisValidator, len(valSet.Validators))
+	for i, val := range valSet.Validators {
+		genVals[i] = types.GenesisValidator{PubKey: val.PubKey, Power: val.VotingPower}
+	}
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:         "test-chain",
+		GenesisTime:     cmttime.Now(),
+		Validators:      genVals,
+		ConsensusParams: types.DefaultConsensusParams(),
+	})
+	require.NoError(t, err)
+
+	chain := &testBlockChain{
+		blockStore: store.NewBlockStore(dbm.NewMemDB()),
+		stateStore: sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{}),
+		states:     make(map[int64]sm.State),
+	}
+	require.NoError(t, chain.stateStore.Save(state))
+
+	lastCommit := &types.Commit{}
+	for height := int64(1); height <= numBlocks; height++ {
+		txs := []types.Tx{types.Tx("tx1"), types.Tx("tx2")}
+		block := state.MakeBlock(height, txs, lastCommit, nil, state.Validators.Proposer.Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		blockID := types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+		lastCommit = &types.Commit{
+			Height:     height,
+			BlockID:    blockID,
+			Signatures: []types.CommitSig{types.NewCommitSigAbsent(), types.NewCommitSigAbsent()},
+		}
+		chain.blockStore.SaveBlock(block, partSet, lastCommit)
+
+		state.LastBlockHeight = height
+		state.LastBlockID = blockID
+		state.LastBlockTime = block.Time
+		state.LastValidators = state.Validators.Copy()
+		require.NoError(t, chain.stateStore.Save(state))
+		chain.states[height] = state.Copy()
+	}
+	return chain
+}
+
+// snapshotData returns the contents of a block snapshot produced by the
+// snapshotter.
+func snapshotData(t *testing.T, snapshotter *blockSnapshotter, s *snapshot) []byte {
+	t.Helper()
+	var data []byte
+	for i := uint32(0); i < s.Chunks; i++ {
+		chunk := snapshotter.Chunk(s.Height, i)
+		require.NotNil(t, chunk)
+		data = append(data, chunk...)
+	}
+	require.Nil(t, snapshotter.Chunk(s.Height, s.Chunks))
+	return data
+}
+
+func TestBlockSnapshotRestore(t *testing.T) {
+	chain := newTestBlockChain(t, 10)
+	snapshotter := newBlockSnapshotter(chain.blockStore, chain.stateStore, 5)
+
+	s, err := snapshotter.Snapshot(8)
+	require.NoError(t, err)
+	assert.EqualValues(t, 8, s.Height)
+	assert.Equal(t, BlockSnapshotFormat, s.Format)
+	assert.EqualValues(t, 1, s.Chunks)
+
+	// The commit of the snapshot height is provided by the state provider.
+	commit := chain.blockStore.LoadBlockCommit(8)
+	entries, err := verifyBlockSnapshot(snapshotData(t, snapshotter, s), chain.states[8], commit)
+	require.NoError(t, err)
+	require.Len(t, entries, 5)
+
+	blockStore := store.NewBlockStore(dbm.NewMemDB())
+	stateStore := sm.NewStore(dbm.NewMemDB(), sm.StoreOptions{})
+	require.NoError(t, restoreBlockSnapshot(entries, commit, blockStore, stateStore))
+	// The state provider sets the consensus params as changed at the next
+	// height, since it doesn't know when they last changed.
+	state := chain.states[8].Copy()
+	state.LastHeightConsensusParamsChanged = 9
+	require.NoError(t, stateStore.Bootstrap(state))
+
+	assert.EqualValues(t, 4, blockStore.Base())
+	assert.EqualValues(t, 8, blockStore.Height())
+	for height := int64(4); height <= 8; height++ {
+		block, _ := blockStore.LoadBlock(height)
+		expected, _ := chain.blockStore.LoadBlock(height)
+		require.NotNil(t, block)
+		assert.Equal(t, expected.Hash(), block.Hash())
+		assert.Equal(t, chain.blockStore.LoadSeenCommit(height).Hash(), blockStore.LoadSeenCommit(height).Hash())
+
+		vals, err := stateStore.LoadValidators(height)
+		require.NoError(t, err)
+		assert.Equal(t, block.ValidatorsHash.Bytes(), vals.Hash())
+		// Only the hash of the block size limits was verified.
+		_, err = stateStore.LoadConsensusParams(height)
+		require.Error(t, err)
+	}
+
+	// Restoring requires an empty block store.
+	require.Error(t, restoreBlockSnapshot(entries, commit, blockStore, stateStore))
+
+	// The block snapshots produced by the restored node skip the restored
+	// blocks, whose consensus params are unknown.
+	block, _ := chain.blockStore.LoadBlock(9)
+	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(t, err)
+	blockStore.SaveBlock(block, partSet, chain.blockStore.LoadSeenCommit(9))
+	snapshotter = newBlockSnapshotter(blockStore, stateStore, 5)
+	_, err = snapshotter.Snapshot(8)
+	require.Error(t, err)
+	s, err = snapshotter.Snapshot(9)
+	require.NoError(t, err)
+	entries, err = verifyBlockSnapshot(snapshotData(t, snapshotter, s), chain.states[9], chain.blockStore.LoadSeenCommit(9))
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+}
+
+func TestBlockSnapshotPrunedBlocks(t *testing.T) {
+	chain := newTestBlockChain(t, 6)
+	_, _, err := chain.blockStore.PruneBlocks(3, chain.states[6])
+	require.NoError(t, err)
+
+	snapshotter := newBlockSnapshotter(chain.blockStore, chain.stateStore, 5)
+	s, err := snapshotter.Snapshot(6)
+	require.NoError(t, err)
+	entries, err := verifyBlockSnapshot(snapshotData(t, snapshotter, s), chain.states[6], chain.blockStore.LoadSeenCommit(6))
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	_, err = snapshotter.Snapshot(7)
+	require.Error(t, err)
+}
+
+func TestBlockSnapshotSnapshotterKeepsRecentSnapshots(t *testing.T) {
+	chain := newTestBlockChain(t, 5)
+	snapshotter := newBlockSnapshotter(chain.blockStore, chain.stateStore, 2)
+	for _, height := range []uint64{3, 5, 4} {
+		_, err := snapshotter.Snapshot(height)
+		require.NoError(t, err)
+	}
+	assert.Nil(t, snapshotter.Chunk(3, 0))
+	assert.NotNil(t, snapshotter.Chunk(4, 0))
+	assert.NotNil(t, snapshotter.Chunk(5, 0))
+}
+
+func TestVerifyBlockSnapshotInvalid(t *testing.T) {
+	chain := newTestBlockChain(t, 5)
+	snapshotter := newBlockSnapshotter(chain.blockStore, chain.stateStore, 3)
+	s, err := snapshotter.Snapshot(5)
+	require.NoError(t, err)
+	data := snapshotData(t, snapshotter, s)
+	state, commit := chain.states[5], chain.blockStore.LoadSeenCommit(5)
+
+	// reencode decodes the entries of the snapshot, modifies them, and
+	// encodes them back.
+	reencode := func(modify func(entries []*ssproto.BlockSnapshotEntry)) []byte {
+		var entries []*ssproto.BlockSnapshotEntry
+		r := protoio.NewDelimitedReader(bytes.NewReader(data), maxBlockSnapshotEntrySize)
+		for {
+			entry := &ssproto.BlockSnapshotEntry{}
+			if _, err := r.ReadMsg(entry); err != nil {
+				break
+			}
+			entries = append(entries, entry)
+		}
+		modify(entries)
+		buf := new(bytes.Buffer)
+		w := protoio.NewDelimitedWriter(buf)
+		for _, entry := range entries {
+			_, err := w.WriteMsg(entry)
+			require.NoError(t, err)
+		}
+		return buf.Bytes()
+	}
+	otherVals, _ := types.RandValidatorSet(1, 10)
+	pbOtherVals, err := otherVals.ToProto()
+	require.NoError(t, err)
+
+	testCases := map[string]struct {
+		data   []byte
+		commit *types.Commit
+	}{
+		"untrusted commit":  {data, chain.blockStore.LoadSeenCommit(4)},
+		"truncated":         {data[:len(data)-10], commit},
+		"empty":             {[]byte{}, commit},
+		"missing last":      {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[2] = e[1] }), commit},
+		"tampered txs":      {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[1].Block.Data.Txs[0] = []byte("tx3") }), commit},
+		"tampered header":   {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[0].Block.Header.AppHash = []byte("app") }), commit},
+		"tampered vals":     {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[1].Validators = pbOtherVals }), commit},
+		"incomplete entry":  {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[1].ConsensusParams = nil }), commit},
+		"tampered max gas":  {reencode(func(e []*ssproto.BlockSnapshotEntry) { e[0].ConsensusParams.Block.MaxGas = 1 }), commit},
+		"not a block entry": {[]byte{0x02, 0xff, 0xff}, commit},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifyBlockSnapshot(tc.data, state, tc.commit)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestSyncer_SyncBlocks(t *testing.T) {
+	chain := newTestBlockChain(t, 5)
+	snapshotter := newBlockSnapshotter(chain.blockStore, chain.stateStore, 3)
+	s, err := snapshotter.Snapshot(5)
+	require.NoError(t, err)
+	data := snapshotData(t, snapshotter, s)
+
+	syncer, _ := setupOfferSyncer()
+
+	// Peer A serves a snapshot whose contents do not match its hash, peer B
+	// serves the valid snapshot.
+	peerA, peerB := simplePeer("a"), simplePeer("b")
+	serve := func(bz []byte) func(args mock.Arguments) {
+		return func(args mock.Arguments) {
+			msg := args[0].(p2p.Envelope).Message.(*ssproto.ChunkRequest)
+			_, err := syncer.AddChunk(&chunk{Height: msg.Height, Format: msg.Format, Index: msg.Index, Chunk: bz})
+			require.NoError(t, err)
+		}
+	}
+	peerA.On("Send", mock.Anything).Maybe().Run(serve([]byte("invalid"))).Return(true)
+	peerB.On("Send", mock.Anything).Maybe().Run(serve(data)).Return(true)
+
+	invalid := *s
+	invalid.Metadata = []byte{1} // a different snapshot key
+	_, err = syncer.AddBlockSnapshot(peerA, &invalid)
+	require.NoError(t, err)
+	_, err = syncer.AddBlockSnapshot(peerB, s)
+	require.NoError(t, err)
+
+	var restored []byte
+	err = syncer.SyncBlocks(5, func(data []byte) error {
+		restored = data
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, data, restored)
+
+	// There is no snapshot at other heights.
+	err = syncer.SyncBlocks(4, func([]byte) error { return nil })
+	require.ErrorIs(t, err, errNoSnapshots)
+}
+
+func TestReactor_Receive_BlockSnapshots(t *testing.T) {
+	chain := newTestBlockChain(t, 5)
+
+	conn := &proxymocks.AppConnSnapshot{}
+	conn.On("ListSnapshots", mock.Anything, &abci.ListSnapshotsRequest{}).Return(&abci.ListSnapshotsResponse{
+		Snapshots: []*abci.Snapshot{
+			{Height: 3, Format: 1, Chunks: 1, Hash: []byte{3}},
+			{Height: 4, Format: 1, Chunks: 1, Hash: []byte{4, 1}},
+			{Height: 4, Format: 2, Chunks: 1, Hash: []byte{4, 2}},
+			{Height: 5, Format: 1, Chunks: 1, Hash: []byte{5}},
+		},
+	}, nil)
+
+	var (
+		snapshots []*ssproto.SnapshotsResponse
+		chunks    []*ssproto.ChunkResponse
+	)
+	peer := simplePeer("id")
+	peer.On("Send", mock.Anything).Run(func(args mock.Arguments) {
+		e := args[0].(p2p.Envelope)
+		// Marshal to simulate a wire roundtrip.
+		bz, err := proto.Marshal(e.Message)
+		require.NoError(t, err)
+		require.NoError(t, proto.Unmarshal(bz, e.Message))
+		switch msg := e.Message.(type) {
+		case *ssproto.SnapshotsResponse:
+			snapshots = append(snapshots, msg)
+		case *ssproto.ChunkResponse:
+			chunks = append(chunks, msg)
+		}
+	}).Return(true)
+
+	cfg := config.DefaultStateSyncConfig()
+	cfg.SnapshotNumBlocks = 3
+	r := NewReactor(*cfg, conn, nil, NopMetrics(), WithStores(chain.blockStore, chain.stateStore))
+	r.SetLogger(log.TestingLogger())
+
+	// Block snapshots are not produced on request, only in the background
+	// once the reactor is started.
+	appSnapshots, err := r.recentSnapshots(recentSnapshots)
+	require.NoError(t, err)
+	require.Empty(t, r.recentBlockSnapshots(appSnapshots))
+
+	require.NoError(t, r.Start())
+	t.Cleanup(func() {
+		if err := r.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Eventually(t, func() bool {
+		return len(r.recentBlockSnapshots(appSnapshots)) == recentBlockSnapshots
+	}, time.Second, 10*time.Millisecond)
+
+	// Block snapshots are only advertised if requested.
+	r.Receive(p2p.Envelope{ChannelID: SnapshotChannel, Src: peer, Message: &ssproto.SnapshotsRequest{}})
+	require.Len(t, snapshots, 4)
+	snapshots = nil
+
+	r.Receive(p2p.Envelope{
+		ChannelID: SnapshotChannel,
+		Src:       peer,
+		Message:   &ssproto.SnapshotsRequest{IncludeBlockSnapshots: true},
+	})
+	require.Len(t, snapshots, 6)
+	var blockSnapshotHeights []uint64
+	for _, s := range snapshots[4:] {
+		assert.Equal(t, BlockSnapshotFormat, s.Format)
+		blockSnapshotHeights = append(blockSnapshotHeights, s.Height)
+	}
+	assert.Equal(t, []uint64{5, 4}, blockSnapshotHeights)
+
+	r.Receive(p2p.Envelope{
+		ChannelID: ChunkChannel,
+		Src:       peer,
+		Message:   &ssproto.ChunkRequest{Height: 5, Format: BlockSnapshotFormat, Index: 0},
+	})
+	r.Receive(p2p.Envelope{
+		ChannelID: ChunkChannel,
+		Src:       peer,
+		Message:   &ssproto.ChunkRequest{Height: 3, Format: BlockSnapshotFormat, Index: 0},
+	})
+	require.Len(t, chunks, 2)
+	assert.NotEmpty(t, chunks[0].Chunk)
+	assert.True(t, chunks[1].Missing)
+
+	// The app is never asked for block snapshot chunks.
+	conn.AssertNotCalled(t, "LoadSnapshotChunk", mock.Anything, mock.Anything)
+}
@@ -3,6 +3,7 @@ package statesync
 import (
 	"context"
 	"errors"
+	"fmt"
 	"sort"
 	"time"
 
@@ -36,18 +37,39 @@ type Reactor struct {
 	tempDir   string
 	metrics   *Metrics
 
+	// The stores are only set with WithStores, in which case block snapshots
+	// are restored, and served if blockSnapshotter is set.
+	blockStore       sm.BlockStore
+	stateStore       sm.Store
+	blockSnapshotter *blockSnapshotter
+
 	// This will only be set when a state sync is in progress. It is used to feed received
 	// snapshots and chunks into the sync.
 	mtx    cmtsync.RWMutex
 	syncer *syncer
 }
 
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithStores sets the block store and state store of the node, which are
+// used to produce the block snapshots served alongside the snapshots of the
+// app if cfg.SnapshotNumBlocks > 0, and to restore the block snapshot at the
+// height of the restored app snapshot if cfg.RestoreBlockSnapshots is true.
+func WithStores(blockStore sm.BlockStore, stateStore sm.Store) ReactorOption {
+	return func(r *Reactor) {
+		r.blockStore = blockStore
+		r.stateStore = stateStore
+	}
+}
+
 // NewReactor creates a new state sync reactor.
 func NewReactor(
 	cfg config.StateSyncConfig,
 	conn proxy.AppConnSnapshot,
 	connQuery proxy.AppConnQuery,
 	metrics *Metrics,
+	options ...ReactorOption,
 ) *Reactor {
 	r := &Reactor{
 		cfg:       cfg,
@@ -57,6 +79,13 @@ func NewReactor(
 	}
 	r.BaseReactor = *p2p.NewBaseReactor("StateSync", r)
 
+	for _, option := range options {
+		option(r)
+	}
+	if r.blockStore != nil && cfg.SnapshotNumBlocks > 0 {
+		r.blockSnapshotter = newBlockSnapshotter(r.blockStore, r.stateStore, cfg.SnapshotNumBlocks)
+	}
+
 	return r
 }
 
@@ -82,6 +111,9 @@ func (r *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 
 // OnStart implements p2p.Reactor.
 func (r *Reactor) OnStart() error {
+	if r.blockSnapshotter != nil {
+		go r.blockSnapshotRoutine()
+	}
 	return nil
 }
 
@@ -125,6 +157,9 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 				r.Logger.Error("Failed to fetch snapshots", "err", err)
 				return
 			}
+			if msg.IncludeBlockSnapshots {
+				snapshots = append(snapshots, r.recentBlockSnapshots(snapshots)...)
+			}
 			for _, snapshot := range snapshots {
 				r.Logger.Debug("Advertising snapshot", "height", snapshot.Height,
 					"format", snapshot.Format, "peer", e.Src.ID())
@@ -148,7 +183,11 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 				return
 			}
 			r.Logger.Debug("Received snapshot", "height", msg.Height, "format", msg.Format, "peer", e.Src.ID())
-			_, err := r.syncer.AddSnapshot(e.Src, &snapshot{
+			addSnapshot := r.syncer.AddSnapshot
+			if msg.Format == BlockSnapshotFormat {
+				addSnapshot = r.syncer.AddBlockSnapshot
+			}
+			_, err := addSnapshot(e.Src, &snapshot{
 				Height:   msg.Height,
 				Format:   msg.Format,
 				Chunks:   msg.Chunks,
@@ -171,6 +210,23 @@ func (r *Reactor) Receive(e p2p.Envelope) {
 		case *ssproto.ChunkRequest:
 			r.Logger.Debug("Received chunk request", "height", msg.Height, "format", msg.Format,
 				"chunk", msg.Index, "peer", e.Src.ID())
+			if msg.Format == BlockSnapshotFormat {
+				var chunk []byte
+				if r.blockSnapshotter != nil {
+					chunk = r.blockSnapshotter.Chunk(msg.Height, msg.Index)
+				}
+				e.Src.Send(p2p.Envelope{
+					ChannelID: ChunkChannel,
+					Message: &ssproto.ChunkResponse{
+						Height:  msg.Height,
+						Format:  msg.Format,
+						Index:   msg.Index,
+						Chunk:   chunk,
+						Missing: chunk == nil,
+					},
+				})
+				return
+			}
 			resp, err := r.conn.LoadSnapshotChunk(context.TODO(), &abci.LoadSnapshotChunkRequest{
 				Height: msg.Height,
 				Format: msg.Format,
@@ -259,6 +315,68 @@ func (r *Reactor) recentSnapshots(n uint32) ([]*snapshot, error) {
 	return snapshots, nil
 }
 
+// recentBlockSnapshots returns the block snapshots already produced at the
+// heights of the given app snapshots, which must be sorted by decreasing
+// height. They are produced by blockSnapshotRoutine, never on request of a
+// peer.
+func (r *Reactor) recentBlockSnapshots(appSnapshots []*snapshot) []*snapshot {
+	if r.blockSnapshotter == nil {
+		return nil
+	}
+	snapshots := make([]*snapshot, 0, recentBlockSnapshots)
+	for _, s := range appSnapshots {
+		if len(snapshots) == recentBlockSnapshots {
+			break
+		}
+		if len(snapshots) > 0 && snapshots[len(snapshots)-1].Height == s.Height {
+			continue
+		}
+		if blockSnapshot, ok := r.blockSnapshotter.Cached(s.Height); ok {
+			snapshots = append(snapshots, blockSnapshot)
+		}
+	}
+	return snapshots
+}
+
+// blockSnapshotRoutine periodically produces the block snapshots at the
+// heights of the recentBlockSnapshots most recent app snapshots.
+func (r *Reactor) blockSnapshotRoutine() {
+	ticker := time.NewTicker(blockSnapshotInterval)
+	defer ticker.Stop()
+
+	for {
+		r.produceBlockSnapshots()
+		select {
+		case <-ticker.C:
+		case <-r.Quit():
+			return
+		}
+	}
+}
+
+// produceBlockSnapshots produces the block snapshots at the heights of the
+// recentBlockSnapshots most recent app snapshots, if not produced yet.
+func (r *Reactor) produceBlockSnapshots() {
+	appSnapshots, err := r.recentSnapshots(recentSnapshots)
+	if err != nil {
+		r.Logger.Error("Failed to fetch snapshots", "err", err)
+		return
+	}
+	var produced int
+	for i, s := range appSnapshots {
+		if produced == recentBlockSnapshots {
+			break
+		}
+		if i > 0 && appSnapshots[i-1].Height == s.Height {
+			continue
+		}
+		produced++
+		if _, err := r.blockSnapshotter.Snapshot(s.Height); err != nil {
+			r.Logger.Error("Failed to produce block snapshot", "height", s.Height, "err", err)
+		}
+	}
+}
+
 // Sync runs a state sync, returning the new state and last commit at the snapshot height.
 // The caller must store the state and commit in the state database and block store.
 func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration) (sm.State, *types.Commit, error) {
@@ -269,6 +387,7 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 	}
 	r.metrics.Syncing.Set(1)
 	r.syncer = newSyncer(r.cfg, r.Logger, r.conn, r.connQuery, stateProvider, r.tempDir)
+	r.syncer.requestBlockSnapshots = r.restoresBlockSnapshots()
 	r.mtx.Unlock()
 
 	hook := func() {
@@ -277,13 +396,21 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 
 		r.Switch.Broadcast(p2p.Envelope{
 			ChannelID: SnapshotChannel,
-			Message:   &ssproto.SnapshotsRequest{},
+			Message:   &ssproto.SnapshotsRequest{IncludeBlockSnapshots: r.restoresBlockSnapshots()},
 		})
 	}
 
 	hook()
 
 	state, commit, err := r.syncer.SyncAny(discoveryTime, hook)
+	if err == nil && r.restoresBlockSnapshots() {
+		// The node can run without the history, so failing to restore it
+		// is not an error.
+		if err := r.syncBlocks(state, commit); err != nil {
+			r.Logger.Info("Failed to restore a block snapshot, continuing without block history",
+				"height", state.LastBlockHeight, "err", err)
+		}
+	}
 
 	r.mtx.Lock()
 	r.syncer = nil
@@ -291,3 +418,21 @@ func (r *Reactor) Sync(stateProvider StateProvider, discoveryTime time.Duration)
 	r.mtx.Unlock()
 	return state, commit, err
 }
+
+// restoresBlockSnapshots returns true if block snapshots are restored after
+// the app snapshot.
+func (r *Reactor) restoresBlockSnapshots() bool {
+	return r.blockStore != nil && r.cfg.RestoreBlockSnapshots
+}
+
+// syncBlocks restores a block snapshot at the height of the state restored
+// from an app snapshot.
+func (r *Reactor) syncBlocks(state sm.State, commit *types.Commit) error {
+	return r.syncer.SyncBlocks(uint64(state.LastBlockHeight), func(data []byte) error {
+		entries, err := verifyBlockSnapshot(data, state, commit)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errRejectSnapshot, err)
+		}
+		return restoreBlockSnapshot(entries, commit, r.blockStore, r.stateStore)
+	})
+}
@@ -3,6 +3,7 @@ package statesync
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
 	"errors"
 	"fmt"
 	"time"
@@ -57,6 +58,12 @@ type syncer struct {
 	connQuery     proxy.AppConnQuery
 	snapshots     *snapshotPool
 	tempDir       string
+
+	// blockSnapshots contains the block snapshots produced by peers, which
+	// are requested if requestBlockSnapshots is true.
+	blockSnapshots        *snapshotPool
+	requestBlockSnapshots bool
+
 	chunkFetchers int32
 	retryTimeout  time.Duration
 
@@ -80,6 +87,9 @@ func newSyncer(
 		connQuery:     connQuery,
 		snapshots:     newSnapshotPool(),
 		tempDir:       tempDir,
+
+		blockSnapshots: newSnapshotPool(),
+
 		chunkFetchers: cfg.ChunkFetchers,
 		retryTimeout:  cfg.ChunkRequestTimeout,
 	}
@@ -121,13 +131,27 @@ func (s *syncer) AddSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
 	return added, nil
 }
 
+// AddBlockSnapshot adds a block snapshot to the block snapshot pool. It
+// returns true if a new, previously unseen snapshot was accepted and added.
+func (s *syncer) AddBlockSnapshot(peer p2p.Peer, snapshot *snapshot) (bool, error) {
+	added, err := s.blockSnapshots.Add(peer, snapshot)
+	if err != nil {
+		return false, err
+	}
+	if added {
+		s.logger.Info("Discovered new block snapshot", "height", snapshot.Height,
+			"hash", log.NewLazySprintf("%X", snapshot.Hash))
+	}
+	return added, nil
+}
+
 // AddPeer adds a peer to the pool. For now we just keep it simple and send a single request
 // to discover snapshots, later we may want to do retries and stuff.
 func (s *syncer) AddPeer(peer p2p.Peer) {
 	s.logger.Debug("Requesting snapshots from peer", "peer", peer.ID())
 	e := p2p.Envelope{
 		ChannelID: SnapshotChannel,
-		Message:   &ssproto.SnapshotsRequest{},
+		Message:   &ssproto.SnapshotsRequest{IncludeBlockSnapshots: s.requestBlockSnapshots},
 	}
 	peer.Send(e)
 }
@@ -136,6 +160,7 @@ func (s *syncer) AddPeer(peer p2p.Peer) {
 func (s *syncer) RemovePeer(peer p2p.Peer) {
 	s.logger.Debug("Removing peer from sync", "peer", peer.ID())
 	s.snapshots.RemovePeer(peer.ID())
+	s.blockSnapshots.RemovePeer(peer.ID())
 }
 
 // SyncAny tries to sync any of the snapshots in the snapshot pool, waiting to discover further
@@ -316,6 +341,91 @@ func (s *syncer) Sync(snapshot *snapshot, chunks *chunkQueue) (sm.State, *types.
 	return state, commit, nil
 }
 
+// SyncBlocks fetches a block snapshot of the given height, and passes its
+// contents to restore. If a snapshot cannot be fetched, or if restore returns
+// errRejectSnapshot, the snapshot is rejected and the next snapshot of the
+// same height, if any, is tried. It returns errNoSnapshots if no snapshot
+// could be restored.
+func (s *syncer) SyncBlocks(height uint64, restore func(data []byte) error) error {
+	for {
+		var snapshot *snapshot
+		for _, candidate := range s.blockSnapshots.Ranked() {
+			if candidate.Height == height {
+				snapshot = candidate
+				break
+			}
+		}
+		if snapshot == nil {
+			return errNoSnapshots
+		}
+
+		data, err := s.fetchSnapshot(snapshot)
+		if err == nil {
+			err = restore(data)
+		}
+		switch {
+		case err == nil:
+			s.logger.Info("Block snapshot restored", "height", snapshot.Height,
+				"hash", log.NewLazySprintf("%X", snapshot.Hash))
+			return nil
+
+		case errors.Is(err, errRejectSnapshot), errors.Is(err, errTimeout):
+			s.logger.Info("Block snapshot rejected", "height", snapshot.Height,
+				"hash", log.NewLazySprintf("%X", snapshot.Hash), "err", err)
+			s.blockSnapshots.Reject(snapshot)
+
+		default:
+			return err
+		}
+	}
+}
+
+// fetchSnapshot fetches all the chunks of a snapshot, without applying them
+// to the app, and returns its contents once their hash has been verified.
+func (s *syncer) fetchSnapshot(snapshot *snapshot) ([]byte, error) {
+	chunks, err := newChunkQueue(snapshot, s.tempDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create chunk queue: %w", err)
+	}
+	defer chunks.Close()
+
+	s.mtx.Lock()
+	if s.chunks != nil {
+		s.mtx.Unlock()
+		return nil, errors.New("a state sync is already in progress")
+	}
+	s.chunks = chunks
+	s.mtx.Unlock()
+	defer func() {
+		s.mtx.Lock()
+		s.chunks = nil
+		s.mtx.Unlock()
+	}()
+
+	fetchCtx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	for i := int32(0); i < s.chunkFetchers; i++ {
+		go s.fetchChunks(fetchCtx, snapshot, chunks)
+	}
+
+	var data bytes.Buffer
+	for {
+		chunk, err := chunks.Next()
+		if err == errDone {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		data.Write(chunk.Chunk)
+	}
+
+	hash := sha256.Sum256(data.Bytes())
+	if !bytes.Equal(hash[:], snapshot.Hash) {
+		return nil, fmt.Errorf("%w: hash mismatch, expected %X, got %X", errRejectSnapshot, snapshot.Hash, hash)
+	}
+	return data.Bytes(), nil
+}
+
 // offerSnapshot offers a snapshot to the app. It returns various errors depending on the app's
 // response, or nil if the snapshot was accepted.
 func (s *syncer) offerSnapshot(snapshot *snapshot) error {
@@ -457,7 +567,11 @@ func (s *syncer) fetchChunks(ctx context.Context, snapshot *snapshot, chunks *ch
 
 // requestChunk requests a chunk from a peer.
 func (s *syncer) requestChunk(snapshot *snapshot, chunk uint32) {
-	peer := s.snapshots.GetPeer(snapshot)
+	pool := s.snapshots
+	if snapshot.Format == BlockSnapshotFormat {
+		pool = s.blockSnapshots
+	}
+	peer := pool.GetPeer(snapshot)
 	if peer == nil {
 		s.logger.Error("No valid peers found for snapshot", "height", snapshot.Height,
 			"format", snapshot.Format, "hash", log.NewLazySprintf("%X", snapshot.Hash))
@@ -58,6 +58,8 @@ type BlockStore struct {
 	mtx    cmtsync.RWMutex
 	base   int64
 	height int64
+	// lowest height whose extended commit may still be stored
+	extCommitBase int64
 
 	blocksDeleted      int64
 	compact            bool
@@ -87,10 +89,11 @@ func NewBlockStore(db dbm.DB, options ...BlockStoreOption) *BlockStore {
 	bs := LoadBlockStoreState(db)
 
 	bStore := &BlockStore{
-		base:    bs.Base,
-		height:  bs.Height,
-		db:      db,
-		metrics: NopMetrics(),
+		base:          bs.Base,
+		height:        bs.Height,
+		extCommitBase: bs.ExtCommitBase,
+		db:            db,
+		metrics:       NopMetrics(),
 	}
 
 	for _, option := range options {
@@ -121,6 +124,16 @@ func (bs *BlockStore) Height() int64 {
 	return bs.height
 }
 
+// ExtendedCommitBase returns the lowest height whose extended commit may
+// still be stored, or 0 for empty block stores. The extended commits are
+// pruned with the blocks, and can be pruned separately with
+// PruneExtendedCommits.
+func (bs *BlockStore) ExtendedCommitBase() int64 {
+	bs.mtx.RLock()
+	defer bs.mtx.RUnlock()
+	return bs.extCommitBase
+}
+
 // Size returns the number of blocks in the block store.
 func (bs *BlockStore) Size() int64 {
 	bs.mtx.RLock()
@@ -393,6 +406,7 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 		defer batch.Close()
 		defer bs.mtx.Unlock()
 		bs.base = base
+		bs.extCommitBase = max(bs.extCommitBase, base)
 		return bs.saveStateAndWriteDB(batch, "failed to prune")
 	}
 
@@ -430,6 +444,9 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 		if err := batch.Delete(calcSeenCommitKey(h)); err != nil {
 			return 0, -1, err
 		}
+		if err := batch.Delete(calcExtCommitKey(h)); err != nil {
+			return 0, -1, err
+		}
 		for p := 0; p < int(meta.BlockID.PartSetHeader.Total); p++ {
 			if err := batch.Delete(calcBlockPartKey(h, p)); err != nil {
 				return 0, -1, err
@@ -469,6 +486,61 @@ func (bs *BlockStore) PruneBlocks(height int64, state sm.State) (uint64, int64,
 	return pruned, evidencePoint, err
 }
 
+// PruneExtendedCommits removes the extended commits up to (but not including)
+// a height, keeping the blocks. It returns the number of heights pruned.
+//
+// The extended commit of the latest height is needed by consensus on restart,
+// and those of the heights served to the peers doing block sync.
+func (bs *BlockStore) PruneExtendedCommits(height int64) (uint64, error) {
+	if height <= 0 {
+		return 0, errors.New("height must be greater than 0")
+	}
+	bs.mtx.RLock()
+	if height > bs.height {
+		bs.mtx.RUnlock()
+		return 0, fmt.Errorf("cannot prune beyond the latest height %v", bs.height)
+	}
+	base := bs.extCommitBase
+	bs.mtx.RUnlock()
+	if height <= base {
+		return 0, nil
+	}
+
+	defer addTimeSample(bs.metrics.BlockStoreAccessDurationSeconds.With("method", "prune_ext_commits"), time.Now())()
+
+	pruned := uint64(0)
+	batch := bs.db.NewBatch()
+	defer batch.Close()
+	flush := func(batch dbm.Batch, base int64) error {
+		bs.mtx.Lock()
+		defer batch.Close()
+		defer bs.mtx.Unlock()
+		bs.extCommitBase = max(bs.extCommitBase, base)
+		return bs.saveStateAndWriteDB(batch, "failed to prune extended commits")
+	}
+
+	for h := base; h < height; h++ {
+		if err := batch.Delete(calcExtCommitKey(h)); err != nil {
+			return 0, err
+		}
+		pruned++
+
+		// flush every 1000 heights to avoid batches becoming too large
+		if pruned%1000 == 0 {
+			if err := flush(batch, h+1); err != nil {
+				return 0, err
+			}
+			batch = bs.db.NewBatch()
+			defer batch.Close()
+		}
+	}
+
+	if err := flush(batch, height); err != nil {
+		return 0, err
+	}
+	return pruned, nil
+}
+
 // SaveBlock persists the given block, blockParts, and seenCommit to the underlying db.
 // blockParts: Must be parts of the block
 // seenCommit: The +2/3 precommits that were seen which committed at height.
@@ -495,6 +567,9 @@ func (bs *BlockStore) SaveBlock(block *types.Block, blockParts *types.PartSet, s
 	if bs.base == 0 {
 		bs.base = block.Height
 	}
+	if bs.extCommitBase == 0 {
+		bs.extCommitBase = block.Height
+	}
 
 	// Save new BlockStoreState descriptor. This also flushes the database.
 	err := bs.saveStateAndWriteDB(batch, "failed to save block")
@@ -545,6 +620,9 @@ func (bs *BlockStore) SaveBlockWithExtendedCommit(block *types.Block, blockParts
 	if bs.base == 0 {
 		bs.base = height
 	}
+	if bs.extCommitBase == 0 {
+		bs.extCommitBase = height
+	}
 
 	// Save new BlockStoreState descriptor. This also flushes the database.
 	err := bs.saveStateAndWriteDB(batch, "failed to save block with extended commit")
@@ -659,8 +737,9 @@ func (bs *BlockStore) saveBlockPart(height int64, index int, part *types.Part, b
 // Contract: the caller MUST have, at least, a read lock on `bs`.
 func (bs *BlockStore) saveStateAndWriteDB(batch dbm.Batch, errMsg string) error {
 	bss := cmtstore.BlockStoreState{
-		Base:   bs.base,
-		Height: bs.height,
+		Base:          bs.base,
+		Height:        bs.height,
+		ExtCommitBase: bs.extCommitBase,
 	}
 	start := time.Now()
 
@@ -758,6 +837,12 @@ func LoadBlockStoreState(db dbm.DB) cmtstore.BlockStoreState {
 	if bsj.Height > 0 && bsj.Base == 0 {
 		bsj.Base = 1
 	}
+
+	// Backwards compatibility with persisted data from before ExtCommitBase
+	// existed.
+	if bsj.Height > 0 && bsj.ExtCommitBase == 0 {
+		bsj.ExtCommitBase = bsj.Base
+	}
 	return bsj
 }
 
@@ -83,10 +83,14 @@ func TestLoadBlockStoreState(t *testing.T) {
 	testCases := []blockStoreTest{
 		{
 			"success", &cmtstore.BlockStoreState{Base: 100, Height: 1000},
-			cmtstore.BlockStoreState{Base: 100, Height: 1000},
+			cmtstore.BlockStoreState{Base: 100, Height: 1000, ExtCommitBase: 100},
+		},
+		{
+			"extended commit base", &cmtstore.BlockStoreState{Base: 100, Height: 1000, ExtCommitBase: 500},
+			cmtstore.BlockStoreState{Base: 100, Height: 1000, ExtCommitBase: 500},
 		},
 		{"empty", &cmtstore.BlockStoreState{}, cmtstore.BlockStoreState{}},
-		{"no base", &cmtstore.BlockStoreState{Height: 1000}, cmtstore.BlockStoreState{Base: 1, Height: 1000}},
+		{"no base", &cmtstore.BlockStoreState{Height: 1000}, cmtstore.BlockStoreState{Base: 1, Height: 1000, ExtCommitBase: 1}},
 	}
 
 	for _, tc := range testCases {
@@ -810,6 +814,11 @@ func TestPruneBlocks(t *testing.T) {
 		require.NotNil(t, meta)
 	}
 
+	// The extended commits are pruned with the blocks
+	assert.EqualValues(t, 1200, bs.ExtendedCommitBase())
+	require.Nil(t, bs.LoadBlockExtendedCommit(1199))
+	require.NotNil(t, bs.LoadBlockExtendedCommit(1200))
+
 	// Pruning below the current base should error
 	_, _, err = bs.PruneBlocks(1199, state)
 	require.Error(t, err)
@@ -851,6 +860,52 @@ func TestPruneBlocks(t *testing.T) {
 	assert.Nil(t, meta)
 }
 
+func TestPruneExtendedCommits(t *testing.T) {
+	state, bs, _, _, cleanup, _ := makeStateAndBlockStoreAndIndexers()
+	defer cleanup()
+
+	_, err := bs.PruneExtendedCommits(1)
+	require.Error(t, err)
+
+	// make more than 1000 blocks, to test batch deletions
+	for h := int64(1); h <= 1500; h++ {
+		block := state.MakeBlock(h, test.MakeNTxs(h, 1), new(types.Commit), nil, state.Validators.GetProposer().Address)
+		partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+		require.NoError(t, err)
+		bs.SaveBlockWithExtendedCommit(block, partSet, makeTestExtCommit(h, cmttime.Now()))
+	}
+	assert.EqualValues(t, 1, bs.ExtendedCommitBase())
+
+	pruned, err := bs.PruneExtendedCommits(1200)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1199, pruned)
+	assert.EqualValues(t, 1200, bs.ExtendedCommitBase())
+
+	// the blocks and their commits are kept
+	assert.EqualValues(t, 1, bs.Base())
+	for h := int64(1); h <= 1500; h++ {
+		block, _ := bs.LoadBlock(h)
+		require.NotNil(t, block)
+		require.NotNil(t, bs.LoadSeenCommit(h))
+		if h < 1200 {
+			require.Nil(t, bs.LoadBlockExtendedCommit(h))
+		} else {
+			require.NotNil(t, bs.LoadBlockExtendedCommit(h))
+		}
+	}
+
+	// pruning below the base is a no-op, beyond the height an error
+	pruned, err = bs.PruneExtendedCommits(1100)
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, pruned)
+	_, err = bs.PruneExtendedCommits(1501)
+	require.Error(t, err)
+
+	// the base is persisted
+	bs2 := NewBlockStore(bs.db)
+	assert.EqualValues(t, 1200, bs2.ExtendedCommitBase())
+}
+
 func TestLoadBlockMeta(t *testing.T) {
 	bs, db := newInMemoryBlockStore()
 	height := int64(10)
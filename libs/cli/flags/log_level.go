@@ -20,6 +20,16 @@ const (
 //
 //	ParseLogLevel("consensus:debug,mempool:debug,*:error", log.NewTMLogger(os.Stdout), "info")
 func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (log.Logger, error) {
+	options, err := ParseLogLevelOptions(lvl, defaultLogLevelValue)
+	if err != nil {
+		return nil, err
+	}
+	return log.NewFilter(logger, options...), nil
+}
+
+// ParseLogLevelOptions parses a complex log level like ParseLogLevel, and
+// returns the options of the corresponding filter.
+func ParseLogLevelOptions(lvl string, defaultLogLevelValue string) ([]log.Option, error) {
 	if lvl == "" {
 		return nil, cmterrors.ErrRequiredField{Field: "LogLevel"}
 	}
@@ -85,5 +95,5 @@ func ParseLogLevel(lvl string, logger log.Logger, defaultLogLevelValue string) (
 		options = append(options, option)
 	}
 
-	return log.NewFilter(logger, options...), nil
+	return options, nil
 }
@@ -0,0 +1,87 @@
+package log
+
+import "sync/atomic"
+
+// ReloadableFilter is a filter whose options can be replaced while the logger
+// and the loggers derived from it with With are in use, e.g. to change the log
+// level without restarting the process.
+type ReloadableFilter struct {
+	next Logger
+	// keyvals of the successive With calls, which are replayed on the root
+	// filter to find the level of this logger.
+	withs [][]interface{}
+	root  *atomic.Pointer[filter]
+	// filter derived from root with withs, computed lazily.
+	derived atomic.Pointer[derivedFilter]
+}
+
+type derivedFilter struct {
+	root   *filter
+	filter *filter
+}
+
+var _ Logger = (*ReloadableFilter)(nil)
+
+// NewReloadableFilter wraps next and implements filtering like NewFilter,
+// except the options can later be replaced with SetOptions.
+func NewReloadableFilter(next Logger, options ...Option) *ReloadableFilter {
+	f := &ReloadableFilter{
+		next: next,
+		root: new(atomic.Pointer[filter]),
+	}
+	f.SetOptions(options...)
+	return f
+}
+
+// SetOptions replaces the options of the filter, for this logger and all the
+// loggers sharing the same root.
+func (f *ReloadableFilter) SetOptions(options ...Option) {
+	f.root.Store(NewFilter(NewNopLogger(), options...).(*filter))
+}
+
+func (f *ReloadableFilter) Info(msg string, keyvals ...interface{}) {
+	if f.allowed()&levelInfo == 0 {
+		return
+	}
+	f.next.Info(msg, keyvals...)
+}
+
+func (f *ReloadableFilter) Debug(msg string, keyvals ...interface{}) {
+	if f.allowed()&levelDebug == 0 {
+		return
+	}
+	f.next.Debug(msg, keyvals...)
+}
+
+func (f *ReloadableFilter) Error(msg string, keyvals ...interface{}) {
+	if f.allowed()&levelError == 0 {
+		return
+	}
+	f.next.Error(msg, keyvals...)
+}
+
+// With implements Logger. The level of the returned logger is determined from
+// the keyvals as in filter.With, using the current options of the root.
+func (f *ReloadableFilter) With(keyvals ...interface{}) Logger {
+	withs := make([][]interface{}, len(f.withs), len(f.withs)+1)
+	copy(withs, f.withs)
+	return &ReloadableFilter{
+		next:  f.next.With(keyvals...),
+		withs: append(withs, keyvals),
+		root:  f.root,
+	}
+}
+
+func (f *ReloadableFilter) allowed() level {
+	root := f.root.Load()
+	derived := f.derived.Load()
+	if derived == nil || derived.root != root {
+		fl := root
+		for _, keyvals := range f.withs {
+			fl = fl.With(keyvals...).(*filter)
+		}
+		derived = &derivedFilter{root: root, filter: fl}
+		f.derived.Store(derived)
+	}
+	return derived.filter.allowed
+}
@@ -0,0 +1,48 @@
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+func TestReloadableFilter(t *testing.T) {
+	var buf bytes.Buffer
+
+	filter := log.NewReloadableFilter(log.NewTMJSONLoggerNoTS(&buf), log.AllowError())
+	logger := filter.With("module", "consensus")
+
+	logger.Info("foo")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	// The derived loggers follow the new options.
+	filter.SetOptions(log.AllowError(), log.AllowDebugWith("module", "consensus"))
+	logger.Debug("foo")
+	want := `{"_msg":"foo","level":"debug","module":"consensus"}`
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	buf.Reset()
+	filter.With("module", "p2p").Info("foo")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+
+	// Loggers derived from derived loggers use the new options too.
+	filter.SetOptions(log.AllowInfo())
+	logger.With("user", "Sam").Info("foo")
+	want = `{"_msg":"foo","level":"info","module":"consensus","user":"Sam"}`
+	if have := strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+	buf.Reset()
+	logger.Debug("foo")
+	if want, have := ``, strings.TrimSpace(buf.String()); want != have {
+		t.Errorf("\nwant '%s'\nhave '%s'", want, have)
+	}
+}
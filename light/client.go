@@ -145,6 +145,9 @@ type Client struct {
 	primary provider.Provider
 	// Providers used to "witness" new headers.
 	witnesses []provider.Provider
+	// Scores the providers when choosing a new primary.
+	witnessScorer  WitnessScorer
+	witnessManager *witnessManager
 
 	// Where trusted light blocks are stored.
 	trustedStore store.Store
@@ -227,6 +230,7 @@ func NewClientFromTrustedStore(
 		maxBlockLag:      defaultMaxBlockLag,
 		primary:          primary,
 		witnesses:        witnesses,
+		witnessScorer:    DefaultWitnessScorer(),
 		trustedStore:     trustedStore,
 		pruningSize:      defaultPruningSize,
 		confirmationFn:   func(action string) bool { return true },
@@ -237,6 +241,7 @@ func NewClientFromTrustedStore(
 	for _, o := range options {
 		o(c)
 	}
+	c.witnessManager = newWitnessManager(c.witnessScorer)
 
 	// Validate the number of witnesses.
 	if len(c.witnesses) == 0 {
@@ -868,6 +873,20 @@ func (c *Client) Witnesses() []provider.Provider {
 	return c.witnesses
 }
 
+// ProviderStats returns the statistics gathered about the primary, followed
+// by those of the witnesses.
+func (c *Client) ProviderStats() []ProviderStats {
+	c.providerMutex.Lock()
+	defer c.providerMutex.Unlock()
+
+	stats := make([]ProviderStats, 0, len(c.witnesses)+1)
+	stats = append(stats, c.witnessManager.snapshot(c.primary, true))
+	for _, w := range c.witnesses {
+		stats = append(stats, c.witnessManager.snapshot(w, false))
+	}
+	return stats
+}
+
 // Cleanup removes all the data (headers and validator sets) stored. Note: the
 // client must be stopped at this point.
 func (c *Client) Cleanup() error {
@@ -989,7 +1008,9 @@ func (c *Client) backwards(
 //     any other error, the primary is permanently dropped and is replaced by a witness.
 func (c *Client) lightBlockFromPrimary(ctx context.Context, height int64) (*types.LightBlock, error) {
 	c.providerMutex.Lock()
+	start := time.Now()
 	l, err := c.primary.LightBlock(ctx, height)
+	c.witnessManager.recordRequest(c.primary, time.Since(start), err)
 	c.providerMutex.Unlock()
 
 	switch err {
@@ -1026,6 +1047,10 @@ func (c *Client) removeWitnesses(indexes []int) error {
 	// order so as to not affect the indexes themselves
 	sort.Ints(indexes)
 	for i := len(indexes) - 1; i >= 0; i-- {
+		// The stats of a witness promoted to primary are kept.
+		if w := c.witnesses[indexes[i]]; w != c.primary {
+			c.witnessManager.remove(w)
+		}
 		c.witnesses[indexes[i]] = c.witnesses[len(c.witnesses)-1]
 		c.witnesses = c.witnesses[:len(c.witnesses)-1]
 	}
@@ -1039,10 +1064,12 @@ type witnessResponse struct {
 	err          error
 }
 
-// findNewPrimary concurrently sends a light block request, promoting the first witness to return
-// a valid light block as the new primary. The remove option indicates whether the primary should be
-// entire removed or just appended to the back of the witnesses list. This method also handles witness
-// errors. If no witness is available, it returns the last error of the witness.
+// findNewPrimary concurrently sends a light block request to all witnesses, promoting the witness with
+// the highest score among those returning a valid light block as the new primary. A witness returning a
+// light block is promoted as soon as no witness with a higher score is still pending. The remove option
+// indicates whether the primary should be entire removed or just appended to the back of the witnesses
+// list. This method also handles witness errors. If no witness is available, it returns the last error
+// of the witness.
 func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool) (*types.LightBlock, error) {
 	c.providerMutex.Lock()
 	defer c.providerMutex.Unlock()
@@ -1056,58 +1083,58 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 		witnessesToRemove []int
 		lastError         error
 		wg                sync.WaitGroup
+
+		scores  = make([]float64, len(c.witnesses))
+		pending = make(map[int]struct{}, len(c.witnesses))
+		best    *witnessResponse
 	)
 
 	// send out a light block request to all witnesses
 	subctx, cancel := context.WithCancel(ctx)
 	defer cancel()
-	for index := range c.witnesses {
+	for index, witness := range c.witnesses {
+		scores[index] = c.witnessManager.score(witness)
+		pending[index] = struct{}{}
+
 		wg.Add(1)
-		go func(witnessIndex int, witnessResponsesC chan witnessResponse) {
+		go func(witnessIndex int, witness provider.Provider, witnessResponsesC chan witnessResponse) {
 			defer wg.Done()
 
-			lb, err := c.witnesses[witnessIndex].LightBlock(subctx, height)
+			start := time.Now()
+			lb, err := witness.LightBlock(subctx, height)
+			c.witnessManager.recordRequest(witness, time.Since(start), err)
 			witnessResponsesC <- witnessResponse{lb, witnessIndex, err}
-		}(index, witnessResponsesC)
+		}(index, witness, witnessResponsesC)
+	}
+
+	// higherScorePending returns true if a witness with a higher score than
+	// the best respondent has not responded yet.
+	higherScorePending := func() bool {
+		for index := range pending {
+			if scores[index] > scores[best.witnessIndex] {
+				return true
+			}
+		}
+		return false
 	}
 
 	// process all the responses as they come in
 	for i := 0; i < cap(witnessResponsesC); i++ {
 		response := <-witnessResponsesC
+		delete(pending, response.witnessIndex)
+
 		switch response.err {
-		// success! We have found a new primary
+		// success! We have found a candidate for the new primary
 		case nil:
-			cancel() // cancel all remaining requests to other witnesses
-
-			wg.Wait() // wait for all goroutines to finish
-
-			// if we are not intending on removing the primary then append the old primary to the end of the witness slice
-			if !remove {
-				c.witnesses = append(c.witnesses, c.primary)
+			if best == nil || scores[response.witnessIndex] > scores[best.witnessIndex] {
+				best = &response
 			}
 
-			// promote respondent as the new primary
-			c.logger.Debug("found new primary", "primary", c.witnesses[response.witnessIndex])
-			c.primary = c.witnesses[response.witnessIndex]
-
-			// add promoted witness to the list of witnesses to be removed
-			witnessesToRemove = append(witnessesToRemove, response.witnessIndex)
-
-			// remove witnesses marked as bad (the client must do this before we alter the witness slice and change the indexes
-			// of witnesses). Removal is done in descending order
-			if err := c.removeWitnesses(witnessesToRemove); err != nil {
-				return nil, err
-			}
-
-			// return the light block that new primary responded with
-			return response.lb, nil
-
 		// process benign errors by logging them only
 		case provider.ErrNoResponse, provider.ErrLightBlockNotFound, provider.ErrHeightTooHigh:
 			lastError = response.err
 			c.logger.Debug("error on light block request from witness",
 				"error", response.err, "primary", c.witnesses[response.witnessIndex])
-			continue
 
 		// process malevolent errors like ErrUnreliableProvider and ErrBadLightBlock by removing the witness
 		default:
@@ -1116,6 +1143,38 @@ func (c *Client) findNewPrimary(ctx context.Context, height int64, remove bool)
 				"error", response.err, "primary", c.witnesses[response.witnessIndex])
 			witnessesToRemove = append(witnessesToRemove, response.witnessIndex)
 		}
+
+		if best == nil || higherScorePending() {
+			continue
+		}
+
+		cancel() // cancel all remaining requests to other witnesses
+
+		wg.Wait() // wait for all goroutines to finish
+
+		// if we are not intending on removing the primary then append the old primary to the end of the witness slice
+		if !remove {
+			c.witnesses = append(c.witnesses, c.primary)
+		} else {
+			c.witnessManager.remove(c.primary)
+		}
+
+		// promote respondent as the new primary
+		c.logger.Debug("found new primary", "primary", c.witnesses[best.witnessIndex],
+			"score", scores[best.witnessIndex])
+		c.primary = c.witnesses[best.witnessIndex]
+
+		// add promoted witness to the list of witnesses to be removed
+		witnessesToRemove = append(witnessesToRemove, best.witnessIndex)
+
+		// remove witnesses marked as bad (the client must do this before we alter the witness slice and change the indexes
+		// of witnesses). Removal is done in descending order
+		if err := c.removeWitnesses(witnessesToRemove); err != nil {
+			return nil, err
+		}
+
+		// return the light block that new primary responded with
+		return best.lb, nil
 	}
 
 	// remove witnesses marked as bad. Removal is done in descending order
@@ -1154,6 +1213,7 @@ func (c *Client) compareFirstHeaderWithWitnesses(ctx context.Context, h *types.S
 		case nil:
 			continue
 		case errConflictingHeaders:
+			c.witnessManager.recordAgreement(c.witnesses[e.WitnessIndex], false)
 			c.logger.Error(fmt.Sprintf(`Witness #%d has a different header. Please check primary is correct
 and remove witness. Otherwise, use the different primary`, e.WitnessIndex), "witness", c.witnesses[e.WitnessIndex])
 			return err
@@ -59,6 +59,7 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 		case nil: // at least one header matched
 			headerMatched = true
 		case errConflictingHeaders:
+			c.witnessManager.recordAgreement(c.witnesses[e.WitnessIndex], false)
 			// We have conflicting headers. This could possibly imply an attack on the light client.
 			// First we need to verify the witness's header using the same skipping verification and then we
 			// need to find the point that the headers diverge and examine this for any evidence of an attack.
@@ -116,7 +117,9 @@ func (c *Client) detectDivergence(ctx context.Context, primaryTrace []*types.Lig
 func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan error, h *types.SignedHeader,
 	witness provider.Provider, witnessIndex int,
 ) {
+	start := time.Now()
 	lightBlock, err := witness.LightBlock(ctx, h.Height)
+	c.witnessManager.recordRequest(witness, time.Since(start), err)
 	switch err {
 	// no error means we move on to checking the hash of the two headers
 	case nil:
@@ -201,6 +204,7 @@ func (c *Client) compareNewHeaderWithWitness(ctx context.Context, errc chan erro
 	}
 
 	c.logger.Debug("Matching header received by witness", "height", h.Height, "witness", witnessIndex)
+	c.witnessManager.recordAgreement(witness, true)
 	errc <- nil
 }
 
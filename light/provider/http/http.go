@@ -60,6 +60,11 @@ func (p *http) ChainID() string {
 	return p.chainID
 }
 
+// ID returns the address of the remote node.
+func (p *http) ID() string {
+	return p.client.Remote()
+}
+
 func (p *http) String() string {
 	return fmt.Sprintf("http{%s}", p.client.Remote())
 }
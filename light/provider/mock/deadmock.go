@@ -9,15 +9,18 @@ import (
 
 type deadMock struct {
 	chainID string
+	id      string
 }
 
 // NewDeadMock creates a mock provider that always errors.
 func NewDeadMock(chainID string) provider.Provider {
-	return &deadMock{chainID: chainID}
+	return &deadMock{chainID: chainID, id: nextID()}
 }
 
 func (p *deadMock) ChainID() string { return p.chainID }
 
+func (p *deadMock) ID() string { return p.id }
+
 func (p *deadMock) String() string { return "deadMock" }
 
 func (p *deadMock) LightBlock(context.Context, int64) (*types.LightBlock, error) {
@@ -6,14 +6,23 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/cometbft/cometbft/light/provider"
 	"github.com/cometbft/cometbft/types"
 )
 
+// lastID is used to give each mock provider a unique ID.
+var lastID atomic.Uint64
+
+func nextID() string {
+	return fmt.Sprintf("mock-%d", lastID.Add(1))
+}
+
 type Mock struct {
 	chainID string
+	id      string
 
 	mtx              sync.Mutex
 	headers          map[int64]*types.SignedHeader
@@ -35,6 +44,7 @@ func New(chainID string, headers map[int64]*types.SignedHeader, vals map[int64]*
 	}
 	return &Mock{
 		chainID:          chainID,
+		id:               nextID(),
 		headers:          headers,
 		vals:             vals,
 		evidenceToReport: make(map[string]types.Evidence),
@@ -47,6 +57,11 @@ func (p *Mock) ChainID() string {
 	return p.chainID
 }
 
+// ID returns the unique ID given to the provider on creation.
+func (p *Mock) ID() string {
+	return p.id
+}
+
 func (p *Mock) String() string {
 	var headers strings.Builder
 	for _, h := range p.headers {
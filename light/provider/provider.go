@@ -12,6 +12,9 @@ type Provider interface {
 	// ChainID returns the blockchain ID.
 	ChainID() string
 
+	// ID returns a string uniquely identifying the provider, e.g. its address.
+	ID() string
+
 	// LightBlock returns the LightBlock that corresponds to the given
 	// height.
 	//
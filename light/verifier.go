@@ -52,6 +52,21 @@ func VerifyNonAdjacent(
 		return ErrInvalidHeader{err}
 	}
 
+	// The aggregated signature of an aggregated commit can only be verified
+	// with the keys of all the signers, so it is verified against
+	// untrustedVals before tallying the trusted voting power.
+	if untrustedHeader.Commit.IsAggregated() {
+		err := trustedVals.VerifyAggregatedCommitLightTrusting(trustedHeader.ChainID, untrustedVals,
+			untrustedHeader.Commit, trustLevel)
+		if err != nil {
+			if e, ok := err.(types.ErrNotEnoughVotingPowerSigned); ok {
+				return ErrNewValSetCantBeTrusted{e}
+			}
+			return ErrInvalidHeader{err}
+		}
+		return nil
+	}
+
 	// Ensure that +`trustLevel` (default 1/3) or more of last trusted validators signed correctly.
 	err := trustedVals.VerifyCommitLightTrusting(trustedHeader.ChainID, untrustedHeader.Commit, trustLevel)
 	if err != nil {
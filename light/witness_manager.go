@@ -0,0 +1,164 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/light/provider"
+)
+
+// latencyWeight is the weight of the latest latency in the moving average of
+// the latencies of a provider.
+const latencyWeight = 0.2
+
+// ProviderStats contains the statistics gathered by the light client about a
+// provider, as well as the resulting score.
+type ProviderStats struct {
+	Provider provider.Provider
+	// Primary is true if the provider is the current primary.
+	Primary bool
+
+	// Requests is the number of light block requests sent to the provider,
+	// and Errors the number of requests that failed.
+	Requests uint64
+	Errors   uint64
+	// Agreements is the number of times the provider returned the same header
+	// as the primary, and Disagreements the number of times it returned a
+	// conflicting header.
+	Agreements    uint64
+	Disagreements uint64
+	// Latency is a moving average of the latency of the successful requests.
+	Latency time.Duration
+	// LastError is the error returned by the last failed request, if any.
+	LastError error
+
+	// Score is the score given by the client's WitnessScorer.
+	Score float64
+}
+
+// WitnessScorer scores providers based on their statistics. Providers with a
+// higher score are preferred when choosing a new primary.
+type WitnessScorer interface {
+	Score(stats ProviderStats) float64
+}
+
+// WitnessScorerFunc is a function implementing WitnessScorer.
+type WitnessScorerFunc func(stats ProviderStats) float64
+
+// Score implements WitnessScorer.
+func (f WitnessScorerFunc) Score(stats ProviderStats) float64 {
+	return f(stats)
+}
+
+// DefaultWitnessScorer returns the scorer used by default. The score, between
+// 0 and 1, is the product of the rate of successful requests, the rate of
+// agreements with the primary, and a latency factor halving the score for
+// every second of latency. Both rates are estimated assuming one success and
+// one failure beforehand, so that providers without statistics get a neutral
+// score.
+func DefaultWitnessScorer() WitnessScorer {
+	return WitnessScorerFunc(func(stats ProviderStats) float64 {
+		successRate := float64(stats.Requests-stats.Errors+1) / float64(stats.Requests+2)
+		agreementRate := float64(stats.Agreements+1) / float64(stats.Agreements+stats.Disagreements+2)
+		latencyFactor := 1 / (1 + stats.Latency.Seconds())
+		return successRate * agreementRate * latencyFactor
+	})
+}
+
+// WitnessScoring option can be used to set the scorer used to rank the
+// providers when choosing a new primary. Default: DefaultWitnessScorer().
+func WitnessScoring(scorer WitnessScorer) Option {
+	return func(c *Client) {
+		c.witnessScorer = scorer
+	}
+}
+
+// witnessManager keeps track of the reliability of the providers.
+type witnessManager struct {
+	mtx    cmtsync.Mutex
+	scorer WitnessScorer
+	// stats by provider ID
+	stats map[string]*ProviderStats
+}
+
+func newWitnessManager(scorer WitnessScorer) *witnessManager {
+	return &witnessManager{
+		scorer: scorer,
+		stats:  make(map[string]*ProviderStats),
+	}
+}
+
+// NOTE: requires a mtx lock.
+func (m *witnessManager) get(p provider.Provider) *ProviderStats {
+	stats, ok := m.stats[p.ID()]
+	if !ok {
+		stats = &ProviderStats{Provider: p}
+		m.stats[p.ID()] = stats
+	}
+	return stats
+}
+
+// recordRequest records the outcome of a light block request. Requests
+// canceled by the light client itself are not recorded.
+func (m *witnessManager) recordRequest(p provider.Provider, latency time.Duration, err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	stats := m.get(p)
+	stats.Requests++
+	if err != nil {
+		stats.Errors++
+		stats.LastError = err
+		return
+	}
+	if stats.Latency == 0 {
+		stats.Latency = latency
+	} else {
+		stats.Latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(stats.Latency))
+	}
+}
+
+// recordAgreement records whether the provider returned the same header as
+// the primary.
+func (m *witnessManager) recordAgreement(p provider.Provider, agreed bool) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	stats := m.get(p)
+	if agreed {
+		stats.Agreements++
+	} else {
+		stats.Disagreements++
+	}
+}
+
+func (m *witnessManager) score(p provider.Provider) float64 {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	return m.scorer.Score(*m.get(p))
+}
+
+// remove forgets about a provider which is no longer used.
+func (m *witnessManager) remove(p provider.Provider) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	delete(m.stats, p.ID())
+}
+
+func (m *witnessManager) snapshot(p provider.Provider, primary bool) ProviderStats {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	stats := *m.get(p)
+	stats.Primary = primary
+	stats.Score = m.scorer.Score(stats)
+	return stats
+}
@@ -0,0 +1,108 @@
+package light_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/light"
+	"github.com/cometbft/cometbft/light/provider"
+	mockp "github.com/cometbft/cometbft/light/provider/mock"
+	dbs "github.com/cometbft/cometbft/light/store/db"
+)
+
+func TestDefaultWitnessScorer(t *testing.T) {
+	scorer := light.DefaultWitnessScorer()
+	neutral := scorer.Score(light.ProviderStats{})
+
+	reliable := light.ProviderStats{Requests: 10, Agreements: 10, Latency: 100 * time.Millisecond}
+	flaky := light.ProviderStats{Requests: 10, Errors: 5, Agreements: 5, Latency: 100 * time.Millisecond}
+	disagreeing := light.ProviderStats{Requests: 10, Agreements: 5, Disagreements: 5, Latency: 100 * time.Millisecond}
+	slow := light.ProviderStats{Requests: 10, Agreements: 10, Latency: 3 * time.Second}
+
+	assert.Greater(t, scorer.Score(reliable), neutral)
+	assert.Greater(t, scorer.Score(reliable), scorer.Score(flaky))
+	assert.Greater(t, scorer.Score(reliable), scorer.Score(disagreeing))
+	assert.Greater(t, scorer.Score(reliable), scorer.Score(slow))
+	assert.Less(t, scorer.Score(light.ProviderStats{Requests: 10, Errors: 10}), neutral)
+	for _, stats := range []light.ProviderStats{{}, reliable, flaky, disagreeing, slow} {
+		score := scorer.Score(stats)
+		assert.True(t, score > 0 && score <= 1, score)
+	}
+}
+
+func TestClientProviderStats(t *testing.T) {
+	primary := mockp.New(chainID, headerSet, valSet)
+	witness := mockp.New(chainID, headerSet, valSet)
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		primary,
+		[]provider.Provider{witness},
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+	)
+	require.NoError(t, err)
+	_, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	stats := c.ProviderStats()
+	require.Len(t, stats, 2)
+
+	assert.Equal(t, primary, stats[0].Provider)
+	assert.True(t, stats[0].Primary)
+	assert.NotZero(t, stats[0].Requests)
+	assert.Zero(t, stats[0].Errors)
+
+	assert.Equal(t, witness, stats[1].Provider)
+	assert.False(t, stats[1].Primary)
+	assert.NotZero(t, stats[1].Requests)
+	assert.NotZero(t, stats[1].Agreements)
+	assert.Zero(t, stats[1].Disagreements)
+	assert.Greater(t, stats[1].Score, light.DefaultWitnessScorer().Score(light.ProviderStats{}))
+}
+
+func TestClientPromotesWitnessWithHighestScore(t *testing.T) {
+	witnesses := []provider.Provider{
+		mockp.New(chainID, headerSet, valSet),
+		mockp.New(chainID, headerSet, valSet),
+		mockp.New(chainID, headerSet, valSet),
+	}
+	preferred := witnesses[1]
+	scorer := light.WitnessScorerFunc(func(stats light.ProviderStats) float64 {
+		if stats.Provider == preferred {
+			return 1
+		}
+		return 0
+	})
+
+	c, err := light.NewClient(
+		ctx,
+		chainID,
+		trustOptions,
+		deadNode,
+		witnesses,
+		dbs.New(dbm.NewMemDB(), chainID),
+		light.Logger(log.TestingLogger()),
+		light.MaxRetryAttempts(1),
+		light.WitnessScoring(scorer),
+	)
+	require.NoError(t, err)
+	_, err = c.Update(ctx, bTime.Add(2*time.Hour))
+	require.NoError(t, err)
+
+	assert.Equal(t, preferred, c.Primary())
+	// The dead primary is kept as a witness, with its failures recorded.
+	require.Len(t, c.Witnesses(), 3)
+	for _, stats := range c.ProviderStats() {
+		if stats.Provider == deadNode {
+			assert.NotZero(t, stats.Errors)
+			assert.Equal(t, provider.ErrNoResponse, stats.LastError)
+		}
+	}
+}
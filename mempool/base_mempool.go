@@ -0,0 +1,447 @@
+package mempool
+
+import (
+	"context"
+	"sync/atomic"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/clist"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// baseMempool holds the state and logic shared by the CListMempool and the
+// PriorityMempool: the limits, the cache, the pre/post-check filters, the
+// connection to the application and the concurrent list of txs, in the order
+// they were added, which the Reactor gossips.
+//
+// The mempools embed it and only implement how their txs are indexed,
+// ordered and rechecked.
+type baseMempool struct {
+	height   atomic.Int64 // the last block Update()'d to
+	txsBytes atomic.Int64 // total size of mempool, in bytes
+
+	// Limits of the mempool, initially from the config, see SetLimits.
+	maxTxs      atomic.Int64
+	maxTxsBytes atomic.Int64
+
+	// notify listeners (ie. consensus) when txs are available
+	notifiedTxsAvailable atomic.Bool
+	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
+
+	// Function set by the reactor to be called when a transaction is removed
+	// from the mempool.
+	removeTxOnReactorCb func(txKey types.TxKey)
+
+	config *config.MempoolConfig
+
+	// Exclusive mutex for Update method to prevent concurrent execution of
+	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
+	updateMtx cmtsync.RWMutex
+	preCheck  PreCheckFunc
+	postCheck PostCheckFunc
+
+	proxyAppConn proxy.AppConnMempool
+
+	// Concurrent linked-list of valid txs, in the order they were added.
+	txs *clist.CList
+
+	// Keep a cache of already-seen txs.
+	// This reduces the pressure on the proxyApp.
+	cache TxCache
+
+	// Optional tracker of the lifecycle of the txs.
+	txTracker *TxTracker
+
+	logger  log.Logger
+	metrics *Metrics
+}
+
+// init initializes the base of a new mempool with the given configuration and
+// connection to an application.
+func (mem *baseMempool) init(cfg *config.MempoolConfig, proxyAppConn proxy.AppConnMempool, height int64) {
+	mem.config = cfg
+	mem.proxyAppConn = proxyAppConn
+	mem.txs = clist.New()
+	mem.logger = log.NewNopLogger()
+	mem.metrics = NopMetrics()
+	mem.height.Store(height)
+	mem.maxTxs.Store(int64(cfg.Size))
+	mem.maxTxsBytes.Store(cfg.MaxTxsBytes)
+
+	if cfg.CacheSize > 0 {
+		mem.cache = NewLRUTxCache(cfg.CacheSize)
+	} else {
+		mem.cache = NopTxCache{}
+	}
+}
+
+// SetLogger sets the Logger.
+func (mem *baseMempool) SetLogger(l log.Logger) {
+	mem.logger = l
+}
+
+// NOTE: not thread safe - should only be called once, on startup.
+func (mem *baseMempool) EnableTxsAvailable() {
+	mem.txsAvailable = make(chan struct{}, 1)
+}
+
+func (mem *baseMempool) SetTxRemovedCallback(cb func(txKey types.TxKey)) {
+	mem.removeTxOnReactorCb = cb
+}
+
+func (mem *baseMempool) invokeRemoveTxOnReactor(txKey types.TxKey) {
+	// Note that the callback is nil in the unit tests, where there are no
+	// reactors.
+	if mem.removeTxOnReactorCb != nil {
+		mem.removeTxOnReactorCb(txKey)
+	}
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) Lock() {
+	mem.updateMtx.Lock()
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) Unlock() {
+	mem.updateMtx.Unlock()
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) Size() int {
+	return mem.txs.Len()
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) SizeBytes() int64 {
+	return mem.txsBytes.Load()
+}
+
+// SetLimits changes the maximum number of transactions in the mempool and
+// their maximum total size, in bytes. The transactions already in the mempool
+// are kept even if they exceed the new limits.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) SetLimits(maxTxs int, maxTxsBytes int64) {
+	mem.maxTxs.Store(int64(maxTxs))
+	mem.maxTxsBytes.Store(maxTxsBytes)
+}
+
+// Lock() must be help by the caller during execution.
+func (mem *baseMempool) FlushAppConn() error {
+	err := mem.proxyAppConn.Flush(context.TODO())
+	if err != nil {
+		return ErrFlushAppConn{Err: err}
+	}
+
+	return nil
+}
+
+// TxsFront returns the first transaction in the order they were added, for
+// peer goroutines to call .NextWait() on.
+// FIXME: leaking implementation details!
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) TxsFront() *clist.CElement {
+	return mem.txs.Front()
+}
+
+// TxsWaitChan returns a channel to wait on transactions. It will be closed
+// once the mempool is not empty (ie. the internal `mem.txs` has at least one
+// element)
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) TxsWaitChan() <-chan struct{} {
+	return mem.txs.WaitChan()
+}
+
+// NewIterator returns an iterator over the transactions in the order they were
+// added.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) NewIterator() Iterator {
+	return newCListIterator(mem.txs)
+}
+
+// Safe for concurrent use by multiple goroutines.
+func (mem *baseMempool) TxsAvailable() <-chan struct{} {
+	return mem.txsAvailable
+}
+
+func (mem *baseMempool) notifyTxsAvailable() {
+	if mem.Size() == 0 {
+		panic("notified txs available but mempool is empty!")
+	}
+	if mem.txsAvailable != nil && mem.notifiedTxsAvailable.CompareAndSwap(false, true) {
+		// channel cap is 1, so this will send once
+		select {
+		case mem.txsAvailable <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// isFull returns an ErrMempoolIsFull error if a tx of the given size does not
+// fit in the mempool.
+func (mem *baseMempool) isFull(txSize int) error {
+	var (
+		memSize     = mem.Size()
+		txsBytes    = mem.SizeBytes()
+		maxTxs      = int(mem.maxTxs.Load())
+		maxTxsBytes = mem.maxTxsBytes.Load()
+	)
+
+	if memSize >= maxTxs || uint64(txSize)+uint64(txsBytes) > uint64(maxTxsBytes) {
+		return ErrMempoolIsFull{
+			NumTxs:      memSize,
+			MaxTxs:      maxTxs,
+			TxsBytes:    txsBytes,
+			MaxTxsBytes: maxTxsBytes,
+		}
+	}
+
+	return nil
+}
+
+func (mem *baseMempool) addToCache(tx types.Tx) bool {
+	return mem.cache.Push(tx)
+}
+
+func (mem *baseMempool) forceRemoveFromCache(tx types.Tx) {
+	mem.cache.Remove(tx)
+}
+
+// tryRemoveFromCache removes a transaction from the cache in case it can be
+// added to the mempool at a later stage (probably when the transaction becomes
+// valid).
+func (mem *baseMempool) tryRemoveFromCache(tx types.Tx) {
+	if !mem.config.KeepInvalidTxsInCache {
+		mem.forceRemoveFromCache(tx)
+	}
+}
+
+// checkTx runs the checks of CheckTx that don't depend on the mempool's
+// content, adds the tx to the cache and sends it to the application.
+//
+// The caller must hold updateMtx for reading.
+func (mem *baseMempool) checkTx(tx types.Tx) (*abcicli.ReqRes, error) {
+	txSize := len(tx)
+
+	if txSize > mem.config.MaxTxBytes {
+		return nil, ErrTxTooLarge{
+			Max:    mem.config.MaxTxBytes,
+			Actual: txSize,
+		}
+	}
+
+	if mem.preCheck != nil {
+		if err := mem.preCheck(tx); err != nil {
+			return nil, ErrPreCheck{Err: err}
+		}
+	}
+
+	// NOTE: proxyAppConn may error if tx buffer is full
+	if err := mem.proxyAppConn.Error(); err != nil {
+		return nil, ErrAppConnMempool{Err: err}
+	}
+
+	if added := mem.addToCache(tx); !added {
+		mem.logger.Debug("Not cached", "tx", tx.Hash())
+		mem.metrics.AlreadyReceivedTxs.Add(1)
+		// TODO: consider punishing peer for dups,
+		// its non-trivial since invalid txs can become valid,
+		// but they can spam the same tx with little cost to them atm.
+		return nil, ErrTxInCache
+	}
+	mem.logger.Debug("Cached", "tx", tx.Hash())
+	mem.txTracker.received(tx.Key(), mem.height.Load())
+
+	reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+		Tx:   tx,
+		Type: abci.CHECK_TX_TYPE_CHECK,
+	})
+	if err != nil {
+		mem.logger.Error("RequestCheckTx", "err", err)
+		// The tx can be submitted again, e.g. once the connection to the
+		// application is re-established.
+		mem.forceRemoveFromCache(tx)
+		return nil, ErrCheckTxAsync{Err: err}
+	}
+
+	return reqRes, nil
+}
+
+// updateSizeMetrics updates the size metrics after each change to the mempool
+// triggered by the application.
+func (mem *baseMempool) updateSizeMetrics() {
+	mem.metrics.Size.Set(float64(mem.Size()))
+	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+}
+
+// acceptCheckTx processes the response of the app to the first check of tx.
+// If the tx is invalid, it removes it from the cache, if needed, and returns
+// nil. Otherwise, it returns the tx to add to the mempool.
+func (mem *baseMempool) acceptCheckTx(tx types.Tx, res *abci.CheckTxResponse) *mempoolTx {
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, res)
+	}
+
+	if res.Code != abci.CodeTypeOK || postCheckErr != nil {
+		mem.tryRemoveFromCache(tx)
+		mem.logger.Debug(
+			"rejected invalid transaction",
+			"tx", tx.Hash(),
+			"res", res,
+			"err", postCheckErr,
+		)
+		mem.metrics.FailedTxs.Add(1)
+		mem.txTracker.checked(tx.Key(), mem.height.Load(), res.Code, rejectionReason(res, postCheckErr))
+		return nil
+	}
+	mem.txTracker.checked(tx.Key(), mem.height.Load(), res.Code, "")
+
+	return &mempoolTx{
+		height:    mem.height.Load(),
+		timestamp: cmttime.Now(),
+		gasWanted: res.GasWanted,
+		tx:        tx,
+		priority:  res.Priority,
+		sender:    res.Sender,
+	}
+}
+
+// rejectRecheckTx processes the response of the app to a recheck of tx and
+// reports whether the tx is no longer valid. In that case, it removes the tx
+// from the cache, if needed; the caller must remove it from the mempool.
+func (mem *baseMempool) rejectRecheckTx(tx types.Tx, res *abci.CheckTxResponse) bool {
+	var postCheckErr error
+	if mem.postCheck != nil {
+		postCheckErr = mem.postCheck(tx, res)
+	}
+
+	if res.Code == abci.CodeTypeOK && postCheckErr == nil {
+		return false
+	}
+
+	// Tx became invalidated due to newly committed block.
+	mem.logger.Debug("tx is no longer valid", "tx", tx.Hash(), "res", res, "postCheckErr", postCheckErr)
+	mem.txTracker.recheckedInvalid(tx.Key(), mem.height.Load(), res.Code, rejectionReason(res, postCheckErr))
+	mem.tryRemoveFromCache(tx)
+	return true
+}
+
+// beginUpdate records the new height and filters at the start of Update.
+func (mem *baseMempool) beginUpdate(height int64, preCheck PreCheckFunc, postCheck PostCheckFunc) {
+	mem.height.Store(height)
+	mem.notifiedTxsAvailable.Store(false)
+
+	if preCheck != nil {
+		mem.preCheck = preCheck
+	}
+	if postCheck != nil {
+		mem.postCheck = postCheck
+	}
+}
+
+// updateCommittedTx updates the cache with a tx committed in the block at
+// the given height. The caller must remove the tx from the mempool.
+func (mem *baseMempool) updateCommittedTx(height int64, tx types.Tx, txResult *abci.ExecTxResult) {
+	if txResult.Code == abci.CodeTypeOK {
+		// Add valid committed tx to the cache (if missing).
+		_ = mem.addToCache(tx)
+	} else {
+		mem.tryRemoveFromCache(tx)
+	}
+	mem.txTracker.included(tx.Key(), height, txResult.Code)
+}
+
+// endUpdate either rechecks the txs left in the mempool, with recheckTxs, to
+// see if they became invalid, or just notifies there're some txs left.
+func (mem *baseMempool) endUpdate(height int64, recheckTxs func()) {
+	if mem.Size() > 0 {
+		if mem.config.Recheck {
+			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
+			recheckTxs()
+		} else {
+			mem.notifyTxsAvailable()
+		}
+	}
+
+	mem.updateSizeMetrics()
+}
+
+// memTxs returns all the txs in the mempool, in the order they were added.
+func (mem *baseMempool) memTxs() []*mempoolTx {
+	memTxs := make([]*mempoolTx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTxs = append(memTxs, e.Value.(*mempoolTx))
+	}
+	return memTxs
+}
+
+// expiredTxs returns the txs that stayed in the mempool for longer than
+// TTLNumBlocks blocks or TTLDuration, if set.
+func (mem *baseMempool) expiredTxs(height int64) []*mempoolTx {
+	if mem.config.TTLNumBlocks == 0 && mem.config.TTLDuration == 0 {
+		return nil
+	}
+
+	now := cmttime.Now()
+	var expired []*mempoolTx
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.isExpired(height, now, mem.config.TTLNumBlocks, mem.config.TTLDuration) {
+			expired = append(expired, memTx)
+		}
+	}
+	return expired
+}
+
+// purgedExpiredTx removes an expired tx, which was just removed from the
+// mempool, from the cache too, so that it can be submitted again.
+func (mem *baseMempool) purgedExpiredTx(height int64, memTx *mempoolTx) {
+	mem.tryRemoveFromCache(memTx.tx)
+	mem.metrics.ExpiredTxs.Add(1)
+	mem.txTracker.removed(memTx.tx.Key(), height, TxRemovedExpired)
+	mem.logger.Debug("purged expired transaction", "tx", memTx.tx.Hash(), "height", memTx.Height())
+}
+
+// reapMaxBytesMaxGas returns the longest prefix of memTxs whose total size
+// and gas do not exceed maxBytes and maxGas. A negative limit is ignored.
+func reapMaxBytesMaxGas(memTxs []*mempoolTx, maxBytes, maxGas int64) types.Txs {
+	var (
+		totalGas    int64
+		runningSize int64
+	)
+
+	txs := make([]types.Tx, 0, len(memTxs))
+	for _, memTx := range memTxs {
+		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
+
+		// Check total size requirement
+		if maxBytes > -1 && runningSize+dataSize > maxBytes {
+			return txs
+		}
+
+		// Check total gas requirement.
+		// If maxGas is negative, skip this check.
+		// Since newTotalGas < masGas, which
+		// must be non-negative, it follows that this won't overflow.
+		newTotalGas := totalGas + memTx.gasWanted
+		if maxGas > -1 && newTotalGas > maxGas {
+			return txs
+		}
+
+		runningSize += dataSize
+		totalGas = newTotalGas
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
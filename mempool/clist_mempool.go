@@ -3,16 +3,14 @@ package mempool
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"sync"
-	"sync/atomic"
 
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	abci "github.com/cometbft/cometbft/abci/types"
 	"github.com/cometbft/cometbft/config"
 	"github.com/cometbft/cometbft/internal/clist"
-	cmtsync "github.com/cometbft/cometbft/internal/sync"
-	"github.com/cometbft/cometbft/libs/log"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
@@ -24,26 +22,7 @@ import (
 // mempool uses a concurrent list structure for storing transactions that can
 // be efficiently accessed by multiple concurrent readers.
 type CListMempool struct {
-	height   atomic.Int64 // the last block Update()'d to
-	txsBytes atomic.Int64 // total size of mempool, in bytes
-
-	// notify listeners (ie. consensus) when txs are available
-	notifiedTxsAvailable atomic.Bool
-	txsAvailable         chan struct{} // fires once for each height, when the mempool is not empty
-
-	// Function set by the reactor to be called when a transaction is removed
-	// from the mempool.
-	removeTxOnReactorCb func(txKey types.TxKey)
-
-	config *config.MempoolConfig
-
-	// Exclusive mutex for Update method to prevent concurrent execution of
-	// CheckTx or ReapMaxBytesMaxGas(ReapMaxTxs) methods.
-	updateMtx cmtsync.RWMutex
-	preCheck  PreCheckFunc
-	postCheck PostCheckFunc
-
-	proxyAppConn proxy.AppConnMempool
+	baseMempool
 
 	// Track whether we're rechecking txs.
 	// These are not protected by a mutex and are expected to be mutated in
@@ -51,18 +30,13 @@ type CListMempool struct {
 	recheckCursor *clist.CElement // next expected response
 	recheckEnd    *clist.CElement // re-checking stops here
 
-	// Concurrent linked-list of valid txs.
 	// `txsMap`: txKey -> CElement is for quick access to txs.
 	// Transactions in both `txs` and `txsMap` must to be kept in sync.
-	txs    *clist.CList
 	txsMap sync.Map
 
-	// Keep a cache of already-seen txs.
-	// This reduces the pressure on the proxyApp.
-	cache TxCache
-
-	logger  log.Logger
-	metrics *Metrics
+	// Optional write-ahead log of the txs in the mempool, used to restore
+	// them after a restart. See InitWAL.
+	wal *txWAL
 }
 
 var _ Mempool = &CListMempool{}
@@ -78,24 +52,13 @@ func NewCListMempool(
 	height int64,
 	options ...CListMempoolOption,
 ) *CListMempool {
-	mp := &CListMempool{
-		config:        cfg,
-		proxyAppConn:  proxyAppConn,
-		txs:           clist.New(),
-		recheckCursor: nil,
-		recheckEnd:    nil,
-		logger:        log.NewNopLogger(),
-		metrics:       NopMetrics(),
-	}
-	mp.height.Store(height)
-
-	if cfg.CacheSize > 0 {
-		mp.cache = NewLRUTxCache(cfg.CacheSize)
-	} else {
-		mp.cache = NopTxCache{}
-	}
+	mp := &CListMempool{}
+	mp.init(cfg, proxyAppConn, height)
 
 	proxyAppConn.SetResponseCallback(mp.globalCb)
+	if rn, ok := proxyAppConn.(abcicli.ReconnectNotifier); ok {
+		rn.SetReconnectCallback(mp.handleReconnect)
+	}
 
 	for _, option := range options {
 		option(mp)
@@ -116,21 +79,19 @@ func (mem *CListMempool) InMempool(txKey types.TxKey) bool {
 	return ok
 }
 
-func (mem *CListMempool) addToCache(tx types.Tx) bool {
-	return mem.cache.Push(tx)
-}
-
-func (mem *CListMempool) forceRemoveFromCache(tx types.Tx) {
-	mem.cache.Remove(tx)
+// GetTxByKey returns the transaction with the given key, if it's in the
+// mempool.
+func (mem *CListMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	if e, ok := mem.getCElement(txKey); ok {
+		return e.Value.(*mempoolTx).tx, true
+	}
+	return nil, false
 }
 
-// tryRemoveFromCache removes a transaction from the cache in case it can be
-// added to the mempool at a later stage (probably when the transaction becomes
-// valid).
-func (mem *CListMempool) tryRemoveFromCache(tx types.Tx) {
-	if !mem.config.KeepInvalidTxsInCache {
-		mem.forceRemoveFromCache(tx)
-	}
+// HasSeen returns true if the transaction with the given key is in the
+// mempool or in its cache.
+func (mem *CListMempool) HasSeen(txKey types.TxKey) bool {
+	return mem.cache.HasKey(txKey) || mem.InMempool(txKey)
 }
 
 func (mem *CListMempool) removeAllTxs() {
@@ -142,32 +103,11 @@ func (mem *CListMempool) removeAllTxs() {
 	mem.txsMap.Range(func(key, _ interface{}) bool {
 		mem.txsMap.Delete(key)
 		mem.invokeRemoveTxOnReactor(key.(types.TxKey))
+		mem.txTracker.removed(key.(types.TxKey), mem.height.Load(), TxRemovedFlushed)
 		return true
 	})
 }
 
-// NOTE: not thread safe - should only be called once, on startup.
-func (mem *CListMempool) EnableTxsAvailable() {
-	mem.txsAvailable = make(chan struct{}, 1)
-}
-
-func (mem *CListMempool) SetTxRemovedCallback(cb func(txKey types.TxKey)) {
-	mem.removeTxOnReactorCb = cb
-}
-
-func (mem *CListMempool) invokeRemoveTxOnReactor(txKey types.TxKey) {
-	// Note that the callback is nil in the unit tests, where there are no
-	// reactors.
-	if mem.removeTxOnReactorCb != nil {
-		mem.removeTxOnReactorCb(txKey)
-	}
-}
-
-// SetLogger sets the Logger.
-func (mem *CListMempool) SetLogger(l log.Logger) {
-	mem.logger = l
-}
-
 // WithPreCheck sets a filter for the mempool to reject a tx if f(tx) returns
 // false. This is ran before CheckTx. Only applies to the first created block.
 // After that, Update overwrites the existing value.
@@ -187,36 +127,75 @@ func WithMetrics(metrics *Metrics) CListMempoolOption {
 	return func(mem *CListMempool) { mem.metrics = metrics }
 }
 
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) Lock() {
-	mem.updateMtx.Lock()
-}
-
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) Unlock() {
-	mem.updateMtx.Unlock()
+// WithTxTracker sets the tracker recording the lifecycle of the txs.
+func WithTxTracker(txTracker *TxTracker) CListMempoolOption {
+	return func(mem *CListMempool) { mem.txTracker = txTracker }
 }
 
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) Size() int {
-	return mem.txs.Len()
-}
+// InitWAL opens the mempool's write-ahead log and replays the transactions
+// it contains, which were in the mempool when the node was stopped. The
+// transactions are checked again by the application, so only those that are
+// still valid are added back to the mempool, and then the WAL is rewritten with
+// them. From then on, every transaction added to or removed from the mempool is
+// appended to the WAL, which is compacted to the transactions left in the
+// mempool by Update once it grows too large.
+//
+// It does nothing if the WAL is disabled in the config. It must be called
+// once, on startup, after the connection to the application is established
+// and before the mempool receives any transaction.
+func (mem *CListMempool) InitWAL() error {
+	if !mem.config.WalEnabled() {
+		return nil
+	}
 
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) SizeBytes() int64 {
-	return mem.txsBytes.Load()
-}
+	wal, err := openTxWAL(mem.config.WalDir(), mem.logger)
+	if err != nil {
+		return err
+	}
 
-// Lock() must be help by the caller during execution.
-func (mem *CListMempool) FlushAppConn() error {
-	err := mem.proxyAppConn.Flush(context.TODO())
+	txs, err := wal.readAll(mem.config.MaxTxBytes)
 	if err != nil {
-		return ErrFlushAppConn{Err: err}
+		if !errors.Is(err, ErrWALCorrupted) {
+			wal.close()
+			return err
+		}
+		// The node probably crashed while writing the last record.
+		mem.logger.Error("Ignoring the rest of the mempool WAL", "err", err, "replayed", len(txs))
+	}
+
+	// The WAL is only rewritten once the txs are replayed, so that they are
+	// not lost if the node crashes in the meantime.
+	mem.logger.Info("Replaying mempool WAL", "numtxs", len(txs))
+	for _, tx := range txs {
+		if _, err := mem.CheckTx(tx); err != nil {
+			mem.logger.Debug("Could not replay tx from mempool WAL", "tx", tx.Hash(), "err", err)
+		}
+	}
+	if err := mem.FlushAppConn(); err != nil {
+		wal.close()
+		return err
 	}
 
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+	if err := wal.truncate(mem.allTxs()); err != nil {
+		wal.close()
+		return err
+	}
+	mem.wal = wal
 	return nil
 }
 
+// CloseWAL flushes and closes the mempool's write-ahead log, if it's enabled.
+func (mem *CListMempool) CloseWAL() {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	if mem.wal != nil {
+		mem.wal.close()
+	}
+}
+
 // XXX: Unsafe! Calling Flush may leave mempool in inconsistent state.
 func (mem *CListMempool) Flush() {
 	mem.updateMtx.RLock()
@@ -226,24 +205,12 @@ func (mem *CListMempool) Flush() {
 	mem.cache.Reset()
 
 	mem.removeAllTxs()
-}
-
-// TxsFront returns the first transaction in the ordered list for peer
-// goroutines to call .NextWait() on.
-// FIXME: leaking implementation details!
-//
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) TxsFront() *clist.CElement {
-	return mem.txs.Front()
-}
 
-// TxsWaitChan returns a channel to wait on transactions. It will be closed
-// once the mempool is not empty (ie. the internal `mem.txs` has at least one
-// element)
-//
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) TxsWaitChan() <-chan struct{} {
-	return mem.txs.WaitChan()
+	if mem.wal != nil {
+		if err := mem.wal.truncate(nil); err != nil {
+			mem.logger.Error("Error truncating mempool WAL", "err", err)
+		}
+	}
 }
 
 // It blocks if we're waiting on Update() or Reap().
@@ -253,50 +220,11 @@ func (mem *CListMempool) CheckTx(tx types.Tx) (*abcicli.ReqRes, error) {
 	// use defer to unlock mutex because application (*local client*) might panic
 	defer mem.updateMtx.RUnlock()
 
-	txSize := len(tx)
-
-	if err := mem.isFull(txSize); err != nil {
+	if err := mem.isFull(len(tx)); err != nil {
 		return nil, err
 	}
 
-	if txSize > mem.config.MaxTxBytes {
-		return nil, ErrTxTooLarge{
-			Max:    mem.config.MaxTxBytes,
-			Actual: txSize,
-		}
-	}
-
-	if mem.preCheck != nil {
-		if err := mem.preCheck(tx); err != nil {
-			return nil, ErrPreCheck{Err: err}
-		}
-	}
-
-	// NOTE: proxyAppConn may error if tx buffer is full
-	if err := mem.proxyAppConn.Error(); err != nil {
-		return nil, ErrAppConnMempool{Err: err}
-	}
-
-	if added := mem.addToCache(tx); !added {
-		mem.logger.Debug("Not cached", "tx", tx.Hash())
-		mem.metrics.AlreadyReceivedTxs.Add(1)
-		// TODO: consider punishing peer for dups,
-		// its non-trivial since invalid txs can become valid,
-		// but they can spam the same tx with little cost to them atm.
-		return nil, ErrTxInCache
-	}
-	mem.logger.Debug("Cached", "tx", tx.Hash())
-
-	reqRes, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
-		Tx:   tx,
-		Type: abci.CHECK_TX_TYPE_CHECK,
-	})
-	if err != nil {
-		mem.logger.Error("RequestCheckTx", "err", err)
-		return nil, ErrCheckTxAsync{Err: err}
-	}
-
-	return reqRes, nil
+	return mem.checkTx(tx)
 }
 
 // Global callback that will be called after every ABCI response.
@@ -323,9 +251,7 @@ func (mem *CListMempool) globalCb(req *abci.Request, res *abci.Response) {
 			panic(fmt.Sprintf("unexpected value %d of RequestCheckTx.type", checkType))
 		}
 
-		// update metrics
-		mem.metrics.Size.Set(float64(mem.Size()))
-		mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+		mem.updateSizeMetrics()
 
 	default:
 		// ignore other messages
@@ -347,6 +273,12 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) bool {
 		return false
 	}
 
+	if mem.wal != nil {
+		if err := mem.wal.write(tx); err != nil {
+			mem.logger.Error("Error writing to mempool WAL", "tx", tx.Hash(), "err", err)
+		}
+	}
+
 	e := mem.txs.PushBack(memTx)
 	mem.txsMap.Store(tx.Key(), e)
 	mem.txsBytes.Add(int64(len(tx)))
@@ -362,10 +294,20 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) bool {
 }
 
 // RemoveTxByKey removes a transaction from the mempool by its TxKey index.
+func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
+	if err := mem.removeTxByKey(txKey); err != nil {
+		return err
+	}
+	mem.txTracker.removed(txKey, mem.height.Load(), TxRemovedByKey)
+	return nil
+}
+
+// removeTxByKey removes a transaction from the mempool by its TxKey index.
 // Called from:
+//   - RemoveTxByKey
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
-func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
+func (mem *CListMempool) removeTxByKey(txKey types.TxKey) error {
 	// The transaction should be removed from the reactor, even if it cannot be
 	// found in the mempool.
 	mem.invokeRemoveTxOnReactor(txKey)
@@ -379,26 +321,13 @@ func (mem *CListMempool) RemoveTxByKey(txKey types.TxKey) error {
 	elem.DetachPrev()
 	mem.txsMap.Delete(txKey)
 	tx := elem.Value.(*mempoolTx).tx
-	mem.txsBytes.Add(int64(-len(tx)))
-	mem.logger.Debug("removed transaction", "tx", tx.Hash(), "height", mem.height.Load(), "total", mem.Size())
-	return nil
-}
-
-func (mem *CListMempool) isFull(txSize int) error {
-	var (
-		memSize  = mem.Size()
-		txsBytes = mem.SizeBytes()
-	)
-
-	if memSize >= mem.config.Size || uint64(txSize)+uint64(txsBytes) > uint64(mem.config.MaxTxsBytes) {
-		return ErrMempoolIsFull{
-			NumTxs:      memSize,
-			MaxTxs:      mem.config.Size,
-			TxsBytes:    txsBytes,
-			MaxTxsBytes: mem.config.MaxTxsBytes,
+	if mem.wal != nil {
+		if err := mem.wal.remove(txKey); err != nil {
+			mem.logger.Error("Error writing to mempool WAL", "tx", tx.Hash(), "err", err)
 		}
 	}
-
+	mem.txsBytes.Add(int64(-len(tx)))
+	mem.logger.Debug("removed transaction", "tx", tx.Hash(), "height", mem.height.Load(), "total", mem.Size())
 	return nil
 }
 
@@ -407,20 +336,8 @@ func (mem *CListMempool) isFull(txSize int) error {
 // The case where the app checks the tx for the second and subsequent times is
 // handled by the resCbRecheck callback.
 func (mem *CListMempool) resCbFirstTime(tx types.Tx, res *abci.CheckTxResponse) {
-	var postCheckErr error
-	if mem.postCheck != nil {
-		postCheckErr = mem.postCheck(tx, res)
-	}
-
-	if res.Code != abci.CodeTypeOK || postCheckErr != nil {
-		mem.tryRemoveFromCache(tx)
-		mem.logger.Debug(
-			"rejected invalid transaction",
-			"tx", tx.Hash(),
-			"res", res,
-			"err", postCheckErr,
-		)
-		mem.metrics.FailedTxs.Add(1)
+	memTx := mem.acceptCheckTx(tx, res)
+	if memTx == nil {
 		return
 	}
 
@@ -429,14 +346,11 @@ func (mem *CListMempool) resCbFirstTime(tx types.Tx, res *abci.CheckTxResponse)
 	if err := mem.isFull(len(tx)); err != nil {
 		mem.forceRemoveFromCache(tx) // mempool might have space later
 		mem.logger.Error(err.Error())
+		mem.txTracker.removed(tx.Key(), mem.height.Load(), TxRemovedMempoolFull)
 		return
 	}
 
-	if mem.addTx(&mempoolTx{
-		height:    mem.height.Load(),
-		gasWanted: res.GasWanted,
-		tx:        tx,
-	}) {
+	if mem.addTx(memTx) {
 		mem.notifyTxsAvailable()
 	}
 }
@@ -476,18 +390,10 @@ func (mem *CListMempool) resCbRecheck(tx types.Tx, res *abci.CheckTxResponse) {
 		memTx = mem.recheckCursor.Value.(*mempoolTx)
 	}
 
-	var postCheckErr error
-	if mem.postCheck != nil {
-		postCheckErr = mem.postCheck(tx, res)
-	}
-
-	if (res.Code != abci.CodeTypeOK) || postCheckErr != nil {
-		// Tx became invalidated due to newly committed block.
-		mem.logger.Debug("tx is no longer valid", "tx", tx.Hash(), "res", res, "postCheckErr", postCheckErr)
-		if err := mem.RemoveTxByKey(memTx.tx.Key()); err != nil {
+	if mem.rejectRecheckTx(tx, res) {
+		if err := mem.removeTxByKey(memTx.tx.Key()); err != nil {
 			mem.logger.Debug("Transaction could not be removed from mempool", "err", err)
 		}
-		mem.tryRemoveFromCache(tx)
 	}
 
 	if mem.recheckCursor == mem.recheckEnd {
@@ -507,63 +413,12 @@ func (mem *CListMempool) resCbRecheck(tx types.Tx, res *abci.CheckTxResponse) {
 	}
 }
 
-// Safe for concurrent use by multiple goroutines.
-func (mem *CListMempool) TxsAvailable() <-chan struct{} {
-	return mem.txsAvailable
-}
-
-func (mem *CListMempool) notifyTxsAvailable() {
-	if mem.Size() == 0 {
-		panic("notified txs available but mempool is empty!")
-	}
-	if mem.txsAvailable != nil && mem.notifiedTxsAvailable.CompareAndSwap(false, true) {
-		// channel cap is 1, so this will send once
-		select {
-		case mem.txsAvailable <- struct{}{}:
-		default:
-		}
-	}
-}
-
 // Safe for concurrent use by multiple goroutines.
 func (mem *CListMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
 	mem.updateMtx.RLock()
 	defer mem.updateMtx.RUnlock()
 
-	var (
-		totalGas    int64
-		runningSize int64
-	)
-
-	// TODO: we will get a performance boost if we have a good estimate of avg
-	// size per tx, and set the initial capacity based off of that.
-	// txs := make([]types.Tx, 0, cmtmath.MinInt(mem.txs.Len(), max/mem.avgTxSize))
-	txs := make([]types.Tx, 0, mem.txs.Len())
-	for e := mem.txs.Front(); e != nil; e = e.Next() {
-		memTx := e.Value.(*mempoolTx)
-
-		txs = append(txs, memTx.tx)
-
-		dataSize := types.ComputeProtoSizeForTxs([]types.Tx{memTx.tx})
-
-		// Check total size requirement
-		if maxBytes > -1 && runningSize+dataSize > maxBytes {
-			return txs[:len(txs)-1]
-		}
-
-		runningSize += dataSize
-
-		// Check total gas requirement.
-		// If maxGas is negative, skip this check.
-		// Since newTotalGas < masGas, which
-		// must be non-negative, it follows that this won't overflow.
-		newTotalGas := totalGas + memTx.gasWanted
-		if maxGas > -1 && newTotalGas > maxGas {
-			return txs[:len(txs)-1]
-		}
-		totalGas = newTotalGas
-	}
-	return txs
+	return reapMaxBytesMaxGas(mem.memTxs(), maxBytes, maxGas)
 }
 
 // Safe for concurrent use by multiple goroutines.
@@ -592,24 +447,10 @@ func (mem *CListMempool) Update(
 	preCheck PreCheckFunc,
 	postCheck PostCheckFunc,
 ) error {
-	// Set height
-	mem.height.Store(height)
-	mem.notifiedTxsAvailable.Store(false)
-
-	if preCheck != nil {
-		mem.preCheck = preCheck
-	}
-	if postCheck != nil {
-		mem.postCheck = postCheck
-	}
+	mem.beginUpdate(height, preCheck, postCheck)
 
 	for i, tx := range txs {
-		if txResults[i].Code == abci.CodeTypeOK {
-			// Add valid committed tx to the cache (if missing).
-			_ = mem.addToCache(tx)
-		} else {
-			mem.tryRemoveFromCache(tx)
-		}
+		mem.updateCommittedTx(height, tx, txResults[i])
 
 		// Remove committed tx from the mempool.
 		//
@@ -621,34 +462,56 @@ func (mem *CListMempool) Update(
 		// Mempool after:
 		//   100
 		// https://github.com/tendermint/tendermint/issues/3322.
-		if err := mem.RemoveTxByKey(tx.Key()); err != nil {
+		if err := mem.removeTxByKey(tx.Key()); err != nil {
 			mem.logger.Debug("Committed transaction not in local mempool (not an error)",
 				"key", tx.Key(),
 				"error", err.Error())
 		}
 	}
 
-	// Either recheck non-committed txs to see if they became invalid
-	// or just notify there're some txs left.
-	if mem.Size() > 0 {
-		if mem.config.Recheck {
-			mem.logger.Debug("recheck txs", "numtxs", mem.Size(), "height", height)
-			mem.recheckTxs()
-			// At this point, mem.txs are being rechecked.
-			// mem.recheckCursor re-scans mem.txs and possibly removes some txs.
-			// Before mem.Reap(), we should wait for mem.recheckCursor to be nil.
-		} else {
-			mem.notifyTxsAvailable()
+	mem.purgeExpiredTxs(height)
+
+	// The removals of the committed txs were appended to the WAL. Rewrite it
+	// once it's mostly made of removed txs.
+	if mem.wal != nil && mem.wal.needsCompaction(mem.Size()) {
+		if err := mem.wal.truncate(mem.allTxs()); err != nil {
+			mem.logger.Error("Error compacting mempool WAL", "height", height, "err", err)
 		}
 	}
 
-	// Update metrics
-	mem.metrics.Size.Set(float64(mem.Size()))
-	mem.metrics.SizeBytes.Set(float64(mem.SizeBytes()))
+	// Either recheck non-committed txs to see if they became invalid
+	// or just notify there're some txs left.
+	// While mem.txs are being rechecked, mem.recheckCursor re-scans mem.txs
+	// and possibly removes some txs. Before mem.Reap(), we should wait for
+	// mem.recheckCursor to be nil.
+	mem.endUpdate(height, mem.recheckTxs)
 
 	return nil
 }
 
+// purgeExpiredTxs removes the txs that stayed in the mempool for longer than
+// TTLNumBlocks blocks or TTLDuration, if set. They are removed from the cache
+// too, so that they can be submitted again.
+//
+// Called from Update (lock held).
+func (mem *CListMempool) purgeExpiredTxs(height int64) {
+	for _, memTx := range mem.expiredTxs(height) {
+		if err := mem.removeTxByKey(memTx.tx.Key()); err != nil {
+			continue
+		}
+		mem.purgedExpiredTx(height, memTx)
+	}
+}
+
+// allTxs returns all the txs in the mempool, in order.
+func (mem *CListMempool) allTxs() []types.Tx {
+	txs := make([]types.Tx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
+	}
+	return txs
+}
+
 func (mem *CListMempool) recheckTxs() {
 	if mem.Size() == 0 {
 		panic("recheckTxs is called, but the mempool is empty")
@@ -667,6 +530,10 @@ func (mem *CListMempool) recheckTxs() {
 		})
 		if err != nil {
 			mem.logger.Error("recheckTx", "err", err)
+			// Abort the recheck, the responses to the requests already sent
+			// are ignored.
+			mem.recheckCursor = nil
+			mem.recheckEnd = nil
 			return
 		}
 	}
@@ -675,3 +542,26 @@ func (mem *CListMempool) recheckTxs() {
 	// all pending messages to the app. There doesn't seem to be any need here as the buffer
 	// will get flushed regularly or when filled.
 }
+
+// handleReconnect is called when the connection to the application was
+// re-established. The requests of the recheck in progress, if any, were lost
+// with the previous connection, so it aborts the recheck, lets the pending
+// CheckTx requests be sent again, and rechecks all the txs once they got a
+// response, so that no response to a new tx arrives during the recheck.
+func (mem *CListMempool) handleReconnect(resume func()) {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	mem.recheckCursor = nil
+	mem.recheckEnd = nil
+	resume()
+
+	if err := mem.proxyAppConn.Flush(context.TODO()); err != nil {
+		mem.logger.Error("Failed to flush the CheckTx requests sent again", "err", err)
+		return
+	}
+	if mem.config.Recheck && mem.Size() > 0 {
+		mem.logger.Debug("recheck txs after reconnecting", "numtxs", mem.Size())
+		mem.recheckTxs()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -262,6 +263,44 @@ func TestMempoolUpdate(t *testing.T) {
 	}
 }
 
+func TestMempoolTTL(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	update := func(height int64) {
+		mp.Lock()
+		defer mp.Unlock()
+		require.NoError(t, mp.Update(height, nil, nil, nil, nil))
+	}
+
+	// 1. Txs expire after TTLNumBlocks blocks
+	mp.config.TTLNumBlocks = 2
+	txs := newUniqueTxs(2)
+	callCheckTx(t, mp, txs[:1])
+	update(1)
+	callCheckTx(t, mp, txs[1:])
+	update(2)
+	require.Equal(t, 2, mp.Size())
+
+	update(3)
+	require.Equal(t, txs[1:], mp.ReapMaxTxs(-1))
+
+	// Expired txs are removed from the cache, so they can be submitted again.
+	callCheckTx(t, mp, txs[:1])
+	require.Equal(t, 2, mp.Size())
+
+	// 2. Txs expire after TTLDuration
+	mp.config.TTLNumBlocks = 0
+	mp.config.TTLDuration = 50 * time.Millisecond
+	time.Sleep(100 * time.Millisecond)
+	tx := kvstore.NewTxFromID(100)
+	callCheckTx(t, mp, types.Txs{tx})
+	update(4)
+	require.Equal(t, types.Txs{tx}, mp.ReapMaxTxs(-1))
+}
+
 // Test dropping CheckTx requests when rechecking transactions. It mocks an asynchronous connection
 // to the app.
 func TestMempoolUpdateDoesNotPanicWhenApplicationMissedTx(t *testing.T) {
@@ -566,6 +605,25 @@ func TestMempool_CheckTxChecksTxSize(t *testing.T) {
 	}
 }
 
+func TestMempoolSetLimits(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	mp.SetLimits(1, 100)
+	_, err := mp.CheckTx(kvstore.NewRandomTx(10))
+	require.NoError(t, err)
+	_, err = mp.CheckTx(kvstore.NewRandomTx(10))
+	require.Equal(t, ErrMempoolIsFull{NumTxs: 1, MaxTxs: 1, TxsBytes: 10, MaxTxsBytes: 100}, err)
+
+	mp.SetLimits(10, 15)
+	_, err = mp.CheckTx(kvstore.NewRandomTx(10))
+	require.Equal(t, ErrMempoolIsFull{NumTxs: 1, MaxTxs: 10, TxsBytes: 10, MaxTxsBytes: 15}, err)
+	_, err = mp.CheckTx(kvstore.NewRandomTx(5))
+	require.NoError(t, err)
+}
+
 func TestMempoolTxsBytes(t *testing.T) {
 	app := kvstore.NewInMemoryApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -701,6 +759,36 @@ func TestMempoolNoCacheOverflow(t *testing.T) {
 	assert.Equal(t, 1, found)
 }
 
+func TestMempoolWALReplay(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	conf := test.ResetTestRoot("mempool_test")
+	defer os.RemoveAll(conf.RootDir)
+	conf.Mempool.WalPath = "data/mempool.wal"
+
+	mp, _ := newMempoolWithAppAndConfig(cc, conf)
+	require.NoError(t, mp.InitWAL())
+
+	txs := checkTxs(t, mp, 5)
+	require.Equal(t, 5, mp.Size())
+
+	// Commit the first two txs; they are removed from the WAL.
+	doCommit(t, mp, app, txs[:2], 1)
+	mp.CloseWAL()
+
+	// Restart the mempool with the same WAL.
+	mp, _ = newMempoolWithAppAndConfig(cc, conf)
+	require.NoError(t, mp.InitWAL())
+	require.Equal(t, txs[2:], mp.ReapMaxTxs(-1))
+
+	// The replayed txs are kept in the WAL.
+	mp.CloseWAL()
+	mp, _ = newMempoolWithAppAndConfig(cc, conf)
+	require.NoError(t, mp.InitWAL())
+	require.Equal(t, txs[2:], mp.ReapMaxTxs(-1))
+	mp.CloseWAL()
+}
+
 // This will non-deterministically catch some concurrency failures like
 // https://github.com/tendermint/tendermint/issues/3509
 // TODO: all of the tests should probably also run using the remote proxy app
@@ -805,6 +893,59 @@ func TestMempoolNotifyTxsAvailable(t *testing.T) {
 	require.False(t, mp.notifiedTxsAvailable.Load())
 }
 
+// recheckDroppingClient is a client whose connection drops when the txs are
+// rechecked.
+type recheckDroppingClient struct {
+	abciclient.Client
+}
+
+func (c *recheckDroppingClient) CheckTxAsync(ctx context.Context, req *abci.CheckTxRequest) (*abciclient.ReqRes, error) {
+	if req.Type == abci.CHECK_TX_TYPE_RECHECK {
+		go func() { _ = c.Stop() }()
+		return abciclient.NewReqRes(abci.ToCheckTxRequest(req)), nil
+	}
+	return c.Client.CheckTxAsync(ctx, req)
+}
+
+func TestMempoolRecheckAfterReconnect(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	var conns atomic.Int32
+	client := abciclient.NewReconnectingClient(
+		func() (abciclient.Client, error) {
+			if conns.Add(1) == 1 {
+				return &recheckDroppingClient{abciclient.NewLocalClient(nil, app)}, nil
+			}
+			return abciclient.NewLocalClient(nil, app), nil
+		},
+		true,
+		abciclient.ReconnectBackoff{Min: 10 * time.Millisecond, Max: 50 * time.Millisecond},
+	)
+	cfg := test.ResetTestRoot("mempool_test")
+	mp, cleanup := newMempoolWithAppAndConfigMock(cfg, client)
+	defer cleanup()
+	defer client.Stop() //nolint:errcheck // ignore for tests
+
+	txs := types.Txs{kvstore.NewTxFromID(1), kvstore.NewTxFromID(2)}
+	callCheckTx(t, mp, txs)
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, 2, mp.Size())
+
+	// The connection drops during the recheck, which is aborted and started
+	// again once reconnected.
+	doCommit(t, mp, app, nil, 1)
+	require.Eventually(t, func() bool {
+		mp.Lock()
+		defer mp.Unlock()
+		return conns.Load() == 2 && mp.recheckCursor == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, 2, mp.Size())
+
+	// New txs are checked on the new connection.
+	callCheckTx(t, mp, types.Txs{kvstore.NewTxFromID(3)})
+	require.NoError(t, mp.FlushAppConn())
+	require.Equal(t, 3, mp.Size())
+}
+
 // caller must close server.
 func newRemoteApp(t *testing.T, addr string, app abci.Application) service.Service {
 	t.Helper()
@@ -0,0 +1,100 @@
+package mempool
+
+import (
+	"context"
+
+	"github.com/cometbft/cometbft/internal/clist"
+	"github.com/cometbft/cometbft/types"
+)
+
+// GossipMempool is a Mempool whose transactions can be gossiped to peers by
+// the Reactor. Any implementation satisfying it can be plugged into the p2p
+// layer, see NewReactor.
+type GossipMempool interface {
+	Mempool
+
+	// InMempool returns true if the transaction with the given key is in the
+	// mempool.
+	InMempool(txKey types.TxKey) bool
+
+	// GetTxByKey returns the transaction with the given key, if it's in the
+	// mempool.
+	GetTxByKey(txKey types.TxKey) (types.Tx, bool)
+
+	// HasSeen returns true if the transaction with the given key was received
+	// recently, i.e. it's in the mempool or in its cache of seen
+	// transactions. Peers announcing such a transaction are not requested it.
+	HasSeen(txKey types.TxKey) bool
+
+	// NewIterator returns an iterator over the transactions to gossip to a
+	// peer, starting from the first transaction in the mempool.
+	NewIterator() Iterator
+}
+
+// Entry is a transaction in the mempool, as seen by the Reactor.
+type Entry interface {
+	// Tx returns the transaction.
+	Tx() types.Tx
+
+	// Height returns the height at which the transaction was validated.
+	Height() int64
+}
+
+// Iterator iterates over the transactions of a mempool in the order they are
+// gossiped. It is not safe for concurrent use; the Reactor creates one per
+// peer.
+type Iterator interface {
+	// Next blocks until a transaction is added to the mempool after the one
+	// previously returned, and returns it. It returns nil once ctx is done.
+	//
+	// Transactions removed from the mempool are skipped. If the previously
+	// returned transaction was removed, the iteration may restart from the
+	// first transaction in the mempool.
+	Next(ctx context.Context) Entry
+}
+
+var (
+	_ GossipMempool = (*CListMempool)(nil)
+	_ GossipMempool = (*PriorityMempool)(nil)
+	_ GossipMempool = (*NopMempool)(nil)
+)
+
+// clistIterator is an Iterator over a concurrent linked list of *mempoolTx,
+// used by the CListMempool and the PriorityMempool.
+type clistIterator struct {
+	txs    *clist.CList
+	cursor *clist.CElement
+}
+
+func newCListIterator(txs *clist.CList) *clistIterator {
+	return &clistIterator{txs: txs}
+}
+
+// Next implements Iterator.
+func (iter *clistIterator) Next(ctx context.Context) Entry {
+	for {
+		// The cursor is nil before the first transaction or when the element
+		// we were looking at got garbage collected (removed). Start from the
+		// beginning.
+		if iter.cursor == nil {
+			select {
+			case <-iter.txs.WaitChan():
+				if iter.cursor = iter.txs.Front(); iter.cursor == nil {
+					continue
+				}
+				return iter.cursor.Value.(*mempoolTx)
+			case <-ctx.Done():
+				return nil
+			}
+		}
+
+		select {
+		case <-iter.cursor.NextWaitChan():
+			if iter.cursor = iter.cursor.Next(); iter.cursor != nil {
+				return iter.cursor.Value.(*mempoolTx)
+			}
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
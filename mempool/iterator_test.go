@@ -0,0 +1,56 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	"github.com/cometbft/cometbft/proxy"
+)
+
+func TestCListIterator(t *testing.T) {
+	app := kvstore.NewInMemoryApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mp, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	iter := mp.NewIterator()
+
+	// Next blocks until a tx is added
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	assert.Nil(t, iter.Next(ctx))
+	cancel()
+
+	txs := newUniqueTxs(3)
+	callCheckTx(t, mp, txs)
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	for _, tx := range txs {
+		entry := iter.Next(ctx)
+		require.NotNil(t, entry)
+		assert.Equal(t, tx, entry.Tx())
+		assert.Equal(t, int64(0), entry.Height())
+	}
+
+	// removed txs are skipped
+	next := mp.NewIterator()
+	require.Equal(t, txs[0], next.Next(ctx).Tx())
+	require.NoError(t, mp.RemoveTxByKey(txs[1].Key()))
+	assert.Equal(t, txs[2], next.Next(ctx).Tx())
+
+	// txs added later are returned
+	later := newUniqueTxs(4)[3:]
+	callCheckTx(t, mp, later)
+	assert.Equal(t, later[0], iter.Next(ctx).Tx())
+}
+
+func TestNopIterator(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Nil(t, (&NopMempool{}).NewIterator().Next(ctx))
+}
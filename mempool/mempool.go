@@ -12,6 +12,11 @@ import (
 const (
 	MempoolChannel = byte(0x30)
 
+	// MempoolAnnounceChannel is used to announce and request txs by their
+	// key. Only the nodes announcing txs advertise it, see
+	// MempoolConfig.ExperimentalTxAnnouncements.
+	MempoolAnnounceChannel = byte(0x31)
+
 	// PeerCatchupSleepIntervalMS defines how much time to sleep if a peer is behind.
 	PeerCatchupSleepIntervalMS = 100
 )
@@ -2,18 +2,40 @@ package mempool
 
 import (
 	"sync/atomic"
+	"time"
 
 	"github.com/cometbft/cometbft/types"
 )
 
 // mempoolTx is an entry in the mempool.
 type mempoolTx struct {
-	height    int64    // height that this tx had been validated in
-	gasWanted int64    // amount of gas this tx states it will require
-	tx        types.Tx // validated by the application
+	height    int64     // height that this tx had been validated in
+	timestamp time.Time // time when this tx was added to the mempool
+	gasWanted int64     // amount of gas this tx states it will require
+	tx        types.Tx  // validated by the application
+
+	// Only used by the PriorityMempool.
+	priority int64  // priority assigned by the application
+	sender   string // sender assigned by the application (optional)
+	seq      uint64 // order in which this tx was added to the mempool
+}
+
+// Tx returns the transaction.
+func (memTx *mempoolTx) Tx() types.Tx {
+	return memTx.tx
 }
 
 // Height returns the height for this transaction.
 func (memTx *mempoolTx) Height() int64 {
 	return atomic.LoadInt64(&memTx.height)
 }
+
+// isExpired returns true if the transaction was added to the mempool more than
+// ttlNumBlocks blocks before height, or more than ttlDuration before now. A
+// zero TTL disables the corresponding check.
+func (memTx *mempoolTx) isExpired(height int64, now time.Time, ttlNumBlocks int64, ttlDuration time.Duration) bool {
+	if ttlNumBlocks > 0 && height-memTx.Height() > ttlNumBlocks {
+		return true
+	}
+	return ttlDuration > 0 && now.Sub(memTx.timestamp) > ttlDuration
+}
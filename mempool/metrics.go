@@ -34,6 +34,18 @@ type Metrics struct {
 	// metrics:Number of rejected transactions.
 	RejectedTxs metrics.Counter
 
+	// EvictedTxs defines the number of evicted transactions. These are valid
+	// transactions that were removed from the mempool to make room for
+	// transactions with a higher priority.
+	// metrics:Number of evicted transactions.
+	EvictedTxs metrics.Counter
+
+	// ExpiredTxs defines the number of expired transactions. These are
+	// transactions that were removed from the mempool because they stayed
+	// there for longer than ttl-num-blocks blocks or ttl-duration.
+	// metrics:Number of expired transactions.
+	ExpiredTxs metrics.Counter
+
 	// Number of times transactions are rechecked in the mempool.
 	RecheckTimes metrics.Counter
 
@@ -1,6 +1,7 @@
 package mempool
 
 import (
+	"context"
 	"errors"
 
 	abcicli "github.com/cometbft/cometbft/abci/client"
@@ -75,6 +76,27 @@ func (*NopMempool) Size() int { return 0 }
 // SizeBytes always returns 0.
 func (*NopMempool) SizeBytes() int64 { return 0 }
 
+// InMempool always returns false.
+func (*NopMempool) InMempool(types.TxKey) bool { return false }
+
+// GetTxByKey always returns false.
+func (*NopMempool) GetTxByKey(types.TxKey) (types.Tx, bool) { return nil, false }
+
+// HasSeen always returns false.
+func (*NopMempool) HasSeen(types.TxKey) bool { return false }
+
+// NewIterator returns an iterator that never returns a transaction.
+func (*NopMempool) NewIterator() Iterator { return nopIterator{} }
+
+// nopIterator is an Iterator over an always empty mempool.
+type nopIterator struct{}
+
+// Next blocks until ctx is done and returns nil.
+func (nopIterator) Next(ctx context.Context) Entry {
+	<-ctx.Done()
+	return nil
+}
+
 // NopMempoolReactor is a mempool reactor that does nothing.
 type NopMempoolReactor struct {
 	service.BaseService
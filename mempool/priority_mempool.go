@@ -0,0 +1,629 @@
+package mempool
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	abcicli "github.com/cometbft/cometbft/abci/client"
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/clist"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// PriorityMempool is an in-memory pool for transactions that orders them by
+// the priority assigned by the application in CheckTx.
+//
+// Transactions are reaped in decreasing order of priority, breaking ties by
+// arrival order. When the application also returns a sender for a
+// transaction, transactions of the same sender are reaped in the order they
+// were received (for example, to respect account nonces), regardless of their
+// individual priorities. When the mempool is full, a new transaction evicts
+// transactions with a strictly lower priority, if any, instead of being
+// rejected.
+//
+// Transactions are also kept in a concurrent linked list, in the order they
+// were added, so that the Reactor can gossip them the same way it does for
+// the CListMempool.
+type PriorityMempool struct {
+	baseMempool
+
+	// Number of transactions sent to the application for rechecking and for
+	// which we haven't received a response yet.
+	recheckPending atomic.Int64
+
+	// mtx protects the indexes below, which must be kept in sync with `txs`.
+	//
+	// `txsMap`: txKey -> CElement is for quick access to txs.
+	// `senders`: sender -> txs of that sender, in the order they were added.
+	mtx     cmtsync.Mutex
+	txsMap  map[types.TxKey]*clist.CElement
+	senders map[string][]*mempoolTx
+	seq     uint64 // sequence number of the last added tx
+}
+
+var _ Mempool = &PriorityMempool{}
+
+// PriorityMempoolOption sets an optional parameter on the mempool.
+type PriorityMempoolOption func(*PriorityMempool)
+
+// NewPriorityMempool returns a new priority mempool with the given
+// configuration and connection to an application.
+func NewPriorityMempool(
+	cfg *config.MempoolConfig,
+	proxyAppConn proxy.AppConnMempool,
+	height int64,
+	options ...PriorityMempoolOption,
+) *PriorityMempool {
+	mp := &PriorityMempool{
+		txsMap:  make(map[types.TxKey]*clist.CElement),
+		senders: make(map[string][]*mempoolTx),
+	}
+	mp.init(cfg, proxyAppConn, height)
+
+	proxyAppConn.SetResponseCallback(mp.globalCb)
+	if rn, ok := proxyAppConn.(abcicli.ReconnectNotifier); ok {
+		rn.SetReconnectCallback(mp.handleReconnect)
+	}
+
+	for _, option := range options {
+		option(mp)
+	}
+
+	return mp
+}
+
+// WithPriorityPreCheck sets a filter for the mempool to reject a tx if f(tx)
+// returns an error. This is ran before CheckTx. Only applies to the first
+// created block. After that, Update overwrites the existing value.
+func WithPriorityPreCheck(f PreCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.preCheck = f }
+}
+
+// WithPriorityPostCheck sets a filter for the mempool to reject a tx if
+// f(tx) returns an error. This is ran after CheckTx. Only applies to the
+// first created block. After that, Update overwrites the existing value.
+func WithPriorityPostCheck(f PostCheckFunc) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.postCheck = f }
+}
+
+// WithPriorityMetrics sets the metrics.
+func WithPriorityMetrics(metrics *Metrics) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.metrics = metrics }
+}
+
+// WithPriorityTxTracker sets the tracker recording the lifecycle of the txs.
+func WithPriorityTxTracker(txTracker *TxTracker) PriorityMempoolOption {
+	return func(mem *PriorityMempool) { mem.txTracker = txTracker }
+}
+
+// InMempool returns true if the transaction with the given key is in the
+// mempool.
+func (mem *PriorityMempool) InMempool(txKey types.TxKey) bool {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	_, ok := mem.txsMap[txKey]
+	return ok
+}
+
+// GetTxByKey returns the transaction with the given key, if it's in the
+// mempool.
+func (mem *PriorityMempool) GetTxByKey(txKey types.TxKey) (types.Tx, bool) {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if e, ok := mem.txsMap[txKey]; ok {
+		return e.Value.(*mempoolTx).tx, true
+	}
+	return nil, false
+}
+
+// HasSeen returns true if the transaction with the given key is in the
+// mempool or in its cache.
+func (mem *PriorityMempool) HasSeen(txKey types.TxKey) bool {
+	return mem.cache.HasKey(txKey) || mem.InMempool(txKey)
+}
+
+// XXX: Unsafe! Calling Flush may leave mempool in inconsistent state.
+func (mem *PriorityMempool) Flush() {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.cache.Reset()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		mem.txs.Remove(e)
+		e.DetachPrev()
+	}
+	for txKey := range mem.txsMap {
+		mem.invokeRemoveTxOnReactor(txKey)
+		mem.txTracker.removed(txKey, mem.height.Load(), TxRemovedFlushed)
+	}
+	mem.txsMap = make(map[types.TxKey]*clist.CElement)
+	mem.senders = make(map[string][]*mempoolTx)
+	mem.txsBytes.Store(0)
+}
+
+// CheckTx sends the transaction to the application for validation. Unlike
+// the CListMempool, it does not reject the transaction if the mempool is
+// full, because the transaction may have a higher priority than others in
+// the mempool; this is only known after the application has checked it.
+//
+// It blocks if we're waiting on Update() or Reap().
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) CheckTx(tx types.Tx) (*abcicli.ReqRes, error) {
+	mem.updateMtx.RLock()
+	// use defer to unlock mutex because application (*local client*) might panic
+	defer mem.updateMtx.RUnlock()
+
+	return mem.checkTx(tx)
+}
+
+// Global callback that will be called after every ABCI response.
+func (mem *PriorityMempool) globalCb(req *abci.Request, res *abci.Response) {
+	switch res.Value.(type) {
+	case *abci.Response_CheckTx:
+		checkType := req.GetCheckTx().GetType()
+		switch checkType {
+		case abci.CHECK_TX_TYPE_CHECK:
+			mem.resCbFirstTime(req.GetCheckTx().Tx, res.GetCheckTx())
+
+		case abci.CHECK_TX_TYPE_RECHECK:
+			mem.metrics.RecheckTimes.Add(1)
+			mem.resCbRecheck(req.GetCheckTx().Tx, res.GetCheckTx())
+
+		default:
+			panic(fmt.Sprintf("unexpected value %d of RequestCheckTx.type", checkType))
+		}
+
+		mem.updateSizeMetrics()
+
+	default:
+		// ignore other messages
+	}
+}
+
+// callback, which is called after the app checked the tx for the first time.
+func (mem *PriorityMempool) resCbFirstTime(tx types.Tx, res *abci.CheckTxResponse) {
+	memTx := mem.acceptCheckTx(tx, res)
+	if memTx == nil {
+		return
+	}
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if _, ok := mem.txsMap[tx.Key()]; ok {
+		mem.logger.Debug(
+			"transaction already in mempool, not adding it again",
+			"tx", tx.Hash(),
+			"height", mem.height.Load(),
+			"total", mem.Size(),
+		)
+		return
+	}
+
+	// Make room for the new transaction by evicting transactions with a lower
+	// priority, if needed and possible.
+	victims, err := mem.evictionVictims(memTx)
+	if err != nil {
+		mem.forceRemoveFromCache(tx) // mempool might have space later
+		mem.metrics.RejectedTxs.Add(1)
+		mem.logger.Debug(err.Error(), "tx", tx.Hash(), "priority", memTx.priority)
+		mem.txTracker.removed(tx.Key(), mem.height.Load(), TxRemovedMempoolFull)
+		return
+	}
+	for _, victim := range victims {
+		mem.logger.Debug(
+			"evicted transaction",
+			"tx", victim.tx.Hash(),
+			"priority", victim.priority,
+			"new_tx", tx.Hash(),
+			"new_priority", memTx.priority,
+		)
+		mem.removeTx(victim.tx.Key())
+		mem.forceRemoveFromCache(victim.tx)
+		mem.metrics.EvictedTxs.Add(1)
+		mem.txTracker.removed(victim.tx.Key(), mem.height.Load(), TxRemovedEvicted)
+	}
+
+	mem.addTx(memTx)
+	mem.notifyTxsAvailable()
+}
+
+// evictionVictims returns the transactions that need to be evicted so that
+// memTx fits in the mempool. Only transactions with a strictly lower priority
+// than memTx can be evicted. In order to preserve the ordering of the
+// transactions of a sender, only its latest transaction is a candidate for
+// eviction at any point, and no transaction from memTx's sender is evicted.
+//
+// It returns an ErrMempoolIsFull error if there is not enough room in the
+// mempool even after evicting all possible candidates.
+//
+// The caller must hold mem.mtx.
+func (mem *PriorityMempool) evictionVictims(memTx *mempoolTx) ([]*mempoolTx, error) {
+	var (
+		numTxs      = mem.Size()
+		txsBytes    = mem.SizeBytes()
+		maxTxs      = int(mem.maxTxs.Load())
+		maxTxsBytes = mem.maxTxsBytes.Load()
+		txSize      = int64(len(memTx.tx))
+		victims     []*mempoolTx
+		// number of txs already picked as victims, by sender
+		evictedBySender = make(map[string]int)
+	)
+	if numTxs < maxTxs && txSize+txsBytes <= maxTxsBytes {
+		return nil, nil
+	}
+
+	// Initially, the queue contains the candidates without a sender and the
+	// latest tx of every other sender, if it's a candidate.
+	pq := txEvictionQueue{make(txPriorityQueue, 0, len(mem.senders))}
+	for _, e := range mem.txsMap {
+		candidate := e.Value.(*mempoolTx)
+		if candidate.sender == "" && candidate.priority < memTx.priority {
+			pq.txPriorityQueue = append(pq.txPriorityQueue, candidate)
+		}
+	}
+	for sender, queue := range mem.senders {
+		if candidate := queue[len(queue)-1]; sender != memTx.sender && candidate.priority < memTx.priority {
+			pq.txPriorityQueue = append(pq.txPriorityQueue, candidate)
+		}
+	}
+	heap.Init(&pq)
+
+	for numTxs >= maxTxs || txSize+txsBytes > maxTxsBytes {
+		if pq.Len() == 0 {
+			return nil, ErrMempoolIsFull{
+				NumTxs:      mem.Size(),
+				MaxTxs:      maxTxs,
+				TxsBytes:    mem.SizeBytes(),
+				MaxTxsBytes: maxTxsBytes,
+			}
+		}
+
+		victim := heap.Pop(&pq).(*mempoolTx)
+		victims = append(victims, victim)
+		numTxs--
+		txsBytes -= int64(len(victim.tx))
+
+		// The previous tx of the sender becomes its latest one.
+		if victim.sender != "" {
+			evictedBySender[victim.sender]++
+			queue := mem.senders[victim.sender]
+			if i := len(queue) - 1 - evictedBySender[victim.sender]; i >= 0 && queue[i].priority < memTx.priority {
+				heap.Push(&pq, queue[i])
+			}
+		}
+	}
+
+	return victims, nil
+}
+
+// addTx adds memTx to the mempool. The caller must hold mem.mtx.
+func (mem *PriorityMempool) addTx(memTx *mempoolTx) {
+	tx := memTx.tx
+
+	mem.seq++
+	memTx.seq = mem.seq
+
+	e := mem.txs.PushBack(memTx)
+	mem.txsMap[tx.Key()] = e
+	if memTx.sender != "" {
+		mem.senders[memTx.sender] = append(mem.senders[memTx.sender], memTx)
+	}
+	mem.txsBytes.Add(int64(len(tx)))
+	mem.metrics.TxSizeBytes.Observe(float64(len(tx)))
+
+	mem.logger.Debug(
+		"added valid transaction",
+		"tx", tx.Hash(),
+		"priority", memTx.priority,
+		"sender", memTx.sender,
+		"height", mem.height.Load(),
+		"total", mem.Size(),
+	)
+}
+
+// removeTx removes the transaction with the given key from the mempool and
+// reports whether it was found. The caller must hold mem.mtx.
+func (mem *PriorityMempool) removeTx(txKey types.TxKey) bool {
+	// The transaction should be removed from the reactor, even if it cannot be
+	// found in the mempool.
+	mem.invokeRemoveTxOnReactor(txKey)
+
+	elem, ok := mem.txsMap[txKey]
+	if !ok {
+		return false
+	}
+
+	mem.txs.Remove(elem)
+	elem.DetachPrev()
+	delete(mem.txsMap, txKey)
+
+	memTx := elem.Value.(*mempoolTx)
+	if memTx.sender != "" {
+		queue := mem.senders[memTx.sender]
+		for i, senderTx := range queue {
+			if senderTx == memTx {
+				queue = append(queue[:i], queue[i+1:]...)
+				break
+			}
+		}
+		if len(queue) == 0 {
+			delete(mem.senders, memTx.sender)
+		} else {
+			mem.senders[memTx.sender] = queue
+		}
+	}
+
+	mem.txsBytes.Add(int64(-len(memTx.tx)))
+	mem.logger.Debug("removed transaction", "tx", memTx.tx.Hash(), "height", mem.height.Load(), "total", mem.Size())
+	return true
+}
+
+// RemoveTxByKey removes a transaction from the mempool by its TxKey index.
+func (mem *PriorityMempool) RemoveTxByKey(txKey types.TxKey) error {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	if !mem.removeTx(txKey) {
+		return ErrTxNotFound
+	}
+	mem.txTracker.removed(txKey, mem.height.Load(), TxRemovedByKey)
+	return nil
+}
+
+// callback, which is called after the app rechecked the tx.
+//
+// The application may assign a new priority to the transaction.
+func (mem *PriorityMempool) resCbRecheck(tx types.Tx, res *abci.CheckTxResponse) {
+	defer func() {
+		if mem.recheckPending.Add(-1) == 0 {
+			// Done!
+			mem.logger.Debug("done rechecking txs")
+
+			// in case the recheck removed all txs
+			if mem.Size() > 0 {
+				mem.notifyTxsAvailable()
+			}
+		}
+	}()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	elem, ok := mem.txsMap[tx.Key()]
+	if !ok {
+		// The transaction was removed (e.g., evicted) while being rechecked.
+		return
+	}
+
+	if mem.rejectRecheckTx(tx, res) {
+		mem.removeTx(tx.Key())
+		return
+	}
+
+	elem.Value.(*mempoolTx).priority = res.Priority
+}
+
+// purgeExpiredTxs removes the txs that stayed in the mempool for longer than
+// TTLNumBlocks blocks or TTLDuration, if set. They are removed from the cache
+// too, so that they can be submitted again.
+//
+// Called from Update (lock held).
+func (mem *PriorityMempool) purgeExpiredTxs(height int64) {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	for _, memTx := range mem.expiredTxs(height) {
+		if !mem.removeTx(memTx.tx.Key()) {
+			continue
+		}
+		mem.purgedExpiredTx(height, memTx)
+	}
+}
+
+// orderedTxs returns all transactions in the mempool in the order in which
+// they should be included in a block: by decreasing priority, except that
+// the transactions of a sender are returned in the order they were added.
+//
+// The caller must hold mem.mtx.
+func (mem *PriorityMempool) orderedTxs() []*mempoolTx {
+	var (
+		txs  = make([]*mempoolTx, 0, len(mem.txsMap))
+		next = make(map[string]int, len(mem.senders)) // index of the next tx of each sender
+		pq   = make(txPriorityQueue, 0, len(mem.senders))
+	)
+
+	// Initially, the queue contains all txs without a sender and the first tx
+	// of every sender.
+	for _, e := range mem.txsMap {
+		memTx := e.Value.(*mempoolTx)
+		if memTx.sender == "" {
+			pq = append(pq, memTx)
+		}
+	}
+	for sender, queue := range mem.senders {
+		pq = append(pq, queue[0])
+		next[sender] = 1
+	}
+	heap.Init(&pq)
+
+	for pq.Len() > 0 {
+		memTx := heap.Pop(&pq).(*mempoolTx)
+		txs = append(txs, memTx)
+
+		if memTx.sender == "" {
+			continue
+		}
+		if queue := mem.senders[memTx.sender]; next[memTx.sender] < len(queue) {
+			heap.Push(&pq, queue[next[memTx.sender]])
+			next[memTx.sender]++
+		}
+	}
+
+	return txs
+}
+
+// ReapMaxBytesMaxGas reaps transactions in priority order.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxBytesMaxGas(maxBytes, maxGas int64) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	return reapMaxBytesMaxGas(mem.orderedTxs(), maxBytes, maxGas)
+}
+
+// ReapMaxTxs reaps up to max transactions in priority order.
+//
+// Safe for concurrent use by multiple goroutines.
+func (mem *PriorityMempool) ReapMaxTxs(max int) types.Txs {
+	mem.updateMtx.RLock()
+	defer mem.updateMtx.RUnlock()
+
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+
+	memTxs := mem.orderedTxs()
+	if max < 0 || max > len(memTxs) {
+		max = len(memTxs)
+	}
+
+	txs := make([]types.Tx, 0, max)
+	for _, memTx := range memTxs[:max] {
+		txs = append(txs, memTx.tx)
+	}
+	return txs
+}
+
+// Lock() must be help by the caller during execution.
+func (mem *PriorityMempool) Update(
+	height int64,
+	txs types.Txs,
+	txResults []*abci.ExecTxResult,
+	preCheck PreCheckFunc,
+	postCheck PostCheckFunc,
+) error {
+	mem.beginUpdate(height, preCheck, postCheck)
+
+	for i, tx := range txs {
+		mem.updateCommittedTx(height, tx, txResults[i])
+
+		// Remove committed tx from the mempool.
+		mem.mtx.Lock()
+		if !mem.removeTx(tx.Key()) {
+			mem.logger.Debug("Committed transaction not in local mempool (not an error)",
+				"key", tx.Key())
+		}
+		mem.mtx.Unlock()
+	}
+
+	mem.purgeExpiredTxs(height)
+
+	// Either recheck non-committed txs to see if they became invalid
+	// or just notify there're some txs left.
+	mem.endUpdate(height, mem.recheckTxs)
+
+	return nil
+}
+
+func (mem *PriorityMempool) recheckTxs() {
+	mem.mtx.Lock()
+	memTxs := mem.memTxs()
+	mem.mtx.Unlock()
+
+	if len(memTxs) == 0 {
+		return
+	}
+	mem.recheckPending.Store(int64(len(memTxs)))
+
+	// Push txs to proxyAppConn
+	// NOTE: globalCb may be called concurrently.
+	for i, memTx := range memTxs {
+		_, err := mem.proxyAppConn.CheckTxAsync(context.TODO(), &abci.CheckTxRequest{
+			Tx:   memTx.tx,
+			Type: abci.CHECK_TX_TYPE_RECHECK,
+		})
+		if err != nil {
+			mem.logger.Error("recheckTx", "err", err)
+			// Account for the requests that won't be sent.
+			mem.recheckPending.Add(int64(i - len(memTxs)))
+			return
+		}
+	}
+}
+
+// handleReconnect is called when the connection to the application was
+// re-established. The requests of the recheck in progress, if any, were lost
+// with the previous connection, so it aborts the recheck, lets the pending
+// CheckTx requests be sent again, and rechecks all the txs.
+func (mem *PriorityMempool) handleReconnect(resume func()) {
+	mem.updateMtx.Lock()
+	defer mem.updateMtx.Unlock()
+
+	mem.recheckPending.Store(0)
+	resume()
+
+	if err := mem.proxyAppConn.Flush(context.TODO()); err != nil {
+		mem.logger.Error("Failed to flush the CheckTx requests sent again", "err", err)
+		return
+	}
+	if mem.config.Recheck {
+		mem.recheckTxs()
+	}
+}
+
+// txPriorityQueue is a max-heap of transactions ordered by priority. Among
+// transactions with the same priority, the first one added to the mempool
+// comes first.
+type txPriorityQueue []*mempoolTx
+
+var _ heap.Interface = (*txPriorityQueue)(nil)
+
+func (pq txPriorityQueue) Len() int { return len(pq) }
+
+func (pq txPriorityQueue) Less(i, j int) bool {
+	if pq[i].priority == pq[j].priority {
+		return pq[i].seq < pq[j].seq
+	}
+	return pq[i].priority > pq[j].priority
+}
+
+func (pq txPriorityQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i] }
+
+func (pq *txPriorityQueue) Push(x any) {
+	*pq = append(*pq, x.(*mempoolTx))
+}
+
+func (pq *txPriorityQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	memTx := old[n-1]
+	old[n-1] = nil
+	*pq = old[:n-1]
+	return memTx
+}
+
+// txEvictionQueue is a min-heap of transactions ordered by priority, i.e. in
+// the reverse order of txPriorityQueue: among transactions with the same
+// priority, the last one added to the mempool comes first.
+type txEvictionQueue struct {
+	txPriorityQueue
+}
+
+var _ heap.Interface = (*txEvictionQueue)(nil)
+
+func (pq txEvictionQueue) Less(i, j int) bool { return pq.txPriorityQueue.Less(j, i) }
@@ -0,0 +1,242 @@
+package mempool
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+// priorityApp accepts transactions of the form "sender:priority:payload",
+// where sender may be empty, and assigns them the given priority and sender.
+// Transactions whose payload is "invalid" are rejected on recheck.
+type priorityApp struct {
+	abci.BaseApplication
+}
+
+func (*priorityApp) CheckTx(_ context.Context, req *abci.CheckTxRequest) (*abci.CheckTxResponse, error) {
+	parts := strings.SplitN(string(req.Tx), ":", 3)
+	if len(parts) != 3 {
+		return &abci.CheckTxResponse{Code: 1}, nil
+	}
+	priority, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return &abci.CheckTxResponse{Code: 1}, nil
+	}
+	if req.Type == abci.CHECK_TX_TYPE_RECHECK && parts[2] == "invalid" {
+		return &abci.CheckTxResponse{Code: 1}, nil
+	}
+	return &abci.CheckTxResponse{
+		Code:      abci.CodeTypeOK,
+		GasWanted: 1,
+		Priority:  priority,
+		Sender:    parts[0],
+	}, nil
+}
+
+func priorityTx(sender string, priority int64, payload string) types.Tx {
+	return types.Tx(fmt.Sprintf("%s:%d:%s", sender, priority, payload))
+}
+
+func newPriorityMempool(t *testing.T, size int) *PriorityMempool {
+	t.Helper()
+
+	conf := test.ResetTestRoot("mempool_test")
+	t.Cleanup(func() { os.RemoveAll(conf.RootDir) })
+	conf.Mempool.Size = size
+
+	appConnMem, err := proxy.NewLocalClientCreator(&priorityApp{}).NewABCIMempoolClient()
+	require.NoError(t, err)
+	require.NoError(t, appConnMem.Start())
+	t.Cleanup(func() {
+		if err := appConnMem.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	mp := NewPriorityMempool(conf.Mempool, appConnMem, 0)
+	mp.SetLogger(log.TestingLogger())
+	return mp
+}
+
+func TestPriorityMempoolReapOrder(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+
+	txs := types.Txs{
+		priorityTx("", 1, "a"),
+		priorityTx("", 10, "b"),
+		priorityTx("", 5, "c"),
+		priorityTx("", 10, "d"),
+	}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, len(txs), mp.Size())
+
+	expected := types.Txs{txs[1], txs[3], txs[2], txs[0]}
+	require.Equal(t, expected, mp.ReapMaxTxs(-1))
+	require.Equal(t, expected[:2], mp.ReapMaxTxs(2))
+	require.Equal(t, expected[:3], mp.ReapMaxBytesMaxGas(-1, 3))
+
+	// The gossip order is the arrival order.
+	i := 0
+	for e := mp.TxsFront(); e != nil; e = e.Next() {
+		require.Equal(t, txs[i], e.Value.(*mempoolTx).tx)
+		i++
+	}
+}
+
+func TestPriorityMempoolSenderOrder(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("alice", 50, "1"),
+		priorityTx("bob", 10, "0"),
+		priorityTx("", 20, "x"),
+	}
+	callCheckTx(t, mp, txs)
+
+	// alice's second tx has the highest priority, but it can only be reaped
+	// after her first one.
+	expected := types.Txs{txs[3], txs[2], txs[0], txs[1]}
+	require.Equal(t, expected, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolEviction(t *testing.T) {
+	mp := newPriorityMempool(t, 3)
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("alice", 2, "1"),
+		priorityTx("", 3, "x"),
+	}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, 3, mp.Size())
+
+	// A tx with a lower priority than all txs in the mempool is rejected.
+	callCheckTx(t, mp, types.Txs{priorityTx("", 0, "y")})
+	require.Equal(t, 3, mp.Size())
+	require.False(t, mp.InMempool(priorityTx("", 0, "y").Key()))
+
+	// Only alice's latest tx can be evicted, even if her first tx has a lower
+	// priority.
+	high := priorityTx("", 4, "z")
+	callCheckTx(t, mp, types.Txs{high})
+	require.Equal(t, 3, mp.Size())
+	require.True(t, mp.InMempool(high.Key()))
+	require.True(t, mp.InMempool(txs[0].Key()))
+	require.False(t, mp.InMempool(txs[1].Key()))
+	require.Equal(t, types.Txs{high, txs[2], txs[0]}, mp.ReapMaxTxs(-1))
+
+	// The evicted tx is removed from the cache, so it can be resubmitted, but
+	// there are no txs with a lower priority left to evict.
+	_, err := mp.CheckTx(txs[1])
+	require.NoError(t, err)
+	require.False(t, mp.InMempool(txs[1].Key()))
+
+	// A tx from alice never evicts one of her previous txs.
+	next := priorityTx("alice", 100, "2")
+	callCheckTx(t, mp, types.Txs{next})
+	require.True(t, mp.InMempool(next.Key()))
+	require.True(t, mp.InMempool(txs[0].Key()))
+	require.False(t, mp.InMempool(txs[2].Key()))
+	require.Equal(t, types.Txs{high, txs[0], next}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolSetLimits(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+
+	low := priorityTx("", 1, "a")
+	callCheckTx(t, mp, types.Txs{low})
+
+	// Once the mempool is full, a new tx evicts the txs with a lower priority.
+	mp.SetLimits(1, 1000)
+	high := priorityTx("", 2, "b")
+	callCheckTx(t, mp, types.Txs{high})
+	require.Equal(t, types.Txs{high}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolEvictionMultipleVictims(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("alice", 2, "1"),
+		priorityTx("bob", 3, "0"),
+		priorityTx("", 5, "x"),
+	}
+	callCheckTx(t, mp, txs)
+
+	// Evicting alice's latest tx makes her first one a candidate, so all txs
+	// with a lower priority than the new one are evicted.
+	mp.SetLimits(2, 1000)
+	tx := priorityTx("", 4, "y")
+	callCheckTx(t, mp, types.Txs{tx})
+	require.Equal(t, types.Txs{txs[3], tx}, mp.ReapMaxTxs(-1))
+}
+
+func TestPriorityMempoolTTL(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+	mp.config.TTLNumBlocks = 1
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("bob", 2, "0"),
+	}
+	callCheckTx(t, mp, txs[:1])
+	mp.Lock()
+	err := mp.Update(1, nil, nil, nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	callCheckTx(t, mp, txs[1:])
+
+	mp.Lock()
+	err = mp.Update(2, nil, nil, nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+	require.Equal(t, txs[1:], mp.ReapMaxTxs(-1))
+
+	// Expired txs are removed from the cache, so they can be submitted again.
+	callCheckTx(t, mp, txs[:1])
+	require.Equal(t, 2, mp.Size())
+}
+
+func TestPriorityMempoolUpdate(t *testing.T) {
+	mp := newPriorityMempool(t, 100)
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("alice", 2, "invalid"),
+		priorityTx("bob", 3, "0"),
+		priorityTx("", 4, "x"),
+	}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, 4, mp.Size())
+
+	// Commit alice's first tx; her second one becomes invalid on recheck.
+	mp.Lock()
+	err := mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+
+	require.Equal(t, types.Txs{txs[3], txs[2]}, mp.ReapMaxTxs(-1))
+	require.Zero(t, mp.recheckPending.Load())
+
+	// Committed txs stay in the cache.
+	_, err = mp.CheckTx(txs[0])
+	require.ErrorIs(t, err, ErrTxInCache)
+
+	mp.Flush()
+	require.Zero(t, mp.Size())
+	require.Zero(t, mp.SizeBytes())
+	require.Empty(t, mp.ReapMaxTxs(-1))
+}
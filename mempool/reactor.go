@@ -12,7 +12,6 @@ import (
 	abci "github.com/cometbft/cometbft/abci/types"
 	protomem "github.com/cometbft/cometbft/api/cometbft/mempool/v1"
 	cfg "github.com/cometbft/cometbft/config"
-	"github.com/cometbft/cometbft/internal/clist"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/p2p"
@@ -25,7 +24,8 @@ import (
 type Reactor struct {
 	p2p.BaseReactor
 	config  *cfg.MempoolConfig
-	mempool *CListMempool
+	mempool GossipMempool
+	metrics *Metrics
 
 	waitSync   atomic.Bool
 	waitSyncCh chan struct{} // for signaling when to start receiving and sending txs
@@ -37,6 +37,11 @@ type Reactor struct {
 	txSenders    map[types.TxKey]map[p2p.ID]bool
 	txSendersMtx cmtsync.Mutex
 
+	// Txs requested from the peers that announced them, and by the peers we
+	// announced them to, when announcing txs is enabled.
+	txRequests  *txRequests
+	txResponses *txResponses
+
 	// Semaphores to keep track of how many connections to peers are active for broadcasting
 	// transactions. Each semaphore has a capacity that puts an upper bound on the number of
 	// connections for different groups of peers.
@@ -44,15 +49,31 @@ type Reactor struct {
 	activeNonPersistentPeersSemaphore *semaphore.Weighted
 }
 
-// NewReactor returns a new Reactor with the given config and mempool.
-func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, waitSync bool) *Reactor {
+// ReactorOption sets an optional parameter on the Reactor.
+type ReactorOption func(*Reactor)
+
+// WithReactorMetrics sets the metrics updated by the Reactor.
+func WithReactorMetrics(metrics *Metrics) ReactorOption {
+	return func(memR *Reactor) { memR.metrics = metrics }
+}
+
+// NewReactor returns a new Reactor with the given config and mempool. The
+// Reactor checks the transactions received from peers with the mempool, and
+// gossips the transactions of the mempool to its peers.
+func NewReactor(config *cfg.MempoolConfig, mempool GossipMempool, waitSync bool, options ...ReactorOption) *Reactor {
 	memR := &Reactor{
-		config:    config,
-		mempool:   mempool,
-		waitSync:  atomic.Bool{},
-		txSenders: make(map[types.TxKey]map[p2p.ID]bool),
+		config:      config,
+		mempool:     mempool,
+		metrics:     NopMetrics(),
+		waitSync:    atomic.Bool{},
+		txSenders:   make(map[types.TxKey]map[p2p.ID]bool),
+		txRequests:  newTxRequests(),
+		txResponses: newTxResponses(),
 	}
 	memR.BaseReactor = *p2p.NewBaseReactor("Mempool", memR)
+	for _, option := range options {
+		option(memR)
+	}
 	if waitSync {
 		memR.waitSync.Store(true)
 		memR.waitSyncCh = make(chan struct{})
@@ -64,10 +85,13 @@ func NewReactor(config *cfg.MempoolConfig, mempool *CListMempool, waitSync bool)
 	return memR
 }
 
-// SetLogger sets the Logger on the reactor and the underlying mempool.
+// SetLogger sets the Logger on the reactor and, if it has one, on the
+// underlying mempool.
 func (memR *Reactor) SetLogger(l log.Logger) {
 	memR.Logger = l
-	memR.mempool.SetLogger(l)
+	if mp, ok := memR.mempool.(interface{ SetLogger(l log.Logger) }); ok {
+		mp.SetLogger(l)
+	}
 }
 
 // OnStart implements p2p.BaseReactor.
@@ -78,6 +102,9 @@ func (memR *Reactor) OnStart() error {
 	if !memR.config.Broadcast {
 		memR.Logger.Info("Tx broadcasting is disabled")
 	}
+	if memR.config.ExperimentalTxAnnouncements {
+		go memR.txRequestsRoutine()
+	}
 	return nil
 }
 
@@ -91,7 +118,7 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 		},
 	}
 
-	return []*p2p.ChannelDescriptor{
+	chDescs := []*p2p.ChannelDescriptor{
 		{
 			ID:                  MempoolChannel,
 			Priority:            5,
@@ -99,6 +126,20 @@ func (memR *Reactor) GetChannels() []*p2p.ChannelDescriptor {
 			MessageType:         &protomem.Message{},
 		},
 	}
+	if memR.config.ExperimentalTxAnnouncements {
+		announceMsg := protomem.Message{
+			Sum: &protomem.Message_HaveTx{
+				HaveTx: &protomem.HaveTx{TxKey: make([]byte, types.TxKeySize)},
+			},
+		}
+		chDescs = append(chDescs, &p2p.ChannelDescriptor{
+			ID:                  MempoolAnnounceChannel,
+			Priority:            5,
+			RecvMessageCapacity: announceMsg.Size(),
+			MessageType:         &protomem.Message{},
+		})
+	}
+	return chDescs
 }
 
 // AddPeer implements Reactor.
@@ -137,13 +178,20 @@ func (memR *Reactor) AddPeer(peer p2p.Peer) {
 				}
 			}
 
-			memR.mempool.metrics.ActiveOutboundConnections.Add(1)
-			defer memR.mempool.metrics.ActiveOutboundConnections.Add(-1)
+			memR.metrics.ActiveOutboundConnections.Add(1)
+			defer memR.metrics.ActiveOutboundConnections.Add(-1)
 			memR.broadcastTxRoutine(peer)
 		}()
 	}
 }
 
+// RemovePeer implements Reactor.
+// It forgets the txs the peer announced, so they are requested from others.
+func (memR *Reactor) RemovePeer(peer p2p.Peer, _ interface{}) {
+	memR.txRequests.removePeer(peer.ID())
+	memR.txResponses.removePeer(peer.ID())
+}
+
 // Receive implements Reactor.
 // It adds any received transactions to the mempool.
 func (memR *Reactor) Receive(e p2p.Envelope) {
@@ -163,10 +211,14 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 
 		for _, txBytes := range protoTxs {
 			tx := types.Tx(txBytes)
+			memR.txRequests.received(tx.Key())
 			reqRes, err := memR.mempool.CheckTx(tx)
 			switch {
 			case errors.Is(err, ErrTxInCache):
 				memR.Logger.Debug("Tx already exists in cache", "tx", tx.Hash())
+			case errors.As(err, &ErrTxTooLarge{}) || IsPreCheckError(err):
+				memR.Logger.Info("Could not check tx", "tx", tx.Hash(), "err", err)
+				memR.Switch.ReportPeer(e.Src, p2p.PeerBehaviorBadTx)
 			case err != nil:
 				memR.Logger.Info("Could not check tx", "tx", tx.Hash(), "err", err)
 			default:
@@ -179,10 +231,37 @@ func (memR *Reactor) Receive(e p2p.Envelope) {
 				reqRes.SetCallback(func(res *abci.Response) {
 					if res.GetCheckTx().Code == abci.CodeTypeOK {
 						memR.addSender(tx.Key(), e.Src.ID())
+					} else {
+						memR.Switch.ReportPeer(e.Src, p2p.PeerBehaviorBadTx)
 					}
 				})
 			}
 		}
+	case *protomem.HaveTx:
+		if memR.WaitSync() {
+			memR.Logger.Debug("Ignored message received while syncing", "msg", msg)
+			return
+		}
+		txKey, err := types.TxKeyFromBytes(msg.TxKey)
+		if err != nil {
+			memR.Switch.StopPeerForError(e.Src, fmt.Errorf("invalid tx announcement: %w", err))
+			return
+		}
+		memR.handleHaveTx(txKey, e.Src)
+	case *protomem.WantTx:
+		txKey, err := types.TxKeyFromBytes(msg.TxKey)
+		if err != nil {
+			memR.Switch.StopPeerForError(e.Src, fmt.Errorf("invalid tx request: %w", err))
+			return
+		}
+		memR.handleWantTx(txKey, e.Src)
+	case *protomem.NoTx:
+		txKey, err := types.TxKeyFromBytes(msg.TxKey)
+		if err != nil {
+			memR.Switch.StopPeerForError(e.Src, fmt.Errorf("invalid tx reply: %w", err))
+			return
+		}
+		memR.txRequests.missing(txKey, e.Src.ID())
 	default:
 		memR.Logger.Error("unknown message type", "src", e.Src, "chId", e.ChannelID, "msg", e.Message)
 		memR.Switch.StopPeerForError(e.Src, fmt.Errorf("mempool cannot handle message of type: %T", e.Message))
@@ -215,8 +294,6 @@ type PeerState interface {
 
 // Send new mempool txs to peer.
 func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
-	var next *clist.CElement
-
 	// If the node is catching up, don't start this routine immediately.
 	if memR.WaitSync() {
 		select {
@@ -227,24 +304,32 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		}
 	}
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-peer.Quit():
+		case <-memR.Quit():
+		case <-ctx.Done():
+		}
+		cancel()
+	}()
+
+	// Announce the txs to the peers that also announce txs, and send the whole
+	// txs to the others.
+	announce := memR.announcesTo(peer)
+
+	iter := memR.mempool.NewIterator()
+	var entry Entry
 	for {
-		// In case of both next.NextWaitChan() and peer.Quit() are variable at the same time
 		if !memR.IsRunning() || !peer.IsRunning() {
 			return
 		}
 
-		// This happens because the CElement we were looking at got garbage
-		// collected (removed). That is, .NextWait() returned nil. Go ahead and
-		// start from the beginning.
-		if next == nil {
-			select {
-			case <-memR.mempool.TxsWaitChan(): // Wait until a tx is available
-				if next = memR.mempool.TxsFront(); next == nil {
-					continue
-				}
-			case <-peer.Quit():
-				return
-			case <-memR.Quit():
+		// Wait until the next tx is available, unless we're still trying to
+		// send the previous one.
+		if entry == nil {
+			if entry = iter.Next(ctx); entry == nil {
 				return
 			}
 		}
@@ -267,8 +352,7 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// node. See [RFC 103] for an analysis on this optimization.
 		//
 		// [RFC 103]: https://github.com/cometbft/cometbft/pull/735
-		memTx := next.Value.(*mempoolTx)
-		if peerState.GetHeight() < memTx.Height()-1 {
+		if peerState.GetHeight() < entry.Height()-1 {
 			time.Sleep(PeerCatchupSleepIntervalMS * time.Millisecond)
 			continue
 		}
@@ -276,23 +360,107 @@ func (memR *Reactor) broadcastTxRoutine(peer p2p.Peer) {
 		// NOTE: Transaction batching was disabled due to
 		// https://github.com/tendermint/tendermint/issues/5796
 
-		if !memR.isSender(memTx.tx.Key(), peer.ID()) {
-			success := peer.Send(p2p.Envelope{
+		tx := entry.Tx()
+		if txKey := tx.Key(); !memR.isSender(txKey, peer.ID()) {
+			envelope := p2p.Envelope{
 				ChannelID: MempoolChannel,
-				Message:   &protomem.Txs{Txs: [][]byte{memTx.tx}},
-			})
-			if !success {
+				Message:   &protomem.Txs{Txs: [][]byte{tx}},
+			}
+			if announce {
+				envelope = p2p.Envelope{
+					ChannelID: MempoolAnnounceChannel,
+					Message:   &protomem.HaveTx{TxKey: txKey[:]},
+				}
+			}
+			if success := peer.Send(envelope); !success {
 				time.Sleep(PeerCatchupSleepIntervalMS * time.Millisecond)
 				continue
 			}
 		}
+		entry = nil
+	}
+}
+
+// announcesTo returns true if the txs are announced to the peer instead of
+// being sent whole, i.e. if both the peer and we advertise the announcement
+// channel.
+func (memR *Reactor) announcesTo(peer p2p.Peer) bool {
+	if !memR.config.ExperimentalTxAnnouncements {
+		return false
+	}
+	ni, ok := peer.NodeInfo().(p2p.DefaultNodeInfo)
+	return ok && ni.HasChannel(MempoolAnnounceChannel)
+}
+
+// handleHaveTx requests an announced tx from the peer, unless we have seen it
+// already or it's already requested from another peer.
+func (memR *Reactor) handleHaveTx(txKey types.TxKey, peer p2p.Peer) {
+	if memR.mempool.HasSeen(txKey) {
+		// Don't announce the tx back to the peer.
+		if memR.mempool.InMempool(txKey) {
+			memR.addSender(txKey, peer.ID())
+		}
+		return
+	}
+	if memR.txRequests.announced(txKey, peer.ID(), time.Now()) {
+		peer.Send(p2p.Envelope{
+			ChannelID: MempoolAnnounceChannel,
+			Message:   &protomem.WantTx{TxKey: txKey[:]},
+		})
+	}
+}
+
+// handleWantTx sends a requested tx to the peer, or replies that we don't
+// have it anymore, e.g. because it was committed since it was announced. Only
+// one request per tx is answered within txResponseWindow, so that a peer
+// cannot make us send the same tx repeatedly.
+func (memR *Reactor) handleWantTx(txKey types.TxKey, peer p2p.Peer) {
+	if !memR.txResponses.allow(txKey, peer.ID(), time.Now()) {
+		return
+	}
+	tx, ok := memR.mempool.GetTxByKey(txKey)
+	if !ok {
+		peer.Send(p2p.Envelope{
+			ChannelID: MempoolAnnounceChannel,
+			Message:   &protomem.NoTx{TxKey: txKey[:]},
+		})
+		return
+	}
+	peer.Send(p2p.Envelope{
+		ChannelID: MempoolChannel,
+		Message:   &protomem.Txs{Txs: [][]byte{tx}},
+	})
+}
+
+// txRequestsRoutine requests the txs that were not received in time from
+// other peers that announced them, and reports the peers that did not send
+// them.
+func (memR *Reactor) txRequestsRoutine() {
+	ticker := time.NewTicker(txRequestsInterval)
+	defer ticker.Stop()
 
+	for {
 		select {
-		case <-next.NextWaitChan():
-			// see the start of the for loop for nil check
-			next = next.Next()
-		case <-peer.Quit():
-			return
+		case now := <-ticker.C:
+			retries, timedOut := memR.txRequests.expired(now)
+			// The peers are reported once per tick, however many txs they
+			// did not send, since they may have removed them from their
+			// mempool in the meantime.
+			for _, peerID := range timedOut {
+				if peer := memR.Switch.Peers().Get(peerID); peer != nil {
+					memR.Switch.ReportPeer(peer, p2p.PeerBehaviorRequestTimeout)
+				}
+			}
+			for txKey, peerID := range retries {
+				// If the peer is gone, the tx is requested from the next one
+				// after the timeout.
+				if peer := memR.Switch.Peers().Get(peerID); peer != nil {
+					peer.Send(p2p.Envelope{
+						ChannelID: MempoolAnnounceChannel,
+						Message:   &protomem.WantTx{TxKey: txKey[:]},
+					})
+				}
+			}
 		case <-memR.Quit():
 			return
 		}
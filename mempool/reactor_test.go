@@ -370,6 +370,78 @@ func TestMempoolFIFOWithParallelCheckTx(t *testing.T) {
 	checkTxsInOrder(t, txs, reactors[0], 0)
 }
 
+// Test that the txs are announced to and requested by the peers announcing
+// txs, and sent whole to the others.
+func TestReactorTxAnnouncements(t *testing.T) {
+	config := cfg.TestConfig()
+	memConfigs := make([]*cfg.MempoolConfig, 4)
+	for i := range memConfigs {
+		memConfig := *config.Mempool
+		// the last reactor doesn't support announcements
+		memConfig.ExperimentalTxAnnouncements = i < 3
+		memConfigs[i] = &memConfig
+	}
+	reactors, _ := makeAndConnectReactorsWithConfigs(config, memConfigs)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				require.NoError(t, err)
+			}
+		}
+	}()
+	for _, r := range reactors {
+		for _, peer := range r.Switch.Peers().Copy() {
+			peer.Set(types.PeerStateKey, peerState{1})
+		}
+	}
+
+	// the announcements are negotiated per peer
+	for i, r := range reactors {
+		for _, peer := range r.Switch.Peers().Copy() {
+			announces := i < 3 && peer.ID() != reactors[3].Switch.NodeInfo().ID()
+			assert.Equal(t, announces, r.announcesTo(peer), "reactor %d", i)
+		}
+	}
+
+	txs := checkTxs(t, reactors[0].mempool, numTxs)
+	waitForReactors(t, txs, reactors, checkTxsInMempool)
+
+	// all the requested txs were received
+	for _, r := range reactors {
+		assert.Eventually(t, func() bool { return r.txRequests.size() == 0 }, 5*time.Second, 10*time.Millisecond)
+	}
+}
+
+// Test that a peer replies to the requests of the txs it does not have, so
+// that they are not waited for, nor the peer reported.
+func TestReactorTxAnnouncementsNoTx(t *testing.T) {
+	config := cfg.TestConfig()
+	config.Mempool.ExperimentalTxAnnouncements = true
+	reactors, _ := makeAndConnectReactors(config, 2)
+	defer func() {
+		for _, r := range reactors {
+			if err := r.Stop(); err != nil {
+				require.NoError(t, err)
+			}
+		}
+	}()
+
+	peer := reactors[1].Switch.Peers().Get(reactors[0].Switch.NodeInfo().ID())
+	require.NotNil(t, peer)
+	txKey := types.Tx("tx").Key()
+	reactors[1].Receive(p2p.Envelope{
+		ChannelID: MempoolAnnounceChannel,
+		Src:       peer,
+		Message:   &memproto.HaveTx{TxKey: txKey[:]},
+	})
+	require.Equal(t, 1, reactors[1].txRequests.size())
+
+	assert.Eventually(t, func() bool {
+		return reactors[1].txRequests.size() == 0
+	}, txRequestTimeout/2, 10*time.Millisecond)
+	assert.Zero(t, reactors[1].Switch.PeerScore(peer.ID()))
+}
+
 // Test the experimental feature that limits the number of outgoing connections for gossiping
 // transactions (only non-persistent peers).
 // Note: in this test we know which gossip connections are active or not because of how the p2p
@@ -554,6 +626,16 @@ func mempoolLogger() log.Logger {
 
 // connect N mempool reactors through N switches.
 func makeAndConnectReactors(config *cfg.Config, n int) ([]*Reactor, []*p2p.Switch) {
+	memConfigs := make([]*cfg.MempoolConfig, n)
+	for i := range memConfigs {
+		memConfigs[i] = config.Mempool
+	}
+	return makeAndConnectReactorsWithConfigs(config, memConfigs)
+}
+
+// connect mempool reactors with the given mempool configs through switches.
+func makeAndConnectReactorsWithConfigs(config *cfg.Config, memConfigs []*cfg.MempoolConfig) ([]*Reactor, []*p2p.Switch) {
+	n := len(memConfigs)
 	reactors := make([]*Reactor, n)
 	logger := mempoolLogger()
 	for i := 0; i < n; i++ {
@@ -562,7 +644,7 @@ func makeAndConnectReactors(config *cfg.Config, n int) ([]*Reactor, []*p2p.Switc
 		mempool, cleanup := newMempoolWithApp(cc)
 		defer cleanup()
 
-		reactors[i] = NewReactor(config.Mempool, mempool, false) // so we dont start the consensus states
+		reactors[i] = NewReactor(memConfigs[i], mempool, false) // so we dont start the consensus states
 		reactors[i].SetLogger(logger.With("validator", i))
 	}
 
@@ -0,0 +1,268 @@
+package mempool
+
+import (
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// txRequestTimeout is how long the Reactor waits for a requested tx
+	// before requesting it from another peer that announced it. Peers reply
+	// to the requests of the txs they don't have anymore, so the timeout only
+	// matters for slow or unresponsive peers.
+	txRequestTimeout = 5 * time.Second
+	// txRequestsInterval is how often the Reactor checks the requests, to
+	// request the txs that were not received, or that the requested peer
+	// does not have, from other peers.
+	txRequestsInterval = 500 * time.Millisecond
+	// txRequestMaxAge is how long the Reactor keeps requesting a tx from the
+	// peers that announced it, after the first announcement.
+	txRequestMaxAge = 30 * time.Second
+	// maxTxRequests bounds the number of announced txs waiting to be
+	// received. The announcements of new txs are ignored beyond it.
+	maxTxRequests = 10000
+	// maxTxRequestsPerPeer bounds the number of announced txs waiting to be
+	// received that a single peer announced, so that a peer cannot fill the
+	// requests with txs it never sends.
+	maxTxRequestsPerPeer = 1000
+	// txResponseWindow is the window during which the Reactor replies at most
+	// once to the requests of a peer for a given tx.
+	txResponseWindow = txRequestTimeout
+	// maxTxResponsesPerPeer bounds the number of txs a single peer can
+	// request within txResponseWindow.
+	maxTxResponsesPerPeer = 10000
+)
+
+// txRequests keeps track of the txs requested from the peers that announced
+// them, so that each tx is requested from one peer at a time.
+type txRequests struct {
+	mtx      cmtsync.Mutex
+	requests map[types.TxKey]*txRequest
+	perPeer  map[p2p.ID]int // number of requests each peer announced
+}
+
+type txRequest struct {
+	peerID     p2p.ID    // peer the tx was requested from, if still connected
+	deadline   time.Time // after which the tx is requested from another peer
+	expiry     time.Time // after which the tx is not requested anymore
+	announcers []p2p.ID  // other peers that announced the tx
+}
+
+func newTxRequests() *txRequests {
+	return &txRequests{
+		requests: make(map[types.TxKey]*txRequest),
+		perPeer:  make(map[p2p.ID]int),
+	}
+}
+
+// announced records that a peer announced a tx and returns true if the tx
+// must be requested from it, i.e. if it's not already requested from another
+// peer. The announcement is ignored if there are too many pending requests,
+// overall or announced by the peer.
+func (r *txRequests) announced(txKey types.TxKey, peerID p2p.ID, now time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if r.perPeer[peerID] >= maxTxRequestsPerPeer {
+		return false
+	}
+	req, ok := r.requests[txKey]
+	if !ok {
+		if len(r.requests) >= maxTxRequests {
+			return false
+		}
+		r.requests[txKey] = &txRequest{
+			peerID:   peerID,
+			deadline: now.Add(txRequestTimeout),
+			expiry:   now.Add(txRequestMaxAge),
+		}
+		r.perPeer[peerID]++
+		return true
+	}
+	if req.peerID == peerID {
+		return false
+	}
+	for _, id := range req.announcers {
+		if id == peerID {
+			return false
+		}
+	}
+	req.announcers = append(req.announcers, peerID)
+	r.perPeer[peerID]++
+	return false
+}
+
+// received removes the request of a tx, once it was received.
+func (r *txRequests) received(txKey types.TxKey) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if req, ok := r.requests[txKey]; ok {
+		r.remove(txKey, req)
+	}
+}
+
+// missing records that a peer replied it does not have the tx requested from
+// it. The tx is requested from the next peer that announced it on the next
+// call to expired, without considering the request as timed out.
+func (r *txRequests) missing(txKey types.TxKey, peerID p2p.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	req, ok := r.requests[txKey]
+	if !ok || req.peerID != peerID {
+		return
+	}
+	r.release(peerID)
+	req.peerID = ""
+	req.deadline = time.Time{}
+}
+
+// removePeer forgets the announcements of a peer, e.g. once it disconnected.
+// The txs requested from it are requested from the next peers that
+// announced them on the next call to expired.
+func (r *txRequests) removePeer(peerID p2p.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	if _, ok := r.perPeer[peerID]; !ok {
+		return
+	}
+	delete(r.perPeer, peerID)
+	for _, req := range r.requests {
+		if req.peerID == peerID {
+			req.peerID = ""
+			req.deadline = time.Time{}
+		}
+		for i, id := range req.announcers {
+			if id == peerID {
+				req.announcers = append(req.announcers[:i], req.announcers[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// expired returns the txs that were not received before the deadline of
+// their request, with the next peer to request each of them from, and the
+// peers that did not send the txs requested from them in time. It removes
+// the requests of the txs that no other peer announced, or that are older
+// than txRequestMaxAge.
+func (r *txRequests) expired(now time.Time) (map[types.TxKey]p2p.ID, []p2p.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	var (
+		retries  = make(map[types.TxKey]p2p.ID)
+		timedOut = make(map[p2p.ID]struct{})
+	)
+	for txKey, req := range r.requests {
+		if now.Before(req.deadline) {
+			continue
+		}
+		if req.peerID != "" {
+			timedOut[req.peerID] = struct{}{}
+			r.release(req.peerID)
+			req.peerID = ""
+		}
+		if len(req.announcers) == 0 || !now.Before(req.expiry) {
+			r.remove(txKey, req)
+			continue
+		}
+		req.peerID, req.announcers = req.announcers[0], req.announcers[1:]
+		req.deadline = now.Add(txRequestTimeout)
+		retries[txKey] = req.peerID
+	}
+
+	peers := make([]p2p.ID, 0, len(timedOut))
+	for id := range timedOut {
+		peers = append(peers, id)
+	}
+	return retries, peers
+}
+
+// size returns the number of pending requests.
+func (r *txRequests) size() int {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	return len(r.requests)
+}
+
+// NOTE: requires a mtx lock.
+func (r *txRequests) remove(txKey types.TxKey, req *txRequest) {
+	if req.peerID != "" {
+		r.release(req.peerID)
+	}
+	for _, id := range req.announcers {
+		r.release(id)
+	}
+	delete(r.requests, txKey)
+}
+
+// NOTE: requires a mtx lock.
+func (r *txRequests) release(peerID p2p.ID) {
+	if n, ok := r.perPeer[peerID]; ok {
+		if n <= 1 {
+			delete(r.perPeer, peerID)
+		} else {
+			r.perPeer[peerID] = n - 1
+		}
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// txResponses keeps track of the txs requested by each peer, so that the
+// Reactor replies at most once per tx and peer within txResponseWindow.
+type txResponses struct {
+	mtx     cmtsync.Mutex
+	perPeer map[p2p.ID]map[types.TxKey]time.Time // time of the last reply
+}
+
+func newTxResponses() *txResponses {
+	return &txResponses{
+		perPeer: make(map[p2p.ID]map[types.TxKey]time.Time),
+	}
+}
+
+// allow records that a peer requested a tx, and returns true if the Reactor
+// must reply, i.e. if it did not reply to a request of the peer for the same
+// tx within txResponseWindow, and the peer did not request too many txs
+// within this window.
+func (r *txResponses) allow(txKey types.TxKey, peerID p2p.ID, now time.Time) bool {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	replies, ok := r.perPeer[peerID]
+	if !ok {
+		replies = make(map[types.TxKey]time.Time)
+		r.perPeer[peerID] = replies
+	}
+	if last, ok := replies[txKey]; ok && now.Before(last.Add(txResponseWindow)) {
+		return false
+	}
+	if len(replies) >= maxTxResponsesPerPeer {
+		for key, last := range replies {
+			if !now.Before(last.Add(txResponseWindow)) {
+				delete(replies, key)
+			}
+		}
+		if len(replies) >= maxTxResponsesPerPeer {
+			return false
+		}
+	}
+	replies[txKey] = now
+	return true
+}
+
+// removePeer forgets the requests of a peer, e.g. once it disconnected.
+func (r *txResponses) removePeer(peerID p2p.ID) {
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+
+	delete(r.perPeer, peerID)
+}
@@ -0,0 +1,168 @@
+package mempool
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/p2p"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxRequests(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+	txKey := types.Tx("tx").Key()
+
+	// the tx is requested from the first peer announcing it only
+	assert.True(t, r.announced(txKey, "a", now))
+	assert.False(t, r.announced(txKey, "a", now))
+	assert.False(t, r.announced(txKey, "b", now))
+	assert.False(t, r.announced(txKey, "c", now))
+	assert.False(t, r.announced(txKey, "b", now))
+	retries, timedOut := r.expired(now)
+	assert.Empty(t, retries)
+	assert.Empty(t, timedOut)
+
+	// then from the next peers, once the requests expire
+	now = now.Add(txRequestTimeout)
+	retries, timedOut = r.expired(now)
+	assert.Equal(t, map[types.TxKey]p2p.ID{txKey: "b"}, retries)
+	assert.Equal(t, []p2p.ID{"a"}, timedOut)
+	retries, timedOut = r.expired(now)
+	assert.Empty(t, retries)
+	assert.Empty(t, timedOut)
+	now = now.Add(txRequestTimeout)
+	retries, timedOut = r.expired(now)
+	assert.Equal(t, map[types.TxKey]p2p.ID{txKey: "c"}, retries)
+	assert.Equal(t, []p2p.ID{"b"}, timedOut)
+	now = now.Add(txRequestTimeout)
+	retries, timedOut = r.expired(now)
+	assert.Empty(t, retries)
+	assert.Equal(t, []p2p.ID{"c"}, timedOut)
+	assert.Zero(t, r.size())
+	assert.Empty(t, r.perPeer)
+
+	// the request is removed once the tx is received
+	assert.True(t, r.announced(txKey, "a", now))
+	assert.False(t, r.announced(txKey, "b", now))
+	r.received(txKey)
+	assert.Zero(t, r.size())
+	assert.Empty(t, r.perPeer)
+	assert.True(t, r.announced(txKey, "b", now))
+}
+
+func TestTxRequestsRemovePeer(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+	txKey := types.Tx("tx").Key()
+
+	assert.True(t, r.announced(txKey, "a", now))
+	assert.False(t, r.announced(txKey, "b", now))
+	assert.False(t, r.announced(txKey, "c", now))
+
+	// the tx is requested from the next peer right away, and the removed
+	// peers are not reported
+	r.removePeer("a")
+	r.removePeer("b")
+	retries, timedOut := r.expired(now)
+	assert.Equal(t, map[types.TxKey]p2p.ID{txKey: "c"}, retries)
+	assert.Empty(t, timedOut)
+	assert.Equal(t, map[p2p.ID]int{"c": 1}, r.perPeer)
+}
+
+func TestTxRequestsMissing(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+	txKey := types.Tx("tx").Key()
+
+	assert.True(t, r.announced(txKey, "a", now))
+	assert.False(t, r.announced(txKey, "b", now))
+
+	// only the peer the tx was requested from can reply it does not have it
+	r.missing(txKey, "b")
+	retries, timedOut := r.expired(now)
+	assert.Empty(t, retries)
+	assert.Empty(t, timedOut)
+
+	// the tx is then requested from the next peer right away, and the peer
+	// is not reported
+	r.missing(txKey, "a")
+	retries, timedOut = r.expired(now)
+	assert.Equal(t, map[types.TxKey]p2p.ID{txKey: "b"}, retries)
+	assert.Empty(t, timedOut)
+
+	r.missing(txKey, "b")
+	retries, timedOut = r.expired(now)
+	assert.Empty(t, retries)
+	assert.Empty(t, timedOut)
+	assert.Zero(t, r.size())
+	assert.Empty(t, r.perPeer)
+}
+
+func TestTxRequestsMaxAge(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+	txKey := types.Tx("tx").Key()
+
+	// the peers keep announcing the tx, but never send it
+	assert.True(t, r.announced(txKey, "a", now))
+	start := now
+	peers := []p2p.ID{"b", "a"}
+	for i := 0; r.size() > 0; i++ {
+		require.Less(t, now.Sub(start), txRequestMaxAge+txRequestTimeout)
+		r.announced(txKey, peers[i%2], now)
+		now = now.Add(txRequestTimeout)
+		r.expired(now)
+	}
+	assert.Equal(t, txRequestMaxAge, now.Sub(start))
+	assert.Empty(t, r.perPeer)
+}
+
+func TestTxRequestsLimits(t *testing.T) {
+	r := newTxRequests()
+	now := time.Now()
+	txKey := func(i int) types.TxKey { return types.Tx(fmt.Sprintf("tx%d", i)).Key() }
+
+	// a peer can't announce more than maxTxRequestsPerPeer pending txs
+	for i := 0; i < maxTxRequestsPerPeer; i++ {
+		require.True(t, r.announced(txKey(i), "a", now))
+	}
+	assert.False(t, r.announced(txKey(maxTxRequestsPerPeer), "a", now))
+	assert.True(t, r.announced(txKey(maxTxRequestsPerPeer), "b", now))
+	r.received(txKey(0))
+	assert.True(t, r.announced(txKey(maxTxRequestsPerPeer+1), "a", now))
+
+	// nor can all the peers together announce more than maxTxRequests
+	for i := r.size(); i < maxTxRequests; i++ {
+		require.True(t, r.announced(txKey(maxTxRequestsPerPeer+1+i), p2p.ID(fmt.Sprintf("peer%d", i/maxTxRequestsPerPeer)), now))
+	}
+	assert.Equal(t, maxTxRequests, r.size())
+	assert.False(t, r.announced(txKey(-1), "c", now))
+}
+
+func TestTxResponses(t *testing.T) {
+	r := newTxResponses()
+	now := time.Now()
+	txKey := func(i int) types.TxKey { return types.Tx(fmt.Sprintf("tx%d", i)).Key() }
+
+	// each peer gets at most one reply per tx within the window
+	assert.True(t, r.allow(txKey(0), "a", now))
+	assert.False(t, r.allow(txKey(0), "a", now))
+	assert.True(t, r.allow(txKey(0), "b", now))
+	assert.False(t, r.allow(txKey(0), "a", now.Add(txResponseWindow-time.Nanosecond)))
+	assert.True(t, r.allow(txKey(0), "a", now.Add(txResponseWindow)))
+
+	// and at most maxTxResponsesPerPeer replies within the window
+	for i := 1; i < maxTxResponsesPerPeer; i++ {
+		require.True(t, r.allow(txKey(i), "b", now))
+	}
+	assert.False(t, r.allow(txKey(-1), "b", now))
+	assert.True(t, r.allow(txKey(-1), "b", now.Add(txResponseWindow)))
+
+	r.removePeer("b")
+	assert.True(t, r.allow(txKey(1), "b", now))
+}
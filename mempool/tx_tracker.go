@@ -0,0 +1,177 @@
+package mempool
+
+import (
+	"container/list"
+	"time"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+// maxTxEvents is the maximum number of events kept per transaction. The
+// oldest events are discarded first.
+const maxTxEvents = 16
+
+// TxEventType is a step of the lifecycle of a transaction in the mempool.
+type TxEventType string
+
+const (
+	// TxEventReceived means that the transaction was received, from a client
+	// or a peer, and sent to the application to be checked.
+	TxEventReceived TxEventType = "received"
+	// TxEventChecked means that the application checked the transaction. It
+	// was added to the mempool unless Code is not OK or Reason is set.
+	TxEventChecked TxEventType = "checked"
+	// TxEventRecheckedInvalid means that the transaction was invalid when
+	// rechecked after a block was committed, and was removed.
+	TxEventRecheckedInvalid TxEventType = "rechecked_invalid"
+	// TxEventRemoved means that the transaction was removed from the
+	// mempool, or not added to it, for Reason.
+	TxEventRemoved TxEventType = "removed"
+	// TxEventIncluded means that the transaction was included in the block at
+	// Height, with the execution result Code.
+	TxEventIncluded TxEventType = "included"
+)
+
+// Reasons of the TxEventRemoved events.
+const (
+	// TxRemovedMempoolFull: the mempool was full once the transaction was
+	// checked.
+	TxRemovedMempoolFull = "mempool_full"
+	// TxRemovedEvicted: the transaction was evicted by a transaction with a
+	// higher priority.
+	TxRemovedEvicted = "evicted"
+	// TxRemovedFlushed: the mempool was flushed.
+	TxRemovedFlushed = "flushed"
+	// TxRemovedByKey: the transaction was removed with RemoveTxByKey.
+	TxRemovedByKey = "removed_by_key"
+	// TxRemovedExpired: the transaction stayed in the mempool for longer than
+	// ttl-num-blocks or ttl-duration.
+	TxRemovedExpired = "expired"
+)
+
+// TxEvent is an event of the lifecycle of a transaction in the mempool.
+type TxEvent struct {
+	Type TxEventType `json:"type"`
+	Time time.Time   `json:"time"`
+	// Height of the mempool when the event occurred, i.e. of the last
+	// committed block, or height of the block including the transaction.
+	Height int64 `json:"height"`
+	// Code returned by CheckTx, or of the execution of the transaction.
+	Code uint32 `json:"code"`
+	// Reason of the removal, or why a checked transaction was rejected.
+	Reason string `json:"reason,omitempty"`
+}
+
+// TxTracker records the latest events of the lifecycle of the transactions
+// in the mempool, for clients to know what happened to the transactions they
+// submitted. It keeps the events of a bounded number of transactions,
+// discarding the ones of the least recently updated transaction first.
+//
+// A nil TxTracker records nothing. It is safe for concurrent use.
+type TxTracker struct {
+	mtx   cmtsync.Mutex
+	size  int
+	txs   map[types.TxKey]*list.Element
+	order *list.List // of *trackedTx, from the least recently updated
+}
+
+type trackedTx struct {
+	key    types.TxKey
+	events []TxEvent
+}
+
+// NewTxTracker returns a TxTracker keeping the events of up to size
+// transactions.
+func NewTxTracker(size int) *TxTracker {
+	return &TxTracker{
+		size:  size,
+		txs:   make(map[types.TxKey]*list.Element, size),
+		order: list.New(),
+	}
+}
+
+// Status returns the events of the transaction with the given key, from the
+// oldest, or nil if there are none.
+func (t *TxTracker) Status(txKey types.TxKey) []TxEvent {
+	if t == nil {
+		return nil
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	e, ok := t.txs[txKey]
+	if !ok {
+		return nil
+	}
+	events := e.Value.(*trackedTx).events
+	return append(make([]TxEvent, 0, len(events)), events...)
+}
+
+// record adds an event to the history of a transaction. If known is set, the
+// event is only recorded for the transactions that already have events.
+func (t *TxTracker) record(txKey types.TxKey, event TxEvent, known bool) {
+	if t == nil || t.size <= 0 {
+		return
+	}
+	event.Time = cmttime.Now()
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if e, ok := t.txs[txKey]; ok {
+		tracked := e.Value.(*trackedTx)
+		if len(tracked.events) == maxTxEvents {
+			tracked.events = append(tracked.events[:0], tracked.events[1:]...)
+		}
+		tracked.events = append(tracked.events, event)
+		t.order.MoveToBack(e)
+		return
+	}
+	if known {
+		return
+	}
+
+	if t.order.Len() >= t.size {
+		oldest := t.order.Front()
+		delete(t.txs, oldest.Value.(*trackedTx).key)
+		t.order.Remove(oldest)
+	}
+	t.txs[txKey] = t.order.PushBack(&trackedTx{key: txKey, events: []TxEvent{event}})
+}
+
+func (t *TxTracker) received(txKey types.TxKey, height int64) {
+	t.record(txKey, TxEvent{Type: TxEventReceived, Height: height}, false)
+}
+
+func (t *TxTracker) checked(txKey types.TxKey, height int64, code uint32, reason string) {
+	t.record(txKey, TxEvent{Type: TxEventChecked, Height: height, Code: code, Reason: reason}, false)
+}
+
+func (t *TxTracker) recheckedInvalid(txKey types.TxKey, height int64, code uint32, reason string) {
+	t.record(txKey, TxEvent{Type: TxEventRecheckedInvalid, Height: height, Code: code, Reason: reason}, false)
+}
+
+func (t *TxTracker) removed(txKey types.TxKey, height int64, reason string) {
+	t.record(txKey, TxEvent{Type: TxEventRemoved, Height: height, Reason: reason}, false)
+}
+
+// included is only recorded for the transactions already tracked, so that
+// the transactions of the blocks don't evict the ones in the mempool.
+func (t *TxTracker) included(txKey types.TxKey, height int64, code uint32) {
+	t.record(txKey, TxEvent{Type: TxEventIncluded, Height: height, Code: code}, true)
+}
+
+// rejectionReason returns why a transaction checked by the application was
+// rejected.
+func rejectionReason(res *abci.CheckTxResponse, postCheckErr error) string {
+	if res.Code != abci.CodeTypeOK {
+		return res.Log
+	}
+	if postCheckErr != nil {
+		return postCheckErr.Error()
+	}
+	return ""
+}
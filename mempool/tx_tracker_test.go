@@ -0,0 +1,133 @@
+package mempool
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	"github.com/cometbft/cometbft/proxy"
+	"github.com/cometbft/cometbft/types"
+)
+
+func eventTypes(events []TxEvent) []TxEventType {
+	res := make([]TxEventType, 0, len(events))
+	for _, e := range events {
+		res = append(res, e.Type)
+	}
+	return res
+}
+
+func TestTxTracker(t *testing.T) {
+	tracker := NewTxTracker(2)
+	txs := newUniqueTxs(3)
+
+	tracker.received(txs[0].Key(), 1)
+	tracker.checked(txs[0].Key(), 1, abci.CodeTypeOK, "")
+	tracker.received(txs[1].Key(), 1)
+	tracker.checked(txs[1].Key(), 1, 1, "bad nonce")
+
+	events := tracker.Status(txs[1].Key())
+	require.Len(t, events, 2)
+	assert.Equal(t, TxEventChecked, events[1].Type)
+	assert.EqualValues(t, 1, events[1].Code)
+	assert.Equal(t, "bad nonce", events[1].Reason)
+
+	// The returned events are a copy.
+	events[0].Type = TxEventIncluded
+	assert.Equal(t, TxEventReceived, tracker.Status(txs[1].Key())[0].Type)
+
+	// Txs of the blocks are only recorded if already tracked.
+	tracker.included(txs[2].Key(), 2, abci.CodeTypeOK)
+	assert.Nil(t, tracker.Status(txs[2].Key()))
+	tracker.included(txs[0].Key(), 2, abci.CodeTypeOK)
+	assert.Equal(t,
+		[]TxEventType{TxEventReceived, TxEventChecked, TxEventIncluded},
+		eventTypes(tracker.Status(txs[0].Key())))
+
+	// The least recently updated tx is forgotten first.
+	tracker.received(txs[2].Key(), 2)
+	assert.Nil(t, tracker.Status(txs[1].Key()))
+	assert.NotNil(t, tracker.Status(txs[0].Key()))
+	assert.NotNil(t, tracker.Status(txs[2].Key()))
+
+	// Only the latest events of a tx are kept.
+	for i := 0; i < maxTxEvents; i++ {
+		tracker.removed(txs[2].Key(), 3, TxRemovedFlushed)
+	}
+	events = tracker.Status(txs[2].Key())
+	require.Len(t, events, maxTxEvents)
+	assert.Equal(t, TxEventRemoved, events[0].Type)
+
+	// A nil or empty tracker records nothing.
+	var nilTracker *TxTracker
+	nilTracker.received(txs[0].Key(), 1)
+	assert.Nil(t, nilTracker.Status(txs[0].Key()))
+	emptyTracker := NewTxTracker(0)
+	emptyTracker.received(txs[0].Key(), 1)
+	assert.Nil(t, emptyTracker.Status(txs[0].Key()))
+}
+
+func TestCListMempoolTxTracker(t *testing.T) {
+	mp, cleanup := newMempoolWithApp(proxy.NewLocalClientCreator(&priorityApp{}))
+	defer cleanup()
+	tracker := NewTxTracker(100)
+	mp.txTracker = tracker
+
+	txs := types.Txs{
+		priorityTx("alice", 1, "0"),
+		priorityTx("alice", 2, "invalid"),
+		priorityTx("bob", 3, "0"),
+		types.Tx("malformed"),
+	}
+	callCheckTx(t, mp, txs)
+	require.Equal(t, 3, mp.Size())
+	require.NoError(t, mp.RemoveTxByKey(txs[2].Key()))
+
+	mp.Lock()
+	err := mp.Update(1, txs[:1], abciResponses(1, abci.CodeTypeOK), nil, nil)
+	mp.Unlock()
+	require.NoError(t, err)
+
+	events := tracker.Status(txs[0].Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventIncluded}, eventTypes(events))
+	assert.EqualValues(t, 1, events[2].Height)
+
+	events = tracker.Status(txs[1].Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventRecheckedInvalid}, eventTypes(events))
+	assert.EqualValues(t, 1, events[2].Code)
+
+	events = tracker.Status(txs[2].Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventRemoved}, eventTypes(events))
+	assert.Equal(t, TxRemovedByKey, events[2].Reason)
+
+	events = tracker.Status(txs[3].Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked}, eventTypes(events))
+	assert.EqualValues(t, 1, events[1].Code)
+}
+
+func TestPriorityMempoolTxTracker(t *testing.T) {
+	mp := newPriorityMempool(t, 1)
+	tracker := NewTxTracker(100)
+	mp.txTracker = tracker
+
+	low, high := priorityTx("", 1, "a"), priorityTx("", 10, "b")
+	callCheckTx(t, mp, types.Txs{low, high})
+
+	events := tracker.Status(low.Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventRemoved}, eventTypes(events))
+	assert.Equal(t, TxRemovedEvicted, events[2].Reason)
+
+	// The mempool is full of txs with a higher priority.
+	lower := priorityTx("", 5, "c")
+	callCheckTx(t, mp, types.Txs{lower})
+	events = tracker.Status(lower.Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventRemoved}, eventTypes(events))
+	assert.Equal(t, TxRemovedMempoolFull, events[2].Reason)
+
+	mp.Flush()
+	events = tracker.Status(high.Key())
+	assert.Equal(t, []TxEventType{TxEventReceived, TxEventChecked, TxEventRemoved}, eventTypes(events))
+	assert.Equal(t, TxRemovedFlushed, events[2].Reason)
+}
@@ -0,0 +1,263 @@
+package mempool
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	auto "github.com/cometbft/cometbft/internal/autofile"
+	cmtos "github.com/cometbft/cometbft/internal/os"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// walFileName is the name of the head file of the mempool's WAL, inside
+	// the WAL directory.
+	walFileName = "wal"
+
+	// walRecordHeaderSize is the size of the header of each record in the
+	// WAL: a 4-byte checksum, the 4-byte length of the data and its 1-byte
+	// type.
+	walRecordHeaderSize = 9
+
+	// walRecordTx is the type of the records containing a tx added to the
+	// mempool.
+	walRecordTx byte = 1
+	// walRecordRemoved is the type of the records containing the key of a tx
+	// removed from the mempool.
+	walRecordRemoved byte = 2
+
+	// walSyncInterval is the maximum time a tx written to the WAL stays in
+	// the buffer before being synced to disk.
+	walSyncInterval = 100 * time.Millisecond
+
+	// walSyncBatchSize is the maximum number of txs written to the WAL
+	// between two syncs to disk.
+	walSyncBatchSize = 100
+
+	// walCompactionMinRecords is the minimum number of records in the WAL
+	// before it is compacted, i.e. rewritten with only the txs left in the
+	// mempool, once it contains more than twice as many records as txs.
+	walCompactionMinRecords = 10000
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// ErrWALCorrupted is returned when reading a record of the mempool's WAL that
+// doesn't match its checksum, is incomplete, typically because the node
+// crashed while writing it, or is larger than the maximum size of a tx.
+var ErrWALCorrupted = errors.New("corrupted mempool WAL record")
+
+// txWAL is a write-ahead log of the transactions in the mempool, backed by an
+// autofile.Group. Each record contains either a transaction added to the
+// mempool, or the key of a transaction removed from it:
+//
+//	crc32c(type | data) (4 bytes) | len(data) (4 bytes) | type (1 byte) | data
+//
+// Writes are buffered and synced to disk in batches: every walSyncBatchSize
+// records, and every walSyncInterval by a background routine, so that at most
+// the records written during the last interval are lost if the node crashes.
+// The WAL is also synced when it is truncated or closed.
+type txWAL struct {
+	group  *auto.Group
+	logger log.Logger
+
+	records  atomic.Int64 // number of records written since the last truncation
+	unsynced atomic.Int64 // number of records written since the last sync
+	quit     chan struct{}
+	done     chan struct{}
+}
+
+// openTxWAL opens the WAL stored in walDir, creating the directory if needed,
+// and starts the routine syncing it to disk.
+func openTxWAL(walDir string, logger log.Logger) (*txWAL, error) {
+	if err := cmtos.EnsureDir(walDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to ensure mempool WAL directory is in place: %w", err)
+	}
+
+	// The total size limit is disabled, so that the group never deletes files
+	// containing txs that are still in the mempool.
+	group, err := auto.OpenGroup(filepath.Join(walDir, walFileName), auto.GroupTotalSizeLimit(0))
+	if err != nil {
+		return nil, err
+	}
+	w := &txWAL{
+		group:  group,
+		logger: logger,
+		quit:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	go w.syncRoutine()
+	return w, nil
+}
+
+// syncRoutine syncs the txs written to the WAL every walSyncInterval, until
+// the WAL is closed.
+func (w *txWAL) syncRoutine() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(walSyncInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := w.sync(); err != nil {
+				w.logger.Error("Error syncing mempool WAL", "err", err)
+			}
+		case <-w.quit:
+			return
+		}
+	}
+}
+
+// sync flushes the txs written since the last sync and syncs them to disk.
+func (w *txWAL) sync() error {
+	if w.unsynced.Swap(0) == 0 {
+		return nil
+	}
+	return w.group.FlushAndSync()
+}
+
+// write appends tx to the WAL, syncing it to disk if walSyncBatchSize records
+// were written since the last sync.
+func (w *txWAL) write(tx types.Tx) error {
+	return w.writeRecord(walRecordTx, tx)
+}
+
+// remove appends the removal of the tx with the given key to the WAL, syncing
+// it to disk if walSyncBatchSize records were written since the last sync.
+func (w *txWAL) remove(txKey types.TxKey) error {
+	return w.writeRecord(walRecordRemoved, txKey[:])
+}
+
+func (w *txWAL) writeRecord(recordType byte, data []byte) error {
+	if err := w.append(recordType, data); err != nil {
+		return err
+	}
+	w.records.Add(1)
+	if w.unsynced.Add(1) >= walSyncBatchSize {
+		return w.sync()
+	}
+	return nil
+}
+
+// append appends a record to the WAL's buffer.
+func (w *txWAL) append(recordType byte, data []byte) error {
+	record := make([]byte, walRecordHeaderSize+len(data))
+	record[8] = recordType
+	copy(record[walRecordHeaderSize:], data)
+	binary.BigEndian.PutUint32(record[0:4], crc32.Checksum(record[8:], crc32c))
+	binary.BigEndian.PutUint32(record[4:8], uint32(len(data)))
+
+	_, err := w.group.Write(record)
+	return err
+}
+
+// needsCompaction returns true if the WAL contains many more records than the
+// numTxs txs left in the mempool, so that it should be truncated to them.
+func (w *txWAL) needsCompaction(numTxs int) bool {
+	records := w.records.Load()
+	return records >= walCompactionMinRecords && records > 2*int64(numTxs)
+}
+
+// readAll returns the transactions in the WAL that were not removed, in the
+// order they were written. If a corrupted record, or a tx larger than
+// maxTxBytes, is found, it returns the transactions read so far together with
+// an error wrapping ErrWALCorrupted.
+func (w *txWAL) readAll(maxTxBytes int) ([]types.Tx, error) {
+	if err := w.group.FlushAndSync(); err != nil {
+		return nil, err
+	}
+
+	r, err := w.group.NewReader(w.group.MinIndex())
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var (
+		txs    []types.Tx
+		header = make([]byte, walRecordHeaderSize)
+	)
+	// The txs removed after being written are only filtered out at the end,
+	// so that a tx written again after its removal is kept.
+	removed := make(map[types.TxKey]int) // index of the last removal of a tx
+	pending := func() []types.Tx {
+		kept := txs[:0]
+		for i, tx := range txs {
+			if j, ok := removed[tx.Key()]; !ok || j < i {
+				kept = append(kept, tx)
+			}
+		}
+		return kept
+	}
+	for {
+		if _, err := io.ReadFull(r, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return pending(), nil
+			}
+			return pending(), fmt.Errorf("%w: failed to read header: %v", ErrWALCorrupted, err)
+		}
+
+		checksum := binary.BigEndian.Uint32(header[0:4])
+		length := binary.BigEndian.Uint32(header[4:8])
+		recordType := header[8]
+		switch {
+		case recordType == walRecordTx && int64(length) > int64(maxTxBytes):
+			return pending(), fmt.Errorf("%w: tx of %d bytes is larger than the maximum %d",
+				ErrWALCorrupted, length, maxTxBytes)
+		case recordType == walRecordRemoved && length != uint32(len(types.TxKey{})):
+			return pending(), fmt.Errorf("%w: tx key of %d bytes", ErrWALCorrupted, length)
+		case recordType != walRecordTx && recordType != walRecordRemoved:
+			return pending(), fmt.Errorf("%w: unknown record type %d", ErrWALCorrupted, recordType)
+		}
+
+		data := make([]byte, length)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return pending(), fmt.Errorf("%w: failed to read record: %v", ErrWALCorrupted, err)
+		}
+		if actual := crc32.Checksum(append([]byte{recordType}, data...), crc32c); actual != checksum {
+			return pending(), fmt.Errorf("%w: checksums do not match: read %d, actual %d",
+				ErrWALCorrupted, checksum, actual)
+		}
+
+		if recordType == walRecordRemoved {
+			removed[types.TxKey(data)] = len(txs) - 1
+			continue
+		}
+		txs = append(txs, data)
+	}
+}
+
+// truncate replaces the content of the WAL with txs and syncs it to disk.
+//
+// The new content is written to a new head file, which is synced before the
+// previous files are removed, so that the txs are never missing from disk.
+func (w *txWAL) truncate(txs []types.Tx) error {
+	w.group.RotateFile()
+	for _, tx := range txs {
+		if err := w.append(walRecordTx, tx); err != nil {
+			return err
+		}
+	}
+	w.records.Store(int64(len(txs)))
+	w.unsynced.Store(0)
+	if err := w.group.FlushAndSync(); err != nil {
+		return err
+	}
+	return w.group.RemoveRotatedFiles()
+}
+
+// close stops the sync routine, flushes the WAL, syncs it to disk and closes
+// it.
+func (w *txWAL) close() {
+	close(w.quit)
+	<-w.done
+	w.group.Close()
+}
@@ -0,0 +1,170 @@
+package mempool
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+const maxTxBytes = 1024
+
+func TestTxWALWriteAndReadAll(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+
+	txs := []types.Tx{types.Tx("tx1"), types.Tx(""), types.Tx("tx3")}
+	for _, tx := range txs {
+		require.NoError(t, wal.write(tx))
+	}
+	read, err := wal.readAll(maxTxBytes)
+	require.NoError(t, err)
+	require.Equal(t, txs, read)
+	wal.close()
+
+	// The txs are still there after reopening the WAL.
+	wal, err = openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+	read, err = wal.readAll(maxTxBytes)
+	require.NoError(t, err)
+	require.Equal(t, txs, read)
+}
+
+func TestTxWALTruncate(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+
+	for _, tx := range []types.Tx{types.Tx("tx1"), types.Tx("tx2")} {
+		require.NoError(t, wal.write(tx))
+	}
+
+	require.NoError(t, wal.truncate([]types.Tx{types.Tx("tx2")}))
+	require.NoError(t, wal.write(types.Tx("tx3")))
+	read, err := wal.readAll(maxTxBytes)
+	require.NoError(t, err)
+	require.Equal(t, []types.Tx{types.Tx("tx2"), types.Tx("tx3")}, read)
+
+	// Only the head file is left.
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	require.NoError(t, wal.truncate(nil))
+	read, err = wal.readAll(maxTxBytes)
+	require.NoError(t, err)
+	require.Empty(t, read)
+}
+
+func TestTxWALRemove(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+
+	for _, tx := range []types.Tx{types.Tx("tx1"), types.Tx("tx2"), types.Tx("tx3")} {
+		require.NoError(t, wal.write(tx))
+	}
+	require.NoError(t, wal.remove(types.Tx("tx1").Key()))
+	require.NoError(t, wal.remove(types.Tx("tx3").Key()))
+	// A tx added again after its removal is kept.
+	require.NoError(t, wal.write(types.Tx("tx1")))
+
+	read, err := wal.readAll(maxTxBytes)
+	require.NoError(t, err)
+	require.Equal(t, []types.Tx{types.Tx("tx2"), types.Tx("tx1")}, read)
+}
+
+func TestTxWALNeedsCompaction(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+
+	for i := 0; i < walCompactionMinRecords/2; i++ {
+		tx := types.Tx(strconv.Itoa(i))
+		require.NoError(t, wal.write(tx))
+		require.NoError(t, wal.remove(tx.Key()))
+	}
+	require.True(t, wal.needsCompaction(0))
+	require.False(t, wal.needsCompaction(walCompactionMinRecords/2))
+
+	require.NoError(t, wal.truncate([]types.Tx{types.Tx("tx")}))
+	require.False(t, wal.needsCompaction(1))
+}
+
+func TestTxWALSync(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, walFileName)
+	fileSize := func() int64 {
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		return info.Size()
+	}
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+
+	// A full batch of txs is synced right away, before the first tick of the
+	// sync routine.
+	recordSize := int64(walRecordHeaderSize + len("tx"))
+	for i := 0; i < walSyncBatchSize; i++ {
+		require.NoError(t, wal.write(types.Tx("tx")))
+	}
+	require.Equal(t, walSyncBatchSize*recordSize, fileSize())
+
+	// Any other tx is synced within walSyncInterval.
+	require.NoError(t, wal.write(types.Tx("tx")))
+	require.Eventually(t, func() bool { return fileSize() == (walSyncBatchSize+1)*recordSize },
+		10*walSyncInterval, walSyncInterval/10)
+}
+
+func TestTxWALCorruptedRecord(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	require.NoError(t, wal.write(types.Tx("tx1")))
+	require.NoError(t, wal.write(types.Tx("tx2")))
+	wal.close()
+
+	// Simulate a crash while writing the last record.
+	path := filepath.Join(dir, walFileName)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	require.NoError(t, os.Truncate(path, info.Size()-1))
+
+	wal, err = openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+	read, err := wal.readAll(maxTxBytes)
+	require.ErrorIs(t, err, ErrWALCorrupted)
+	require.Equal(t, []types.Tx{types.Tx("tx1")}, read)
+}
+
+func TestTxWALTxTooLarge(t *testing.T) {
+	dir := t.TempDir()
+
+	wal, err := openTxWAL(dir, log.NewNopLogger())
+	require.NoError(t, err)
+	defer wal.close()
+
+	require.NoError(t, wal.write(types.Tx("tx1")))
+	require.NoError(t, wal.write(make(types.Tx, maxTxBytes+1)))
+	read, err := wal.readAll(maxTxBytes)
+	require.ErrorIs(t, err, ErrWALCorrupted)
+	require.Equal(t, []types.Tx{types.Tx("tx1")}, read)
+}
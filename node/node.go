@@ -8,6 +8,7 @@ import (
 	"net"
 	"net/http"
 	"os"
+	"sync/atomic"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -28,11 +29,13 @@ import (
 	"github.com/cometbft/cometbft/internal/state/txindex/null"
 	"github.com/cometbft/cometbft/internal/statesync"
 	"github.com/cometbft/cometbft/internal/store"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	"github.com/cometbft/cometbft/libs/log"
 	"github.com/cometbft/cometbft/light"
 	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
+	privvalgrpc "github.com/cometbft/cometbft/privval/grpc"
 	"github.com/cometbft/cometbft/proxy"
 	rpccore "github.com/cometbft/cometbft/rpc/core"
 	grpcserver "github.com/cometbft/cometbft/rpc/grpc/server"
@@ -54,7 +57,7 @@ type Node struct {
 	privValidator types.PrivValidator // local node's validator key
 
 	// network
-	transport   *p2p.MultiplexTransport
+	transport   p2pTransport
 	sw          *p2p.Switch  // p2p connections
 	addrBook    pex.AddrBook // known peers
 	nodeInfo    p2p.NodeInfo
@@ -69,6 +72,7 @@ type Node struct {
 	bcReactor         p2p.Reactor        // for block-syncing
 	mempoolReactor    waitSyncP2PReactor // for gossipping transactions
 	mempool           mempl.Mempool
+	txTracker         *mempl.TxTracker        // lifecycle of the mempool txs, nil if disabled
 	stateSync         bool                    // whether the node should state sync on startup
 	stateSyncReactor  *statesync.Reactor      // for hosting and restoring state sync snapshots
 	stateSyncProvider statesync.StateProvider // provides state data for bootstrapping a node
@@ -84,6 +88,13 @@ type Node struct {
 	indexerService    *txindex.IndexerService
 	prometheusSrv     *http.Server
 	pprofSrv          *http.Server
+	rpcCORS           atomic.Pointer[cors.Cors] // nil if CORS is disabled
+
+	// config reloading, see ReloadConfig
+	reloadMtx    cmtsync.Mutex
+	configLoader ConfigLoader
+	logFilter    *log.ReloadableFilter
+	liveConfig   *cfg.Config // config with the reloaded options applied
 }
 
 type waitSyncP2PReactor interface {
@@ -328,9 +339,15 @@ func NewNode(ctx context.Context,
 		return nil, err
 	}
 
-	// If an address is provided, listen on the socket for a connection from an
-	// external signing process.
-	if config.PrivValidatorListenAddr != "" {
+	// If an address is provided, either connect to a gRPC remote signer, or
+	// listen on the socket for a connection from an external signing process.
+	switch {
+	case privvalgrpc.IsGRPCAddr(config.PrivValidatorListenAddr):
+		privValidator, err = createAndStartPrivValidatorGRPCClient(config, genDoc.ChainID, logger)
+		if err != nil {
+			return nil, fmt.Errorf("error with private validator gRPC client: %w", err)
+		}
+	case config.PrivValidatorListenAddr != "":
 		// FIXME: we should start services inside OnStart
 		privValidator, err = createAndStartPrivValidatorSocketClient(config.PrivValidatorListenAddr, genDoc.ChainID, logger)
 		if err != nil {
@@ -374,7 +391,11 @@ func NewNode(ctx context.Context,
 
 	logNodeStartupInfo(state, pubKey, logger, consensusLogger)
 
-	mempool, mempoolReactor := createMempoolAndMempoolReactor(config, proxyApp, state, waitSync, memplMetrics, logger)
+	txTracker := createTxTracker(config)
+	mempool, mempoolReactor, err := createMempoolAndMempoolReactor(config, proxyApp, state, waitSync, memplMetrics, txTracker, logger)
+	if err != nil {
+		return nil, err
+	}
 
 	evidenceReactor, evidencePool, err := createEvidenceReactor(config, dbProvider, stateStore, blockStore, logger)
 	if err != nil {
@@ -437,6 +458,7 @@ func NewNode(ctx context.Context,
 		proxyApp.Snapshot(),
 		proxyApp.Query(),
 		ssMetrics,
+		statesync.WithStores(blockStore, stateStore),
 	)
 	stateSyncReactor.SetLogger(logger.With("module", "statesync"))
 
@@ -445,11 +467,19 @@ func NewNode(ctx context.Context,
 		return nil, err
 	}
 
-	transport, peerFilters := createTransport(config, nodeInfo, nodeKey, proxyApp)
+	ipFilter, err := createIPFilter(config)
+	if err != nil {
+		return nil, err
+	}
+
+	transport, peerFilters, err := createTransport(config, nodeInfo, nodeKey, proxyApp, ipFilter)
+	if err != nil {
+		return nil, err
+	}
 
 	p2pLogger := logger.With("module", "p2p")
 	sw := createSwitch(
-		config, transport, p2pMetrics, peerFilters, mempoolReactor, bcReactor,
+		config, transport, p2pMetrics, peerFilters, ipFilter, mempoolReactor, bcReactor,
 		stateSyncReactor, consensusReactor, evidenceReactor, nodeInfo, nodeKey, p2pLogger,
 	)
 
@@ -505,6 +535,7 @@ func NewNode(ctx context.Context,
 		bcReactor:        bcReactor,
 		mempoolReactor:   mempoolReactor,
 		mempool:          mempool,
+		txTracker:        txTracker,
 		consensusState:   consensusState,
 		consensusReactor: consensusReactor,
 		stateSyncReactor: stateSyncReactor,
@@ -636,11 +667,20 @@ func (n *Node) OnStop() {
 		}
 	}
 
+	if mp, ok := n.mempool.(*mempl.CListMempool); ok {
+		mp.CloseWAL()
+	}
+
 	if pvsc, ok := n.privValidator.(service.Service); ok {
 		if err := pvsc.Stop(); err != nil {
 			n.Logger.Error("Error closing private validator", "err", err)
 		}
 	}
+	if pvsc, ok := n.privValidator.(*privvalgrpc.SignerClient); ok {
+		if err := pvsc.Close(); err != nil {
+			n.Logger.Error("Error closing private validator", "err", err)
+		}
+	}
 
 	if n.prometheusSrv != nil {
 		if err := n.prometheusSrv.Shutdown(context.Background()); err != nil {
@@ -689,6 +729,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		ConsensusState: n.consensusState,
 		P2PPeers:       n.sw,
 		P2PTransport:   n,
+		ConfigReloader: n,
 		PubKey:         pubKey,
 
 		GenDoc:           n.genesisDoc,
@@ -698,6 +739,7 @@ func (n *Node) ConfigureRPC() (*rpccore.Environment, error) {
 		MempoolReactor:   n.mempoolReactor,
 		EventBus:         n.eventBus,
 		Mempool:          n.mempool,
+		TxTracker:        n.txTracker,
 
 		Logger: n.Logger.With("module", "rpc"),
 
@@ -717,6 +759,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 
 	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
 	routes := env.GetRoutes()
+	n.rpcCORS.Store(newCORS(n.config.RPC))
 
 	if n.config.RPC.Unsafe {
 		env.AddUnsafeRoutes(routes)
@@ -761,15 +804,7 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 			return nil, err
 		}
 
-		var rootHandler http.Handler = mux
-		if n.config.RPC.IsCorsEnabled() {
-			corsMiddleware := cors.New(cors.Options{
-				AllowedOrigins: n.config.RPC.CORSAllowedOrigins,
-				AllowedMethods: n.config.RPC.CORSAllowedMethods,
-				AllowedHeaders: n.config.RPC.CORSAllowedHeaders,
-			})
-			rootHandler = corsMiddleware.Handler(mux)
-		}
+		rootHandler := n.corsHandler(mux)
 		if n.config.RPC.IsTLSEnabled() {
 			go func() {
 				if err := rpcserver.ServeTLS(
@@ -816,6 +851,12 @@ func (n *Node) startRPC() ([]net.Listener, error) {
 		if n.config.GRPC.BlockResultsService.Enabled {
 			opts = append(opts, grpcserver.WithBlockResultsService(n.blockStore, n.stateStore, n.Logger))
 		}
+		if n.config.GRPC.StreamService.Enabled {
+			opts = append(opts, grpcserver.WithStreamService(n.blockStore, n.stateStore, n.eventBus, n.Logger))
+		}
+		if n.config.GRPC.TxStatusService.Enabled {
+			opts = append(opts, grpcserver.WithTxStatusService(n.txTracker, n.Logger))
+		}
 		go func() {
 			if err := grpcserver.Serve(listener, opts...); err != nil {
 				n.Logger.Error("Error starting gRPC server", "err", err)
@@ -1001,6 +1042,12 @@ func makeNodeInfo(
 		nodeInfo.Channels = append(nodeInfo.Channels, pex.PexChannel)
 	}
 
+	// Peers announce txs only to the nodes advertising the announcement
+	// channel, and send whole txs to the others.
+	if config.Mempool.ExperimentalTxAnnouncements && config.Mempool.Type != cfg.MempoolTypeNop {
+		nodeInfo.Channels = append(nodeInfo.Channels, mempl.MempoolAnnounceChannel)
+	}
+
 	lAddr := config.P2P.ExternalAddress
 
 	if lAddr == "" {
@@ -1029,6 +1076,7 @@ func createPruner(
 	prunerOpts := []sm.PrunerOption{
 		sm.WithPrunerInterval(config.Storage.Pruning.Interval),
 		sm.WithPrunerMetrics(metrics),
+		sm.WithPrunerExtendedCommitRetainBlocks(config.Storage.Pruning.ExtendedCommitRetainBlocks),
 	}
 
 	if config.Storage.Pruning.DataCompanion.Enabled {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/credentials/insecure"
 
 	dbm "github.com/cometbft/cometbft-db"
 	"github.com/cometbft/cometbft/abci/example/kvstore"
@@ -33,6 +34,7 @@ import (
 	"github.com/cometbft/cometbft/p2p/conn"
 	p2pmock "github.com/cometbft/cometbft/p2p/mock"
 	"github.com/cometbft/cometbft/privval"
+	privvalgrpc "github.com/cometbft/cometbft/privval/grpc"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -208,6 +210,35 @@ func TestNodeSetPrivValTCP(t *testing.T) {
 	assert.IsType(t, &privval.RetrySignerClient{}, n.PrivValidator())
 }
 
+func TestNodeSetPrivValGRPC(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	config := test.ResetTestRoot("node_priv_val_grpc_test")
+	defer os.RemoveAll(config.RootDir)
+	config.BaseConfig.PrivValidatorListenAddr = privvalgrpc.Scheme + ln.Addr().String()
+
+	signerServer, err := privvalgrpc.NewServer(privvalgrpc.NewSignerServer(
+		test.DefaultTestChainID,
+		types.NewMockPV(),
+		log.TestingLogger(),
+	), insecure.NewCredentials())
+	require.NoError(t, err)
+	go func() {
+		_ = signerServer.Serve(ln)
+	}()
+	defer signerServer.Stop()
+
+	// Without mutual TLS, the node only connects if explicitly allowed to.
+	_, err = DefaultNewNode(config, log.TestingLogger())
+	require.Error(t, err)
+
+	config.BaseConfig.PrivValidatorInsecure = true
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+	assert.IsType(t, &privvalgrpc.SignerClient{}, n.PrivValidator())
+}
+
 // address without a protocol must result in error.
 func TestPrivValidatorListenAddrNoProtocol(t *testing.T) {
 	addrNoPrefix := testFreeAddr(t)
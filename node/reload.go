@@ -0,0 +1,198 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/rs/cors"
+
+	cfg "github.com/cometbft/cometbft/config"
+	cmtflags "github.com/cometbft/cometbft/libs/cli/flags"
+	"github.com/cometbft/cometbft/libs/log"
+)
+
+// ConfigLoader loads the configuration of the node when it is reloaded, e.g.
+// from the config file.
+type ConfigLoader func() (*cfg.Config, error)
+
+// mempoolLimiter is implemented by the mempools whose limits can be changed
+// while the node is running.
+type mempoolLimiter interface {
+	SetLimits(maxTxs int, maxTxsBytes int64)
+}
+
+// SetConfigLoader sets the function used by ReloadConfig to load the new
+// configuration.
+func (n *Node) SetConfigLoader(loader ConfigLoader) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	n.configLoader = loader
+}
+
+// SetLogFilter sets the filter of the logger of the node, so that the log
+// level can be changed by reloading the configuration.
+func (n *Node) SetLogFilter(filter *log.ReloadableFilter) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+	n.logFilter = filter
+}
+
+// ReloadConfig loads the configuration with the ConfigLoader and applies it
+// with ApplyConfig.
+func (n *Node) ReloadConfig() (applied, restartRequired []string, err error) {
+	n.reloadMtx.Lock()
+	loader := n.configLoader
+	n.reloadMtx.Unlock()
+	if loader == nil {
+		return nil, nil, errors.New("config reloading is not enabled")
+	}
+
+	config, err := loader()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load config: %w", err)
+	}
+	return n.ApplyConfig(config)
+}
+
+// ApplyConfig compares the configuration with the one the node is running
+// with, and applies the changes to the following options without restarting
+// the node:
+//   - log_level, if the log filter was set with SetLogFilter
+//   - p2p.persistent_peers, if peers were only added
+//   - mempool.size and mempool.max_txs_bytes, if the mempool supports it
+//   - rpc.cors_allowed_origins, rpc.cors_allowed_methods and
+//     rpc.cors_allowed_headers
+//
+// It returns the keys of the options which were applied, and of those which
+// changed but need a restart to be applied.
+func (n *Node) ApplyConfig(config *cfg.Config) (applied, restartRequired []string, err error) {
+	if err := config.ValidateBasic(); err != nil {
+		return nil, nil, fmt.Errorf("invalid config: %w", err)
+	}
+
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+
+	if n.liveConfig == nil {
+		n.liveConfig = copyConfig(n.config)
+	}
+	live := n.liveConfig
+
+	applied, restartRequired = make([]string, 0), make([]string, 0)
+	for _, key := range cfg.Diff(live, config) {
+		ok := false
+		switch key {
+		case "log_level":
+			ok, err = n.applyLogLevel(config.LogLevel)
+			if ok {
+				live.LogLevel = config.LogLevel
+			}
+		case "p2p.persistent_peers":
+			ok, err = n.applyPersistentPeers(live.P2P.PersistentPeers, config.P2P.PersistentPeers)
+			if ok {
+				live.P2P.PersistentPeers = config.P2P.PersistentPeers
+			}
+		case "mempool.size", "mempool.max_txs_bytes":
+			var limiter mempoolLimiter
+			if limiter, ok = n.mempool.(mempoolLimiter); ok {
+				limiter.SetLimits(config.Mempool.Size, config.Mempool.MaxTxsBytes)
+				live.Mempool.Size = config.Mempool.Size
+				live.Mempool.MaxTxsBytes = config.Mempool.MaxTxsBytes
+			}
+		case "rpc.cors_allowed_origins", "rpc.cors_allowed_methods", "rpc.cors_allowed_headers":
+			n.rpcCORS.Store(newCORS(config.RPC))
+			live.RPC.CORSAllowedOrigins = config.RPC.CORSAllowedOrigins
+			live.RPC.CORSAllowedMethods = config.RPC.CORSAllowedMethods
+			live.RPC.CORSAllowedHeaders = config.RPC.CORSAllowedHeaders
+			ok = true
+		}
+		if err != nil {
+			return applied, restartRequired, fmt.Errorf("could not apply %s: %w", key, err)
+		}
+		if ok {
+			applied = append(applied, key)
+		} else {
+			restartRequired = append(restartRequired, key)
+		}
+	}
+
+	n.Logger.Info("Applied config", "applied", applied, "restart_required", restartRequired)
+	return applied, restartRequired, nil
+}
+
+func (n *Node) applyLogLevel(logLevel string) (bool, error) {
+	if n.logFilter == nil {
+		return false, nil
+	}
+	options, err := cmtflags.ParseLogLevelOptions(logLevel, cfg.DefaultLogLevel)
+	if err != nil {
+		return false, err
+	}
+	n.logFilter.SetOptions(options...)
+	return true, nil
+}
+
+// applyPersistentPeers adds and dials the new persistent peers. Since
+// persistent peers can't be removed from the Switch, a restart is required if
+// some peers were removed.
+func (n *Node) applyPersistentPeers(oldPeers, newPeers string) (bool, error) {
+	old := make(map[string]struct{})
+	for _, peer := range splitAndTrimEmpty(oldPeers, ",", " ") {
+		old[peer] = struct{}{}
+	}
+	added := make([]string, 0)
+	for _, peer := range splitAndTrimEmpty(newPeers, ",", " ") {
+		if _, ok := old[peer]; ok {
+			delete(old, peer)
+			continue
+		}
+		added = append(added, peer)
+	}
+	if len(old) > 0 {
+		return false, nil
+	}
+
+	if err := n.sw.AddPersistentPeers(added); err != nil {
+		return false, err
+	}
+	if err := n.sw.DialPeersAsync(added); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// corsHandler returns a handler applying the current CORS settings of the RPC
+// server, which can be changed by reloading the configuration, to the
+// requests before passing them to next.
+func (n *Node) corsHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if c := n.rpcCORS.Load(); c != nil {
+			c.ServeHTTP(w, r, next.ServeHTTP)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// newCORS returns the CORS middleware for the configuration of the RPC
+// server, or nil if CORS is disabled.
+func newCORS(config *cfg.RPCConfig) *cors.Cors {
+	if !config.IsCorsEnabled() {
+		return nil
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: config.CORSAllowedOrigins,
+		AllowedMethods: config.CORSAllowedMethods,
+		AllowedHeaders: config.CORSAllowedHeaders,
+	})
+}
+
+// copyConfig copies the configuration, including the sections whose options
+// ApplyConfig can change.
+func copyConfig(config *cfg.Config) *cfg.Config {
+	c := *config
+	rpc, p2p, mempool := *config.RPC, *config.P2P, *config.Mempool
+	c.RPC, c.P2P, c.Mempool = &rpc, &p2p, &mempool
+	return &c
+}
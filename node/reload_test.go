@@ -0,0 +1,109 @@
+package node
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/abci/example/kvstore"
+	cfg "github.com/cometbft/cometbft/config"
+	"github.com/cometbft/cometbft/internal/test"
+	"github.com/cometbft/cometbft/libs/log"
+	mempl "github.com/cometbft/cometbft/mempool"
+)
+
+func TestNodeApplyConfig(t *testing.T) {
+	config := test.ResetTestRoot("node_reload_test")
+	defer os.RemoveAll(config.RootDir)
+
+	var buf bytes.Buffer
+	logFilter := log.NewReloadableFilter(log.NewTMLogger(&buf), log.AllowInfo())
+	n, err := DefaultNewNode(config, logFilter)
+	require.NoError(t, err)
+	n.SetLogFilter(logFilter)
+	require.NoError(t, n.Start())
+	t.Cleanup(func() {
+		if err := n.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	newConfig := copyConfig(config)
+	newConfig.LogLevel = "debug"
+	newConfig.P2P.PersistentPeers = "0123456789abcdef0123456789abcdef01234567@127.0.0.1:1"
+	newConfig.Mempool.Size = 0
+	newConfig.RPC.CORSAllowedOrigins = []string{"*"}
+	consensus := *config.Consensus
+	consensus.TimeoutPropose *= 2
+	newConfig.Consensus = &consensus
+
+	applied, restartRequired, err := n.ApplyConfig(newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"log_level",
+		"rpc.cors_allowed_origins",
+		"p2p.persistent_peers",
+		"mempool.size",
+	}, applied)
+	assert.Equal(t, []string{"consensus.timeout_propose"}, restartRequired)
+
+	buf.Reset()
+	n.Logger.Debug("reloaded")
+	assert.True(t, strings.Contains(buf.String(), "reloaded"))
+	assert.NotNil(t, n.rpcCORS.Load())
+	_, err = n.Mempool().CheckTx(kvstore.NewTx("key", "value"))
+	assert.ErrorAs(t, err, &mempl.ErrMempoolIsFull{})
+
+	// Only the options which still differ are reported.
+	applied, restartRequired, err = n.ApplyConfig(newConfig)
+	require.NoError(t, err)
+	assert.Empty(t, applied)
+	assert.Equal(t, []string{"consensus.timeout_propose"}, restartRequired)
+
+	// Persistent peers can't be removed without restarting.
+	newConfig = copyConfig(newConfig)
+	newConfig.P2P.PersistentPeers = ""
+	newConfig.RPC.CORSAllowedOrigins = nil
+	applied, restartRequired, err = n.ApplyConfig(newConfig)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rpc.cors_allowed_origins"}, applied)
+	assert.Equal(t, []string{"p2p.persistent_peers", "consensus.timeout_propose"}, restartRequired)
+	assert.Nil(t, n.rpcCORS.Load())
+
+	newConfig = copyConfig(newConfig)
+	newConfig.LogLevel = "foo:bar"
+	_, _, err = n.ApplyConfig(newConfig)
+	require.Error(t, err)
+}
+
+func TestNodeReloadConfig(t *testing.T) {
+	config := test.ResetTestRoot("node_reload_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, log.TestingLogger())
+	require.NoError(t, err)
+
+	_, _, err = n.ReloadConfig()
+	require.Error(t, err)
+
+	n.SetConfigLoader(func() (*cfg.Config, error) {
+		return nil, errors.New("no config")
+	})
+	_, _, err = n.ReloadConfig()
+	require.Error(t, err)
+
+	n.SetConfigLoader(func() (*cfg.Config, error) {
+		newConfig := copyConfig(config)
+		newConfig.Mempool.MaxTxsBytes = 1
+		return newConfig, nil
+	})
+	applied, restartRequired, err := n.ReloadConfig()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"mempool.max_txs_bytes"}, applied)
+	assert.Empty(t, restartRequired)
+}
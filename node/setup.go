@@ -3,6 +3,7 @@ package node
 import (
 	"bytes"
 	"context"
+	"database/sql"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -14,6 +15,8 @@ import (
 	_ "net/http/pprof" //nolint: gosec,gci // securely exposed on separate, optional port
 
 	_ "github.com/lib/pq" //nolint: gci // provide the psql db driver.
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
 
 	dbm "github.com/cometbft/cometbft-db"
 	abci "github.com/cometbft/cometbft/abci/types"
@@ -35,6 +38,7 @@ import (
 	"github.com/cometbft/cometbft/p2p"
 	"github.com/cometbft/cometbft/p2p/pex"
 	"github.com/cometbft/cometbft/privval"
+	privvalgrpc "github.com/cometbft/cometbft/privval/grpc"
 	"github.com/cometbft/cometbft/proxy"
 	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
@@ -86,10 +90,15 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 		return nil, fmt.Errorf("failed to load or gen node key %s: %w", config.NodeKeyFile(), err)
 	}
 
+	pv, err := loadOrGenFilePV(config)
+	if err != nil {
+		return nil, err
+	}
+
 	return NewNode(context.Background(), config,
-		privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile()),
+		pv,
 		nodeKey,
-		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir()),
+		proxy.DefaultClientCreator(config.ProxyApp, config.ABCI, config.DBDir(), remoteClientOptions(config)...),
 		DefaultGenesisDocProviderFunc(config),
 		cfg.DefaultDBProvider,
 		DefaultMetricsProvider(config.Instrumentation),
@@ -97,6 +106,30 @@ func DefaultNewNode(config *cfg.Config, logger log.Logger) (*Node, error) {
 	)
 }
 
+// loadOrGenFilePV loads or generates the validator key, and keeps its last
+// sign state in a PostgreSQL database if priv_validator_state_psql_conn is
+// set, or in priv_validator_state_file otherwise.
+func loadOrGenFilePV(config *cfg.Config) (*privval.FilePV, error) {
+	pv := privval.LoadOrGenFilePV(config.PrivValidatorKeyFile(), config.PrivValidatorStateFile())
+	if config.PrivValidatorStatePsqlConn == "" {
+		return pv, nil
+	}
+
+	db, err := sql.Open("postgres", config.PrivValidatorStatePsqlConn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the last sign state database: %w", err)
+	}
+	store, err := privval.NewSQLLastSignStateStore(db, pv.Key.Address.String())
+	if err == nil {
+		err = pv.SetLastSignStateStore(store)
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to load the last sign state: %w", err)
+	}
+	return pv, nil
+}
+
 // MetricsProvider returns a consensus, p2p and mempool Metrics.
 type MetricsProvider func(chainID string) (*cs.Metrics, *p2p.Metrics, *mempl.Metrics, *sm.Metrics, *store.Metrics, *proxy.Metrics, *blocksync.Metrics, *statesync.Metrics)
 
@@ -238,6 +271,25 @@ func onlyValidatorIsUs(state sm.State, pubKey crypto.PubKey) bool {
 	return bytes.Equal(pubKey.Address(), addr)
 }
 
+// remoteClientOptions returns the options of the clients connecting to a
+// remote ABCI application.
+func remoteClientOptions(config *cfg.Config) []proxy.RemoteClientOption {
+	opts := []proxy.RemoteClientOption{proxy.WithQueryConnections(config.ABCIQueryConnections)}
+	if config.ABCIReconnect {
+		opts = append(opts, proxy.WithReconnect(config.ABCIReconnectMinBackoff, config.ABCIReconnectMaxBackoff))
+	}
+	return opts
+}
+
+// createTxTracker creates the tracker of the lifecycle of the mempool txs, or
+// returns nil if it's disabled.
+func createTxTracker(config *cfg.Config) *mempl.TxTracker {
+	if config.Mempool.TxStatusHistorySize == 0 || config.Mempool.Type == cfg.MempoolTypeNop {
+		return nil
+	}
+	return mempl.NewTxTracker(config.Mempool.TxStatusHistorySize)
+}
+
 // createMempoolAndMempoolReactor creates a mempool and a mempool reactor based on the config.
 func createMempoolAndMempoolReactor(
 	config *cfg.Config,
@@ -245,8 +297,9 @@ func createMempoolAndMempoolReactor(
 	state sm.State,
 	waitSync bool,
 	memplMetrics *mempl.Metrics,
+	txTracker *mempl.TxTracker,
 	logger log.Logger,
-) (mempl.Mempool, waitSyncP2PReactor) {
+) (mempl.Mempool, waitSyncP2PReactor, error) {
 	switch config.Mempool.Type {
 	// allow empty string for backward compatibility
 	case cfg.MempoolTypeFlood, "":
@@ -258,23 +311,52 @@ func createMempoolAndMempoolReactor(
 			mempl.WithMetrics(memplMetrics),
 			mempl.WithPreCheck(sm.TxPreCheck(state)),
 			mempl.WithPostCheck(sm.TxPostCheck(state)),
+			mempl.WithTxTracker(txTracker),
 		)
 		mp.SetLogger(logger)
 		reactor := mempl.NewReactor(
 			config.Mempool,
 			mp,
 			waitSync,
+			mempl.WithReactorMetrics(memplMetrics),
 		)
 		if config.Consensus.WaitForTxs() {
 			mp.EnableTxsAvailable()
 		}
 		reactor.SetLogger(logger)
+		if err := mp.InitWAL(); err != nil {
+			return nil, nil, fmt.Errorf("failed to initialize mempool WAL: %w", err)
+		}
 
-		return mp, reactor
+		return mp, reactor, nil
+	case cfg.MempoolTypePriority:
+		logger = logger.With("module", "mempool")
+		mp := mempl.NewPriorityMempool(
+			config.Mempool,
+			proxyApp.Mempool(),
+			state.LastBlockHeight,
+			mempl.WithPriorityMetrics(memplMetrics),
+			mempl.WithPriorityPreCheck(sm.TxPreCheck(state)),
+			mempl.WithPriorityPostCheck(sm.TxPostCheck(state)),
+			mempl.WithPriorityTxTracker(txTracker),
+		)
+		mp.SetLogger(logger)
+		reactor := mempl.NewReactor(
+			config.Mempool,
+			mp,
+			waitSync,
+			mempl.WithReactorMetrics(memplMetrics),
+		)
+		if config.Consensus.WaitForTxs() {
+			mp.EnableTxsAvailable()
+		}
+		reactor.SetLogger(logger)
+
+		return mp, reactor, nil
 	case cfg.MempoolTypeNop:
 		// Strictly speaking, there's no need to have a `mempl.NopMempoolReactor`, but
 		// adding it leads to a cleaner code.
-		return &mempl.NopMempool{}, mempl.NewNopMempoolReactor()
+		return &mempl.NopMempool{}, mempl.NewNopMempoolReactor(), nil
 	default:
 		panic(fmt.Sprintf("unknown mempool type: %q", config.Mempool.Type))
 	}
@@ -354,19 +436,42 @@ func createConsensusReactor(config *cfg.Config,
 	return consensusReactor, consensusState
 }
 
+// p2pTransport is a p2p transport started and stopped by the node.
+type p2pTransport interface {
+	p2p.Transport
+	Listen(addr p2p.NetAddress) error
+	Close() error
+	AddChannel(chID byte)
+}
+
+func createIPFilter(config *cfg.Config) (*p2p.IPFilter, error) {
+	ipFilter, err := p2p.NewIPFilter(p2p.IPFilterRules{
+		AllowedCIDRs:        splitAndTrimEmpty(config.P2P.AllowedCIDRs, ",", " "),
+		DeniedCIDRs:         splitAndTrimEmpty(config.P2P.DeniedCIDRs, ",", " "),
+		MaxConnsPerSubnet:   config.P2P.MaxConnsPerSubnet,
+		IPv4SubnetPrefixLen: config.P2P.IPv4SubnetPrefixLen,
+		IPv6SubnetPrefixLen: config.P2P.IPv6SubnetPrefixLen,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("could not create IP filter: %w", err)
+	}
+	return ipFilter, nil
+}
+
 func createTransport(
 	config *cfg.Config,
 	nodeInfo p2p.NodeInfo,
 	nodeKey *p2p.NodeKey,
 	proxyApp proxy.AppConns,
+	ipFilter *p2p.IPFilter,
 ) (
-	*p2p.MultiplexTransport,
+	p2pTransport,
 	[]p2p.PeerFilterFunc,
+	error,
 ) {
 	var (
 		mConnConfig = p2p.MConnConfig(config.P2P)
-		transport   = p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
-		connFilters = []p2p.ConnFilterFunc{}
+		connFilters = []p2p.ConnFilterFunc{ipFilter.ConnFilter()}
 		peerFilters = []p2p.PeerFilterFunc{}
 	)
 
@@ -414,19 +519,35 @@ func createTransport(
 		)
 	}
 
-	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
-
 	// Limit the number of incoming connections.
 	max := config.P2P.MaxNumInboundPeers + len(splitAndTrimEmpty(config.P2P.UnconditionalPeerIDs, ",", " "))
+
+	if config.P2P.Transport == cfg.P2PTransportQUIC {
+		transport, err := p2p.NewQUICTransport(
+			nodeInfo,
+			*nodeKey,
+			mConnConfig,
+			p2p.QUICTransportConnFilters(connFilters...),
+			p2p.QUICTransportMaxIncomingConnections(max),
+		)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not create QUIC transport: %w", err)
+		}
+		return transport, peerFilters, nil
+	}
+
+	transport := p2p.NewMultiplexTransport(nodeInfo, *nodeKey, mConnConfig)
+	p2p.MultiplexTransportConnFilters(connFilters...)(transport)
 	p2p.MultiplexTransportMaxIncomingConnections(max)(transport)
 
-	return transport, peerFilters
+	return transport, peerFilters, nil
 }
 
 func createSwitch(config *cfg.Config,
 	transport p2p.Transport,
 	p2pMetrics *p2p.Metrics,
 	peerFilters []p2p.PeerFilterFunc,
+	ipFilter *p2p.IPFilter,
 	mempoolReactor p2p.Reactor,
 	bcReactor p2p.Reactor,
 	stateSyncReactor *statesync.Reactor,
@@ -441,6 +562,7 @@ func createSwitch(config *cfg.Config,
 		transport,
 		p2p.WithMetrics(p2pMetrics),
 		p2p.SwitchPeerFilters(peerFilters...),
+		p2p.SwitchIPFilter(ipFilter),
 	)
 	sw.SetLogger(p2pLogger)
 	if config.Mempool.Type != cfg.MempoolTypeNop {
@@ -652,6 +774,52 @@ func createAndStartPrivValidatorSocketClient(
 	return pvscWithRetries, nil
 }
 
+func createAndStartPrivValidatorGRPCClient(
+	config *cfg.Config,
+	chainID string,
+	logger log.Logger,
+) (types.PrivValidator, error) {
+	var creds credentials.TransportCredentials
+	if config.ArePrivValidatorClientAndRootCAKeysSet() {
+		var err error
+		creds, err = privvalgrpc.ClientTLSCredentials(
+			config.PrivValidatorClientCertificateFile(),
+			config.PrivValidatorClientKeyFile(),
+			config.PrivValidatorRootCAFile(),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load remote signer credentials: %w", err)
+		}
+	} else {
+		if !config.PrivValidatorInsecure {
+			return nil, errors.New("refusing to connect to the remote signer without mutual TLS: " +
+				"set priv_validator_client_certificate_file, priv_validator_client_key_file and " +
+				"priv_validator_root_ca_file, or priv_validator_insecure = true for testing only")
+		}
+		logger.Error("WARNING: the connection to the remote signer is not encrypted nor authenticated " +
+			"(priv_validator_insecure = true)")
+		creds = insecure.NewCredentials()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := privvalgrpc.DialRemoteSigner(ctx, config.PrivValidatorListenAddr, creds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to start private validator: %w", err)
+	}
+
+	pvsc := privvalgrpc.NewSignerClient(conn, chainID, privvalgrpc.DefaultTimeout)
+
+	// try to get a pubkey from private validate first time
+	_, err = pvsc.GetPubKey()
+	if err != nil {
+		_ = pvsc.Close()
+		return nil, fmt.Errorf("can't get pubkey: %w", err)
+	}
+
+	return pvsc, nil
+}
+
 // splitAndTrimEmpty slices s into all subslices separated by sep and returns a
 // slice of the string s with all leading and trailing Unicode code points
 // contained in cutset removed. If sep is empty, SplitAndTrim splits after each
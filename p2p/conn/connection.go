@@ -46,6 +46,10 @@ const (
 	defaultSendTimeout         = 10 * time.Second
 	defaultPingInterval        = 60 * time.Second
 	defaultPongTimeout         = 45 * time.Second
+
+	// channelThrottleRetry is the interval at which sending is retried when
+	// all the channels with pending messages exceed their send rate.
+	channelThrottleRetry = 10 * time.Millisecond
 )
 
 type (
@@ -107,8 +111,9 @@ type MConnection struct {
 	// are safe to call concurrently.
 	stopMtx cmtsync.Mutex
 
-	flushTimer *timer.ThrottleTimer // flush writes as necessary but throttled.
-	pingTimer  *time.Ticker         // send pings periodically
+	flushTimer    *timer.ThrottleTimer // flush writes as necessary but throttled.
+	throttleTimer *timer.ThrottleTimer // retry sending when channels are throttled.
+	pingTimer     *time.Ticker         // send pings periodically
 
 	// close conn if pong is not received in pongTimeout
 	pongTimer     *time.Timer
@@ -229,6 +234,7 @@ func (c *MConnection) OnStart() error {
 		return err
 	}
 	c.flushTimer = timer.NewThrottleTimer("flush", c.config.FlushThrottle)
+	c.throttleTimer = timer.NewThrottleTimer("throttle", channelThrottleRetry)
 	c.pingTimer = time.NewTicker(c.config.PingInterval)
 	c.pongTimeoutCh = make(chan bool, 1)
 	c.chStatsTimer = time.NewTicker(updateStats)
@@ -263,6 +269,7 @@ func (c *MConnection) stopServices() (alreadyStopped bool) {
 
 	c.BaseService.OnStop()
 	c.flushTimer.Stop()
+	c.throttleTimer.Stop()
 	c.pingTimer.Stop()
 	c.chStatsTimer.Stop()
 
@@ -445,6 +452,12 @@ FOR_LOOP:
 			for _, channel := range c.channels {
 				channel.updateStats()
 			}
+		case <-c.throttleTimer.Ch:
+			// Retry sending the messages of the throttled channels.
+			select {
+			case c.send <- struct{}{}:
+			default:
+			}
 		case <-c.pingTimer.C:
 			c.Logger.Debug("Send Ping")
 			_n, err = protoWriter.WriteMsg(mustWrapPacket(&tmp2p.PacketPing{}))
@@ -523,17 +536,24 @@ func (c *MConnection) sendSomePacketMsgs() bool {
 	return false
 }
 
-// Returns true if messages from channels were exhausted.
+// Returns true if messages from channels were exhausted, or if the channels
+// with pending messages are throttled.
 func (c *MConnection) sendPacketMsg() bool {
 	// Choose a channel to create a PacketMsg from.
 	// The chosen channel will be the one whose recentlySent/priority is the least.
 	var leastRatio float32 = math.MaxFloat32
 	var leastChannel *Channel
+	var throttled bool
 	for _, channel := range c.channels {
 		// If nothing to send, skip this channel
 		if !channel.isSendPending() {
 			continue
 		}
+		// If the channel exceeds its send rate, skip it for now
+		if channel.isSendThrottled() {
+			throttled = true
+			continue
+		}
 		// Get ratio, and keep track of lowest ratio.
 		ratio := float32(channel.recentlySent) / float32(channel.desc.Priority)
 		if ratio < leastRatio {
@@ -544,6 +564,9 @@ func (c *MConnection) sendPacketMsg() bool {
 
 	// Nothing to send?
 	if leastChannel == nil {
+		if throttled {
+			c.throttleTimer.Set()
+		}
 		return true
 	}
 	// c.Logger.Info("Found a msgPacket to send")
@@ -649,6 +672,8 @@ FOR_LOOP:
 				}
 				break FOR_LOOP
 			}
+			// Block while the channel exceeds its receive rate.
+			channel.limitRecvRate(_n)
 			if msgBytes != nil {
 				c.Logger.Debug("Received bytes", "chID", channelID, "msgBytes", msgBytes)
 				// NOTE: This means the reactor.Receive runs in the same thread as the p2p recv routine
@@ -704,6 +729,10 @@ type ChannelStatus struct {
 	SendQueueSize     int
 	Priority          int
 	RecentlySent      int64
+	// Number of times sending or receiving was delayed because the channel
+	// exceeded its send or receive rate.
+	SendThrottled int64
+	RecvThrottled int64
 }
 
 func (c *MConnection) Status() ConnectionStatus {
@@ -720,6 +749,8 @@ func (c *MConnection) Status() ConnectionStatus {
 			SendQueueSize:     int(atomic.LoadInt32(&channel.sendQueueSize)),
 			Priority:          channel.desc.Priority,
 			RecentlySent:      atomic.LoadInt64(&channel.recentlySent),
+			SendThrottled:     atomic.LoadInt64(&channel.sendThrottled),
+			RecvThrottled:     atomic.LoadInt64(&channel.recvThrottled),
 		}
 	}
 	return status
@@ -734,6 +765,12 @@ type ChannelDescriptor struct {
 	RecvBufferCapacity  int
 	RecvMessageCapacity int
 	MessageType         proto.Message
+
+	// Rates at which the messages of the channel can be sent and received,
+	// in bytes/second, on top of the rates of the connection. Zero means no
+	// limit.
+	SendRate int64
+	RecvRate int64
 }
 
 func (chDesc ChannelDescriptor) FillDefaults() (filled ChannelDescriptor) {
@@ -761,6 +798,12 @@ type Channel struct {
 	sending       []byte
 	recentlySent  int64 // exponential moving average
 
+	// only set if the channel has a send or receive rate.
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+	sendThrottled int64 // atomic.
+	recvThrottled int64 // atomic.
+
 	maxPacketMsgPayloadSize int
 
 	Logger log.Logger
@@ -771,13 +814,20 @@ func newChannel(conn *MConnection, desc ChannelDescriptor) *Channel {
 	if desc.Priority <= 0 {
 		panic("Channel default priority must be a positive integer")
 	}
-	return &Channel{
+	ch := &Channel{
 		conn:                    conn,
 		desc:                    desc,
 		sendQueue:               make(chan []byte, desc.SendQueueCapacity),
 		recving:                 make([]byte, 0, desc.RecvBufferCapacity),
 		maxPacketMsgPayloadSize: conn.config.MaxPacketMsgPayloadSize,
 	}
+	if desc.SendRate > 0 {
+		ch.sendMonitor = flow.New(0, 0)
+	}
+	if desc.RecvRate > 0 {
+		ch.recvMonitor = flow.New(0, 0)
+	}
+	return ch
 }
 
 func (ch *Channel) SetLogger(l log.Logger) {
@@ -834,6 +884,20 @@ func (ch *Channel) isSendPending() bool {
 	return true
 }
 
+// Returns true if the channel exceeded its send rate in the current sample,
+// in which case it must not send until the next one.
+// Not goroutine-safe.
+func (ch *Channel) isSendThrottled() bool {
+	if ch.sendMonitor == nil {
+		return false
+	}
+	if ch.sendMonitor.Limit(ch.conn._maxPacketMsgSize, ch.desc.SendRate, false) > 0 {
+		return false
+	}
+	atomic.AddInt64(&ch.sendThrottled, 1)
+	return true
+}
+
 // Creates a new PacketMsg to send.
 // Not goroutine-safe.
 func (ch *Channel) nextPacketMsg() tmp2p.PacketMsg {
@@ -857,6 +921,9 @@ func (ch *Channel) writePacketMsgTo(w io.Writer) (n int, err error) {
 	packet := ch.nextPacketMsg()
 	n, err = protoio.NewDelimitedWriter(w).WriteMsg(mustWrapPacket(&packet))
 	atomic.AddInt64(&ch.recentlySent, int64(n))
+	if ch.sendMonitor != nil {
+		ch.sendMonitor.Update(n)
+	}
 	return
 }
 
@@ -883,6 +950,21 @@ func (ch *Channel) recvPacketMsg(packet tmp2p.PacketMsg) ([]byte, error) {
 	return nil, nil
 }
 
+// Records n received bytes, and blocks while the channel exceeds its receive
+// rate. Note this delays the messages of the other channels too.
+// Not goroutine-safe.
+func (ch *Channel) limitRecvRate(n int) {
+	if ch.recvMonitor == nil {
+		return
+	}
+	ch.recvMonitor.Update(n)
+	if ch.recvMonitor.Limit(ch.conn._maxPacketMsgSize, ch.desc.RecvRate, false) > 0 {
+		return
+	}
+	atomic.AddInt64(&ch.recvThrottled, 1)
+	ch.recvMonitor.Limit(ch.conn._maxPacketMsgSize, ch.desc.RecvRate, true)
+}
+
 // Call this periodically to update stats for throttling purposes.
 // Not goroutine-safe.
 func (ch *Channel) updateStats() {
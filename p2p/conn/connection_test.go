@@ -1,6 +1,7 @@
 package conn
 
 import (
+	"bytes"
 	"encoding/hex"
 	"net"
 	"testing"
@@ -631,3 +632,53 @@ func stopAll(t *testing.T, stoppers ...stopper) func() {
 		}
 	}
 }
+
+func TestMConnectionChannelRates(t *testing.T) {
+	server, client := NetPipe()
+	defer server.Close()
+	defer client.Close()
+
+	received := make(chan byte, 100)
+	onReceive := func(chID byte, msgBytes []byte) {
+		received <- chID
+	}
+	onError := func(r interface{}) {}
+	chDescs := func(sendRate, recvRate int64) []*ChannelDescriptor {
+		return []*ChannelDescriptor{
+			{ID: 0x01, Priority: 1, SendQueueCapacity: 20, SendRate: sendRate, RecvRate: recvRate},
+			{ID: 0x02, Priority: 1, SendQueueCapacity: 20},
+		}
+	}
+	cfg := DefaultMConnConfig()
+	mconnClient := NewMConnectionWithConfig(client, chDescs(5120, 0), onReceive, onError, cfg)
+	mconnClient.SetLogger(log.TestingLogger())
+	mconnServer := NewMConnectionWithConfig(server, chDescs(0, 2048), onReceive, onError, cfg)
+	mconnServer.SetLogger(log.TestingLogger())
+	require.NoError(t, mconnClient.Start())
+	require.NoError(t, mconnServer.Start())
+	t.Cleanup(stopAll(t, mconnClient, mconnServer))
+
+	msg := make([]byte, 1000)
+	for i := 0; i < 10; i++ {
+		require.True(t, mconnClient.Send(0x01, msg))
+	}
+	require.True(t, mconnClient.Send(0x02, []byte("Quicksilver")))
+
+	// The message of the unlimited channel is not delayed by the throttled
+	// one on the sending side.
+	var got []byte
+	for len(got) < 11 {
+		select {
+		case chID := <-received:
+			got = append(got, chID)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("received %d messages out of 11", len(got))
+		}
+	}
+	assert.Less(t, bytes.IndexByte(got, 0x02), 10)
+
+	assert.Positive(t, mconnClient.Status().Channels[0].SendThrottled)
+	assert.Zero(t, mconnClient.Status().Channels[1].SendThrottled)
+	assert.Positive(t, mconnServer.Status().Channels[0].RecvThrottled)
+	assert.Zero(t, mconnServer.Status().Channels[1].RecvThrottled)
+}
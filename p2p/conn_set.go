@@ -10,6 +10,7 @@ import (
 type ConnSet interface {
 	Has(conn net.Conn) bool
 	HasIP(ip net.IP) bool
+	IPs() []net.IP
 	Set(conn net.Conn, ip []net.IP)
 	Remove(conn net.Conn)
 	RemoveAddr(addr net.Addr)
@@ -57,6 +58,18 @@ func (cs *connSet) HasIP(ip net.IP) bool {
 	return false
 }
 
+func (cs *connSet) IPs() []net.IP {
+	cs.RLock()
+	defer cs.RUnlock()
+
+	ips := make([]net.IP, 0, len(cs.conns))
+	for _, c := range cs.conns {
+		ips = append(ips, c.ips...)
+	}
+
+	return ips
+}
+
 func (cs *connSet) Remove(c net.Conn) {
 	cs.Lock()
 	defer cs.Unlock()
@@ -0,0 +1,164 @@
+package p2p
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+// IPFilterRules are the rules applied by an IPFilter.
+type IPFilterRules struct {
+	// CIDR ranges the peers must belong to. Empty means peers can connect
+	// from any address not in DeniedCIDRs.
+	AllowedCIDRs []string
+	// CIDR ranges the peers must not belong to. Takes precedence over
+	// AllowedCIDRs.
+	DeniedCIDRs []string
+	// Maximum number of connections with peers in the same subnet. Zero means
+	// unlimited.
+	MaxConnsPerSubnet int
+	// Length of the prefix of the subnets of IPv4 and IPv6 peers, used by
+	// MaxConnsPerSubnet.
+	IPv4SubnetPrefixLen int
+	IPv6SubnetPrefixLen int
+}
+
+// IPFilter admits or rejects connections based on the IP of the peer: the IP
+// must be in an allowed and not in a denied CIDR range, and the number of
+// connections with peers in its subnet is capped. This protects a node from
+// being eclipsed by an attacker controlling many IPs in a single hosting
+// range.
+//
+// The rules can be changed at runtime, and an IPFilter can be shared by the
+// transport, for which it is a ConnFilterFunc, and the Switch, which applies
+// it before dialing peers.
+type IPFilter struct {
+	mtx     cmtsync.RWMutex
+	rules   IPFilterRules
+	allowed []*net.IPNet
+	denied  []*net.IPNet
+}
+
+// NewIPFilter returns a new IPFilter applying the given rules.
+func NewIPFilter(rules IPFilterRules) (*IPFilter, error) {
+	f := &IPFilter{}
+	if err := f.SetRules(rules); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// SetRules replaces the rules of the filter. The existing connections are not
+// affected.
+func (f *IPFilter) SetRules(rules IPFilterRules) error {
+	allowed, err := parseCIDRs(rules.AllowedCIDRs)
+	if err != nil {
+		return err
+	}
+	denied, err := parseCIDRs(rules.DeniedCIDRs)
+	if err != nil {
+		return err
+	}
+	if rules.MaxConnsPerSubnet < 0 {
+		return errors.New("max conns per subnet can't be negative")
+	}
+	if rules.IPv4SubnetPrefixLen < 0 || rules.IPv4SubnetPrefixLen > 8*net.IPv4len {
+		return fmt.Errorf("invalid IPv4 subnet prefix length %d", rules.IPv4SubnetPrefixLen)
+	}
+	if rules.IPv6SubnetPrefixLen < 0 || rules.IPv6SubnetPrefixLen > 8*net.IPv6len {
+		return fmt.Errorf("invalid IPv6 subnet prefix length %d", rules.IPv6SubnetPrefixLen)
+	}
+
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.rules = rules
+	f.allowed = allowed
+	f.denied = denied
+	return nil
+}
+
+// Rules returns the rules of the filter.
+func (f *IPFilter) Rules() IPFilterRules {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+	return f.rules
+}
+
+// Check returns an error if a connection with a peer at ip must be rejected,
+// given the IPs of the peers the node is already connected to.
+func (f *IPFilter) Check(ip net.IP, connected []net.IP) error {
+	f.mtx.RLock()
+	defer f.mtx.RUnlock()
+
+	for _, n := range f.denied {
+		if n.Contains(ip) {
+			return fmt.Errorf("ip<%v> is in denied range %v", ip, n)
+		}
+	}
+
+	if len(f.allowed) > 0 {
+		allowed := false
+		for _, n := range f.allowed {
+			if n.Contains(ip) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return fmt.Errorf("ip<%v> is not in an allowed range", ip)
+		}
+	}
+
+	if f.rules.MaxConnsPerSubnet > 0 {
+		subnet := f.subnet(ip)
+		conns := 0
+		for _, c := range connected {
+			if subnet.Contains(c) {
+				conns++
+			}
+		}
+		if conns >= f.rules.MaxConnsPerSubnet {
+			return fmt.Errorf("too many connections with subnet %v (max: %d)", subnet, f.rules.MaxConnsPerSubnet)
+		}
+	}
+
+	return nil
+}
+
+// ConnFilter returns a ConnFilterFunc applying the filter to the resolved IPs
+// of new connections.
+func (f *IPFilter) ConnFilter() ConnFilterFunc {
+	return func(cs ConnSet, _ net.Conn, ips []net.IP) error {
+		connected := cs.IPs()
+		for _, ip := range ips {
+			if err := f.Check(ip, connected); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// subnet returns the subnet of ip. Must be called with the mutex held.
+func (f *IPFilter) subnet(ip net.IP) *net.IPNet {
+	if ip4 := ip.To4(); ip4 != nil {
+		mask := net.CIDRMask(f.rules.IPv4SubnetPrefixLen, 8*net.IPv4len)
+		return &net.IPNet{IP: ip4.Mask(mask), Mask: mask}
+	}
+	mask := net.CIDRMask(f.rules.IPv6SubnetPrefixLen, 8*net.IPv6len)
+	return &net.IPNet{IP: ip.Mask(mask), Mask: mask}
+}
+
+func parseCIDRs(cidrs []string) ([]*net.IPNet, error) {
+	nets := make([]*net.IPNet, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CIDR %q: %w", cidr, err)
+		}
+		nets = append(nets, n)
+	}
+	return nets, nil
+}
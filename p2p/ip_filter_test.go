@@ -0,0 +1,129 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func TestIPFilterCheck(t *testing.T) {
+	f, err := NewIPFilter(IPFilterRules{
+		AllowedCIDRs:        []string{"10.0.0.0/8", "2001:db8::/32"},
+		DeniedCIDRs:         []string{"10.1.0.0/16"},
+		MaxConnsPerSubnet:   2,
+		IPv4SubnetPrefixLen: 24,
+		IPv6SubnetPrefixLen: 48,
+	})
+	require.NoError(t, err)
+
+	testCases := []struct {
+		ip        string
+		connected []string
+		ok        bool
+	}{
+		{"10.0.0.1", nil, true},
+		{"2001:db8::1", nil, true},
+		{"::ffff:10.0.0.1", nil, true},
+		{"11.0.0.1", nil, false},
+		{"2001:db9::1", nil, false},
+		{"10.1.0.1", nil, false},
+		{"10.0.0.1", []string{"10.0.0.2"}, true},
+		{"10.0.0.1", []string{"10.0.0.2", "10.0.0.3"}, false},
+		{"10.0.1.1", []string{"10.0.0.2", "10.0.0.3"}, true},
+		{"2001:db8::1", []string{"2001:db8::2", "2001:db8:0:ffff::3"}, false},
+		{"2001:db8:1::1", []string{"2001:db8::2", "2001:db8:0:ffff::3"}, true},
+	}
+	for _, tc := range testCases {
+		connected := make([]net.IP, 0, len(tc.connected))
+		for _, c := range tc.connected {
+			connected = append(connected, net.ParseIP(c))
+		}
+		err := f.Check(net.ParseIP(tc.ip), connected)
+		if tc.ok {
+			assert.NoError(t, err, "%s %v", tc.ip, tc.connected)
+		} else {
+			assert.Error(t, err, "%s %v", tc.ip, tc.connected)
+		}
+	}
+
+	// Without rules, everything is allowed.
+	require.NoError(t, f.SetRules(IPFilterRules{}))
+	assert.NoError(t, f.Check(net.ParseIP("11.0.0.1"), []net.IP{net.ParseIP("11.0.0.2")}))
+}
+
+func TestIPFilterSetRulesInvalid(t *testing.T) {
+	f, err := NewIPFilter(IPFilterRules{AllowedCIDRs: []string{"10.0.0.0/8"}})
+	require.NoError(t, err)
+
+	for _, rules := range []IPFilterRules{
+		{AllowedCIDRs: []string{"10.0.0.1"}},
+		{DeniedCIDRs: []string{"foo"}},
+		{MaxConnsPerSubnet: -1},
+		{IPv4SubnetPrefixLen: 33},
+		{IPv6SubnetPrefixLen: -1},
+	} {
+		assert.Error(t, f.SetRules(rules), "%+v", rules)
+	}
+	// The rules are left unchanged.
+	assert.Equal(t, []string{"10.0.0.0/8"}, f.Rules().AllowedCIDRs)
+	assert.Error(t, f.Check(net.ParseIP("11.0.0.1"), nil))
+}
+
+func TestIPFilterConnFilter(t *testing.T) {
+	f, err := NewIPFilter(IPFilterRules{MaxConnsPerSubnet: 1, IPv4SubnetPrefixLen: 24})
+	require.NoError(t, err)
+
+	var (
+		cs       = NewConnSet()
+		c1, _    = net.Pipe()
+		c2, _    = net.Pipe()
+		filter   = f.ConnFilter()
+		ip       = net.ParseIP("1.2.3.4")
+		neighbor = net.ParseIP("1.2.3.5")
+	)
+	require.NoError(t, filter(cs, c1, []net.IP{ip}))
+	cs.Set(c1, []net.IP{ip})
+	assert.Error(t, filter(cs, c2, []net.IP{neighbor}))
+	assert.NoError(t, filter(cs, c2, []net.IP{net.ParseIP("1.2.4.5")}))
+}
+
+func TestSwitchSetIPFilterRules(t *testing.T) {
+	f, err := NewIPFilter(IPFilterRules{IPv4SubnetPrefixLen: 24, IPv6SubnetPrefixLen: 48})
+	require.NoError(t, err)
+	sw := MakeSwitch(cfg, 1, initSwitchFunc, SwitchIPFilter(f))
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+	rp.Start()
+	t.Cleanup(rp.Stop)
+
+	require.NoError(t, sw.DialPeerWithAddress(rp.Addr()))
+	require.Equal(t, 1, sw.Peers().Size())
+
+	_, err = sw.SetIPFilterRules([]string{"foo"}, nil, 0)
+	require.Error(t, err)
+	assert.Equal(t, 1, sw.Peers().Size())
+
+	stopped, err := sw.SetIPFilterRules(nil, []string{"127.0.0.0/8"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []ID{rp.ID()}, stopped)
+	assert.Equal(t, 0, sw.Peers().Size())
+
+	// Outbound peers are filtered before dialing.
+	err = sw.DialPeerWithAddress(rp.Addr())
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", err)
+	assert.True(t, e.IsFiltered())
+
+	_, err = NewSwitch(cfg, errorTransport{ErrTransportClosed{}}).SetIPFilterRules(nil, nil, 0)
+	require.Error(t, err)
+}
@@ -56,17 +56,24 @@ func PrometheusMetrics(namespace string, labelsAndValues ...string) *Metrics {
 			Name:      "message_send_bytes_total",
 			Help:      "Number of bytes of each message type sent.",
 		}, append(labels, "message_type")).With(labelsAndValues...),
+		PeerChannelThrottledTotal: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: MetricsSubsystem,
+			Name:      "peer_channel_throttled_total",
+			Help:      "Number of times sending to or receiving from a given peer was delayed because a channel exceeded its configured send or receive rate.",
+		}, append(labels, "peer_id", "chID", "direction")).With(labelsAndValues...),
 	}
 }
 
 func NopMetrics() *Metrics {
 	return &Metrics{
-		Peers:                    discard.NewGauge(),
-		PeerReceiveBytesTotal:    discard.NewCounter(),
-		PeerSendBytesTotal:       discard.NewCounter(),
-		PeerPendingSendBytes:     discard.NewGauge(),
-		NumTxs:                   discard.NewGauge(),
-		MessageReceiveBytesTotal: discard.NewCounter(),
-		MessageSendBytesTotal:    discard.NewCounter(),
+		Peers:                     discard.NewGauge(),
+		PeerReceiveBytesTotal:     discard.NewCounter(),
+		PeerSendBytesTotal:        discard.NewCounter(),
+		PeerPendingSendBytes:      discard.NewGauge(),
+		NumTxs:                    discard.NewGauge(),
+		MessageReceiveBytesTotal:  discard.NewCounter(),
+		MessageSendBytesTotal:     discard.NewCounter(),
+		PeerChannelThrottledTotal: discard.NewCounter(),
 	}
 }
@@ -7,6 +7,8 @@ import (
 	"sync"
 
 	"github.com/go-kit/kit/metrics"
+
+	cmtconn "github.com/cometbft/cometbft/p2p/conn"
 )
 
 const (
@@ -38,6 +40,9 @@ type Metrics struct {
 	MessageReceiveBytesTotal metrics.Counter `metrics_labels:"message_type"`
 	// Number of bytes of each message type sent.
 	MessageSendBytesTotal metrics.Counter `metrics_labels:"message_type"`
+	// Number of times sending to or receiving from a given peer was delayed
+	// because a channel exceeded its configured send or receive rate.
+	PeerChannelThrottledTotal metrics.Counter `metrics_labels:"peer_id,chID,direction"`
 }
 
 type metricsLabelCache struct {
@@ -68,6 +73,24 @@ func (m *metricsLabelCache) ValueToMetricLabel(i interface{}) string {
 	return l
 }
 
+// channelThrottles keeps the last throttle counters of the channels of a
+// peer, to add their increase to the metrics.
+type channelThrottles map[byte]cmtconn.ChannelStatus
+
+func (ct channelThrottles) report(m *Metrics, peerID ID, channels []cmtconn.ChannelStatus) {
+	for _, ch := range channels {
+		last := ct[ch.ID]
+		chID := fmt.Sprintf("%#x", ch.ID)
+		if d := ch.SendThrottled - last.SendThrottled; d > 0 {
+			m.PeerChannelThrottledTotal.With("peer_id", string(peerID), "chID", chID, "direction", "send").Add(float64(d))
+		}
+		if d := ch.RecvThrottled - last.RecvThrottled; d > 0 {
+			m.PeerChannelThrottledTotal.With("peer_id", string(peerID), "chID", chID, "direction", "recv").Add(float64(d))
+		}
+		ct[ch.ID] = ch
+	}
+}
+
 func newMetricsLabelCache() *metricsLabelCache {
 	return &metricsLabelCache{
 		mtx:               &sync.RWMutex{},
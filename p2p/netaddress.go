@@ -63,6 +63,24 @@ func NewNetAddress(id ID, addr net.Addr) *NetAddress {
 	return na
 }
 
+// newRemoteNetAddress returns a new NetAddress using the remote address of
+// the connection, which is a UDP address for QUIC connections and a TCP
+// address otherwise (see NewNetAddress). Panics if ID is invalid.
+func newRemoteNetAddress(id ID, c net.Conn) *NetAddress {
+	udpAddr, ok := c.RemoteAddr().(*net.UDPAddr)
+	if !ok {
+		return NewNetAddress(id, c.RemoteAddr())
+	}
+
+	if err := validateID(id); err != nil {
+		panic(fmt.Sprintf("Invalid ID %v: %v (addr: %v)", id, err, udpAddr))
+	}
+
+	na := NewNetAddressIPPort(udpAddr.IP, uint16(udpAddr.Port))
+	na.ID = id
+	return na
+}
+
 // NewNetAddressString returns a new NetAddress using the provided address in
 // the form of "ID@IP:Port".
 // Also resolves the host if host is not an IP.
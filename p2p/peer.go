@@ -372,6 +372,7 @@ func PeerMetrics(metrics *Metrics) PeerOption {
 func (p *peer) metricsReporter() {
 	metricsTicker := time.NewTicker(metricsTickerDuration)
 	defer metricsTicker.Stop()
+	throttles := make(channelThrottles)
 
 	for {
 		select {
@@ -383,6 +384,7 @@ func (p *peer) metricsReporter() {
 			}
 
 			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			throttles.report(p.metrics, p.ID(), status.Channels)
 		case <-p.Quit():
 			return
 		}
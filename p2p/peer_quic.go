@@ -0,0 +1,596 @@
+package p2p
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"reflect"
+	"runtime/debug"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/cosmos/gogoproto/proto"
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/internal/cmap"
+	flow "github.com/cometbft/cometbft/internal/flowrate"
+	"github.com/cometbft/cometbft/internal/service"
+	"github.com/cometbft/cometbft/libs/log"
+	cmtconn "github.com/cometbft/cometbft/p2p/conn"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// quicSendTimeout is the time Send waits for room in the send queue of a
+	// channel.
+	quicSendTimeout = 10 * time.Second
+	// quicFlushTimeout is the time FlushStop waits for the peer to receive
+	// the pending messages and close the connection.
+	quicFlushTimeout = 2 * time.Second
+)
+
+// quicChannel is a channel of a quicPeer, whose messages are sent on a
+// dedicated unidirectional stream.
+type quicChannel struct {
+	desc          cmtconn.ChannelDescriptor
+	sendQueue     chan []byte
+	sendQueueSize atomic.Int32
+	recentlySent  atomic.Int64 // exponential moving average
+
+	// monitors of the channel's own send and receive rates, if any.
+	sendMonitor   *flow.Monitor
+	recvMonitor   *flow.Monitor
+	sendThrottled atomic.Int64
+	recvThrottled atomic.Int64
+}
+
+// limitRate blocks until the monitor allows to transfer n bytes at the given
+// rate, counting in throttled the times it had to wait.
+func limitRate(monitor *flow.Monitor, n int, rate int64, throttled *atomic.Int64) {
+	if monitor == nil {
+		return
+	}
+	if monitor.Limit(n, rate, false) == 0 {
+		throttled.Add(1)
+		monitor.Limit(n, rate, true)
+	}
+}
+
+// quicPeer implements Peer over a QUIC connection.
+//
+// Every channel sends its messages on a separate unidirectional stream, which
+// starts with the channel ID followed by the length-prefixed messages, so that
+// a slow channel does not delay the others. As a consequence, the messages of
+// different channels are received concurrently.
+type quicPeer struct {
+	service.BaseService
+
+	// raw peerConn, whose conn is the handshake stream, and the connection
+	peerConn
+	qconn   quic.Connection
+	config  cmtconn.MConnConfig
+	created time.Time
+
+	// peer's node info and the channel it knows about
+	// channels = nodeInfo.Channels
+	// cached to avoid copying nodeInfo in hasChannel
+	nodeInfo NodeInfo
+	channels []byte
+
+	channelList   []*quicChannel
+	channelsByID  map[byte]*quicChannel
+	reactorsByCh  map[byte]Reactor
+	msgTypeByChID map[byte]proto.Message
+	onPeerError   func(Peer, interface{})
+	errorOnce     sync.Once
+	closing       atomic.Bool
+
+	sendMonitor *flow.Monitor
+	recvMonitor *flow.Monitor
+	sendWg      sync.WaitGroup
+	flushc      chan struct{}
+	flushOnce   sync.Once
+
+	// User data
+	Data *cmap.CMap
+
+	metrics *Metrics
+	mlc     *metricsLabelCache
+
+	// When removal of a peer fails, we set this flag
+	removalAttemptFailed bool
+}
+
+var _ Peer = (*quicPeer)(nil)
+
+func newQUICPeer(
+	pc peerConn,
+	qconn quic.Connection,
+	config cmtconn.MConnConfig,
+	nodeInfo NodeInfo,
+	reactorsByCh map[byte]Reactor,
+	msgTypeByChID map[byte]proto.Message,
+	chDescs []*cmtconn.ChannelDescriptor,
+	onPeerError func(Peer, interface{}),
+	mlc *metricsLabelCache,
+	metrics *Metrics,
+) *quicPeer {
+	if metrics == nil {
+		metrics = NopMetrics()
+	}
+	p := &quicPeer{
+		peerConn:      pc,
+		qconn:         qconn,
+		config:        config,
+		created:       time.Now(),
+		nodeInfo:      nodeInfo,
+		channels:      nodeInfo.(DefaultNodeInfo).Channels,
+		channelsByID:  make(map[byte]*quicChannel, len(chDescs)),
+		reactorsByCh:  reactorsByCh,
+		msgTypeByChID: msgTypeByChID,
+		onPeerError:   onPeerError,
+		sendMonitor:   flow.New(0, 0),
+		recvMonitor:   flow.New(0, 0),
+		flushc:        make(chan struct{}),
+		Data:          cmap.NewCMap(),
+		metrics:       metrics,
+		mlc:           mlc,
+	}
+	for _, desc := range chDescs {
+		desc := desc.FillDefaults()
+		ch := &quicChannel{
+			desc:      desc,
+			sendQueue: make(chan []byte, desc.SendQueueCapacity),
+		}
+		if desc.SendRate > 0 {
+			ch.sendMonitor = flow.New(0, 0)
+		}
+		if desc.RecvRate > 0 {
+			ch.recvMonitor = flow.New(0, 0)
+		}
+		p.channelList = append(p.channelList, ch)
+		p.channelsByID[desc.ID] = ch
+	}
+	p.BaseService = *service.NewBaseService(nil, "Peer", p)
+
+	return p
+}
+
+// String representation.
+func (p *quicPeer) String() string {
+	if p.outbound {
+		return fmt.Sprintf("Peer{QUIC{%v} %v out}", p.RemoteAddr(), p.ID())
+	}
+
+	return fmt.Sprintf("Peer{QUIC{%v} %v in}", p.RemoteAddr(), p.ID())
+}
+
+//---------------------------------------------------
+// Implements service.Service
+
+// OnStart implements BaseService.
+func (p *quicPeer) OnStart() error {
+	if err := p.BaseService.OnStart(); err != nil {
+		return err
+	}
+
+	for _, ch := range p.channelList {
+		p.sendWg.Add(1)
+		go p.sendRoutine(ch)
+	}
+	go p.recvRoutine()
+	go p.metricsReporter()
+	return nil
+}
+
+// FlushStop mimics OnStop but additionally ensures that all successful
+// .Send() calls will get flushed before closing the connection.
+//
+// NOTE: it is not safe to call this method more than once.
+func (p *quicPeer) FlushStop() {
+	p.flushOnce.Do(func() { close(p.flushc) })
+	p.sendWg.Wait()
+
+	// Closing the connection discards the data not received by the peer yet,
+	// so give it a chance to receive it and close the connection.
+	select {
+	case <-p.qconn.Context().Done():
+	case <-time.After(quicFlushTimeout):
+	}
+	_ = p.CloseConn()
+}
+
+// OnStop implements BaseService.
+func (p *quicPeer) OnStop() {
+	if err := p.CloseConn(); err != nil {
+		p.Logger.Debug("Error while stopping peer", "err", err)
+	}
+}
+
+//---------------------------------------------------
+// Implements Peer
+
+// ID returns the peer's ID - the hex encoded hash of its pubkey.
+func (p *quicPeer) ID() ID {
+	return p.nodeInfo.ID()
+}
+
+// IsOutbound returns true if the connection is outbound, false otherwise.
+func (p *quicPeer) IsOutbound() bool {
+	return p.peerConn.outbound
+}
+
+// IsPersistent returns true if the peer is persistent, false otherwise.
+func (p *quicPeer) IsPersistent() bool {
+	return p.peerConn.persistent
+}
+
+// NodeInfo returns a copy of the peer's NodeInfo.
+func (p *quicPeer) NodeInfo() NodeInfo {
+	return p.nodeInfo
+}
+
+// SocketAddr returns the address of the socket.
+// For outbound peers, it's the address dialed (after DNS resolution).
+// For inbound peers, it's the address returned by the underlying connection
+// (not what's reported in the peer's NodeInfo).
+func (p *quicPeer) SocketAddr() *NetAddress {
+	return p.peerConn.socketAddr
+}
+
+// RemoteAddr returns peer's remote network address.
+func (p *quicPeer) RemoteAddr() net.Addr {
+	return p.qconn.RemoteAddr()
+}
+
+// Status returns the peer's ConnectionStatus.
+func (p *quicPeer) Status() cmtconn.ConnectionStatus {
+	status := cmtconn.ConnectionStatus{
+		Duration:    time.Since(p.created),
+		SendMonitor: p.sendMonitor.Status(),
+		RecvMonitor: p.recvMonitor.Status(),
+		Channels:    make([]cmtconn.ChannelStatus, 0, len(p.channelList)),
+	}
+	for _, ch := range p.channelList {
+		status.Channels = append(status.Channels, cmtconn.ChannelStatus{
+			ID:                ch.desc.ID,
+			SendQueueCapacity: cap(ch.sendQueue),
+			SendQueueSize:     int(ch.sendQueueSize.Load()),
+			Priority:          ch.desc.Priority,
+			RecentlySent:      ch.recentlySent.Load(),
+			SendThrottled:     ch.sendThrottled.Load(),
+			RecvThrottled:     ch.recvThrottled.Load(),
+		})
+	}
+	return status
+}
+
+// Send msg bytes to the channel identified by chID byte. Returns false if the
+// send queue is full after timeout.
+//
+// thread safe.
+func (p *quicPeer) Send(e Envelope) bool {
+	return p.send(e.ChannelID, e.Message, true)
+}
+
+// TrySend msg bytes to the channel identified by chID byte. Immediately returns
+// false if the send queue is full.
+//
+// thread safe.
+func (p *quicPeer) TrySend(e Envelope) bool {
+	return p.send(e.ChannelID, e.Message, false)
+}
+
+func (p *quicPeer) send(chID byte, msg proto.Message, block bool) bool {
+	if !p.IsRunning() {
+		return false
+	} else if !p.hasChannel(chID) {
+		return false
+	}
+	ch, ok := p.channelsByID[chID]
+	if !ok {
+		p.Logger.Error(fmt.Sprintf("Cannot send bytes, unknown channel %X", chID))
+		return false
+	}
+	metricLabelValue := p.mlc.ValueToMetricLabel(msg)
+	if w, ok := msg.(types.Wrapper); ok {
+		msg = w.Wrap()
+	}
+	msgBytes, err := proto.Marshal(msg)
+	if err != nil {
+		p.Logger.Error("marshaling message to send", "error", err)
+		return false
+	}
+
+	ch.sendQueueSize.Add(1)
+	if block {
+		select {
+		case ch.sendQueue <- msgBytes:
+		case <-time.After(quicSendTimeout):
+			ch.sendQueueSize.Add(-1)
+			return false
+		case <-p.Quit():
+			ch.sendQueueSize.Add(-1)
+			return false
+		}
+	} else {
+		select {
+		case ch.sendQueue <- msgBytes:
+		default:
+			ch.sendQueueSize.Add(-1)
+			return false
+		}
+	}
+
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+	}
+	p.metrics.PeerSendBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	p.metrics.MessageSendBytesTotal.With("message_type", metricLabelValue).Add(float64(len(msgBytes)))
+	return true
+}
+
+// Get the data for a given key.
+//
+// thread safe.
+func (p *quicPeer) Get(key string) interface{} {
+	return p.Data.Get(key)
+}
+
+// Set sets the data for the given key.
+//
+// thread safe.
+func (p *quicPeer) Set(key string, data interface{}) {
+	p.Data.Set(key, data)
+}
+
+// hasChannel returns true if the peer reported
+// knowing about the given chID.
+func (p *quicPeer) hasChannel(chID byte) bool {
+	for _, ch := range p.channels {
+		if ch == chID {
+			return true
+		}
+	}
+	p.Logger.Debug(
+		"Unknown channel for peer",
+		"channel",
+		chID,
+		"channels",
+		p.channels,
+	)
+	return false
+}
+
+// CloseConn closes the connection. Used for cleaning up in cases where the
+// peer had not been started at all.
+func (p *quicPeer) CloseConn() error {
+	p.closing.Store(true)
+	return p.qconn.CloseWithError(quicErrorCodeNoError, "")
+}
+
+func (p *quicPeer) SetRemovalFailed() {
+	p.removalAttemptFailed = true
+}
+
+func (p *quicPeer) GetRemovalFailed() bool {
+	return p.removalAttemptFailed
+}
+
+//---------------------------------------------------
+
+// stopForError reports the first error of the connection, unless it is being
+// closed.
+func (p *quicPeer) stopForError(err error) {
+	if p.closing.Load() {
+		return
+	}
+	p.errorOnce.Do(func() {
+		p.Logger.Debug("Peer connection failed", "err", err)
+		if p.onPeerError != nil {
+			p.onPeerError(p, err)
+		}
+	})
+}
+
+// sendRoutine writes the messages of the send queue of the channel to its
+// stream, which is opened when the first message is sent.
+func (p *quicPeer) sendRoutine(ch *quicChannel) {
+	defer p.sendWg.Done()
+
+	var stream quic.SendStream
+	write := func(msgBytes []byte) error {
+		ch.sendQueueSize.Add(-1)
+		if stream == nil {
+			var err error
+			stream, err = p.qconn.OpenUniStreamSync(p.qconn.Context())
+			if err != nil {
+				return err
+			}
+			if _, err := stream.Write([]byte{ch.desc.ID}); err != nil {
+				return err
+			}
+		}
+		buf := make([]byte, 0, binary.MaxVarintLen64+len(msgBytes))
+		buf = binary.AppendUvarint(buf, uint64(len(msgBytes)))
+		buf = append(buf, msgBytes...)
+
+		// Block until the monitors of the channel and the peer say we can
+		// write.
+		limitRate(ch.sendMonitor, len(buf), ch.desc.SendRate, &ch.sendThrottled)
+		p.sendMonitor.Limit(len(buf), atomic.LoadInt64(&p.config.SendRate), true)
+		n, err := stream.Write(buf)
+		p.sendMonitor.Update(n)
+		if ch.sendMonitor != nil {
+			ch.sendMonitor.Update(n)
+		}
+		ch.recentlySent.Add(int64(n))
+		return err
+	}
+
+	for {
+		select {
+		case msgBytes := <-ch.sendQueue:
+			if err := write(msgBytes); err != nil {
+				p.stopForError(err)
+				return
+			}
+
+		case <-p.flushc:
+			// Send the pending messages, then close the stream.
+			for {
+				select {
+				case msgBytes := <-ch.sendQueue:
+					if err := write(msgBytes); err != nil {
+						p.stopForError(err)
+						return
+					}
+				default:
+					if stream != nil {
+						_ = stream.Close()
+					}
+					return
+				}
+			}
+
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// recvRoutine accepts the streams of the channels of the peer.
+func (p *quicPeer) recvRoutine() {
+	for {
+		stream, err := p.qconn.AcceptUniStream(p.qconn.Context())
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+		go p.recvChannel(stream)
+	}
+}
+
+// recvChannel reads the messages of a channel from its stream.
+func (p *quicPeer) recvChannel(stream quic.ReceiveStream) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.Logger.Error("Peer panicked", "err", r, "stack", string(debug.Stack()))
+			stream.CancelRead(quicStreamErrorCodeRejected)
+			p.stopForError(fmt.Errorf("recovered from panic: %v", r))
+		}
+	}()
+
+	r := bufio.NewReader(stream)
+	chID, err := r.ReadByte()
+	if err != nil {
+		p.stopForError(err)
+		return
+	}
+	ch, ok := p.channelsByID[chID]
+	if !ok {
+		stream.CancelRead(quicStreamErrorCodeRejected)
+		p.stopForError(fmt.Errorf("unknown channel %X", chID))
+		return
+	}
+
+	for {
+		size, err := binary.ReadUvarint(r)
+		if errors.Is(err, io.EOF) {
+			// The peer closed the stream, e.g. on FlushStop.
+			return
+		} else if err != nil {
+			p.stopForError(err)
+			return
+		}
+		if size > uint64(ch.desc.RecvMessageCapacity) {
+			stream.CancelRead(quicStreamErrorCodeRejected)
+			p.stopForError(fmt.Errorf("received message exceeds available capacity of channel %X: %v > %v",
+				chID, size, ch.desc.RecvMessageCapacity))
+			return
+		}
+
+		// Block until the monitors of the channel and the peer say we can
+		// read. As every channel has its own stream, throttling a channel
+		// does not delay the others.
+		limitRate(ch.recvMonitor, int(size), ch.desc.RecvRate, &ch.recvThrottled)
+		p.recvMonitor.Limit(int(size), atomic.LoadInt64(&p.config.RecvRate), true)
+		msgBytes := make([]byte, size)
+		n, err := io.ReadFull(r, msgBytes)
+		p.recvMonitor.Update(n)
+		if ch.recvMonitor != nil {
+			ch.recvMonitor.Update(n)
+		}
+		if err != nil {
+			p.stopForError(err)
+			return
+		}
+
+		p.receive(chID, msgBytes)
+	}
+}
+
+// receive decodes a message and passes it to the reactor of its channel. It
+// panics if the message is invalid.
+func (p *quicPeer) receive(chID byte, msgBytes []byte) {
+	reactor := p.reactorsByCh[chID]
+	if reactor == nil {
+		panic(fmt.Sprintf("Unknown channel %X", chID))
+	}
+	mt := p.msgTypeByChID[chID]
+	msg := proto.Clone(mt)
+	err := proto.Unmarshal(msgBytes, msg)
+	if err != nil {
+		panic(fmt.Sprintf("unmarshaling message: %v into type: %s", err, reflect.TypeOf(mt)))
+	}
+	labels := []string{
+		"peer_id", string(p.ID()),
+		"chID", fmt.Sprintf("%#x", chID),
+	}
+	if w, ok := msg.(types.Unwrapper); ok {
+		msg, err = w.Unwrap()
+		if err != nil {
+			panic(fmt.Sprintf("unwrapping message: %v", err))
+		}
+	}
+	p.metrics.PeerReceiveBytesTotal.With(labels...).Add(float64(len(msgBytes)))
+	p.metrics.MessageReceiveBytesTotal.With("message_type", p.mlc.ValueToMetricLabel(msg)).Add(float64(len(msgBytes)))
+	reactor.Receive(Envelope{
+		ChannelID: chID,
+		Src:       p,
+		Message:   msg,
+	})
+}
+
+func (p *quicPeer) metricsReporter() {
+	metricsTicker := time.NewTicker(metricsTickerDuration)
+	defer metricsTicker.Stop()
+	throttles := make(channelThrottles)
+
+	for {
+		select {
+		case <-metricsTicker.C:
+			var sendQueueSize float64
+			for _, ch := range p.channelList {
+				sendQueueSize += float64(ch.sendQueueSize.Load())
+				// Decay the recently sent bytes, as the MConnection does.
+				ch.recentlySent.Store(int64(float64(ch.recentlySent.Load()) * 0.8))
+			}
+
+			p.metrics.PeerPendingSendBytes.With("peer_id", string(p.ID())).Set(sendQueueSize)
+			throttles.report(p.metrics, p.ID(), p.Status().Channels)
+		case <-p.Quit():
+			return
+		}
+	}
+}
+
+// SetLogger implements BaseService.
+func (p *quicPeer) SetLogger(l log.Logger) {
+	p.Logger = l
+}
@@ -0,0 +1,202 @@
+package p2p
+
+import (
+	"math"
+	"time"
+
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+)
+
+const (
+	// maxPeerScore and minPeerScore bound the score of a peer, so that a peer
+	// that has been useful for a long time can still be banned quickly, and a
+	// peer can recover from a few mistakes.
+	maxPeerScore = 100
+	minPeerScore = -100
+
+	// peerBanScore is the score at or below which a peer is banned.
+	peerBanScore = -50
+	// peerBanDuration is the duration for which a peer is banned.
+	peerBanDuration = 24 * time.Hour
+
+	// peerScoreHalfLife is the duration after which the score of a peer is
+	// halved, so that old behaviors matter less than recent ones.
+	peerScoreHalfLife = 10 * time.Minute
+
+	// maxTrackedPeerScores is the maximum number of peers whose score is
+	// remembered, including disconnected ones.
+	maxTrackedPeerScores = 10000
+)
+
+// PeerBehavior is a behavior of a peer, reported to the Switch by the
+// reactors, which changes the score of the peer.
+type PeerBehavior uint8
+
+const (
+	// PeerBehaviorUsefulVote means the peer sent us a vote we did not have.
+	PeerBehaviorUsefulVote PeerBehavior = iota + 1
+	// PeerBehaviorUsefulBlockPart means the peer sent us a block part we did
+	// not have.
+	PeerBehaviorUsefulBlockPart
+	// PeerBehaviorBadTx means the peer sent us a transaction rejected by the
+	// application. Since a transaction can become invalid while it is being
+	// gossiped, the penalty is small.
+	PeerBehaviorBadTx
+	// PeerBehaviorInvalidVote means the peer sent us a vote that could not be
+	// added, e.g. because of an invalid signature.
+	PeerBehaviorInvalidVote
+	// PeerBehaviorRequestTimeout means the peer did not respond to a request
+	// in time.
+	PeerBehaviorRequestTimeout
+	// PeerBehaviorBadMessage means the peer sent us an invalid message.
+	PeerBehaviorBadMessage
+	// PeerBehaviorSuspectBlock means the peer sent us a block, or a commit,
+	// that failed verification against data sent by another peer, so that it
+	// is unknown which of them is at fault. The penalty is below the ban
+	// score, so that an honest peer is not banned for a single failure.
+	PeerBehaviorSuspectBlock
+)
+
+// scoreDelta returns the change in score caused by the behavior.
+func (b PeerBehavior) scoreDelta() float64 {
+	switch b {
+	case PeerBehaviorUsefulVote, PeerBehaviorUsefulBlockPart:
+		return 1
+	case PeerBehaviorBadTx:
+		return -1
+	case PeerBehaviorInvalidVote, PeerBehaviorRequestTimeout:
+		return -10
+	case PeerBehaviorSuspectBlock:
+		return -25
+	case PeerBehaviorBadMessage:
+		return -50
+	default:
+		return 0
+	}
+}
+
+func (b PeerBehavior) String() string {
+	switch b {
+	case PeerBehaviorUsefulVote:
+		return "useful vote"
+	case PeerBehaviorUsefulBlockPart:
+		return "useful block part"
+	case PeerBehaviorBadTx:
+		return "bad tx"
+	case PeerBehaviorInvalidVote:
+		return "invalid vote"
+	case PeerBehaviorRequestTimeout:
+		return "request timeout"
+	case PeerBehaviorBadMessage:
+		return "bad message"
+	case PeerBehaviorSuspectBlock:
+		return "suspect block"
+	default:
+		return "unknown"
+	}
+}
+
+type peerScore struct {
+	score       float64
+	updated     time.Time
+	bannedUntil time.Time
+}
+
+// peerScores keeps the scores of the peers, which decay towards zero over
+// time. The scores of disconnected peers are remembered, up to
+// maxTrackedPeerScores peers, so that reconnecting does not reset them.
+type peerScores struct {
+	mtx    cmtsync.Mutex
+	scores map[ID]*peerScore
+	now    func() time.Time
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{
+		scores: make(map[ID]*peerScore),
+		now:    time.Now,
+	}
+}
+
+// Report updates the score of the peer with the behavior, and returns true if
+// the peer has just been banned.
+func (ps *peerScores) Report(id ID, b PeerBehavior) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	now := ps.now()
+	s, ok := ps.scores[id]
+	if !ok {
+		if len(ps.scores) >= maxTrackedPeerScores {
+			ps.evictOldest()
+		}
+		s = &peerScore{updated: now}
+		ps.scores[id] = s
+	}
+	ps.decay(s, now)
+	s.score = math.Max(minPeerScore, math.Min(maxPeerScore, s.score+b.scoreDelta()))
+
+	if s.score <= peerBanScore && !now.Before(s.bannedUntil) {
+		s.bannedUntil = now.Add(peerBanDuration)
+		// Start afresh once the ban is over.
+		s.score = 0
+		return true
+	}
+	return false
+}
+
+// Score returns the current score of the peer, zero if unknown.
+func (ps *peerScores) Score(id ID) int64 {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	s, ok := ps.scores[id]
+	if !ok {
+		return 0
+	}
+	ps.decay(s, ps.now())
+	return int64(math.Round(s.score))
+}
+
+// IsBanned returns true if the peer is currently banned.
+func (ps *peerScores) IsBanned(id ID) bool {
+	ps.mtx.Lock()
+	defer ps.mtx.Unlock()
+
+	s, ok := ps.scores[id]
+	return ok && ps.now().Before(s.bannedUntil)
+}
+
+func (*peerScores) decay(s *peerScore, now time.Time) {
+	elapsed := now.Sub(s.updated)
+	if elapsed <= 0 {
+		return
+	}
+	s.score *= math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+	s.updated = now
+}
+
+// evictOldest forgets the peer whose score was updated the longest time ago,
+// skipping the banned peers if possible.
+func (ps *peerScores) evictOldest() {
+	now := ps.now()
+	var (
+		oldest       ID
+		oldestBanned ID
+	)
+	for id, s := range ps.scores {
+		if now.Before(s.bannedUntil) {
+			if oldestBanned == "" || s.updated.Before(ps.scores[oldestBanned].updated) {
+				oldestBanned = id
+			}
+			continue
+		}
+		if oldest == "" || s.updated.Before(ps.scores[oldest].updated) {
+			oldest = id
+		}
+	}
+	if oldest == "" {
+		oldest = oldestBanned
+	}
+	delete(ps.scores, oldest)
+}
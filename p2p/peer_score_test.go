@@ -0,0 +1,178 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cometbft/cometbft/crypto/ed25519"
+)
+
+func newTestPeerScores() (*peerScores, *time.Time) {
+	now := time.Now()
+	ps := newPeerScores()
+	ps.now = func() time.Time { return now }
+	return ps, &now
+}
+
+func TestPeerScoresReportAndDecay(t *testing.T) {
+	ps, now := newTestPeerScores()
+
+	assert.EqualValues(t, 0, ps.Score("unknown"))
+
+	for i := 0; i < 20; i++ {
+		assert.False(t, ps.Report("a", PeerBehaviorUsefulVote))
+	}
+	assert.False(t, ps.Report("b", PeerBehaviorInvalidVote))
+	assert.EqualValues(t, 20, ps.Score("a"))
+	assert.EqualValues(t, -10, ps.Score("b"))
+
+	// Scores are halved after each half-life.
+	*now = now.Add(peerScoreHalfLife)
+	assert.EqualValues(t, 10, ps.Score("a"))
+	assert.EqualValues(t, -5, ps.Score("b"))
+
+	// Scores are bounded.
+	for i := 0; i < 2*maxPeerScore; i++ {
+		ps.Report("a", PeerBehaviorUsefulBlockPart)
+	}
+	assert.EqualValues(t, maxPeerScore, ps.Score("a"))
+}
+
+func TestPeerScoresBan(t *testing.T) {
+	ps, now := newTestPeerScores()
+
+	// A peer which was useful is banned after a few bad messages.
+	for i := 0; i < 50; i++ {
+		ps.Report("a", PeerBehaviorUsefulVote)
+	}
+	assert.False(t, ps.Report("a", PeerBehaviorBadMessage))
+	assert.False(t, ps.IsBanned("a"))
+	assert.True(t, ps.Report("a", PeerBehaviorBadMessage))
+	assert.True(t, ps.IsBanned("a"))
+
+	// Reports while banned don't extend the ban.
+	for i := 0; i < 5; i++ {
+		assert.False(t, ps.Report("a", PeerBehaviorBadMessage))
+	}
+
+	*now = now.Add(peerBanDuration)
+	assert.False(t, ps.IsBanned("a"))
+}
+
+func TestPeerScoresSuspectBlock(t *testing.T) {
+	ps, _ := newTestPeerScores()
+
+	// A single failure of which the peer may not be the culprit does not
+	// ban it, unlike a message which is certainly invalid.
+	assert.False(t, ps.Report("a", PeerBehaviorSuspectBlock))
+	assert.False(t, ps.IsBanned("a"))
+	assert.True(t, ps.Report("b", PeerBehaviorBadMessage))
+
+	// Repeated failures do.
+	assert.True(t, ps.Report("a", PeerBehaviorSuspectBlock))
+	assert.True(t, ps.IsBanned("a"))
+}
+
+func TestPeerScoresEvictsOldest(t *testing.T) {
+	ps, now := newTestPeerScores()
+
+	ps.Report("banned", PeerBehaviorBadMessage)
+	ps.Report("banned", PeerBehaviorBadMessage)
+	require.True(t, ps.IsBanned("banned"))
+	*now = now.Add(time.Second)
+	ps.Report("oldest", PeerBehaviorInvalidVote)
+	*now = now.Add(time.Second)
+	for i := 0; len(ps.scores) < maxTrackedPeerScores; i++ {
+		ps.Report(ID(fmt.Sprintf("peer%d", i)), PeerBehaviorUsefulVote)
+	}
+
+	ps.Report("new", PeerBehaviorUsefulVote)
+	assert.Len(t, ps.scores, maxTrackedPeerScores)
+	assert.EqualValues(t, 0, ps.Score("oldest"))
+	assert.True(t, ps.IsBanned("banned"))
+	assert.EqualValues(t, 1, ps.Score("new"))
+}
+
+func TestSwitchReportPeerBans(t *testing.T) {
+	sw1, sw2 := MakeSwitchPair(initSwitchFunc)
+	t.Cleanup(func() {
+		for _, sw := range []*Switch{sw1, sw2} {
+			if err := sw.Stop(); err != nil {
+				t.Error(err)
+			}
+		}
+	})
+
+	p := sw1.Peers().Get(sw2.NodeInfo().ID())
+	require.NotNil(t, p)
+
+	sw1.ReportPeer(p, PeerBehaviorInvalidVote)
+	assert.EqualValues(t, -10, sw1.PeerScore(p.ID()))
+	require.NotNil(t, sw1.Peers().Get(p.ID()))
+
+	sw1.ReportPeer(p, PeerBehaviorBadMessage)
+	assert.Nil(t, sw1.Peers().Get(p.ID()))
+
+	// The banned peer is rejected when reconnecting.
+	err := sw1.filterPeer(p)
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", err)
+	assert.True(t, e.IsFiltered())
+}
+
+func TestSwitchEvictsLowScoredInboundPeer(t *testing.T) {
+	defer func(max int) { cfg.MaxNumInboundPeers = max }(cfg.MaxNumInboundPeers)
+	cfg.MaxNumInboundPeers = 1
+
+	sw := MakeSwitch(cfg, 1, initSwitchFunc)
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+
+	dial := func() *remotePeer {
+		rp := &remotePeer{PrivKey: ed25519.GenPrivKey(), Config: cfg}
+		rp.Start()
+		t.Cleanup(rp.Stop)
+		c, err := rp.Dial(sw.NetAddress())
+		require.NoError(t, err)
+		// spawn a reading routine to prevent connection from closing
+		go func(c net.Conn) {
+			one := make([]byte, 1)
+			for {
+				if _, err := c.Read(one); err != nil {
+					return
+				}
+			}
+		}(c)
+		return rp
+	}
+
+	rp1 := dial()
+	require.Eventually(t, func() bool {
+		return sw.Peers().Has(rp1.ID())
+	}, time.Second, 10*time.Millisecond)
+
+	// A new peer does not replace a peer with the same score.
+	rp2 := dial()
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, sw.Peers().Has(rp2.ID()))
+	assert.True(t, sw.Peers().Has(rp1.ID()))
+
+	// But it replaces a peer with a lower score.
+	sw.ReportPeer(sw.Peers().Get(rp1.ID()), PeerBehaviorBadTx)
+	rp3 := dial()
+	require.Eventually(t, func() bool {
+		return sw.Peers().Has(rp3.ID())
+	}, time.Second, 10*time.Millisecond)
+	assert.False(t, sw.Peers().Has(rp1.ID()))
+	assert.Equal(t, 1, sw.Peers().Size())
+}
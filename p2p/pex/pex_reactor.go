@@ -3,6 +3,7 @@ package pex
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -481,11 +482,13 @@ func (r *Reactor) ensurePeers() {
 	// NOTE: range here is [10, 90]. Too high ?
 	newBias := cmtmath.MinInt(out, 8)*10 + 10
 
-	toDial := make(map[p2p.ID]*p2p.NetAddress)
-	// Try maxAttempts times to pick numToDial addresses to dial
+	// Pick up to maxAttempts candidates, and dial the numToDial ones with the
+	// highest scores, i.e. the peers which were the most useful to us.
 	maxAttempts := numToDial * 3
+	selected := make(map[p2p.ID]struct{})
+	toDial := make([]*p2p.NetAddress, 0, maxAttempts)
 
-	for i := 0; i < maxAttempts && len(toDial) < numToDial; i++ {
+	for i := 0; i < maxAttempts; i++ {
 		if !r.IsRunning() || !r.book.IsRunning() {
 			return
 		}
@@ -494,7 +497,7 @@ func (r *Reactor) ensurePeers() {
 		if try == nil {
 			continue
 		}
-		if _, selected := toDial[try.ID]; selected {
+		if _, ok := selected[try.ID]; ok {
 			continue
 		}
 		if r.Switch.IsDialingOrExistingAddress(try) {
@@ -503,7 +506,15 @@ func (r *Reactor) ensurePeers() {
 		// TODO: consider moving some checks from toDial into here
 		// so we don't even consider dialing peers that we want to wait
 		// before dialing again, or have dialed too many times already
-		toDial[try.ID] = try
+		selected[try.ID] = struct{}{}
+		toDial = append(toDial, try)
+	}
+
+	sort.SliceStable(toDial, func(i, j int) bool {
+		return r.Switch.PeerScore(toDial[i].ID) > r.Switch.PeerScore(toDial[j].ID)
+	})
+	if len(toDial) > numToDial {
+		toDial = toDial[:numToDial]
 	}
 
 	// Dial picked addresses
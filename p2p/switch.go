@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
 	"sync"
 	"time"
 
@@ -55,6 +56,7 @@ type AddrBook interface {
 	AddOurAddress(addr *NetAddress)
 	OurAddress(addr *NetAddress) bool
 	MarkGood(id ID)
+	MarkBad(addr *NetAddress, dur time.Duration)
 	RemoveAddress(addr *NetAddress)
 	HasAddress(addr *NetAddress) bool
 	Save()
@@ -92,9 +94,12 @@ type Switch struct {
 
 	filterTimeout time.Duration
 	peerFilters   []PeerFilterFunc
+	ipFilter      *IPFilter
 
 	rng *rand.Rand // seed for randomizing dial times and orders
 
+	scores *peerScores
+
 	metrics *Metrics
 	mlc     *metricsLabelCache
 }
@@ -129,6 +134,7 @@ func NewSwitch(
 		persistentPeersAddrs: make([]*NetAddress, 0),
 		unconditionalPeerIDs: make(map[ID]struct{}),
 		mlc:                  newMetricsLabelCache(),
+		scores:               newPeerScores(),
 	}
 
 	// Ensure we have a completely undeterministic PRNG.
@@ -153,6 +159,13 @@ func SwitchPeerFilters(filters ...PeerFilterFunc) SwitchOption {
 	return func(sw *Switch) { sw.peerFilters = filters }
 }
 
+// SwitchIPFilter sets the filter applied to the IPs of the peers before
+// dialing them. It should also be set as a connection filter of the transport,
+// so that it applies to inbound peers.
+func SwitchIPFilter(filter *IPFilter) SwitchOption {
+	return func(sw *Switch) { sw.ipFilter = filter }
+}
+
 // WithMetrics sets the metrics.
 func WithMetrics(metrics *Metrics) SwitchOption {
 	return func(sw *Switch) { sw.metrics = metrics }
@@ -165,6 +178,7 @@ func WithMetrics(metrics *Metrics) SwitchOption {
 // NOTE: Not goroutine safe.
 func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 	for _, chDesc := range reactor.GetChannels() {
+		chDesc = sw.applyChannelConfig(chDesc)
 		chID := chDesc.ID
 		// No two reactors can share the same channel.
 		if sw.reactorsByCh[chID] != nil {
@@ -179,6 +193,31 @@ func (sw *Switch) AddReactor(name string, reactor Reactor) Reactor {
 	return reactor
 }
 
+// applyChannelConfig returns a copy of the channel descriptor with the
+// overrides of the config applied, if any.
+func (sw *Switch) applyChannelConfig(chDesc *conn.ChannelDescriptor) *conn.ChannelDescriptor {
+	for _, chCfg := range sw.config.Channels {
+		if chCfg.ID != chDesc.ID {
+			continue
+		}
+		desc := *chDesc
+		if chCfg.Priority > 0 {
+			desc.Priority = chCfg.Priority
+		}
+		if chCfg.SendQueueCapacity > 0 {
+			desc.SendQueueCapacity = chCfg.SendQueueCapacity
+		}
+		if chCfg.SendRate > 0 {
+			desc.SendRate = chCfg.SendRate
+		}
+		if chCfg.RecvRate > 0 {
+			desc.RecvRate = chCfg.RecvRate
+		}
+		return &desc
+	}
+	return chDesc
+}
+
 // RemoveReactor removes the given Reactor from the Switch.
 // NOTE: Not goroutine safe.
 func (sw *Switch) RemoveReactor(name string, reactor Reactor) {
@@ -459,6 +498,59 @@ func (sw *Switch) MarkPeerAsGood(peer Peer) {
 	}
 }
 
+// ReportPeer updates the score of the given peer with a behavior reported by
+// a reactor. If the score drops too low, the peer is disconnected and banned,
+// unless it is persistent or unconditional.
+func (sw *Switch) ReportPeer(peer Peer, behavior PeerBehavior) {
+	if !sw.scores.Report(peer.ID(), behavior) {
+		return
+	}
+	if peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+		sw.Logger.Info("Not banning persistent or unconditional peer", "peer", peer, "behavior", behavior)
+		return
+	}
+
+	if sw.addrBook != nil {
+		sw.addrBook.MarkBad(peer.SocketAddr(), peerBanDuration)
+	}
+	sw.StopPeerForError(peer, fmt.Errorf("banned for %v after %v", peerBanDuration, behavior))
+}
+
+// PeerScore returns the score of the peer with the given ID, which reflects
+// how useful or abusive the peer has recently been. Unknown peers have a
+// score of zero.
+func (sw *Switch) PeerScore(id ID) int64 {
+	return sw.scores.Score(id)
+}
+
+// evictInboundPeerFor stops the lowest scored inbound peer to make room for
+// the given peer, if the latter has a higher score. Persistent and
+// unconditional peers are never evicted. Returns true if a peer was evicted.
+func (sw *Switch) evictInboundPeerFor(p Peer) bool {
+	var (
+		victim      Peer
+		victimScore int64
+	)
+	sw.peers.ForEach(func(peer Peer) {
+		if peer.IsOutbound() || peer.IsPersistent() || sw.IsPeerUnconditional(peer.ID()) {
+			return
+		}
+		if score := sw.PeerScore(peer.ID()); victim == nil || score < victimScore {
+			victim, victimScore = peer, score
+		}
+	})
+
+	score := sw.PeerScore(p.ID())
+	if victim == nil || victimScore >= score {
+		return false
+	}
+
+	sw.Logger.Info("Evicting inbound peer to make room for a better scored peer",
+		"peer", victim, "score", victimScore, "newPeer", p.ID(), "newScore", score)
+	sw.stopAndRemovePeer(victim, errors.New("evicted for a better scored peer"))
+	return true
+}
+
 //---------------------------------------------------------------------
 // Dialing
 
@@ -623,6 +715,39 @@ func (sw *Switch) AddPrivatePeerIDs(ids []string) error {
 	return nil
 }
 
+// SetIPFilterRules changes the CIDR ranges and the maximum number of
+// connections per subnet of the IP filter, and stops the peers rejected by the
+// new rules. It returns the IDs of the stopped peers.
+func (sw *Switch) SetIPFilterRules(allowedCIDRs, deniedCIDRs []string, maxConnsPerSubnet int) ([]ID, error) {
+	if sw.ipFilter == nil {
+		return nil, errors.New("IP filter is not enabled")
+	}
+
+	rules := sw.ipFilter.Rules()
+	rules.AllowedCIDRs = allowedCIDRs
+	rules.DeniedCIDRs = deniedCIDRs
+	rules.MaxConnsPerSubnet = maxConnsPerSubnet
+	if err := sw.ipFilter.SetRules(rules); err != nil {
+		return nil, err
+	}
+
+	var (
+		kept    = make([]net.IP, 0, sw.peers.Size())
+		stopped = make([]ID, 0)
+	)
+	for _, p := range sw.peers.Copy() {
+		if err := sw.ipFilter.Check(p.RemoteIP(), kept); err != nil {
+			sw.Logger.Info("Stopping peer rejected by the IP filter", "peer", p, "err", err)
+			sw.StopPeerForError(p, err)
+			stopped = append(stopped, p.ID())
+			continue
+		}
+		kept = append(kept, p.RemoteIP())
+	}
+
+	return stopped, nil
+}
+
 func (sw *Switch) IsPeerPersistent(na *NetAddress) bool {
 	for _, pa := range sw.persistentPeersAddrs {
 		if pa.Equals(na) {
@@ -693,7 +818,7 @@ func (sw *Switch) acceptRoutine() {
 		if !sw.IsPeerUnconditional(p.NodeInfo().ID()) {
 			// Ignore connection if we already have enough peers.
 			_, in, _ := sw.NumPeers()
-			if in >= sw.config.MaxNumInboundPeers {
+			if in >= sw.config.MaxNumInboundPeers && !sw.evictInboundPeerFor(p) {
 				sw.Logger.Info(
 					"Ignoring inbound connection: already have enough inbound peers",
 					"address", p.SocketAddr(),
@@ -732,6 +857,10 @@ func (sw *Switch) addOutboundPeerWithConfig(
 ) error {
 	sw.Logger.Debug("Dialing peer", "address", addr)
 
+	if err := sw.filterAddr(addr); err != nil {
+		return err
+	}
+
 	// XXX(xla): Remove the leakage of test concerns in implementation.
 	if cfg.TestDialFail {
 		go sw.reconnectToPeer(addr)
@@ -779,12 +908,35 @@ func (sw *Switch) addOutboundPeerWithConfig(
 	return nil
 }
 
+// filterAddr returns an error if the IP filter rejects the address, given the
+// peers we are already connected to.
+func (sw *Switch) filterAddr(addr *NetAddress) error {
+	if sw.ipFilter == nil {
+		return nil
+	}
+
+	peers := sw.peers.Copy()
+	ips := make([]net.IP, 0, len(peers))
+	for _, p := range peers {
+		ips = append(ips, p.RemoteIP())
+	}
+	if err := sw.ipFilter.Check(addr.IP, ips); err != nil {
+		return ErrRejected{addr: *addr, id: addr.ID, err: err, isFiltered: true}
+	}
+
+	return nil
+}
+
 func (sw *Switch) filterPeer(p Peer) error {
 	// Avoid duplicate
 	if sw.peers.Has(p.ID()) {
 		return ErrRejected{id: p.ID(), isDuplicate: true}
 	}
 
+	if sw.scores.IsBanned(p.ID()) && !p.IsPersistent() && !sw.IsPeerUnconditional(p.ID()) {
+		return ErrRejected{id: p.ID(), err: errors.New("peer is banned"), isFiltered: true}
+	}
+
 	errc := make(chan error, len(sw.peerFilters))
 
 	for _, f := range sw.peerFilters {
@@ -738,6 +738,30 @@ func TestSwitchAcceptRoutineErrorCases(t *testing.T) {
 	})
 }
 
+func TestSwitchAddReactorAppliesChannelConfig(t *testing.T) {
+	p2pCfg := *cfg
+	p2pCfg.Channels = []config.P2PChannelConfig{
+		{ID: 0x01, Priority: 7, SendRate: 1024},
+		{ID: 0x03, RecvRate: 2048},
+	}
+	sw := NewSwitch(&p2pCfg, errorTransport{ErrTransportClosed{}})
+	chDescs := []*conn.ChannelDescriptor{
+		{ID: 0x01, Priority: 1, SendQueueCapacity: 10, MessageType: &p2pproto.Message{}},
+		{ID: 0x02, Priority: 2, SendQueueCapacity: 20, MessageType: &p2pproto.Message{}},
+	}
+	sw.AddReactor("foo", NewTestReactor(chDescs, false))
+
+	require.Len(t, sw.chDescs, 2)
+	assert.Equal(t, 7, sw.chDescs[0].Priority)
+	assert.Equal(t, 10, sw.chDescs[0].SendQueueCapacity)
+	assert.EqualValues(t, 1024, sw.chDescs[0].SendRate)
+	assert.Zero(t, sw.chDescs[0].RecvRate)
+	assert.Same(t, chDescs[1], sw.chDescs[1])
+	// The descriptors of the reactor are left untouched.
+	assert.Equal(t, 1, chDescs[0].Priority)
+	assert.Zero(t, chDescs[0].SendRate)
+}
+
 // mockReactor checks that InitPeer never called before RemovePeer. If that's
 // not true, InitCalledBeforeRemoveFinished will return true.
 type mockReactor struct {
@@ -332,6 +332,9 @@ func (book *AddrBookMock) OurAddress(addr *NetAddress) bool {
 	return ok
 }
 func (book *AddrBookMock) MarkGood(ID) {}
+func (book *AddrBookMock) MarkBad(addr *NetAddress, _ time.Duration) {
+	delete(book.Addrs, addr.String())
+}
 func (book *AddrBookMock) HasAddress(addr *NetAddress) bool {
 	_, ok := book.Addrs[addr.String()]
 	return ok
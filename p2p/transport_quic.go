@@ -0,0 +1,552 @@
+package p2p
+
+import (
+	"context"
+	stded25519 "crypto/ed25519"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"sync/atomic"
+	"time"
+
+	"github.com/quic-go/quic-go"
+
+	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/p2p/conn"
+)
+
+const (
+	// quicALPN is the application protocol negotiated by the QUIC transport.
+	quicALPN = "cometbft-p2p"
+
+	// quicMaxChannels is the maximum number of channel streams a peer can
+	// open, i.e. one per channel ID.
+	quicMaxChannels = 256
+
+	quicErrorCodeNoError        quic.ApplicationErrorCode = 0
+	quicErrorCodeRejected       quic.ApplicationErrorCode = 1
+	quicStreamErrorCodeRejected quic.StreamErrorCode      = 1
+)
+
+// QUICTransportOption sets an optional parameter on the QUICTransport.
+type QUICTransportOption func(*QUICTransport)
+
+// QUICTransportConnFilters sets the filters for rejection new connections.
+func QUICTransportConnFilters(filters ...ConnFilterFunc) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.connFilters = filters }
+}
+
+// QUICTransportFilterTimeout sets the timeout waited for filter calls to
+// return.
+func QUICTransportFilterTimeout(timeout time.Duration) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.filterTimeout = timeout }
+}
+
+// QUICTransportResolver sets the Resolver used for ip lookups, defaults to
+// net.DefaultResolver.
+func QUICTransportResolver(resolver IPResolver) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.resolver = resolver }
+}
+
+// QUICTransportMaxIncomingConnections sets the maximum number of
+// simultaneous connections (incoming). Default: 0 (unlimited).
+func QUICTransportMaxIncomingConnections(n int) QUICTransportOption {
+	return func(qt *QUICTransport) { qt.maxIncomingConnections = int32(n) }
+}
+
+// QUICTransport accepts and dials QUIC connections, authenticated with the
+// node key, and upgrades them to peers which send the messages of every
+// channel on a separate stream. Unlike with the MultiplexTransport, a channel
+// sending large messages does not delay the messages of the other channels.
+//
+// Both ends of a connection must use the QUICTransport.
+type QUICTransport struct {
+	netAddr                NetAddress
+	listener               *quic.Listener
+	maxIncomingConnections int32 // see MaxIncomingConnections
+	numIncomingConnections atomic.Int32
+
+	acceptc chan accept
+	closec  chan struct{}
+
+	// Lookup table for duplicate ip and id checks.
+	conns       ConnSet
+	connFilters []ConnFilterFunc
+
+	dialTimeout      time.Duration
+	filterTimeout    time.Duration
+	handshakeTimeout time.Duration
+	nodeInfo         NodeInfo
+	nodeKey          NodeKey
+	resolver         IPResolver
+	tlsConfig        *tls.Config
+
+	mConfig conn.MConnConfig
+}
+
+// Test QUICTransport for interface completeness.
+var (
+	_ Transport          = (*QUICTransport)(nil)
+	_ transportLifecycle = (*QUICTransport)(nil)
+)
+
+// NewQUICTransport returns a QUIC transport. The node key must be an ed25519
+// key, which is used to authenticate the connections.
+func NewQUICTransport(
+	nodeInfo NodeInfo,
+	nodeKey NodeKey,
+	mConfig conn.MConnConfig,
+	options ...QUICTransportOption,
+) (*QUICTransport, error) {
+	tlsConfig, err := quicTLSConfig(nodeKey.PrivKey)
+	if err != nil {
+		return nil, err
+	}
+	qt := &QUICTransport{
+		acceptc:          make(chan accept),
+		closec:           make(chan struct{}),
+		dialTimeout:      defaultDialTimeout,
+		filterTimeout:    defaultFilterTimeout,
+		handshakeTimeout: defaultHandshakeTimeout,
+		mConfig:          mConfig,
+		nodeInfo:         nodeInfo,
+		nodeKey:          nodeKey,
+		conns:            NewConnSet(),
+		resolver:         net.DefaultResolver,
+		tlsConfig:        tlsConfig,
+	}
+	for _, option := range options {
+		option(qt)
+	}
+	return qt, nil
+}
+
+// NetAddress implements Transport.
+func (qt *QUICTransport) NetAddress() NetAddress {
+	return qt.netAddr
+}
+
+// Accept implements Transport.
+func (qt *QUICTransport) Accept(cfg peerConfig) (Peer, error) {
+	select {
+	case a := <-qt.acceptc:
+		if a.err != nil {
+			return nil, a.err
+		}
+
+		cfg.outbound = false
+
+		return qt.wrapPeer(a.conn.(*quicConn), a.nodeInfo, cfg, a.netAddr), nil
+	case <-qt.closec:
+		return nil, ErrTransportClosed{}
+	}
+}
+
+// Dial implements Transport.
+func (qt *QUICTransport) Dial(addr NetAddress, cfg peerConfig) (Peer, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), qt.dialTimeout+qt.handshakeTimeout)
+	defer cancel()
+
+	qc, err := quic.DialAddr(ctx, addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	// The handshake stream is opened by the dialer.
+	stream, err := qc.OpenStreamSync(ctx)
+	if err != nil {
+		_ = qc.CloseWithError(quicErrorCodeNoError, "")
+		return nil, err
+	}
+	c := &quicConn{Stream: stream, conn: qc}
+
+	// TODO(xla): Evaluate if we should apply filters if we explicitly dial.
+	if err := qt.filterConn(c); err != nil {
+		return nil, err
+	}
+
+	nodeInfo, err := qt.upgrade(c, &addr)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.outbound = true
+
+	return qt.wrapPeer(c, nodeInfo, cfg, &addr), nil
+}
+
+// Close implements transportLifecycle.
+func (qt *QUICTransport) Close() error {
+	close(qt.closec)
+
+	if qt.listener != nil {
+		return qt.listener.Close()
+	}
+
+	return nil
+}
+
+// Listen implements transportLifecycle.
+func (qt *QUICTransport) Listen(addr NetAddress) error {
+	ln, err := quic.ListenAddr(addr.DialString(), qt.tlsConfig, qt.quicConfig())
+	if err != nil {
+		return err
+	}
+
+	qt.netAddr = addr
+	qt.listener = ln
+
+	go qt.acceptPeers()
+
+	return nil
+}
+
+// AddChannel registers a channel to nodeInfo.
+// NOTE: NodeInfo must be of type DefaultNodeInfo else channels won't be updated.
+func (qt *QUICTransport) AddChannel(chID byte) {
+	if ni, ok := qt.nodeInfo.(DefaultNodeInfo); ok {
+		if !ni.HasChannel(chID) {
+			ni.Channels = append(ni.Channels, chID)
+		}
+		qt.nodeInfo = ni
+	}
+}
+
+// Cleanup removes the given address from the connections set and
+// closes the connection.
+func (qt *QUICTransport) Cleanup(p Peer) {
+	qt.conns.RemoveAddr(p.RemoteAddr())
+	_ = p.CloseConn()
+}
+
+func (qt *QUICTransport) acceptPeers() {
+	for {
+		qc, err := qt.listener.Accept(context.Background())
+		if err != nil {
+			// If Close() has been called, silently exit.
+			select {
+			case _, ok := <-qt.closec:
+				if !ok {
+					return
+				}
+			default:
+				// Transport is not closed
+			}
+
+			qt.acceptc <- accept{err: err}
+			return
+		}
+
+		if max := qt.maxIncomingConnections; max > 0 && qt.numIncomingConnections.Load() >= max {
+			_ = qc.CloseWithError(quicErrorCodeRejected, "too many connections")
+			continue
+		}
+		qt.numIncomingConnections.Add(1)
+		go func() {
+			<-qc.Context().Done()
+			qt.numIncomingConnections.Add(-1)
+		}()
+
+		// Connection upgrade and filtering are asynchronous, as for the
+		// MultiplexTransport, to avoid head-of-line blocking.
+		go func(qc quic.Connection) {
+			defer func() {
+				if r := recover(); r != nil {
+					_ = qc.CloseWithError(quicErrorCodeRejected, "")
+					err := ErrRejected{
+						err:           fmt.Errorf("recovered from panic: %v", r),
+						isAuthFailure: true,
+					}
+					select {
+					case qt.acceptc <- accept{err: err}:
+					case <-qt.closec:
+					}
+				}
+			}()
+
+			var (
+				c        *quicConn
+				nodeInfo NodeInfo
+				netAddr  *NetAddress
+			)
+
+			ctx, cancel := context.WithTimeout(context.Background(), qt.handshakeTimeout)
+			stream, err := qc.AcceptStream(ctx)
+			cancel()
+			if err != nil {
+				_ = qc.CloseWithError(quicErrorCodeRejected, "")
+				err = ErrRejected{
+					err:           fmt.Errorf("handshake stream failed: %w", err),
+					isAuthFailure: true,
+				}
+			} else {
+				c = &quicConn{Stream: stream, conn: qc}
+				err = qt.filterConn(c)
+				if err == nil {
+					nodeInfo, err = qt.upgrade(c, nil)
+					if err == nil {
+						netAddr = newRemoteNetAddress(nodeInfo.ID(), c)
+					}
+				}
+			}
+
+			select {
+			case qt.acceptc <- accept{netAddr, c, nodeInfo, err}:
+				// Make the upgraded peer available.
+			case <-qt.closec:
+				// Give up if the transport was closed.
+				_ = qc.CloseWithError(quicErrorCodeNoError, "")
+				return
+			}
+		}(qc)
+	}
+}
+
+func (qt *QUICTransport) cleanup(c *quicConn) error {
+	qt.conns.Remove(c)
+
+	return c.Close()
+}
+
+func (qt *QUICTransport) filterConn(c *quicConn) (err error) {
+	defer func() {
+		if err != nil {
+			_ = c.Close()
+		}
+	}()
+
+	// Reject if connection is already present.
+	if qt.conns.Has(c) {
+		return ErrRejected{conn: c, isDuplicate: true}
+	}
+
+	// Resolve ips for incoming conn.
+	ips, err := resolveIPs(qt.resolver, c)
+	if err != nil {
+		return err
+	}
+
+	errc := make(chan error, len(qt.connFilters))
+
+	for _, f := range qt.connFilters {
+		go func(f ConnFilterFunc, c net.Conn, ips []net.IP, errc chan<- error) {
+			errc <- f(qt.conns, c, ips)
+		}(f, c, ips, errc)
+	}
+
+	for i := 0; i < cap(errc); i++ {
+		select {
+		case err := <-errc:
+			if err != nil {
+				return ErrRejected{conn: c, err: err, isFiltered: true}
+			}
+		case <-time.After(qt.filterTimeout):
+			return ErrFilterTimeout{}
+		}
+	}
+
+	qt.conns.Set(c, ips)
+
+	return nil
+}
+
+// upgrade exchanges the NodeInfo on the handshake stream of the connection,
+// which was authenticated by the TLS handshake.
+func (qt *QUICTransport) upgrade(c *quicConn, dialedAddr *NetAddress) (nodeInfo NodeInfo, err error) {
+	defer func() {
+		if err != nil {
+			_ = qt.cleanup(c)
+		}
+	}()
+
+	connID, err := quicRemoteID(c.conn)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           err,
+			isAuthFailure: true,
+		}
+	}
+
+	// For outgoing conns, ensure connection key matches dialed key.
+	if dialedAddr != nil {
+		if dialedID := dialedAddr.ID; connID != dialedID {
+			return nil, ErrRejected{
+				conn: c,
+				id:   connID,
+				err: fmt.Errorf(
+					"conn.ID (%v) dialed ID (%v) mismatch",
+					connID,
+					dialedID,
+				),
+				isAuthFailure: true,
+			}
+		}
+	}
+
+	nodeInfo, err = handshake(c, qt.handshakeTimeout, qt.nodeInfo)
+	if err != nil {
+		return nil, ErrRejected{
+			conn:          c,
+			err:           fmt.Errorf("handshake failed: %w", err),
+			isAuthFailure: true,
+		}
+	}
+
+	if err := checkNodeInfo(c, connID, qt.nodeInfo, nodeInfo); err != nil {
+		return nil, err
+	}
+
+	return nodeInfo, nil
+}
+
+func (qt *QUICTransport) wrapPeer(
+	c *quicConn,
+	ni NodeInfo,
+	cfg peerConfig,
+	socketAddr *NetAddress,
+) Peer {
+	persistent := false
+	if cfg.isPersistent != nil {
+		if cfg.outbound {
+			persistent = cfg.isPersistent(socketAddr)
+		} else {
+			selfReportedAddr, err := ni.NetAddress()
+			if err == nil {
+				persistent = cfg.isPersistent(selfReportedAddr)
+			}
+		}
+	}
+
+	peerConn := newPeerConn(
+		cfg.outbound,
+		persistent,
+		c,
+		socketAddr,
+	)
+
+	return newQUICPeer(
+		peerConn,
+		c.conn,
+		qt.mConfig,
+		ni,
+		cfg.reactorsByCh,
+		cfg.msgTypeByChID,
+		cfg.chDescs,
+		cfg.onPeerError,
+		cfg.mlc,
+		cfg.metrics,
+	)
+}
+
+// quicConfig returns the configuration of the QUIC connections. The
+// keep-alive and idle timeout replace the pings and pongs of the
+// MConnection.
+func (qt *QUICTransport) quicConfig() *quic.Config {
+	return &quic.Config{
+		HandshakeIdleTimeout:  qt.handshakeTimeout,
+		MaxIdleTimeout:        qt.mConfig.PingInterval + qt.mConfig.PongTimeout,
+		KeepAlivePeriod:       qt.mConfig.PingInterval,
+		MaxIncomingStreams:    1, // the handshake stream
+		MaxIncomingUniStreams: quicMaxChannels,
+	}
+}
+
+//-----------------------------------------------------------------------------
+
+// quicConn is the handshake stream of a QUIC connection. It represents the
+// connection as a net.Conn, e.g. for the connection filters; closing it closes
+// the connection.
+type quicConn struct {
+	quic.Stream
+	conn quic.Connection
+}
+
+var _ net.Conn = (*quicConn)(nil)
+
+// LocalAddr implements net.Conn.
+func (c *quicConn) LocalAddr() net.Addr {
+	return c.conn.LocalAddr()
+}
+
+// RemoteAddr implements net.Conn.
+func (c *quicConn) RemoteAddr() net.Addr {
+	return c.conn.RemoteAddr()
+}
+
+// Close implements net.Conn.
+func (c *quicConn) Close() error {
+	return c.conn.CloseWithError(quicErrorCodeNoError, "")
+}
+
+// quicTLSConfig returns the TLS configuration of the QUIC connections, with a
+// self-signed certificate for the node key. Peers are authenticated by the
+// key of their certificate rather than by a certificate authority.
+func quicTLSConfig(privKey crypto.PrivKey) (*tls.Config, error) {
+	edKey, ok := privKey.(ed25519.PrivKey)
+	if !ok {
+		return nil, fmt.Errorf("the QUIC transport requires an ed25519 node key, got %s", privKey.Type())
+	}
+	key := stded25519.PrivateKey(edKey)
+
+	serialNumber, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, err
+	}
+	template := &x509.Certificate{
+		SerialNumber: serialNumber,
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(100 * 365 * 24 * time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("creating certificate: %w", err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		ClientAuth:   tls.RequireAnyClientCert,
+		// The certificates are self-signed, and verified by
+		// verifyQUICCertificate instead.
+		InsecureSkipVerify:    true, //nolint:gosec
+		VerifyPeerCertificate: verifyQUICCertificate,
+		NextProtos:            []string{quicALPN},
+		MinVersion:            tls.VersionTLS13,
+	}, nil
+}
+
+// verifyQUICCertificate verifies that the peer presented a single certificate,
+// self-signed with an ed25519 key. The TLS handshake proves that the peer
+// owns that key.
+func verifyQUICCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) != 1 {
+		return fmt.Errorf("expected 1 certificate, got %d", len(rawCerts))
+	}
+	cert, err := x509.ParseCertificate(rawCerts[0])
+	if err != nil {
+		return err
+	}
+	if _, ok := cert.PublicKey.(stded25519.PublicKey); !ok {
+		return fmt.Errorf("unsupported certificate key type %T", cert.PublicKey)
+	}
+	return cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+}
+
+// quicRemoteID returns the ID of the node key of the remote end of the
+// connection.
+func quicRemoteID(qc quic.Connection) (ID, error) {
+	certs := qc.ConnectionState().TLS.PeerCertificates
+	if len(certs) != 1 {
+		return "", errors.New("missing peer certificate")
+	}
+	pubKey, ok := certs[0].PublicKey.(stded25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("unsupported certificate key type %T", certs[0].PublicKey)
+	}
+	return PubKeyToID(ed25519.PubKey(pubKey)), nil
+}
@@ -0,0 +1,199 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	p2pproto "github.com/cometbft/cometbft/api/cometbft/p2p/v1"
+	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/p2p/conn"
+)
+
+// blockingReactor is a reactor whose Receive blocks on channel 0x01 until
+// unblocked, and passes the messages of the other channels to received.
+type blockingReactor struct {
+	BaseReactor
+
+	unblock  chan struct{}
+	received chan Envelope
+}
+
+func newBlockingReactor() *blockingReactor {
+	r := &blockingReactor{
+		unblock:  make(chan struct{}),
+		received: make(chan Envelope, 100),
+	}
+	r.BaseReactor = *NewBaseReactor("BlockingReactor", r)
+	return r
+}
+
+func (*blockingReactor) GetChannels() []*conn.ChannelDescriptor {
+	return []*conn.ChannelDescriptor{
+		{ID: byte(0x01), Priority: 10, MessageType: &p2pproto.Message{}},
+		{ID: byte(0x02), Priority: 1, MessageType: &p2pproto.Message{}},
+	}
+}
+
+func (r *blockingReactor) Receive(e Envelope) {
+	if e.ChannelID == 0x01 {
+		<-r.unblock
+	}
+	r.received <- e
+}
+
+func makeQUICSwitch(t *testing.T, name string, reactors map[string]Reactor) *Switch {
+	t.Helper()
+
+	nodeKey := NodeKey{PrivKey: ed25519.GenPrivKey()}
+	nodeInfo := testNodeInfo(nodeKey.ID(), name).(DefaultNodeInfo)
+	addr, err := NewNetAddressString(IDAddressString(nodeKey.ID(), nodeInfo.ListenAddr))
+	require.NoError(t, err)
+
+	nodeInfo.Channels = nil
+	for _, reactor := range reactors {
+		for _, chDesc := range reactor.GetChannels() {
+			nodeInfo.Channels = append(nodeInfo.Channels, chDesc.ID)
+		}
+	}
+	transport, err := NewQUICTransport(nodeInfo, nodeKey, MConnConfig(cfg))
+	require.NoError(t, err)
+	require.NoError(t, transport.Listen(*addr))
+
+	sw := NewSwitch(cfg, transport)
+	sw.SetLogger(log.TestingLogger().With("switch", name))
+	sw.SetNodeKey(&nodeKey)
+	sw.SetNodeInfo(nodeInfo)
+	for name, reactor := range reactors {
+		sw.AddReactor(name, reactor)
+	}
+	require.NoError(t, sw.Start())
+	t.Cleanup(func() {
+		if err := sw.Stop(); err != nil {
+			t.Error(err)
+		}
+		if err := transport.Close(); err != nil {
+			t.Error(err)
+		}
+	})
+	return sw
+}
+
+func TestQUICTransportSwitches(t *testing.T) {
+	newReactors := func() map[string]Reactor {
+		return map[string]Reactor{
+			"foo": NewTestReactor([]*conn.ChannelDescriptor{
+				{ID: byte(0x00), Priority: 10, MessageType: &p2pproto.Message{}},
+				{ID: byte(0x01), Priority: 10, MessageType: &p2pproto.Message{}},
+			}, true),
+		}
+	}
+	s1 := makeQUICSwitch(t, "s1", newReactors())
+	s2 := makeQUICSwitch(t, "s2", newReactors())
+
+	addr := s2.NetAddress()
+	require.NoError(t, s1.DialPeerWithAddress(addr))
+	require.Eventually(t, func() bool {
+		return s1.Peers().Size() == 1 && s2.Peers().Size() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	peer := s1.Peers().Get(addr.ID)
+	require.NotNil(t, peer)
+	assert.True(t, peer.IsOutbound())
+	assert.Equal(t, s2.NodeInfo().ID(), peer.NodeInfo().ID())
+	assert.False(t, s2.Peers().Get(s1.NodeInfo().ID()).IsOutbound())
+
+	for _, s := range []*Switch{s1, s2} {
+		for _, chID := range []byte{0x00, 0x01} {
+			msg := &p2pproto.PexAddrs{Addrs: []p2pproto.NetAddress{{ID: string(s.NodeInfo().ID())}}}
+			s.Broadcast(Envelope{ChannelID: chID, Message: msg})
+		}
+	}
+	for _, s := range []*Switch{s1, s2} {
+		reactor := s.Reactor("foo").(*TestReactor)
+		for _, chID := range []byte{0x00, 0x01} {
+			require.Eventually(t, func() bool {
+				return len(reactor.getMsgs(chID)) == 1
+			}, 5*time.Second, 10*time.Millisecond)
+			msg := reactor.getMsgs(chID)[0].Contents.(*p2pproto.PexAddrs)
+			assert.NotEqual(t, s.NodeInfo().ID(), ID(msg.Addrs[0].ID))
+		}
+	}
+
+	status := peer.Status()
+	require.Len(t, status.Channels, 2)
+	assert.EqualValues(t, 0x00, status.Channels[0].ID)
+
+	// Stopping a peer disconnects it from the other switch.
+	s1.StopPeerGracefully(peer)
+	require.Eventually(t, func() bool {
+		return s2.Peers().Size() == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestQUICTransportChannelsDoNotBlockEachOther(t *testing.T) {
+	r1, r2 := newBlockingReactor(), newBlockingReactor()
+	s1 := makeQUICSwitch(t, "s1", map[string]Reactor{"blocking": r1})
+	s2 := makeQUICSwitch(t, "s2", map[string]Reactor{"blocking": r2})
+	defer close(r2.unblock)
+
+	require.NoError(t, s1.DialPeerWithAddress(s2.NetAddress()))
+	require.Eventually(t, func() bool {
+		return s1.Peers().Size() == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	peer := s1.Peers().Get(s2.NodeInfo().ID())
+
+	// The messages of channel 0x01 are not processed, but those of channel
+	// 0x02 are still received.
+	require.True(t, peer.Send(Envelope{ChannelID: 0x01, Message: &p2pproto.PexRequest{}}))
+	for i := 0; i < 10; i++ {
+		require.True(t, peer.Send(Envelope{ChannelID: 0x02, Message: &p2pproto.PexRequest{}}))
+	}
+	for i := 0; i < 10; i++ {
+		select {
+		case e := <-r2.received:
+			assert.EqualValues(t, 0x02, e.ChannelID)
+			assert.Equal(t, s1.NodeInfo().ID(), e.Src.ID())
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for message")
+		}
+	}
+}
+
+func TestQUICTransportDialRejectWrongID(t *testing.T) {
+	var (
+		pv       = ed25519.GenPrivKey()
+		id       = PubKeyToID(pv.PubKey())
+		nodeInfo = testNodeInfo(id, "transport")
+	)
+	qt, err := NewQUICTransport(nodeInfo, NodeKey{PrivKey: pv}, conn.DefaultMConnConfig())
+	require.NoError(t, err)
+	addr, err := NewNetAddressString(IDAddressString(id, nodeInfo.(DefaultNodeInfo).ListenAddr))
+	require.NoError(t, err)
+	require.NoError(t, qt.Listen(*addr))
+	t.Cleanup(func() { _ = qt.Close() })
+
+	pv = ed25519.GenPrivKey()
+	dialer, err := NewQUICTransport(testNodeInfo(PubKeyToID(pv.PubKey()), "dialer"), NodeKey{PrivKey: pv},
+		conn.DefaultMConnConfig())
+	require.NoError(t, err)
+
+	wrongAddr := *addr
+	wrongAddr.ID = PubKeyToID(ed25519.GenPrivKey().PubKey())
+	_, err = dialer.Dial(wrongAddr, peerConfig{})
+	require.Error(t, err)
+	e, ok := err.(ErrRejected)
+	require.True(t, ok, "expected ErrRejected, got %v", err)
+	assert.True(t, e.IsAuthFailure())
+}
+
+func TestQUICTransportRequiresEd25519Key(t *testing.T) {
+	pv := secp256k1.GenPrivKey()
+	_, err := NewQUICTransport(testNodeInfo(PubKeyToID(pv.PubKey()), "node"), NodeKey{PrivKey: pv},
+		conn.DefaultMConnConfig())
+	require.Error(t, err)
+}
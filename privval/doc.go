@@ -5,6 +5,9 @@ Package privval provides different implementations of the types.PrivValidator.
 
 FilePV is the simplest implementation and developer default.
 It uses one file for the private key and another to store state.
+The state can instead be persisted to a LastSignStateStore, such as a
+DBLastSignStateStore, which can be shared by several signers of the same
+validator to prevent them from signing conflicting messages.
 
 # SignerListenerEndpoint
 
@@ -23,5 +26,12 @@ SignerDialerEndpoint is a simple wrapper around a net.Conn. It's used by both IP
 SignerClient handles remote validator connections that provide signing services.
 In production, it's recommended to wrap it with RetrySignerClient to avoid
 termination in case of temporary errors.
+
+# gRPC remote signer
+
+The privval/grpc package provides a remote signer serving the PrivValidatorAPI
+gRPC service, and the matching client. The node dials the remote signer when
+priv_validator_laddr has the grpc:// scheme, and both authenticate each other
+with mutual TLS.
 */
 package privval
@@ -11,7 +11,9 @@ import (
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
+	"github.com/cometbft/cometbft/crypto/secp256k1"
 	cmtos "github.com/cometbft/cometbft/internal/os"
 	"github.com/cometbft/cometbft/internal/protoio"
 	"github.com/cometbft/cometbft/internal/tempfile"
@@ -164,6 +166,10 @@ func (lss *FilePVLastSignState) Save() {
 type FilePV struct {
 	Key           FilePVKey
 	LastSignState FilePVLastSignState
+
+	// stateStore, if set, persists the last sign state instead of
+	// LastSignState, which then only caches the last state.
+	stateStore LastSignStateStore
 }
 
 // NewFilePV generates a new validator from the given key and paths.
@@ -188,6 +194,28 @@ func GenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return NewFilePV(ed25519.GenPrivKey(), keyFilePath, stateFilePath)
 }
 
+// GenFilePVWithKeyType generates a new validator with a randomly generated
+// private key of the given type (see the ABCIPubKeyType constants in the types
+// package) and sets the filePaths, but does not call Save().
+func GenFilePVWithKeyType(keyFilePath, stateFilePath, keyType string) (*FilePV, error) {
+	var privKey crypto.PrivKey
+	switch keyType {
+	case types.ABCIPubKeyTypeEd25519:
+		privKey = ed25519.GenPrivKey()
+	case types.ABCIPubKeyTypeSecp256k1:
+		privKey = secp256k1.GenPrivKey()
+	case types.ABCIPubKeyTypeBls12381:
+		pk, err := bls12381.GenPrivKey()
+		if err != nil {
+			return nil, err
+		}
+		privKey = pk
+	default:
+		return nil, fmt.Errorf("unsupported key type: %q", keyType)
+	}
+	return NewFilePV(privKey, keyFilePath, stateFilePath), nil
+}
+
 // LoadFilePV loads a FilePV from the filePaths.  The FilePV handles double
 // signing prevention by persisting data to the stateFilePath.  If either file path
 // does not exist, the program will exit.
@@ -239,6 +267,17 @@ func loadFilePV(keyFilePath, stateFilePath string, loadState bool) *FilePV {
 	}
 }
 
+// LoadFilePVWithLastSignStateStore loads a FilePV from the given keyFilePath,
+// using store to persist its last sign state. If the keyFilePath does not
+// exist, the program will exit.
+func LoadFilePVWithLastSignStateStore(keyFilePath string, store LastSignStateStore) (*FilePV, error) {
+	pv := loadFilePV(keyFilePath, "", false)
+	if err := pv.SetLastSignStateStore(store); err != nil {
+		return nil, err
+	}
+	return pv, nil
+}
+
 // LoadOrGenFilePV loads a FilePV from the given filePaths
 // or else generates a new one and saves it to the filePaths.
 func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
@@ -252,6 +291,34 @@ func LoadOrGenFilePV(keyFilePath, stateFilePath string) *FilePV {
 	return pv
 }
 
+// SetLastSignStateStore makes pv persist its last sign state to store, which
+// may be shared with other signers, instead of its state file. The last sign
+// state is loaded from the store.
+func (pv *FilePV) SetLastSignStateStore(store LastSignStateStore) error {
+	lss, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("error loading last sign state: %w", err)
+	}
+	pv.stateStore = store
+	pv.setLastSignState(lss)
+	return nil
+}
+
+// lastSignStateStore returns the store of the last sign state, defaulting to
+// LastSignState, which is saved to its file.
+func (pv *FilePV) lastSignStateStore() LastSignStateStore {
+	if pv.stateStore != nil {
+		return pv.stateStore
+	}
+	return &pv.LastSignState
+}
+
+// setLastSignState caches lss in LastSignState, keeping its file path.
+func (pv *FilePV) setLastSignState(lss FilePVLastSignState) {
+	lss.filePath = pv.LastSignState.filePath
+	pv.LastSignState = lss
+}
+
 // GetAddress returns the address of the validator.
 // Implements PrivValidator.
 func (pv *FilePV) GetAddress() types.Address {
@@ -264,6 +331,17 @@ func (pv *FilePV) GetPubKey() (crypto.PubKey, error) {
 	return pv.Key.PubKey, nil
 }
 
+// ProofOfPossession returns the proof of possession of the private key that a
+// BLS12-381 validator must provide to join a validator set, or nil for keys of
+// the other types.
+func (pv *FilePV) ProofOfPossession() ([]byte, error) {
+	privKey, ok := pv.Key.PrivKey.(bls12381.PrivKey)
+	if !ok {
+		return nil, nil
+	}
+	return privKey.ProvePossession()
+}
+
 // SignVote signs a canonical representation of the vote, along with the
 // chainID. Implements PrivValidator.
 func (pv *FilePV) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
@@ -282,17 +360,31 @@ func (pv *FilePV) SignProposal(chainID string, proposal *cmtproto.Proposal) erro
 	return nil
 }
 
-// Save persists the FilePV to disk.
+// Save persists the FilePV to disk. The last sign state is not saved if it
+// is persisted to a LastSignStateStore.
 func (pv *FilePV) Save() {
 	pv.Key.Save()
-	pv.LastSignState.Save()
+	if pv.stateStore == nil {
+		pv.LastSignState.Save()
+	}
 }
 
-// Reset resets all fields in the FilePV.
+// Reset resets all fields in the FilePV. It returns an error if the last sign
+// state store could not be reset, in which case the FilePV is left unchanged.
 // NOTE: Unsafe!
-func (pv *FilePV) Reset() {
+func (pv *FilePV) Reset() error {
+	if pv.stateStore != nil {
+		lss, err := pv.stateStore.Load()
+		if err != nil {
+			return fmt.Errorf("loading the last sign state: %w", err)
+		}
+		if err := pv.stateStore.CompareAndSwap(lss, FilePVLastSignState{Step: stepNone}); err != nil {
+			return fmt.Errorf("resetting the last sign state: %w", err)
+		}
+	}
 	pv.LastSignState.reset()
 	pv.Save()
+	return nil
 }
 
 // String returns a string representation of the FilePV.
@@ -315,7 +407,11 @@ func (pv *FilePV) String() string {
 func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
 	height, round, step := vote.Height, vote.Round, voteToStep(vote)
 
-	lss := pv.LastSignState
+	store := pv.lastSignStateStore()
+	lss, err := store.Load()
+	if err != nil {
+		return err
+	}
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -369,7 +465,9 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote, signExtension bo
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(store, lss, height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	vote.Signature = sig
 
 	return nil
@@ -381,7 +479,11 @@ func (pv *FilePV) signVote(chainID string, vote *cmtproto.Vote, signExtension bo
 func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) error {
 	height, round, step := proposal.Height, proposal.Round, stepPropose
 
-	lss := pv.LastSignState
+	store := pv.lastSignStateStore()
+	lss, err := store.Load()
+	if err != nil {
+		return err
+	}
 
 	sameHRS, err := lss.CheckHRS(height, round, step)
 	if err != nil {
@@ -412,21 +514,32 @@ func (pv *FilePV) signProposal(chainID string, proposal *cmtproto.Proposal) erro
 	if err != nil {
 		return err
 	}
-	pv.saveSigned(height, round, step, signBytes, sig)
+	if err := pv.saveSigned(store, lss, height, round, step, signBytes, sig); err != nil {
+		return err
+	}
 	proposal.Signature = sig
 	return nil
 }
 
-// Persist height/round/step and signature.
-func (pv *FilePV) saveSigned(height int64, round int32, step int8,
-	signBytes []byte, sig []byte,
-) {
-	pv.LastSignState.Height = height
-	pv.LastSignState.Round = round
-	pv.LastSignState.Step = step
-	pv.LastSignState.Signature = sig
-	pv.LastSignState.SignBytes = signBytes
-	pv.LastSignState.Save()
+// Persist height/round/step and signature, if the last sign state is still
+// lss. The signature must not be used if an error is returned.
+func (pv *FilePV) saveSigned(store LastSignStateStore, lss FilePVLastSignState,
+	height int64, round int32, step int8, signBytes []byte, sig []byte,
+) error {
+	next := FilePVLastSignState{
+		Height:    height,
+		Round:     round,
+		Step:      step,
+		Signature: sig,
+		SignBytes: signBytes,
+	}
+	if err := store.CompareAndSwap(lss, next); err != nil {
+		return err
+	}
+	if pv.stateStore != nil {
+		pv.setLastSignState(next)
+	}
+	return nil
 }
 
 //-----------------------------------------------------------------------------------------
@@ -51,7 +51,7 @@ func TestResetValidator(t *testing.T) {
 	assert.NotEqual(t, privVal.LastSignState, emptyState)
 
 	// priv val after AcceptNewConnection is same as empty
-	privVal.Reset()
+	require.NoError(t, privVal.Reset())
 	assert.Equal(t, privVal.LastSignState, emptyState)
 }
 
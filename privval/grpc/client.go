@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto"
+	cryptoenc "github.com/cometbft/cometbft/crypto/encoding"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+)
+
+// DefaultTimeout is the default timeout of the requests sent to a remote
+// signer.
+const DefaultTimeout = 3 * time.Second
+
+// SignerClient implements PrivValidator by sending requests to a gRPC remote
+// signer.
+type SignerClient struct {
+	conn    *grpc.ClientConn
+	client  pvproto.PrivValidatorAPIClient
+	chainID string
+	timeout time.Duration
+}
+
+var _ types.PrivValidator = (*SignerClient)(nil)
+
+// NewSignerClient returns a SignerClient sending requests over conn. Requests
+// time out after the given timeout, waiting for the connection to become
+// ready in the meantime if needed.
+func NewSignerClient(conn *grpc.ClientConn, chainID string, timeout time.Duration) *SignerClient {
+	return &SignerClient{
+		conn:    conn,
+		client:  pvproto.NewPrivValidatorAPIClient(conn),
+		chainID: chainID,
+		timeout: timeout,
+	}
+}
+
+// Close closes the underlying connection.
+func (sc *SignerClient) Close() error {
+	return sc.conn.Close()
+}
+
+// GetPubKey retrieves a public key from the remote signer.
+func (sc *SignerClient) GetPubKey() (crypto.PubKey, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.GetPubKey(ctx, &pvproto.PubKeyRequest{ChainId: sc.chainID}, grpc.WaitForReady(true))
+	if err != nil {
+		return nil, fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return nil, remoteSignerError(resp.Error)
+	}
+
+	return cryptoenc.PubKeyFromProto(resp.PubKey)
+}
+
+// SignVote requests the remote signer to sign a vote.
+func (sc *SignerClient) SignVote(chainID string, vote *cmtproto.Vote, signExtension bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignVote(ctx, &pvproto.SignVoteRequest{
+		Vote:                 vote,
+		ChainId:              chainID,
+		SkipExtensionSigning: !signExtension,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*vote = resp.Vote
+
+	return nil
+}
+
+// SignProposal requests the remote signer to sign a proposal.
+func (sc *SignerClient) SignProposal(chainID string, proposal *cmtproto.Proposal) error {
+	ctx, cancel := context.WithTimeout(context.Background(), sc.timeout)
+	defer cancel()
+
+	resp, err := sc.client.SignProposal(ctx, &pvproto.SignProposalRequest{
+		Proposal: proposal,
+		ChainId:  chainID,
+	}, grpc.WaitForReady(true))
+	if err != nil {
+		return fmt.Errorf("send: %w", err)
+	}
+	if resp.Error != nil {
+		return remoteSignerError(resp.Error)
+	}
+
+	*proposal = resp.Proposal
+
+	return nil
+}
+
+func remoteSignerError(err *pvproto.RemoteSignerError) error {
+	return &privval.RemoteSignerError{Code: int(err.Code), Description: err.Description}
+}
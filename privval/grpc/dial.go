@@ -0,0 +1,45 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// Scheme is the scheme of the priv_validator_laddr of gRPC remote signers.
+const Scheme = "grpc://"
+
+// IsGRPCAddr returns true if addr is the address of a gRPC remote signer.
+func IsGRPCAddr(addr string) bool {
+	return strings.HasPrefix(addr, Scheme)
+}
+
+// insecureProtocol is the security protocol of insecure.NewCredentials.
+const insecureProtocol = "insecure"
+
+// DialRemoteSigner connects to the remote signer at addr, with or without the
+// grpc:// prefix, blocking until the connection is established or ctx is
+// done. The transport credentials are required: use ClientTLSCredentials to
+// authenticate with mutual TLS, and insecure.NewCredentials only to connect
+// without encryption nor authentication, e.g. in tests.
+func DialRemoteSigner(
+	ctx context.Context,
+	addr string,
+	creds credentials.TransportCredentials,
+	opts ...grpc.DialOption,
+) (*grpc.ClientConn, error) {
+	if creds == nil {
+		return nil, errors.New("remote signer transport credentials are required")
+	}
+	opts = append([]grpc.DialOption{grpc.WithTransportCredentials(creds), grpc.WithBlock()}, opts...)
+
+	conn, err := grpc.DialContext(ctx, strings.TrimPrefix(addr, Scheme), opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial remote signer %s: %w", addr, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,26 @@
+/*
+Package grpc provides a gRPC remote signer, serving the PrivValidatorAPI, as
+well as a client implementing types.PrivValidator on top of it.
+
+Unlike with the socket protocol, where the remote signer dials the node, the
+node dials the remote signer, whose address is set with
+priv_validator_laddr = "grpc://host:port". Connections are secured with mutual
+TLS: the node and the remote signer authenticate each other with certificates
+signed by a CA they both trust. Neither the node nor NewServer use plaintext
+connections unless explicitly told to (priv_validator_insecure, or
+insecure.NewCredentials).
+
+# SignerServer
+
+SignerServer serves the requests of the node using the same request handler
+as privval.SignerServer, so a FilePV wrapped in a SignerServer refuses to sign
+votes and proposals that could lead to double signing, based on its last sign
+state.
+
+# SignerClient
+
+SignerClient implements types.PrivValidator by sending requests to a remote
+signer. Requests wait for the connection to be (re-)established until their
+timeout expires.
+*/
+package grpc
@@ -0,0 +1,271 @@
+package grpc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/cometbft/cometbft/crypto/tmhash"
+	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+const chainID = "test-chain"
+
+// startSigner starts a remote signer signing with privVal, and returns its
+// address.
+func startSigner(t *testing.T, privVal types.PrivValidator, creds credentials.TransportCredentials) string {
+	t.Helper()
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server, err := NewServer(NewSignerServer(chainID, privVal, log.TestingLogger()), creds)
+	require.NoError(t, err)
+	go func() {
+		_ = server.Serve(ln)
+	}()
+	t.Cleanup(server.Stop)
+
+	return Scheme + ln.Addr().String()
+}
+
+func newClient(t *testing.T, addr string, creds credentials.TransportCredentials) *SignerClient {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := DialRemoteSigner(ctx, addr, creds)
+	require.NoError(t, err)
+
+	client := NewSignerClient(conn, chainID, DefaultTimeout)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func newFilePV(t *testing.T) *privval.FilePV {
+	t.Helper()
+	dir := t.TempDir()
+	return privval.GenFilePV(filepath.Join(dir, "key.json"), filepath.Join(dir, "state.json"))
+}
+
+func newVote(t *testing.T, privVal types.PrivValidator, round int32) *types.Vote {
+	t.Helper()
+	pubKey, err := privVal.GetPubKey()
+	require.NoError(t, err)
+	hash := cmtrand.Bytes(tmhash.Size)
+	return &types.Vote{
+		Type:             types.PrevoteType,
+		Height:           1,
+		Round:            round,
+		BlockID:          types.BlockID{Hash: hash, PartSetHeader: types.PartSetHeader{Hash: hash, Total: 2}},
+		Timestamp:        cmttime.Now(),
+		ValidatorAddress: pubKey.Address(),
+	}
+}
+
+func TestSignerClient(t *testing.T) {
+	filePV := newFilePV(t)
+	client := newClient(t, startSigner(t, filePV, insecure.NewCredentials()), insecure.NewCredentials())
+
+	pubKey, err := client.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, filePV.Key.PubKey, pubKey)
+
+	vote := newVote(t, filePV, 0)
+	pbVote := vote.ToProto()
+	require.NoError(t, client.SignVote(chainID, pbVote, false))
+	assert.True(t, pubKey.VerifySignature(types.VoteSignBytes(chainID, pbVote), pbVote.Signature))
+
+	proposal := types.NewProposal(1, 1, -1, vote.BlockID, cmttime.Now())
+	pbProposal := proposal.ToProto()
+	require.NoError(t, client.SignProposal(chainID, pbProposal))
+	assert.True(t, pubKey.VerifySignature(types.ProposalSignBytes(chainID, pbProposal), pbProposal.Signature))
+}
+
+func TestSignerClientDoubleSign(t *testing.T) {
+	filePV := newFilePV(t)
+	client := newClient(t, startSigner(t, filePV, insecure.NewCredentials()), insecure.NewCredentials())
+
+	vote := newVote(t, filePV, 0)
+	require.NoError(t, client.SignVote(chainID, vote.ToProto(), false))
+
+	// A vote for another block at the same height, round and step is refused.
+	conflicting := newVote(t, filePV, 0)
+	err := client.SignVote(chainID, conflicting.ToProto(), false)
+	var remoteErr *privval.RemoteSignerError
+	require.ErrorAs(t, err, &remoteErr)
+	assert.Contains(t, remoteErr.Description, "conflicting data")
+
+	// Votes for the next rounds are signed.
+	require.NoError(t, client.SignVote(chainID, newVote(t, filePV, 1).ToProto(), false))
+}
+
+func TestSignerClientWrongChainID(t *testing.T) {
+	filePV := newFilePV(t)
+	client := newClient(t, startSigner(t, filePV, insecure.NewCredentials()), insecure.NewCredentials())
+
+	err := client.SignVote("other-chain", newVote(t, filePV, 0).ToProto(), false)
+	var remoteErr *privval.RemoteSignerError
+	require.ErrorAs(t, err, &remoteErr)
+}
+
+func TestSignerClientTimeout(t *testing.T) {
+	// Nothing listens on the address: the request times out while waiting
+	// for the connection to be established.
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	conn, err := grpc.Dial(addr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	client := NewSignerClient(conn, chainID, 100*time.Millisecond)
+	defer client.Close()
+
+	_, err = client.GetPubKey()
+	require.Error(t, err)
+}
+
+func TestCredentialsRequired(t *testing.T) {
+	_, err := NewServer(NewSignerServer(chainID, newFilePV(t), log.TestingLogger()), nil)
+	require.Error(t, err)
+
+	_, err = DialRemoteSigner(context.Background(), Scheme+"127.0.0.1:0", nil)
+	require.Error(t, err)
+}
+
+func TestSignerMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	serverCert, serverKey := ca.issue(t, dir, "server")
+	clientCert, clientKey := ca.issue(t, dir, "client")
+	otherCA := newTestCA(t, dir, "other-ca")
+	otherCert, otherKey := otherCA.issue(t, dir, "other")
+
+	serverCreds, err := ServerTLSCredentials(serverCert, serverKey, ca.certFile)
+	require.NoError(t, err)
+	filePV := newFilePV(t)
+	addr := startSigner(t, filePV, serverCreds)
+
+	// A client with a certificate signed by the CA is served.
+	clientCreds, err := ClientTLSCredentials(clientCert, clientKey, ca.certFile)
+	require.NoError(t, err)
+	client := newClient(t, addr, clientCreds)
+	pubKey, err := client.GetPubKey()
+	require.NoError(t, err)
+	assert.Equal(t, filePV.Key.PubKey, pubKey)
+
+	health, err := healthpb.NewHealthClient(client.conn).Check(
+		context.Background(), &healthpb.HealthCheckRequest{Service: ServiceName})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, health.Status)
+
+	// Clients with a certificate signed by another CA, or without TLS, are
+	// rejected.
+	for name, creds := range map[string]credentials.TransportCredentials{
+		"other CA": mustClientCreds(t, otherCert, otherKey, ca.certFile),
+		"insecure": insecure.NewCredentials(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+			defer cancel()
+			conn, err := DialRemoteSigner(ctx, addr, creds)
+			if err == nil {
+				client := NewSignerClient(conn, chainID, 500*time.Millisecond)
+				defer client.Close()
+				_, err = client.GetPubKey()
+			}
+			require.Error(t, err)
+		})
+	}
+
+	_, err = ClientTLSCredentials(clientCert, clientKey, filepath.Join(dir, "missing.pem"))
+	require.Error(t, err)
+}
+
+func mustClientCreds(t *testing.T, certFile, keyFile, rootCAFile string) credentials.TransportCredentials {
+	t.Helper()
+	creds, err := ClientTLSCredentials(certFile, keyFile, rootCAFile)
+	require.NoError(t, err)
+	return creds
+}
+
+type testCA struct {
+	cert     *x509.Certificate
+	key      *ecdsa.PrivateKey
+	certFile string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	return &testCA{cert: cert, key: key, certFile: certFile}
+}
+
+// issue creates a certificate for 127.0.0.1 signed by the CA, returning the
+// paths to the certificate and its key.
+func (ca *testCA) issue(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(cmtrand.Int63()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile, keyFile := filepath.Join(dir, name+".pem"), filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	bz := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	require.NoError(t, os.WriteFile(path, bz, 0o600))
+}
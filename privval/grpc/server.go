@@ -0,0 +1,125 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+
+	pvproto "github.com/cometbft/cometbft/api/cometbft/privval/v1"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/privval"
+	"github.com/cometbft/cometbft/types"
+)
+
+// ServiceName is the name of the PrivValidatorAPI gRPC service, as reported
+// by the health checking service.
+const ServiceName = "cometbft.privval.v1.PrivValidatorAPI"
+
+// SignerServer implements the PrivValidatorAPI gRPC service, serving the
+// requests with a privval.ValidationRequestHandlerFunc.
+type SignerServer struct {
+	logger  log.Logger
+	chainID string
+	privVal types.PrivValidator
+
+	handlerMtx               cmtsync.Mutex
+	validationRequestHandler privval.ValidationRequestHandlerFunc
+}
+
+var _ pvproto.PrivValidatorAPIServer = (*SignerServer)(nil)
+
+// NewSignerServer returns a SignerServer signing for the given chain with
+// privVal, using privval.DefaultValidationRequestHandler.
+func NewSignerServer(chainID string, privVal types.PrivValidator, logger log.Logger) *SignerServer {
+	return &SignerServer{
+		logger:                   logger,
+		chainID:                  chainID,
+		privVal:                  privVal,
+		validationRequestHandler: privval.DefaultValidationRequestHandler,
+	}
+}
+
+// SetRequestHandler overrides the default function that is used to service
+// requests.
+func (ss *SignerServer) SetRequestHandler(validationRequestHandler privval.ValidationRequestHandlerFunc) {
+	ss.handlerMtx.Lock()
+	defer ss.handlerMtx.Unlock()
+	ss.validationRequestHandler = validationRequestHandler
+}
+
+// GetPubKey implements PrivValidatorAPIServer.
+func (ss *SignerServer) GetPubKey(_ context.Context, req *pvproto.PubKeyRequest) (*pvproto.PubKeyResponse, error) {
+	res, err := ss.handle(pvproto.Message{Sum: &pvproto.Message_PubKeyRequest{PubKeyRequest: req}})
+	if resp := res.GetPubKeyResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "failed to get pubkey: %v", err)
+}
+
+// SignVote implements PrivValidatorAPIServer.
+func (ss *SignerServer) SignVote(_ context.Context, req *pvproto.SignVoteRequest) (*pvproto.SignedVoteResponse, error) {
+	if req.Vote == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing vote")
+	}
+	res, err := ss.handle(pvproto.Message{Sum: &pvproto.Message_SignVoteRequest{SignVoteRequest: req}})
+	if resp := res.GetSignedVoteResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "failed to sign vote: %v", err)
+}
+
+// SignProposal implements PrivValidatorAPIServer.
+func (ss *SignerServer) SignProposal(_ context.Context, req *pvproto.SignProposalRequest) (*pvproto.SignedProposalResponse, error) {
+	if req.Proposal == nil {
+		return nil, status.Error(codes.InvalidArgument, "missing proposal")
+	}
+	res, err := ss.handle(pvproto.Message{Sum: &pvproto.Message_SignProposalRequest{SignProposalRequest: req}})
+	if resp := res.GetSignedProposalResponse(); resp != nil {
+		return resp, nil
+	}
+	return nil, status.Errorf(codes.Internal, "failed to sign proposal: %v", err)
+}
+
+// handle serves a request with the request handler. Requests are served one
+// at a time, since checking and updating the last sign state of the
+// validator must be atomic.
+func (ss *SignerServer) handle(req pvproto.Message) (pvproto.Message, error) {
+	ss.handlerMtx.Lock()
+	defer ss.handlerMtx.Unlock()
+
+	res, err := ss.validationRequestHandler(ss.privVal, req, ss.chainID)
+	if err != nil {
+		// only log the error; the response contains the error for the client
+		ss.logger.Error("SignerServer: handleMessage", "err", err)
+	}
+	return res, err
+}
+
+// NewServer returns a gRPC server serving the PrivValidatorAPI with ss, as
+// well as the standard health checking service, over the given transport
+// credentials. They are required: use ServerTLSCredentials to only serve
+// clients authenticated with mutual TLS, and insecure.NewCredentials only to
+// serve unauthenticated clients without encryption, e.g. in tests.
+func NewServer(ss *SignerServer, creds credentials.TransportCredentials, opts ...grpc.ServerOption) (*grpc.Server, error) {
+	if creds == nil {
+		return nil, errors.New("remote signer transport credentials are required")
+	}
+	if creds.Info().SecurityProtocol == insecureProtocol {
+		ss.logger.Error("WARNING: the remote signer serves clients without encryption nor authentication")
+	}
+	server := grpc.NewServer(append([]grpc.ServerOption{grpc.Creds(creds)}, opts...)...)
+	pvproto.RegisterPrivValidatorAPIServer(server, ss)
+
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus(ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(server, healthServer)
+
+	return server, nil
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+)
+
+// ClientTLSCredentials returns the credentials used by the node to connect to
+// a remote signer over mutual TLS. The node authenticates with the given
+// certificate and key, and the remote signer's certificate must be signed by
+// the CA whose certificate is in rootCAFile.
+func ClientTLSCredentials(certFile, keyFile, rootCAFile string) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadKeyPairAndCA(certFile, keyFile, rootCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
+
+// ServerTLSCredentials returns the credentials used by a remote signer to
+// serve the node over mutual TLS. The remote signer authenticates with the
+// given certificate and key, and only accepts clients with a certificate
+// signed by the CA whose certificate is in rootCAFile.
+func ServerTLSCredentials(certFile, keyFile, rootCAFile string) (credentials.TransportCredentials, error) {
+	cert, pool, err := loadKeyPairAndCA(certFile, keyFile, rootCAFile)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		ClientCAs:    pool,
+		MinVersion:   tls.VersionTLS13,
+	}), nil
+}
+
+func loadKeyPairAndCA(certFile, keyFile, rootCAFile string) (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to load key pair: %w", err)
+	}
+
+	bz, err := os.ReadFile(rootCAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, fmt.Errorf("failed to read root CA certificate: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(bz) {
+		return tls.Certificate{}, nil, errors.New("failed to parse root CA certificate")
+	}
+
+	return cert, pool, nil
+}
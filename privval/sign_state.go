@@ -0,0 +1,276 @@
+package privval
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	dbm "github.com/cometbft/cometbft-db"
+	cmtsync "github.com/cometbft/cometbft/internal/sync"
+	cmtjson "github.com/cometbft/cometbft/libs/json"
+)
+
+// ErrLastSignStateChanged is returned by LastSignStateStore.CompareAndSwap
+// when the last sign state was changed since it was loaded, for example by
+// another signer sharing the store.
+var ErrLastSignStateChanged = errors.New("last sign state changed concurrently")
+
+// LastSignStateStore persists the last sign state of a validator, which is
+// used to prevent double signing. A FilePV loads the last sign state before
+// checking a vote or proposal against it, and swaps it for the new state
+// before returning the signature. Signers sharing a store therefore never
+// sign conflicting messages for the same height, round and step, as long as
+// the store's CompareAndSwap is atomic for all of them. Replicas running in
+// separate processes or on separate hosts need a store backed by a service
+// with conditional writes, such as SQLLastSignStateStore.
+type LastSignStateStore interface {
+	// Load returns the last sign state.
+	Load() (FilePVLastSignState, error)
+	// CompareAndSwap atomically replaces the last sign state with next, if
+	// it is still equal to prev. Otherwise, it returns
+	// ErrLastSignStateChanged.
+	CompareAndSwap(prev, next FilePVLastSignState) error
+}
+
+var (
+	_ LastSignStateStore = (*FilePVLastSignState)(nil)
+	_ LastSignStateStore = (*DBLastSignStateStore)(nil)
+	_ LastSignStateStore = (*SQLLastSignStateStore)(nil)
+)
+
+// Load implements LastSignStateStore, returning a copy of lss.
+func (lss *FilePVLastSignState) Load() (FilePVLastSignState, error) {
+	return *lss, nil
+}
+
+// CompareAndSwap implements LastSignStateStore, updating lss and saving it to
+// its file. The file is only written by lss, which therefore only protects a
+// single signer.
+func (lss *FilePVLastSignState) CompareAndSwap(prev, next FilePVLastSignState) error {
+	if !lss.equal(prev) {
+		return ErrLastSignStateChanged
+	}
+	lss.Height = next.Height
+	lss.Round = next.Round
+	lss.Step = next.Step
+	lss.Signature = next.Signature
+	lss.SignBytes = next.SignBytes
+	lss.Save()
+	return nil
+}
+
+// equal returns true if both states are for the same height, round and step,
+// with the same signature and sign bytes.
+func (lss *FilePVLastSignState) equal(other FilePVLastSignState) bool {
+	return lss.Height == other.Height &&
+		lss.Round == other.Round &&
+		lss.Step == other.Step &&
+		bytes.Equal(lss.Signature, other.Signature) &&
+		bytes.Equal(lss.SignBytes, other.SignBytes)
+}
+
+//-------------------------------------------------------------------------------
+
+// DBLastSignStateStore is a LastSignStateStore persisting the last sign state
+// under a key of a database. The compare-and-swap is atomic across all the
+// stores created with the same dbm.DB instance in this process, so signers
+// sharing it are protected against signing conflicting messages. It is not
+// atomic across processes opening the same database (which most backends,
+// such as goleveldb, do not allow anyway): use SQLLastSignStateStore instead.
+type DBLastSignStateStore struct {
+	db   dbm.DB
+	key  []byte
+	lock *dbLock
+}
+
+// dbLock is the lock of a database used by DBLastSignStateStores, so that the
+// compare-and-swap is atomic across stores sharing a database instance.
+type dbLock struct {
+	cmtsync.Mutex
+	refs int // number of open stores using the database
+}
+
+// dbLocks contains the lock of each database used by an open
+// DBLastSignStateStore.
+var (
+	dbLocksMtx cmtsync.Mutex
+	dbLocks    = make(map[dbm.DB]*dbLock)
+)
+
+// NewDBLastSignStateStore returns a store persisting the last sign state
+// under the given name, e.g. the validator address, in db. The store must be
+// closed once it is not used anymore.
+func NewDBLastSignStateStore(db dbm.DB, name string) *DBLastSignStateStore {
+	dbLocksMtx.Lock()
+	defer dbLocksMtx.Unlock()
+
+	lock, ok := dbLocks[db]
+	if !ok {
+		lock = &dbLock{}
+		dbLocks[db] = lock
+	}
+	lock.refs++
+	return &DBLastSignStateStore{
+		db:   db,
+		key:  []byte("lastSignState:" + name),
+		lock: lock,
+	}
+}
+
+// Close releases the lock of the database once no other open store uses it.
+// It does not close the database.
+func (s *DBLastSignStateStore) Close() {
+	dbLocksMtx.Lock()
+	defer dbLocksMtx.Unlock()
+
+	if s.lock == nil {
+		return
+	}
+	if s.lock.refs--; s.lock.refs == 0 {
+		delete(dbLocks, s.db)
+	}
+	s.lock = nil
+}
+
+// Load implements LastSignStateStore. It returns an empty state if none was
+// saved yet.
+func (s *DBLastSignStateStore) Load() (FilePVLastSignState, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.load()
+}
+
+// NOTE: requires a mtx lock.
+func (s *DBLastSignStateStore) load() (FilePVLastSignState, error) {
+	lss := FilePVLastSignState{Step: stepNone}
+	bz, err := s.db.Get(s.key)
+	if err != nil {
+		return lss, err
+	}
+	if len(bz) == 0 {
+		return lss, nil
+	}
+	if err := cmtjson.Unmarshal(bz, &lss); err != nil {
+		return lss, fmt.Errorf("error reading last sign state: %w", err)
+	}
+	return lss, nil
+}
+
+// CompareAndSwap implements LastSignStateStore.
+func (s *DBLastSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	current, err := s.load()
+	if err != nil {
+		return err
+	}
+	if !current.equal(prev) {
+		return ErrLastSignStateChanged
+	}
+
+	bz, err := cmtjson.Marshal(next)
+	if err != nil {
+		return err
+	}
+	return s.db.SetSync(s.key, bz)
+}
+
+//-------------------------------------------------------------------------------
+
+// sqlLastSignStateSchema creates the table in which SQLLastSignStateStore
+// persists the last sign states, if it does not exist.
+const sqlLastSignStateSchema = `
+CREATE TABLE IF NOT EXISTS last_sign_states (
+  name       TEXT PRIMARY KEY,
+  height     BIGINT NOT NULL,
+  round      INTEGER NOT NULL,
+  step       SMALLINT NOT NULL,
+  signature  BYTEA NOT NULL,
+  sign_bytes BYTEA NOT NULL
+);
+`
+
+// SQLLastSignStateStore is a LastSignStateStore persisting the last sign
+// state in a row of a PostgreSQL table. The compare-and-swap is a conditional
+// UPDATE of the row, so it is atomic across all the processes and hosts
+// sharing the database.
+type SQLLastSignStateStore struct {
+	db   *sql.DB
+	name string
+}
+
+// NewSQLLastSignStateStore returns a store persisting the last sign state
+// under the given name, e.g. the validator address, in db. It creates the
+// table of the last sign states if needed.
+func NewSQLLastSignStateStore(db *sql.DB, name string) (*SQLLastSignStateStore, error) {
+	if _, err := db.Exec(sqlLastSignStateSchema); err != nil {
+		return nil, fmt.Errorf("error creating last sign states table: %w", err)
+	}
+	// The state is only ever updated, so that the compare-and-swap of the
+	// first signature is a conditional UPDATE too.
+	_, err := db.Exec(`
+INSERT INTO last_sign_states (name, height, round, step, signature, sign_bytes)
+  VALUES ($1, 0, 0, $2, '', '')
+  ON CONFLICT (name) DO NOTHING;
+`, name, stepNone)
+	if err != nil {
+		return nil, fmt.Errorf("error creating last sign state: %w", err)
+	}
+	return &SQLLastSignStateStore{db: db, name: name}, nil
+}
+
+// Load implements LastSignStateStore.
+func (s *SQLLastSignStateStore) Load() (FilePVLastSignState, error) {
+	var (
+		lss                  FilePVLastSignState
+		signature, signBytes []byte
+	)
+	err := s.db.QueryRow(`
+SELECT height, round, step, signature, sign_bytes FROM last_sign_states WHERE name = $1;
+`, s.name).Scan(&lss.Height, &lss.Round, &lss.Step, &signature, &signBytes)
+	if err != nil {
+		return lss, fmt.Errorf("error reading last sign state: %w", err)
+	}
+	if len(signature) > 0 {
+		lss.Signature = signature
+	}
+	if len(signBytes) > 0 {
+		lss.SignBytes = signBytes
+	}
+	return lss, nil
+}
+
+// CompareAndSwap implements LastSignStateStore.
+func (s *SQLLastSignStateStore) CompareAndSwap(prev, next FilePVLastSignState) error {
+	res, err := s.db.Exec(`
+UPDATE last_sign_states
+  SET height = $2, round = $3, step = $4, signature = $5, sign_bytes = $6
+  WHERE name = $1
+    AND height = $7 AND round = $8 AND step = $9 AND signature = $10 AND sign_bytes = $11;
+`, s.name,
+		next.Height, next.Round, next.Step, nonNilBytes(next.Signature), nonNilBytes(next.SignBytes),
+		prev.Height, prev.Round, prev.Step, nonNilBytes(prev.Signature), nonNilBytes(prev.SignBytes))
+	if err != nil {
+		return fmt.Errorf("error writing last sign state: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("error writing last sign state: %w", err)
+	}
+	if n == 0 {
+		return ErrLastSignStateChanged
+	}
+	return nil
+}
+
+// nonNilBytes returns bz, or an empty slice if bz is nil, which the SQL
+// driver would otherwise write as NULL.
+func nonNilBytes(bz []byte) []byte {
+	if bz == nil {
+		return []byte{}
+	}
+	return bz
+}
@@ -129,6 +129,16 @@ func (app *appConnMempool) SetResponseCallback(cb abcicli.Callback) {
 	app.appConn.SetResponseCallback(cb)
 }
 
+var _ abcicli.ReconnectNotifier = (*appConnMempool)(nil)
+
+// SetReconnectCallback implements abcicli.ReconnectNotifier, if the
+// connection reconnects to the application. Otherwise, it does nothing.
+func (app *appConnMempool) SetReconnectCallback(cb abcicli.ReconnectCallback) {
+	if rn, ok := app.appConn.(abcicli.ReconnectNotifier); ok {
+		rn.SetReconnectCallback(cb)
+	}
+}
+
 func (app *appConnMempool) Error() error {
 	return app.appConn.Error()
 }
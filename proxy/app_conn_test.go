@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
 
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	"github.com/cometbft/cometbft/abci/server"
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtrand "github.com/cometbft/cometbft/internal/rand"
+	"github.com/cometbft/cometbft/internal/service"
 	"github.com/cometbft/cometbft/libs/log"
 )
 
@@ -57,6 +61,53 @@ func TestEcho(t *testing.T) {
 	}
 }
 
+func TestQueryReconnect(t *testing.T) {
+	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", cmtrand.Str(6))
+	clientCreator := NewRemoteClientCreator(sockPath, SOCKET, true,
+		WithReconnect(10*time.Millisecond, 100*time.Millisecond),
+		WithQueryConnections(2))
+	startServer := func() service.Service {
+		s := server.NewSocketServer(sockPath, kvstore.NewInMemoryApplication())
+		s.SetLogger(log.TestingLogger().With("module", "abci-server"))
+		require.NoError(t, s.Start())
+		return s
+	}
+
+	s := startServer()
+	cli, err := clientCreator.NewABCIQueryClient()
+	require.NoError(t, err)
+	cli.SetLogger(log.TestingLogger().With("module", "abci-client"))
+	require.NoError(t, cli.Start())
+	t.Cleanup(func() {
+		if err := cli.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	proxy := NewAppConnQuery(cli, NopMetrics())
+
+	_, err = proxy.Echo(context.Background(), "hello")
+	require.NoError(t, err)
+
+	// The application restarts: the queries fail until the client reconnects.
+	require.NoError(t, s.Stop())
+	require.Eventually(t, func() bool {
+		_, err := proxy.Echo(context.Background(), "hello")
+		return err != nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	s = startServer()
+	t.Cleanup(func() {
+		if err := s.Stop(); err != nil {
+			t.Error(err)
+		}
+	})
+	require.Eventually(t, func() bool {
+		_, err := proxy.Echo(context.Background(), "hello")
+		return err == nil && proxy.Error() == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.True(t, cli.IsRunning())
+}
+
 func BenchmarkEcho(b *testing.B) {
 	b.StopTimer() // Initialize
 	sockPath := fmt.Sprintf("unix:///tmp/echo_%v.sock", cmtrand.Str(6))
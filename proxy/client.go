@@ -1,6 +1,8 @@
 package proxy
 
 import (
+	"time"
+
 	abcicli "github.com/cometbft/cometbft/abci/client"
 	"github.com/cometbft/cometbft/abci/example/kvstore"
 	"github.com/cometbft/cometbft/abci/types"
@@ -211,41 +213,82 @@ type remoteClientCreator struct {
 	addr        string
 	transport   string
 	mustConnect bool
+
+	reconnect        bool
+	reconnectBackoff abcicli.ReconnectBackoff
+	queryConns       int
+}
+
+// RemoteClientOption sets an optional parameter on the [ClientCreator]
+// returned by [NewRemoteClientCreator].
+type RemoteClientOption func(*remoteClientCreator)
+
+// WithReconnect makes the mempool, query and snapshot clients reconnect to
+// the application when their connection breaks (e.g. the application
+// restarted), waiting between minBackoff and maxBackoff between two
+// attempts. The consensus client never reconnects.
+func WithReconnect(minBackoff, maxBackoff time.Duration) RemoteClientOption {
+	return func(r *remoteClientCreator) {
+		r.reconnect = true
+		r.reconnectBackoff = abcicli.ReconnectBackoff{Min: minBackoff, Max: maxBackoff}
+	}
+}
+
+// WithQueryConnections sets the number of parallel connections used by the
+// query client. The default is 1.
+func WithQueryConnections(n int) RemoteClientOption {
+	return func(r *remoteClientCreator) { r.queryConns = n }
 }
 
 // NewRemoteClientCreator returns a ClientCreator for the given address (e.g.
 // "192.168.0.1") and transport (e.g. "tcp"). Set mustConnect to true if you
 // want the client to connect before reporting success.
-func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
-	return &remoteClientCreator{
+func NewRemoteClientCreator(addr, transport string, mustConnect bool, opts ...RemoteClientOption) ClientCreator {
+	r := &remoteClientCreator{
 		addr:        addr,
 		transport:   transport,
 		mustConnect: mustConnect,
+		queryConns:  1,
+	}
+	for _, opt := range opts {
+		opt(r)
 	}
+	return r
 }
 
 // NewABCIConsensusClient implements ClientCreator.
 func (r *remoteClientCreator) NewABCIConsensusClient() (abcicli.Client, error) {
-	return r.newABCIClient()
+	return r.newABCIClient(r.mustConnect)
 }
 
 // NewABCIMempoolClient implements ClientCreator.
 func (r *remoteClientCreator) NewABCIMempoolClient() (abcicli.Client, error) {
-	return r.newABCIClient()
+	return r.newReconnectingABCIClient()
 }
 
 // NewABCIQueryClient implements ClientCreator.
 func (r *remoteClientCreator) NewABCIQueryClient() (abcicli.Client, error) {
-	return r.newABCIClient()
+	if r.queryConns <= 1 {
+		return r.newReconnectingABCIClient()
+	}
+	clients := make([]abcicli.Client, 0, r.queryConns)
+	for i := 0; i < r.queryConns; i++ {
+		c, err := r.newReconnectingABCIClient()
+		if err != nil {
+			return nil, err
+		}
+		clients = append(clients, c)
+	}
+	return abcicli.NewPoolClient(clients), nil
 }
 
 // NewABCISnapshotClient implements ClientCreator.
 func (r *remoteClientCreator) NewABCISnapshotClient() (abcicli.Client, error) {
-	return r.newABCIClient()
+	return r.newReconnectingABCIClient()
 }
 
-func (r *remoteClientCreator) newABCIClient() (abcicli.Client, error) {
-	remoteApp, err := abcicli.NewClient(r.addr, r.transport, r.mustConnect)
+func (r *remoteClientCreator) newABCIClient(mustConnect bool) (abcicli.Client, error) {
+	remoteApp, err := abcicli.NewClient(r.addr, r.transport, mustConnect)
 	if err != nil {
 		return nil, ErrUnreachableProxy{Err: err}
 	}
@@ -253,16 +296,32 @@ func (r *remoteClientCreator) newABCIClient() (abcicli.Client, error) {
 	return remoteApp, nil
 }
 
+// newReconnectingABCIClient returns a plain client unless reconnection is
+// enabled.
+func (r *remoteClientCreator) newReconnectingABCIClient() (abcicli.Client, error) {
+	if !r.reconnect {
+		return r.newABCIClient(r.mustConnect)
+	}
+	// Report an invalid transport now rather than on every attempt.
+	if _, err := r.newABCIClient(true); err != nil {
+		return nil, err
+	}
+	// The clients must fail to start if the application cannot be reached
+	// for the reconnecting client to apply its backoff.
+	newClient := func() (abcicli.Client, error) { return r.newABCIClient(true) }
+	return abcicli.NewReconnectingClient(newClient, r.mustConnect, r.reconnectBackoff), nil
+}
+
 // DefaultClientCreator returns a default [ClientCreator], which will create a
 // local client if addr is one of "kvstore", "persistent_kvstore", "e2e",
 // "noop".
 //
-// Otherwise a remote client will be created.
+// Otherwise a remote client will be created, configured by opts.
 //
 // Each of "kvstore", "persistent_kvstore" and "e2e" also currently have an
 // "_connsync" variant (i.e. "kvstore_connsync", etc.), which attempts to
 // replicate the same concurrency model as the remote client.
-func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
+func DefaultClientCreator(addr, transport, dbDir string, opts ...RemoteClientOption) ClientCreator {
 	switch addr {
 	case "kvstore":
 		return NewLocalClientCreator(kvstore.NewInMemoryApplication())
@@ -298,6 +357,6 @@ func DefaultClientCreator(addr, transport, dbDir string) ClientCreator {
 		return NewLocalClientCreator(types.NewBaseApplication())
 	default:
 		mustConnect := false // loop retrying
-		return NewRemoteClientCreator(addr, transport, mustConnect)
+		return NewRemoteClientCreator(addr, transport, mustConnect, opts...)
 	}
 }
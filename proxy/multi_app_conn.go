@@ -38,7 +38,9 @@ func NewAppConns(clientCreator ClientCreator, metrics *Metrics) AppConns {
 //
 // A multiAppConn is made of a few appConns and manages their underlying abci
 // clients.
-// TODO: on app restart, clients must reboot together.
+// Only the mempool, query and snapshot clients can reconnect on app restart
+// (see WithReconnect).
+// TODO: on app restart, the consensus client must reboot with the others.
 type multiAppConn struct {
 	service.BaseService
 
@@ -99,7 +101,8 @@ func (app *multiAppConn) OnStart() error {
 		return err
 	}
 
-	// Kill CometBFT if the ABCI application crashes.
+	// Kill CometBFT if the ABCI application crashes. Reconnecting clients do
+	// not stop with an error, so only the others can trigger this.
 	go app.killTMOnClientError()
 
 	return nil
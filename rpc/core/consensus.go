@@ -1,7 +1,10 @@
 package core
 
 import (
+	"bytes"
 	"fmt"
+	"sort"
+	"time"
 
 	cm "github.com/cometbft/cometbft/internal/consensus"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
@@ -101,6 +104,58 @@ func (env *Environment) GetConsensusState(*rpctypes.Context) (*ctypes.ResultCons
 	return &ctypes.ResultConsensusState{RoundState: bz}, err
 }
 
+// ProposalTimeliness returns, for each proposer, the PBTS verdicts on its
+// latest proposals received by the node, most untimely proposers first. If
+// proposer is set, only the proposals of this proposer are returned.
+// UNSTABLE
+// More: https://docs.cometbft.com/main/rpc/#/Info/proposal_timeliness
+func (env *Environment) ProposalTimeliness(
+	_ *rpctypes.Context,
+	proposer []byte,
+) (*ctypes.ResultProposalTimeliness, error) {
+	byProposer := make(map[string]*ctypes.ProposerTimeliness)
+	sums := make(map[string]time.Duration)
+	for _, t := range env.ConsensusState.GetProposalTimeliness() {
+		if len(proposer) > 0 && !bytes.Equal(proposer, t.Proposer) {
+			continue
+		}
+		key := string(t.Proposer)
+		p, ok := byProposer[key]
+		if !ok {
+			p = &ctypes.ProposerTimeliness{
+				Address:       t.Proposer,
+				MinDifference: t.Difference,
+				MaxDifference: t.Difference,
+			}
+			byProposer[key] = p
+		}
+		p.Proposals++
+		if !t.Timely {
+			p.Untimely++
+		}
+		p.MinDifference = min(p.MinDifference, t.Difference)
+		p.MaxDifference = max(p.MaxDifference, t.Difference)
+		sums[key] += t.Difference
+		p.Recent = append(p.Recent, t)
+		if len(p.Recent) > maxRecentProposalTimeliness {
+			p.Recent = p.Recent[1:]
+		}
+	}
+
+	proposers := make([]ctypes.ProposerTimeliness, 0, len(byProposer))
+	for key, p := range byProposer {
+		p.MeanDifference = sums[key] / time.Duration(p.Proposals)
+		proposers = append(proposers, *p)
+	}
+	sort.Slice(proposers, func(i, j int) bool {
+		if proposers[i].Untimely != proposers[j].Untimely {
+			return proposers[i].Untimely > proposers[j].Untimely
+		}
+		return bytes.Compare(proposers[i].Address, proposers[j].Address) < 0
+	})
+	return &ctypes.ResultProposalTimeliness{Proposers: proposers}, nil
+}
+
 // ConsensusParams gets the consensus parameters at the given block height.
 // If no height is provided, it will fetch the latest consensus params.
 // More: https://docs.cometbft.com/main/rpc/#/Info/consensus_params
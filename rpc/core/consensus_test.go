@@ -0,0 +1,69 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+type timelinessConsensus struct {
+	Consensus
+	timeliness []types.EventDataProposalTimeliness
+}
+
+func (c timelinessConsensus) GetProposalTimeliness() []types.EventDataProposalTimeliness {
+	return c.timeliness
+}
+
+func TestProposalTimeliness(t *testing.T) {
+	skewed, correct := types.Address{0x01}, types.Address{0x02}
+	var timeliness []types.EventDataProposalTimeliness
+	for h := int64(1); h <= 20; h++ {
+		timeliness = append(timeliness, types.EventDataProposalTimeliness{
+			Height:     h,
+			Proposer:   correct,
+			Difference: time.Duration(h) * time.Millisecond,
+			Timely:     true,
+		})
+		if h%2 == 0 {
+			timeliness = append(timeliness, types.EventDataProposalTimeliness{
+				Height:     h,
+				Round:      1,
+				Proposer:   skewed,
+				Difference: -2 * time.Second,
+				Timely:     false,
+			})
+		}
+	}
+	env := &Environment{ConsensusState: timelinessConsensus{timeliness: timeliness}}
+
+	res, err := env.ProposalTimeliness(&rpctypes.Context{}, nil)
+	require.NoError(t, err)
+	require.Len(t, res.Proposers, 2)
+
+	p := res.Proposers[0]
+	assert.Equal(t, skewed, p.Address)
+	assert.Equal(t, 10, p.Proposals)
+	assert.Equal(t, 10, p.Untimely)
+	assert.Equal(t, -2*time.Second, p.MeanDifference)
+	assert.Len(t, p.Recent, maxRecentProposalTimeliness)
+	assert.EqualValues(t, 20, p.Recent[len(p.Recent)-1].Height)
+
+	p = res.Proposers[1]
+	assert.Equal(t, correct, p.Address)
+	assert.Equal(t, 20, p.Proposals)
+	assert.Equal(t, 0, p.Untimely)
+	assert.Equal(t, time.Millisecond, p.MinDifference)
+	assert.Equal(t, 20*time.Millisecond, p.MaxDifference)
+	assert.Equal(t, 10500*time.Microsecond, p.MeanDifference)
+
+	res, err = env.ProposalTimeliness(&rpctypes.Context{}, correct)
+	require.NoError(t, err)
+	require.Len(t, res.Proposers, 1)
+	assert.Equal(t, correct, res.Proposers[0].Address)
+}
@@ -1,6 +1,8 @@
 package core
 
 import (
+	"errors"
+
 	ctypes "github.com/cometbft/cometbft/rpc/core/types"
 	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
 )
@@ -10,3 +12,17 @@ func (env *Environment) UnsafeFlushMempool(*rpctypes.Context) (*ctypes.ResultUns
 	env.Mempool.Flush()
 	return &ctypes.ResultUnsafeFlushMempool{}, nil
 }
+
+// UnsafeReloadConfig reloads the configuration of the node, applies the
+// options which can be changed without restarting the node, and returns the
+// keys of the changed options which need a restart.
+func (env *Environment) UnsafeReloadConfig(*rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if env.ConfigReloader == nil {
+		return nil, errors.New("config reloading is not supported")
+	}
+	applied, restartRequired, err := env.ConfigReloader.ReloadConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &ctypes.ResultReloadConfig{Applied: applied, RestartRequired: restartRequired}, nil
+}
@@ -23,6 +23,9 @@ const (
 	defaultPerPage = 30
 	maxPerPage     = 100
 
+	// number of proposals returned per proposer by ProposalTimeliness.
+	maxRecentProposalTimeliness = 10
+
 	// SubscribeTimeout is the maximum time we wait to subscribe for an event.
 	// must be less than the server's write timeout (see rpcserver.DefaultConfig).
 	SubscribeTimeout = 5 * time.Second
@@ -41,6 +44,7 @@ type Consensus interface {
 	GetLastHeight() int64
 	GetRoundStateJSON() ([]byte, error)
 	GetRoundStateSimpleJSON() ([]byte, error)
+	GetProposalTimeliness() []types.EventDataProposalTimeliness
 }
 
 type transport interface {
@@ -55,6 +59,12 @@ type peers interface {
 	AddPrivatePeerIDs(peerIDs []string) error
 	DialPeersAsync(peers []string) error
 	Peers() p2p.IPeerSet
+	PeerScore(id p2p.ID) int64
+	SetIPFilterRules(allowedCIDRs, deniedCIDRs []string, maxConnsPerSubnet int) ([]p2p.ID, error)
+}
+
+type configReloader interface {
+	ReloadConfig() (applied, restartRequired []string, err error)
 }
 
 // A reactor that transitions from block sync or state sync to consensus mode.
@@ -79,6 +89,7 @@ type Environment struct {
 	MempoolReactor   syncReactor
 	P2PPeers         peers
 	P2PTransport     transport
+	ConfigReloader   configReloader
 
 	// objects
 	PubKey       crypto.PubKey
@@ -87,6 +98,7 @@ type Environment struct {
 	BlockIndexer indexer.BlockIndexer
 	EventBus     *types.EventBus // thread safe
 	Mempool      mempl.Mempool
+	TxTracker    *mempl.TxTracker // nil if the tracking is disabled
 
 	Logger log.Logger
 
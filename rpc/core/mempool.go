@@ -182,6 +182,39 @@ func (env *Environment) NumUnconfirmedTxs(*rpctypes.Context) (*ctypes.ResultUnco
 	}, nil
 }
 
+// TxStatus returns the latest events of the lifecycle of the transaction with
+// the given hash in the mempool, from the oldest: received, checked,
+// rechecked_invalid, removed (with the reason) and included (at a height).
+// More: https://docs.cometbft.com/main/rpc/#/Info/tx_status
+func (env *Environment) TxStatus(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTxStatus, error) {
+	if env.TxTracker == nil {
+		return nil, errors.New("transaction status tracking is disabled")
+	}
+	txKey, err := types.TxKeyFromBytes(hash)
+	if err != nil {
+		return nil, err
+	}
+
+	events := env.TxTracker.Status(txKey)
+	if len(events) == 0 {
+		return nil, fmt.Errorf("tx (%X) not found", hash)
+	}
+	result := &ctypes.ResultTxStatus{
+		Hash:   hash,
+		Events: make([]ctypes.TxStatusEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		result.Events = append(result.Events, ctypes.TxStatusEvent{
+			Type:   string(e.Type),
+			Time:   e.Time,
+			Height: e.Height,
+			Code:   e.Code,
+			Reason: e.Reason,
+		})
+	}
+	return result, nil
+}
+
 // CheckTx checks the transaction without executing it. The transaction won't
 // be added to the mempool either.
 // More: https://docs.cometbft.com/main/rpc/#/Tx/check_tx
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	mempl "github.com/cometbft/cometbft/mempool"
+	rpctypes "github.com/cometbft/cometbft/rpc/jsonrpc/types"
+	"github.com/cometbft/cometbft/types"
+)
+
+func TestTxStatus(t *testing.T) {
+	tx := types.Tx("tx")
+	hash := tx.Hash()
+
+	env := &Environment{}
+	_, err := env.TxStatus(&rpctypes.Context{}, hash)
+	require.ErrorContains(t, err, "disabled")
+
+	env.TxTracker = mempl.NewTxTracker(10)
+	_, err = env.TxStatus(&rpctypes.Context{}, []byte{0x01})
+	require.Error(t, err)
+	_, err = env.TxStatus(&rpctypes.Context{}, hash)
+	require.ErrorContains(t, err, "not found")
+}
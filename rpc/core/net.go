@@ -26,6 +26,7 @@ func (env *Environment) NetInfo(*rpctypes.Context) (*ctypes.ResultNetInfo, error
 			IsOutbound:       peer.IsOutbound(),
 			ConnectionStatus: peer.Status(),
 			RemoteIP:         peer.RemoteIP().String(),
+			Score:            env.P2PPeers.PeerScore(peer.ID()),
 		})
 	})
 	if err != nil {
@@ -98,6 +99,25 @@ func (env *Environment) UnsafeDialPeers(
 	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
 }
 
+// UnsafeSetIPFilter replaces the CIDR ranges peers must and must not belong
+// to, and the maximum number of connections with peers in the same subnet,
+// and stops the peers rejected by the new rules.
+func (env *Environment) UnsafeSetIPFilter(
+	_ *rpctypes.Context,
+	allowedCIDRs, deniedCIDRs []string,
+	maxConnsPerSubnet int,
+) (*ctypes.ResultSetIPFilter, error) {
+	env.Logger.Info("SetIPFilter", "allowed_cidrs", allowedCIDRs,
+		"denied_cidrs", deniedCIDRs, "max_conns_per_subnet", maxConnsPerSubnet)
+
+	stopped, err := env.P2PPeers.SetIPFilterRules(allowedCIDRs, deniedCIDRs, maxConnsPerSubnet)
+	if err != nil {
+		return &ctypes.ResultSetIPFilter{}, err
+	}
+
+	return &ctypes.ResultSetIPFilter{StoppedPeers: stopped}, nil
+}
+
 // Genesis returns genesis file.
 // More: https://docs.cometbft.com/main/rpc/#/Info/genesis
 func (env *Environment) Genesis(*rpctypes.Context) (*ctypes.ResultGenesis, error) {
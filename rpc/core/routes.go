@@ -37,8 +37,10 @@ func (env *Environment) GetRoutes() RoutesMap {
 		"dump_consensus_state": rpc.NewRPCFunc(env.DumpConsensusState, ""),
 		"consensus_state":      rpc.NewRPCFunc(env.GetConsensusState, ""),
 		"consensus_params":     rpc.NewRPCFunc(env.ConsensusParams, "height", rpc.Cacheable("height")),
+		"proposal_timeliness":  rpc.NewRPCFunc(env.ProposalTimeliness, "proposer"),
 		"unconfirmed_txs":      rpc.NewRPCFunc(env.UnconfirmedTxs, "limit"),
 		"num_unconfirmed_txs":  rpc.NewRPCFunc(env.NumUnconfirmedTxs, ""),
+		"tx_status":            rpc.NewRPCFunc(env.TxStatus, "hash"),
 
 		// tx broadcast API
 		"broadcast_tx_commit": rpc.NewRPCFunc(env.BroadcastTxCommit, "tx"),
@@ -59,5 +61,7 @@ func (env *Environment) AddUnsafeRoutes(routes RoutesMap) {
 	// control API
 	routes["dial_seeds"] = rpc.NewRPCFunc(env.UnsafeDialSeeds, "seeds")
 	routes["dial_peers"] = rpc.NewRPCFunc(env.UnsafeDialPeers, "peers,persistent,unconditional,private")
+	routes["unsafe_set_ip_filter"] = rpc.NewRPCFunc(env.UnsafeSetIPFilter, "allowed_cidrs,denied_cidrs,max_conns_per_subnet")
 	routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(env.UnsafeFlushMempool, "")
+	routes["unsafe_reload_config"] = rpc.NewRPCFunc(env.UnsafeReloadConfig, "")
 }
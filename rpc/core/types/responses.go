@@ -129,12 +129,26 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Options of the config applied or needing a restart after reloading it.
+type ResultReloadConfig struct {
+	Applied         []string `json:"applied"`
+	RestartRequired []string `json:"restart_required"`
+}
+
+// Peers stopped after changing the IP filter.
+type ResultSetIPFilter struct {
+	StoppedPeers []p2p.ID `json:"stopped_peers"`
+}
+
 // A peer.
 type Peer struct {
 	NodeInfo         p2p.DefaultNodeInfo  `json:"node_info"`
 	IsOutbound       bool                 `json:"is_outbound"`
 	ConnectionStatus p2p.ConnectionStatus `json:"connection_status"`
 	RemoteIP         string               `json:"remote_ip"`
+	// Score reflects how useful (positive) or abusive (negative) the peer
+	// has recently been.
+	Score int64 `json:"score"`
 }
 
 // Validators for a height.
@@ -171,6 +185,28 @@ type ResultConsensusState struct {
 	RoundState json.RawMessage `json:"round_state"`
 }
 
+// Timeliness of the proposals of each proposer, from the latest proposals
+// received by the node.
+// UNSTABLE.
+type ResultProposalTimeliness struct {
+	Proposers []ProposerTimeliness `json:"proposers"`
+}
+
+// Timeliness of the proposals of a proposer. The differences are the
+// receive times minus the timestamps of the proposals: a large negative or
+// positive mean difference usually means the clock of the proposer is
+// ahead of, or behind, the clock of the node.
+type ProposerTimeliness struct {
+	Address        types.Address `json:"address"`
+	Proposals      int           `json:"proposals"`
+	Untimely       int           `json:"untimely"`
+	MinDifference  time.Duration `json:"min_difference"`
+	MaxDifference  time.Duration `json:"max_difference"`
+	MeanDifference time.Duration `json:"mean_difference"`
+	// Latest proposals, from the oldest to the newest.
+	Recent []types.EventDataProposalTimeliness `json:"recent"`
+}
+
 // CheckTx result.
 type ResultBroadcastTx struct {
 	Code      uint32         `json:"code"`
@@ -224,6 +260,22 @@ type ResultUnconfirmedTxs struct {
 	Txs        []types.Tx `json:"txs"`
 }
 
+// Lifecycle of a tx in the mempool.
+type ResultTxStatus struct {
+	Hash   bytes.HexBytes  `json:"hash"`
+	Events []TxStatusEvent `json:"events"`
+}
+
+// Event of the lifecycle of a tx in the mempool: received, checked,
+// rechecked_invalid, removed or included.
+type TxStatusEvent struct {
+	Type   string    `json:"type"`
+	Time   time.Time `json:"time"`
+	Height int64     `json:"height"`
+	Code   uint32    `json:"code"`
+	Reason string    `json:"reason,omitempty"`
+}
+
 // Info abci msg.
 type ResultABCIInfo struct {
 	Response abci.InfoResponse `json:"response"`
@@ -23,6 +23,8 @@ type Client interface {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	StreamServiceClient
+	TxStatusServiceClient
 
 	// Close the connection to the server. Any subsequent requests will fail.
 	Close() error
@@ -35,6 +37,8 @@ type clientBuilder struct {
 	versionServiceEnabled      bool
 	blockServiceEnabled        bool
 	blockResultsServiceEnabled bool
+	streamServiceEnabled       bool
+	txStatusServiceEnabled     bool
 }
 
 func newClientBuilder() *clientBuilder {
@@ -44,6 +48,8 @@ func newClientBuilder() *clientBuilder {
 		versionServiceEnabled:      true,
 		blockServiceEnabled:        true,
 		blockResultsServiceEnabled: true,
+		streamServiceEnabled:       true,
+		txStatusServiceEnabled:     true,
 	}
 }
 
@@ -57,6 +63,8 @@ type client struct {
 	VersionServiceClient
 	BlockServiceClient
 	BlockResultsServiceClient
+	StreamServiceClient
+	TxStatusServiceClient
 }
 
 // Close implements Client.
@@ -95,6 +103,28 @@ func WithBlockServiceEnabled(enabled bool) Option {
 	}
 }
 
+// WithStreamServiceEnabled allows control of whether or not to create a
+// client for interacting with the stream service of a CometBFT node.
+//
+// If disabled and the client attempts to access the stream service API, the
+// client will panic.
+func WithStreamServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.streamServiceEnabled = enabled
+	}
+}
+
+// WithTxStatusServiceEnabled allows control of whether or not to create a
+// client for interacting with the tx status service of a CometBFT node.
+//
+// If disabled and the client attempts to access the tx status service API,
+// the client will panic.
+func WithTxStatusServiceEnabled(enabled bool) Option {
+	return func(b *clientBuilder) {
+		b.txStatusServiceEnabled = enabled
+	}
+}
+
 // WithGRPCDialOption allows passing lower-level gRPC dial options through to
 // the gRPC dialer when creating the client.
 func WithGRPCDialOption(opt ggrpc.DialOption) Option {
@@ -133,10 +163,20 @@ func New(ctx context.Context, addr string, opts ...Option) (Client, error) {
 	if builder.blockResultsServiceEnabled {
 		blockResultServiceClient = newBlockResultsServiceClient(conn)
 	}
+	streamServiceClient := newDisabledStreamServiceClient()
+	if builder.streamServiceEnabled {
+		streamServiceClient = newStreamServiceClient(conn)
+	}
+	txStatusServiceClient := newDisabledTxStatusServiceClient()
+	if builder.txStatusServiceEnabled {
+		txStatusServiceClient = newTxStatusServiceClient(conn)
+	}
 	return &client{
 		conn:                      conn,
 		VersionServiceClient:      versionServiceClient,
 		BlockServiceClient:        blockServiceClient,
 		BlockResultsServiceClient: blockResultServiceClient,
+		StreamServiceClient:       streamServiceClient,
+		TxStatusServiceClient:     txStatusServiceClient,
 	}, nil
 }
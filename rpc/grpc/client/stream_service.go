@@ -0,0 +1,190 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	streamsvc "github.com/cometbft/cometbft/api/cometbft/services/stream/v1"
+	"github.com/cometbft/cometbft/types"
+)
+
+// StreamResult contains the data of a single height streamed by the
+// StreamService, or the error that terminated the stream. The data that was
+// not requested is left empty.
+type StreamResult struct {
+	Height int64
+	// Cursor can be passed to StreamFromCursor to resume streaming after this
+	// height.
+	Cursor []byte
+
+	BlockID *types.BlockID
+	Block   *types.Block
+
+	Results *abci.FinalizeBlockResponse
+
+	BlockEvents []*abci.Event
+	TxEvents    []*TxEvents
+
+	Error error
+}
+
+// TxEvents contains the events emitted by a transaction.
+type TxEvents struct {
+	Index  uint32
+	Hash   []byte
+	Events []*abci.Event
+}
+
+type streamConfig struct {
+	req    streamsvc.StreamRequest
+	chSize uint
+}
+
+type StreamOption func(*streamConfig)
+
+// StreamFromHeight starts streaming from the given height. If neither this
+// option nor StreamFromCursor are used, streaming starts from the next
+// committed height.
+func StreamFromHeight(height int64) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.req.StartHeight = height
+	}
+}
+
+// StreamFromCursor resumes streaming after the height at which the given
+// cursor was returned.
+func StreamFromCursor(cursor []byte) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.req.Cursor = cursor
+	}
+}
+
+// StreamBlocks includes the block of each height in the results.
+func StreamBlocks() StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.req.IncludeBlock = true
+	}
+}
+
+// StreamBlockResults includes the FinalizeBlock response of each height in
+// the results.
+func StreamBlockResults() StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.req.IncludeResults = true
+	}
+}
+
+// StreamEvents includes the events emitted at each height in the results.
+func StreamEvents() StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.req.IncludeEvents = true
+	}
+}
+
+// StreamChannelSize allows control over the channel size. If not used or the
+// channel size is set to 0, an unbuffered channel will be created.
+func StreamChannelSize(sz uint) StreamOption {
+	return func(cfg *streamConfig) {
+		cfg.chSize = sz
+	}
+}
+
+// StreamServiceClient streams the blocks, block results and events of each
+// height.
+type StreamServiceClient interface {
+	// Stream sends the requested data of each height, in increasing order of
+	// heights and without gaps, to the resulting output channel. At least one
+	// of StreamBlocks, StreamBlockResults or StreamEvents must be given.
+	//
+	// The channel is closed after a result with a non-nil Error is sent, or
+	// when the context is canceled.
+	Stream(ctx context.Context, opts ...StreamOption) (<-chan StreamResult, error)
+}
+
+type streamServiceClient struct {
+	client streamsvc.StreamServiceClient
+}
+
+func newStreamServiceClient(conn grpc.ClientConn) StreamServiceClient {
+	return &streamServiceClient{
+		client: streamsvc.NewStreamServiceClient(conn),
+	}
+}
+
+// Stream implements StreamServiceClient Stream.
+func (c *streamServiceClient) Stream(ctx context.Context, opts ...StreamOption) (<-chan StreamResult, error) {
+	cfg := &streamConfig{}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	streamClient, err := c.client.Stream(ctx, &cfg.req)
+	if err != nil {
+		return nil, fmt.Errorf("error getting a stream: %w", err)
+	}
+	resultCh := make(chan StreamResult, cfg.chSize)
+
+	go func(client streamsvc.StreamService_StreamClient) {
+		defer close(resultCh)
+		for {
+			res := streamResultFromProto(client.Recv())
+			// Unlike the latest height, results cannot be skipped without
+			// creating gaps, so wait for the channel to open up.
+			select {
+			case <-ctx.Done():
+				return
+			case resultCh <- res:
+			}
+			if res.Error != nil {
+				return
+			}
+		}
+	}(streamClient)
+
+	return resultCh, nil
+}
+
+func streamResultFromProto(res *streamsvc.StreamResponse, err error) StreamResult {
+	if err != nil {
+		return StreamResult{Error: fmt.Errorf("error receiving from a stream: %w", err)}
+	}
+	result := StreamResult{
+		Height:      res.Height,
+		Cursor:      res.Cursor,
+		Results:     res.Results,
+		BlockEvents: res.BlockEvents,
+	}
+	if res.Block != nil {
+		block, err := blockFromProto(res.BlockId, res.Block)
+		if err != nil {
+			return StreamResult{Error: fmt.Errorf("invalid block at height %d: %w", res.Height, err)}
+		}
+		result.BlockID = block.BlockID
+		result.Block = block.Block
+	}
+	if len(res.TxEvents) > 0 {
+		result.TxEvents = make([]*TxEvents, len(res.TxEvents))
+		for i, txEvents := range res.TxEvents {
+			result.TxEvents[i] = &TxEvents{
+				Index:  txEvents.Index,
+				Hash:   txEvents.Hash,
+				Events: txEvents.Events,
+			}
+		}
+	}
+	return result
+}
+
+type disabledStreamServiceClient struct{}
+
+func newDisabledStreamServiceClient() StreamServiceClient {
+	return &disabledStreamServiceClient{}
+}
+
+// Stream implements StreamServiceClient Stream - disabled client.
+func (*disabledStreamServiceClient) Stream(context.Context, ...StreamOption) (<-chan StreamResult, error) {
+	panic("stream service client is disabled")
+}
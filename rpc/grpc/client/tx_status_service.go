@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"time"
+
+	"github.com/cosmos/gogoproto/grpc"
+
+	pbsvc "github.com/cometbft/cometbft/api/cometbft/services/tx_status/v1"
+)
+
+// TxStatusEvent is an event of the lifecycle of a transaction in the mempool
+// of a CometBFT node.
+type TxStatusEvent struct {
+	Type   string    // received, checked, rechecked_invalid, removed or included
+	Time   time.Time // When the event occurred
+	Height int64     // Height of the mempool, or of the block including the tx
+	Code   uint32    // Code returned by CheckTx, or of the execution of the tx
+	Reason string    // Reason of the removal, or why a checked tx was rejected
+}
+
+// TxStatusServiceClient provides the lifecycle of the transactions submitted
+// to the mempool of a CometBFT node.
+type TxStatusServiceClient interface {
+	// GetTxStatus returns the latest events of the lifecycle of the
+	// transaction with the given hash, from the oldest.
+	GetTxStatus(ctx context.Context, hash []byte) ([]TxStatusEvent, error)
+}
+
+type txStatusServiceClient struct {
+	client pbsvc.TxStatusServiceClient
+}
+
+func newTxStatusServiceClient(conn grpc.ClientConn) TxStatusServiceClient {
+	return &txStatusServiceClient{
+		client: pbsvc.NewTxStatusServiceClient(conn),
+	}
+}
+
+// GetTxStatus implements TxStatusServiceClient.
+func (c *txStatusServiceClient) GetTxStatus(ctx context.Context, hash []byte) ([]TxStatusEvent, error) {
+	res, err := c.client.GetTxStatus(ctx, &pbsvc.GetTxStatusRequest{Hash: hash})
+	if err != nil {
+		return nil, err
+	}
+	events := make([]TxStatusEvent, 0, len(res.Events))
+	for _, e := range res.Events {
+		events = append(events, TxStatusEvent{
+			Type:   e.Type,
+			Time:   e.Time,
+			Height: e.Height,
+			Code:   e.Code,
+			Reason: e.Reason,
+		})
+	}
+	return events, nil
+}
+
+type disabledTxStatusServiceClient struct{}
+
+func newDisabledTxStatusServiceClient() TxStatusServiceClient {
+	return &disabledTxStatusServiceClient{}
+}
+
+// GetTxStatus implements TxStatusServiceClient.
+func (*disabledTxStatusServiceClient) GetTxStatus(context.Context, []byte) ([]TxStatusEvent, error) {
+	panic("tx status service client is disabled")
+}
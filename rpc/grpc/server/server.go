@@ -9,12 +9,17 @@ import (
 
 	pbblocksvc "github.com/cometbft/cometbft/api/cometbft/services/block/v1"
 	brs "github.com/cometbft/cometbft/api/cometbft/services/block_results/v1"
+	pbstreamsvc "github.com/cometbft/cometbft/api/cometbft/services/stream/v1"
+	pbtxstatussvc "github.com/cometbft/cometbft/api/cometbft/services/tx_status/v1"
 	pbversionsvc "github.com/cometbft/cometbft/api/cometbft/services/version/v1"
 	sm "github.com/cometbft/cometbft/internal/state"
 	"github.com/cometbft/cometbft/internal/store"
 	"github.com/cometbft/cometbft/libs/log"
+	mempl "github.com/cometbft/cometbft/mempool"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/blockresultservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/blockservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/streamservice"
+	"github.com/cometbft/cometbft/rpc/grpc/server/services/txstatusservice"
 	"github.com/cometbft/cometbft/rpc/grpc/server/services/versionservice"
 	"github.com/cometbft/cometbft/types"
 )
@@ -28,6 +33,8 @@ type serverBuilder struct {
 	versionService      pbversionsvc.VersionServiceServer
 	blockService        pbblocksvc.BlockServiceServer
 	blockResultsService brs.BlockResultsServiceServer
+	streamService       pbstreamsvc.StreamServiceServer
+	txStatusService     pbtxstatussvc.TxStatusServiceServer
 	logger              log.Logger
 	grpcOpts            []grpc.ServerOption
 }
@@ -76,6 +83,21 @@ func WithBlockResultsService(bs *store.BlockStore, ss sm.Store, logger log.Logge
 	}
 }
 
+// WithStreamService enables the stream service on the CometBFT server.
+func WithStreamService(bs *store.BlockStore, ss sm.Store, eventBus *types.EventBus, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.streamService = streamservice.New(bs, ss, eventBus, logger)
+	}
+}
+
+// WithTxStatusService enables the tx status service on the CometBFT server. A
+// nil txTracker means that the tracking of the mempool txs is disabled.
+func WithTxStatusService(txTracker *mempl.TxTracker, logger log.Logger) Option {
+	return func(b *serverBuilder) {
+		b.txStatusService = txstatusservice.New(txTracker, logger)
+	}
+}
+
 // WithLogger enables logging using the given logger. If not specified, the
 // gRPC server does not log anything.
 func WithLogger(logger log.Logger) Option {
@@ -115,6 +137,14 @@ func Serve(listener net.Listener, opts ...Option) error {
 		brs.RegisterBlockResultsServiceServer(server, b.blockResultsService)
 		b.logger.Debug("Registered block results service")
 	}
+	if b.streamService != nil {
+		pbstreamsvc.RegisterStreamServiceServer(server, b.streamService)
+		b.logger.Debug("Registered stream service")
+	}
+	if b.txStatusService != nil {
+		pbtxstatussvc.RegisterTxStatusServiceServer(server, b.txStatusService)
+		b.logger.Debug("Registered tx status service")
+	}
 	b.logger.Info("serve", "msg", fmt.Sprintf("Starting gRPC server on %s", listener.Addr()))
 	return server.Serve(b.listener)
 }
@@ -0,0 +1,314 @@
+package streamservice
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	streamsvc "github.com/cometbft/cometbft/api/cometbft/services/stream/v1"
+	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
+	"github.com/cometbft/cometbft/internal/rpctrace"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+)
+
+const (
+	// cursorVersion is the first byte of the cursors, to allow changing their
+	// format in the future.
+	cursorVersion byte = 1
+	cursorSize         = 9
+
+	// subscriptionCapacity is the number of new block events that can be
+	// buffered. New block events are only used as notifications that new
+	// heights are available, so losing some is harmless.
+	subscriptionCapacity = 100
+)
+
+var newBlockQuery = types.QueryForEvent(types.EventNewBlock)
+
+type streamService struct {
+	blockStore *store.BlockStore
+	stateStore sm.Store
+	eventBus   *types.EventBus
+	logger     log.Logger
+}
+
+// New creates a new CometBFT stream service server.
+func New(bs *store.BlockStore, ss sm.Store, eventBus *types.EventBus, logger log.Logger) streamsvc.StreamServiceServer {
+	return &streamService{
+		blockStore: bs,
+		stateStore: ss,
+		eventBus:   eventBus,
+		logger:     logger.With("service", "StreamService"),
+	}
+}
+
+// Stream implements v1.StreamServiceServer Stream method.
+func (s *streamService) Stream(req *streamsvc.StreamRequest, stream streamsvc.StreamService_StreamServer) error {
+	logger := s.logger.With("endpoint", "Stream")
+	if !req.IncludeBlock && !req.IncludeResults && !req.IncludeEvents {
+		return status.Error(codes.InvalidArgument, "At least one of include_block, include_results or include_events must be set")
+	}
+
+	traceID, err := rpctrace.New()
+	if err != nil {
+		logger.Error("Error generating RPC trace ID", "err", err)
+		return status.Error(codes.Internal, "Internal server error")
+	}
+	logger = logger.With("traceID", traceID)
+
+	// Subscribe before looking up the latest height, so that no new height is
+	// missed. The trace ID is reused as a unique subscriber ID.
+	ctx := stream.Context()
+	sub, err := s.eventBus.Subscribe(ctx, traceID, newBlockQuery, subscriptionCapacity)
+	if err != nil {
+		logger.Error("Cannot subscribe to new block events", "err", err)
+		return status.Errorf(codes.Internal, "Cannot subscribe to new block events (see logs for trace ID: %s)", traceID)
+	}
+	defer func() {
+		if err := s.eventBus.Unsubscribe(context.Background(), traceID, newBlockQuery); err != nil &&
+			!errors.Is(err, cmtpubsub.ErrSubscriptionNotFound) {
+			logger.Error("Failed to unsubscribe from new block events", "err", err)
+		}
+	}()
+
+	latest, err := s.latestHeight()
+	if err != nil {
+		logger.Error("Error loading latest height", "err", err)
+		return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+	}
+	next, err := startHeight(req, latest)
+	if err != nil {
+		return err
+	}
+	if err := s.checkRetained(req, next); err != nil {
+		return err
+	}
+
+	for {
+		for ; next <= latest; next++ {
+			res, err := s.response(req, next, logger)
+			if err != nil {
+				return err
+			}
+			if err := stream.Send(res); err != nil {
+				logger.Error("Failed to stream response", "err", err, "height", next)
+				return status.Errorf(codes.Unavailable, "Cannot send stream response (see logs for trace ID: %s)", traceID)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case msg := <-sub.Out():
+			if data, ok := msg.Data().(types.EventDataNewBlock); ok && data.Block.Height > latest {
+				latest = data.Block.Height
+			}
+		case <-sub.Canceled():
+			if !errors.Is(sub.Err(), cmtpubsub.ErrOutOfCapacity) {
+				logger.Info("Subscription canceled", "err", sub.Err())
+				return status.Error(codes.Canceled, "Subscription canceled")
+			}
+			// We were too slow to consume the notifications, which is fine
+			// as the heights are read from the stores: resubscribe, and catch
+			// up with the latest height.
+			if sub, err = s.eventBus.Subscribe(ctx, traceID, newBlockQuery, subscriptionCapacity); err != nil {
+				logger.Error("Cannot resubscribe to new block events", "err", err)
+				return status.Errorf(codes.Internal, "Cannot subscribe to new block events (see logs for trace ID: %s)", traceID)
+			}
+			if latest, err = s.latestHeight(); err != nil {
+				logger.Error("Error loading latest height", "err", err)
+				return status.Errorf(codes.Internal, "Internal server error (see logs for trace ID: %s)", traceID)
+			}
+		}
+	}
+}
+
+// latestHeight returns the latest height whose block and FinalizeBlock
+// response have both been saved.
+func (s *streamService) latestHeight() (int64, error) {
+	state, err := s.stateStore.Load()
+	if err != nil {
+		return 0, err
+	}
+	return state.LastBlockHeight, nil
+}
+
+// startHeight returns the first height to stream, given the request and the
+// latest committed height.
+func startHeight(req *streamsvc.StreamRequest, latest int64) (int64, error) {
+	switch {
+	case len(req.Cursor) > 0:
+		if req.StartHeight != 0 {
+			return 0, status.Error(codes.InvalidArgument, "Cannot set both a start height and a cursor")
+		}
+		height, err := decodeCursor(req.Cursor)
+		if err != nil {
+			return 0, status.Errorf(codes.InvalidArgument, "Invalid cursor: %v", err)
+		}
+		return height + 1, nil
+	case req.StartHeight < 0:
+		return 0, status.Error(codes.InvalidArgument, "Start height cannot be negative")
+	case req.StartHeight == 0:
+		return latest + 1, nil
+	default:
+		return req.StartHeight, nil
+	}
+}
+
+// checkRetained returns an error if the requested data at the given height
+// has been, or is about to be, pruned according to the retain heights set on
+// the pruning service.
+func (s *streamService) checkRetained(req *streamsvc.StreamRequest, height int64) error {
+	if req.IncludeBlock || req.IncludeEvents {
+		if base := s.blockStore.Base(); height < base {
+			return status.Errorf(codes.OutOfRange, "Requested height %d is below base height %d", height, base)
+		}
+		retainHeight, err := s.blockRetainHeight()
+		if err != nil {
+			s.logger.Error("Error loading block retain height", "err", err)
+			return status.Error(codes.Internal, "Internal server error")
+		}
+		if height < retainHeight {
+			return status.Errorf(codes.OutOfRange, "Requested height %d is below block retain height %d", height, retainHeight)
+		}
+	}
+	if req.IncludeResults || req.IncludeEvents {
+		retainHeight, err := s.stateStore.GetABCIResRetainHeight()
+		if err != nil && !errors.Is(err, sm.ErrKeyNotFound) {
+			s.logger.Error("Error loading block results retain height", "err", err)
+			return status.Error(codes.Internal, "Internal server error")
+		}
+		if height < retainHeight {
+			return status.Errorf(codes.OutOfRange, "Requested height %d is below block results retain height %d", height, retainHeight)
+		}
+	}
+	return nil
+}
+
+// blockRetainHeight returns the height below which the pruning service prunes
+// blocks: the application retain height, or the data companion block retain
+// height if it is set and lower. It returns 0 if the application retain height
+// is not set, in which case blocks are not pruned.
+func (s *streamService) blockRetainHeight() (int64, error) {
+	appRetainHeight, err := s.stateStore.GetApplicationRetainHeight()
+	if errors.Is(err, sm.ErrKeyNotFound) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	dcRetainHeight, err := s.stateStore.GetCompanionBlockRetainHeight()
+	if errors.Is(err, sm.ErrKeyNotFound) {
+		return appRetainHeight, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return min(appRetainHeight, dcRetainHeight), nil
+}
+
+// response builds the response for the given height. Since pruning may have
+// progressed since the stream started, data missing below the latest height
+// is reported as being out of range.
+func (s *streamService) response(req *streamsvc.StreamRequest, height int64, logger log.Logger) (*streamsvc.StreamResponse, error) {
+	if err := s.checkRetained(req, height); err != nil {
+		return nil, err
+	}
+	res := &streamsvc.StreamResponse{
+		Height: height,
+		Cursor: encodeCursor(height),
+	}
+
+	var block *types.Block
+	if req.IncludeBlock || req.IncludeEvents {
+		var blockMeta *types.BlockMeta
+		block, blockMeta = s.blockStore.LoadBlock(height)
+		if block == nil || blockMeta == nil {
+			return nil, status.Errorf(codes.OutOfRange, "Block not found for height %d, it may have been pruned", height)
+		}
+		if req.IncludeBlock {
+			bp, err := block.ToProto()
+			if err != nil {
+				logger.Error("Error attempting to convert block to its Protobuf representation", "err", err, "height", height)
+				return nil, status.Error(codes.Internal, "Internal server error")
+			}
+			blockID := blockMeta.BlockID.ToProto()
+			res.BlockId = &blockID
+			res.Block = bp
+		}
+	}
+
+	if req.IncludeResults || req.IncludeEvents {
+		results, err := s.stateStore.LoadFinalizeBlockResponse(height)
+		switch {
+		case errors.Is(err, sm.ErrFinalizeBlockResponsesNotPersisted):
+			return nil, status.Error(codes.FailedPrecondition, "Block results are not persisted by the node")
+		case errors.As(err, &sm.ErrNoABCIResponsesForHeight{}):
+			return nil, status.Errorf(codes.OutOfRange, "Block results not found for height %d, they may have been pruned", height)
+		case err != nil:
+			logger.Error("Error fetching block results", "err", err, "height", height)
+			return nil, status.Error(codes.Internal, "Internal server error")
+		}
+		if req.IncludeResults {
+			res.Results = results
+		}
+		if req.IncludeEvents {
+			txEvents, err := txEventsFromResults(block, results)
+			if err != nil {
+				logger.Error("Inconsistent block results", "err", err, "height", height)
+				return nil, status.Error(codes.Internal, "Internal server error")
+			}
+			res.BlockEvents = formatProtoToRef(results.Events)
+			res.TxEvents = txEvents
+		}
+	}
+
+	return res, nil
+}
+
+func txEventsFromResults(block *types.Block, results *abci.FinalizeBlockResponse) ([]*streamsvc.TxEvents, error) {
+	if len(block.Txs) != len(results.TxResults) {
+		return nil, fmt.Errorf("block has %d txs, but there are %d tx results", len(block.Txs), len(results.TxResults))
+	}
+	txEvents := make([]*streamsvc.TxEvents, len(block.Txs))
+	for i, tx := range block.Txs {
+		txEvents[i] = &streamsvc.TxEvents{
+			Index:  uint32(i),
+			Hash:   tx.Hash(),
+			Events: formatProtoToRef(results.TxResults[i].Events),
+		}
+	}
+	return txEvents, nil
+}
+
+func encodeCursor(height int64) []byte {
+	cursor := make([]byte, cursorSize)
+	cursor[0] = cursorVersion
+	binary.BigEndian.PutUint64(cursor[1:], uint64(height))
+	return cursor
+}
+
+func decodeCursor(cursor []byte) (int64, error) {
+	if len(cursor) != cursorSize || cursor[0] != cursorVersion {
+		return 0, errors.New("unknown cursor format")
+	}
+	height := int64(binary.BigEndian.Uint64(cursor[1:]))
+	if height <= 0 {
+		return 0, fmt.Errorf("invalid height %d", height)
+	}
+	return height, nil
+}
+
+func formatProtoToRef[T any](collection []T) []*T {
+	res := make([]*T, len(collection))
+	for i := range collection {
+		res[i] = &collection[i]
+	}
+	return res
+}
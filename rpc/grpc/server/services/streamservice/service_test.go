@@ -0,0 +1,280 @@
+package streamservice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbm "github.com/cometbft/cometbft-db"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	abci "github.com/cometbft/cometbft/abci/types"
+	streamsvc "github.com/cometbft/cometbft/api/cometbft/services/stream/v1"
+	sm "github.com/cometbft/cometbft/internal/state"
+	"github.com/cometbft/cometbft/internal/state/mocks"
+	"github.com/cometbft/cometbft/internal/store"
+	"github.com/cometbft/cometbft/libs/log"
+	"github.com/cometbft/cometbft/types"
+	cmttime "github.com/cometbft/cometbft/types/time"
+)
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx  context.Context
+	sent chan *streamsvc.StreamResponse
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *testServerStream) Send(res *streamsvc.StreamResponse) error {
+	s.sent <- res
+	return nil
+}
+
+type testChain struct {
+	t          *testing.T
+	state      sm.State
+	blockStore *store.BlockStore
+	stateStore *mocks.Store
+	eventBus   *types.EventBus
+	lastID     types.BlockID
+}
+
+func newTestChain(t *testing.T) *testChain {
+	t.Helper()
+	valSet, _ := types.RandValidatorSet(1, 10)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		ChainID:     "test-chain",
+		GenesisTime: cmttime.Now(),
+		Validators: []types.GenesisValidator{{
+			PubKey: valSet.Validators[0].PubKey,
+			Power:  10,
+		}},
+		ConsensusParams: types.DefaultConsensusParams(),
+	})
+	require.NoError(t, err)
+
+	eventBus := types.NewEventBus()
+	require.NoError(t, eventBus.Start())
+	t.Cleanup(func() { _ = eventBus.Stop() })
+
+	stateStore := &mocks.Store{}
+	stateStore.On("GetABCIResRetainHeight").Return(int64(0), sm.ErrKeyNotFound)
+	stateStore.On("GetApplicationRetainHeight").Return(int64(0), sm.ErrKeyNotFound)
+	stateStore.On("GetCompanionBlockRetainHeight").Return(int64(0), sm.ErrKeyNotFound)
+
+	return &testChain{
+		t:          t,
+		state:      state,
+		blockStore: store.NewBlockStore(dbm.NewMemDB()),
+		stateStore: stateStore,
+		eventBus:   eventBus,
+	}
+}
+
+// commit adds a block to the chain, and publishes it if publish is true.
+func (c *testChain) commit(publish bool) *types.Block {
+	c.t.Helper()
+	height := c.blockStore.Height() + 1
+	lastCommit := &types.Commit{}
+	if height > 1 {
+		lastCommit = &types.Commit{
+			Height:     height - 1,
+			BlockID:    c.lastID,
+			Signatures: []types.CommitSig{types.NewCommitSigAbsent()},
+		}
+	}
+	txs := []types.Tx{types.Tx("tx1"), types.Tx("tx2")}
+	block := c.state.MakeBlock(height, txs, lastCommit, nil, c.state.Validators.Proposer.Address)
+	partSet, err := block.MakePartSet(types.BlockPartSizeBytes)
+	require.NoError(c.t, err)
+	c.lastID = types.BlockID{Hash: block.Hash(), PartSetHeader: partSet.Header()}
+	c.blockStore.SaveBlock(block, partSet, &types.Commit{Height: height, BlockID: c.lastID})
+
+	results := &abci.FinalizeBlockResponse{
+		Events: []abci.Event{{Type: "block", Attributes: []abci.EventAttribute{{Key: "height", Value: "x"}}}},
+		TxResults: []*abci.ExecTxResult{
+			{Events: []abci.Event{{Type: "tx", Attributes: []abci.EventAttribute{{Key: "index", Value: "0"}}}}},
+			{Events: []abci.Event{{Type: "tx", Attributes: []abci.EventAttribute{{Key: "index", Value: "1"}}}}},
+		},
+	}
+	c.stateStore.On("LoadFinalizeBlockResponse", height).Return(results, nil)
+
+	if publish {
+		require.NoError(c.t, c.eventBus.PublishEventNewBlock(types.EventDataNewBlock{
+			Block:               block,
+			BlockID:             c.lastID,
+			ResultFinalizeBlock: *results,
+		}))
+	}
+	return block
+}
+
+// stream starts streaming with the given request, after setting the latest
+// height of the state store.
+func (c *testChain) stream(ctx context.Context, req *streamsvc.StreamRequest) (<-chan *streamsvc.StreamResponse, <-chan error) {
+	c.t.Helper()
+	state := c.state
+	state.LastBlockHeight = c.blockStore.Height()
+	c.stateStore.On("Load").Return(state, nil).Once()
+
+	svc := New(c.blockStore, c.stateStore, c.eventBus, log.TestingLogger())
+	stream := &testServerStream{ctx: ctx, sent: make(chan *streamsvc.StreamResponse, 10)}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- svc.Stream(req, stream)
+	}()
+	return stream.sent, errCh
+}
+
+func receive(t *testing.T, ch <-chan *streamsvc.StreamResponse) *streamsvc.StreamResponse {
+	t.Helper()
+	select {
+	case res := <-ch:
+		return res
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timed out waiting for a stream response")
+		return nil
+	}
+}
+
+func TestStreamBackfillAndFollow(t *testing.T) {
+	chain := newTestChain(t)
+	for i := 0; i < 3; i++ {
+		chain.commit(false)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent, errCh := chain.stream(ctx, &streamsvc.StreamRequest{
+		StartHeight:   2,
+		IncludeBlock:  true,
+		IncludeEvents: true,
+	})
+
+	// Backfill from the block store.
+	for _, height := range []int64{2, 3} {
+		res := receive(t, sent)
+		require.Equal(t, height, res.Height)
+		require.Equal(t, height, res.Block.Header.Height)
+		require.NotNil(t, res.BlockId)
+		require.Nil(t, res.Results)
+		require.Len(t, res.BlockEvents, 1)
+		require.Len(t, res.TxEvents, 2)
+		require.Equal(t, []byte(types.Tx("tx2").Hash()), res.TxEvents[1].Hash)
+		require.Equal(t, "1", res.TxEvents[1].Events[0].Attributes[0].Value)
+	}
+
+	// Follow new heights as they are committed.
+	block := chain.commit(true)
+	res := receive(t, sent)
+	require.Equal(t, block.Height, res.Height)
+	require.Equal(t, []byte(block.Hash()), res.BlockId.Hash)
+
+	cancel()
+	select {
+	case err := <-errCh:
+		require.Equal(t, codes.Canceled, status.Code(err))
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "stream did not terminate")
+	}
+}
+
+func TestStreamResumeFromCursor(t *testing.T) {
+	chain := newTestChain(t)
+	for i := 0; i < 3; i++ {
+		chain.commit(false)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent, _ := chain.stream(ctx, &streamsvc.StreamRequest{StartHeight: 1, IncludeResults: true})
+	res := receive(t, sent)
+	require.EqualValues(t, 1, res.Height)
+	require.Nil(t, res.Block)
+	require.Len(t, res.Results.TxResults, 2)
+	cancel()
+
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+	sent, _ = chain.stream(ctx, &streamsvc.StreamRequest{Cursor: res.Cursor, IncludeResults: true})
+	res = receive(t, sent)
+	require.EqualValues(t, 2, res.Height)
+}
+
+func TestStreamInvalidRequests(t *testing.T) {
+	chain := newTestChain(t)
+	chain.commit(false)
+
+	testCases := map[string]*streamsvc.StreamRequest{
+		"nothing to include":      {StartHeight: 1},
+		"negative start height":   {StartHeight: -1, IncludeBlock: true},
+		"start height and cursor": {StartHeight: 1, Cursor: encodeCursor(1), IncludeBlock: true},
+		"invalid cursor":          {Cursor: []byte("cursor"), IncludeBlock: true},
+	}
+	for name, req := range testCases {
+		t.Run(name, func(t *testing.T) {
+			chain.stateStore.On("Load").Return(chain.state, nil).Maybe()
+			svc := New(chain.blockStore, chain.stateStore, chain.eventBus, log.TestingLogger())
+			stream := &testServerStream{ctx: context.Background(), sent: make(chan *streamsvc.StreamResponse, 1)}
+			err := svc.Stream(req, stream)
+			require.Equal(t, codes.InvalidArgument, status.Code(err), err)
+		})
+	}
+}
+
+func TestStreamPrunedResults(t *testing.T) {
+	chain := newTestChain(t)
+	chain.commit(false)
+	chain.commit(false)
+
+	stateStore := &mocks.Store{}
+	stateStore.On("GetABCIResRetainHeight").Return(int64(2), nil)
+	stateStore.On("GetApplicationRetainHeight").Return(int64(0), sm.ErrKeyNotFound)
+	stateStore.On("GetCompanionBlockRetainHeight").Return(int64(0), sm.ErrKeyNotFound)
+	stateStore.On("Load").Return(chain.state, nil).Once()
+	chain.stateStore = stateStore
+
+	svc := New(chain.blockStore, stateStore, chain.eventBus, log.TestingLogger())
+	stream := &testServerStream{ctx: context.Background(), sent: make(chan *streamsvc.StreamResponse, 1)}
+	err := svc.Stream(&streamsvc.StreamRequest{StartHeight: 1, IncludeResults: true}, stream)
+	require.Equal(t, codes.OutOfRange, status.Code(err), err)
+
+	// Blocks are still available.
+	stateStore.On("LoadFinalizeBlockResponse", mock.Anything).Return(nil, sm.ErrNoABCIResponsesForHeight{Height: 1})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent, _ := chain.stream(ctx, &streamsvc.StreamRequest{StartHeight: 1, IncludeBlock: true})
+	require.EqualValues(t, 1, receive(t, sent).Height)
+}
+
+func TestStreamPrunedBlocks(t *testing.T) {
+	chain := newTestChain(t)
+	for i := 0; i < 3; i++ {
+		chain.commit(false)
+	}
+
+	// The blocks are pruned below the lowest of the application and data
+	// companion retain heights.
+	stateStore := &mocks.Store{}
+	stateStore.On("GetApplicationRetainHeight").Return(int64(3), nil)
+	stateStore.On("GetCompanionBlockRetainHeight").Return(int64(2), nil)
+	stateStore.On("Load").Return(chain.state, nil).Once()
+
+	svc := New(chain.blockStore, stateStore, chain.eventBus, log.TestingLogger())
+	stream := &testServerStream{ctx: context.Background(), sent: make(chan *streamsvc.StreamResponse, 1)}
+	err := svc.Stream(&streamsvc.StreamRequest{StartHeight: 1, IncludeBlock: true}, stream)
+	require.Equal(t, codes.OutOfRange, status.Code(err), err)
+
+	chain.stateStore = stateStore
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	sent, _ := chain.stream(ctx, &streamsvc.StreamRequest{StartHeight: 2, IncludeBlock: true})
+	require.EqualValues(t, 2, receive(t, sent).Height)
+}
@@ -0,0 +1,57 @@
+package txstatusservice
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbsvc "github.com/cometbft/cometbft/api/cometbft/services/tx_status/v1"
+	"github.com/cometbft/cometbft/libs/log"
+	mempl "github.com/cometbft/cometbft/mempool"
+	"github.com/cometbft/cometbft/types"
+)
+
+type txStatusService struct {
+	txTracker *mempl.TxTracker
+	logger    log.Logger
+}
+
+// New creates a new CometBFT tx status service server. A nil txTracker means
+// that the tracking is disabled.
+func New(txTracker *mempl.TxTracker, logger log.Logger) pbsvc.TxStatusServiceServer {
+	return &txStatusService{
+		txTracker: txTracker,
+		logger:    logger.With("service", "TxStatusService"),
+	}
+}
+
+// GetTxStatus implements v1.TxStatusServiceServer.
+func (s *txStatusService) GetTxStatus(_ context.Context, req *pbsvc.GetTxStatusRequest) (*pbsvc.GetTxStatusResponse, error) {
+	if s.txTracker == nil {
+		return nil, status.Error(codes.FailedPrecondition, "Transaction status tracking is disabled")
+	}
+	txKey, err := types.TxKeyFromBytes(req.Hash)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	events := s.txTracker.Status(txKey)
+	if len(events) == 0 {
+		return nil, status.Errorf(codes.NotFound, "Transaction %X not found", req.Hash)
+	}
+	res := &pbsvc.GetTxStatusResponse{
+		Hash:   req.Hash,
+		Events: make([]*pbsvc.TxStatusEvent, 0, len(events)),
+	}
+	for _, e := range events {
+		res.Events = append(res.Events, &pbsvc.TxStatusEvent{
+			Type:   string(e.Type),
+			Time:   e.Time,
+			Height: e.Height,
+			Code:   e.Code,
+			Reason: e.Reason,
+		})
+	}
+	return res, nil
+}
@@ -7,10 +7,14 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	grpcstatus "google.golang.org/grpc/status"
 
 	coretypes "github.com/cometbft/cometbft/rpc/core/types"
+	grpcclient "github.com/cometbft/cometbft/rpc/grpc/client"
 	"github.com/cometbft/cometbft/rpc/grpc/client/privileged"
 	e2e "github.com/cometbft/cometbft/test/e2e/pkg"
+	"github.com/cometbft/cometbft/types"
 	"github.com/cometbft/cometbft/version"
 )
 
@@ -34,6 +38,20 @@ func TestGRPC_Version(t *testing.T) {
 	})
 }
 
+func TestGRPC_TxStatus_Unknown(t *testing.T) {
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+		client, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer client.Close()
+
+		_, err = client.GetTxStatus(ctx, types.Tx("unknown").Hash())
+		require.Equal(t, codes.NotFound, grpcstatus.Code(err))
+	})
+}
+
 func TestGRPC_Block_GetByHeight(t *testing.T) {
 	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
 		t.Helper()
@@ -160,6 +178,66 @@ func TestGRPC_GetBlockResults(t *testing.T) {
 	})
 }
 
+func TestGRPC_Stream(t *testing.T) {
+	t.Helper()
+	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
+		t.Helper()
+		client, err := node.Client()
+		require.NoError(t, err)
+		status, err := client.Status(ctx)
+		require.NoError(t, err)
+
+		// Start a few heights below the latest one, to test both backfilling
+		// from the stores and following new heights.
+		start := status.SyncInfo.LatestBlockHeight - 2
+		if start < status.SyncInfo.EarliestBlockHeight+int64(node.RetainBlocks) {
+			start = status.SyncInfo.LatestBlockHeight
+		}
+
+		ctx, ctxCancel := context.WithTimeout(context.Background(), time.Minute)
+		defer ctxCancel()
+		gRPCClient, err := node.GRPCClient(ctx)
+		require.NoError(t, err)
+		defer gRPCClient.Close()
+
+		resultCh, err := gRPCClient.Stream(ctx,
+			grpcclient.StreamFromHeight(start),
+			grpcclient.StreamBlocks(),
+			grpcclient.StreamEvents(),
+		)
+		require.NoError(t, err)
+
+		var cursor []byte
+		for height := start; height <= start+4; height++ {
+			select {
+			case <-ctx.Done():
+				require.Fail(t, "did not expect context to be canceled")
+			case result := <-resultCh:
+				require.NoError(t, result.Error)
+				require.Equal(t, height, result.Height)
+				require.Equal(t, height, result.Block.Height)
+				require.Len(t, result.TxEvents, len(result.Block.Txs))
+				cursor = result.Cursor
+			}
+		}
+
+		// Resume from the last cursor.
+		resultCh, err = gRPCClient.Stream(ctx,
+			grpcclient.StreamFromCursor(cursor),
+			grpcclient.StreamBlockResults(),
+		)
+		require.NoError(t, err)
+		select {
+		case <-ctx.Done():
+			require.Fail(t, "did not expect context to be canceled")
+		case result := <-resultCh:
+			require.NoError(t, result.Error)
+			require.Equal(t, start+5, result.Height)
+			require.NotNil(t, result.Results)
+		}
+	})
+}
+
 func TestGRPC_BlockRetainHeight(t *testing.T) {
 	t.Helper()
 	testFullNodesOrValidators(t, 0, func(t *testing.T, node e2e.Node) {
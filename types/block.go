@@ -4,21 +4,25 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/cosmos/gogoproto/proto"
 	gogotypes "github.com/cosmos/gogoproto/types"
 
+	abci "github.com/cometbft/cometbft/abci/types"
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/bits"
 	cmtsync "github.com/cometbft/cometbft/internal/sync"
 	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
+	cmterrors "github.com/cometbft/cometbft/types/errors"
 	cmttime "github.com/cometbft/cometbft/types/time"
 	"github.com/cometbft/cometbft/version"
 )
@@ -277,10 +281,18 @@ func BlockFromProto(bp *cmtproto.Block) (*Block, error) {
 //
 // XXX: Panics on negative result.
 func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
+	return MaxDataBytesForKeyTypes(maxBytes, evidenceBytes, valsCount, nil)
+}
+
+// MaxDataBytesForKeyTypes is MaxDataBytes for validators whose keys have one
+// of the given types (see MaxCommitBytesForKeyTypes).
+//
+// XXX: Panics on negative result.
+func MaxDataBytesForKeyTypes(maxBytes, evidenceBytes int64, valsCount int, keyTypes []string) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount) -
+		MaxCommitBytesForKeyTypes(valsCount, keyTypes) -
 		evidenceBytes
 
 	if maxDataBytes < 0 {
@@ -299,10 +311,18 @@ func MaxDataBytes(maxBytes, evidenceBytes int64, valsCount int) int64 {
 //
 // XXX: Panics on negative result.
 func MaxDataBytesNoEvidence(maxBytes int64, valsCount int) int64 {
+	return MaxDataBytesNoEvidenceForKeyTypes(maxBytes, valsCount, nil)
+}
+
+// MaxDataBytesNoEvidenceForKeyTypes is MaxDataBytesNoEvidence for validators
+// whose keys have one of the given types (see MaxCommitBytesForKeyTypes).
+//
+// XXX: Panics on negative result.
+func MaxDataBytesNoEvidenceForKeyTypes(maxBytes int64, valsCount int, keyTypes []string) int64 {
 	maxDataBytes := maxBytes -
 		MaxOverheadForBlock -
 		MaxHeaderBytes -
-		MaxCommitBytes(valsCount)
+		MaxCommitBytesForKeyTypes(valsCount, keyTypes)
 
 	if maxDataBytes < 0 {
 		panic(fmt.Sprintf(
@@ -590,6 +610,9 @@ const (
 	// Commit sig size is made up of 64 bytes for the signature, 20 bytes for the address,
 	// 1 byte for the flag and 14 bytes for the timestamp.
 	MaxCommitSigBytes int64 = 109
+	// MaxCommitSigBytesBls12381 is MaxCommitSigBytes with a 96-byte BLS12-381
+	// signature.
+	MaxCommitSigBytesBls12381 int64 = 141
 )
 
 // CommitSig is a part of the Vote included in a Commit.
@@ -606,6 +629,19 @@ func MaxCommitBytes(valCount int) int64 {
 	return MaxCommitOverheadBytes + ((MaxCommitSigBytes + protoEncodingOverhead) * int64(valCount))
 }
 
+// MaxCommitBytesForKeyTypes returns the maximum size of a commit of valCount
+// validators whose keys have one of the given types (see
+// ValidatorParams.PubKeyTypes). It is MaxCommitBytes, unless BLS12-381 keys,
+// whose signatures are larger, are allowed.
+func MaxCommitBytesForKeyTypes(valCount int, keyTypes []string) int64 {
+	if !slices.Contains(keyTypes, ABCIPubKeyTypeBls12381) {
+		return MaxCommitBytes(valCount)
+	}
+	// The size of the larger commit sigs takes 2 bytes to encode.
+	var protoEncodingOverhead int64 = 3
+	return MaxCommitOverheadBytes + ((MaxCommitSigBytesBls12381 + protoEncodingOverhead) * int64(valCount))
+}
+
 // NewCommitSigAbsent returns new CommitSig with BlockIDFlagAbsent. Other
 // fields are all empty.
 func NewCommitSigAbsent() CommitSig {
@@ -647,6 +683,12 @@ func (cs CommitSig) BlockID(commitBlockID BlockID) BlockID {
 
 // ValidateBasic performs basic validation.
 func (cs CommitSig) ValidateBasic() error {
+	return cs.validateBasic(false)
+}
+
+// validateBasic performs basic validation. If aggregated is true, the
+// CommitSig is part of an aggregated commit, and must not have a signature.
+func (cs CommitSig) validateBasic(aggregated bool) error {
 	switch cs.BlockIDFlag {
 	case BlockIDFlagAbsent:
 	case BlockIDFlagCommit:
@@ -674,6 +716,12 @@ func (cs CommitSig) ValidateBasic() error {
 			)
 		}
 		// NOTE: Timestamp validation is subtle and handled elsewhere.
+		if aggregated {
+			if len(cs.Signature) != 0 {
+				return errors.New("signature is present in aggregated commit")
+			}
+			break
+		}
 		if len(cs.Signature) == 0 {
 			return errors.New("signature is missing")
 		}
@@ -702,12 +750,17 @@ func (cs *CommitSig) ToProto() *cmtproto.CommitSig {
 // FromProto sets a protobuf CommitSig to the given pointer.
 // It returns an error if the CommitSig is invalid.
 func (cs *CommitSig) FromProto(csp cmtproto.CommitSig) error {
+	cs.fromProto(csp)
+	return cs.ValidateBasic()
+}
+
+// fromProto sets a protobuf CommitSig to the given pointer, without
+// validating it.
+func (cs *CommitSig) fromProto(csp cmtproto.CommitSig) {
 	cs.BlockIDFlag = BlockIDFlag(csp.BlockIdFlag)
 	cs.ValidatorAddress = csp.ValidatorAddress
 	cs.Timestamp = csp.Timestamp
 	cs.Signature = csp.Signature
-
-	return cs.ValidateBasic()
 }
 
 //-------------------------------------
@@ -844,6 +897,11 @@ type Commit struct {
 	BlockID    BlockID     `json:"block_id"`
 	Signatures []CommitSig `json:"signatures"`
 
+	// AggregatedSignature, if set, is the aggregation of the signatures of
+	// all the non-absent CommitSigs, whose own signatures are then empty.
+	// See Aggregate.
+	AggregatedSignature []byte `json:"aggregated_signature,omitempty"`
+
 	// Memoized in first call to corresponding method.
 	// NOTE: can't memoize in constructor because constructor isn't used for
 	// unmarshaling.
@@ -853,7 +911,7 @@ type Commit struct {
 // GetVote converts the CommitSig for the given valIdx to a Vote. Commits do
 // not contain vote extensions, so the vote extension and vote extension
 // signature will not be present in the returned vote.
-// Returns nil if the precommit at valIdx is nil.
+// If the commit is aggregated, the signature will not be present either.
 // Panics if valIdx >= commit.Size().
 func (commit *Commit) GetVote(valIdx int32) *Vote {
 	commitSig := commit.Signatures[valIdx]
@@ -910,14 +968,66 @@ func (commit *Commit) ValidateBasic() error {
 			return errors.New("no signatures in commit")
 		}
 		for i, commitSig := range commit.Signatures {
-			if err := commitSig.ValidateBasic(); err != nil {
+			if err := commitSig.validateBasic(commit.IsAggregated()); err != nil {
 				return fmt.Errorf("wrong CommitSig #%d: %w", i, err)
 			}
 		}
 	}
+	if commit.IsAggregated() && len(commit.AggregatedSignature) != bls12381.SignatureLength {
+		return fmt.Errorf("expected AggregatedSignature size to be %d bytes, got %d bytes",
+			bls12381.SignatureLength,
+			len(commit.AggregatedSignature),
+		)
+	}
 	return nil
 }
 
+// IsAggregated returns true if the signatures of the commit are aggregated
+// into a single AggregatedSignature.
+func (commit *Commit) IsAggregated() bool {
+	return len(commit.AggregatedSignature) != 0
+}
+
+// Aggregate returns a copy of the commit whose signatures are aggregated into
+// a single AggregatedSignature. vals must be the validator set that signed the
+// commit, and all the validators that signed it must have BLS12-381 keys.
+// The signatures are not verified.
+func (commit *Commit) Aggregate(vals *ValidatorSet) (*Commit, error) {
+	if commit.IsAggregated() {
+		return nil, errors.New("commit is already aggregated")
+	}
+	if vals.Size() != len(commit.Signatures) {
+		return nil, cmterrors.NewErrInvalidCommitSignatures(vals.Size(), len(commit.Signatures))
+	}
+
+	aggregated := &Commit{
+		Height:     commit.Height,
+		Round:      commit.Round,
+		BlockID:    commit.BlockID,
+		Signatures: make([]CommitSig, len(commit.Signatures)),
+	}
+	sigs := make([][]byte, 0, len(commit.Signatures))
+	for i, commitSig := range commit.Signatures {
+		aggregated.Signatures[i] = commitSig
+		if commitSig.BlockIDFlag == BlockIDFlagAbsent {
+			continue
+		}
+		if _, ok := vals.Validators[i].PubKey.(bls12381.PubKey); !ok {
+			return nil, fmt.Errorf("validator %v at index %d does not have a %s key",
+				vals.Validators[i], i, bls12381.KeyType)
+		}
+		sigs = append(sigs, commitSig.Signature)
+		aggregated.Signatures[i].Signature = nil
+	}
+
+	aggSig, err := bls12381.AggregateSignatures(sigs)
+	if err != nil {
+		return nil, fmt.Errorf("aggregating signatures: %w", err)
+	}
+	aggregated.AggregatedSignature = aggSig
+	return aggregated, nil
+}
+
 // MedianTime computes the median time for a Commit based on the associated validator set.
 // The median time is the weighted median of the Timestamp fields of the commit votes,
 // with heights defined by the validator's voting powers.
@@ -960,6 +1070,9 @@ func (commit *Commit) Hash() cmtbytes.HexBytes {
 
 			bs[i] = bz
 		}
+		if commit.IsAggregated() {
+			bs = append(bs, commit.AggregatedSignature)
+		}
 		commit.hash = merkle.HashFromByteSlices(bs)
 	}
 	return commit.hash
@@ -1000,12 +1113,14 @@ func (commit *Commit) StringIndented(indent string) string {
 %s  BlockID:    %v
 %s  Signatures:
 %s    %v
+%s  AggregatedSignature: %X
 %s}#%v`,
 		indent, commit.Height,
 		indent, commit.Round,
 		indent, commit.BlockID,
 		indent,
 		indent, strings.Join(commitSigStrings, "\n"+indent+"    "),
+		indent, cmtbytes.Fingerprint(commit.AggregatedSignature),
 		indent, commit.hash)
 }
 
@@ -1025,6 +1140,7 @@ func (commit *Commit) ToProto() *cmtproto.Commit {
 	c.Height = commit.Height
 	c.Round = commit.Round
 	c.BlockID = commit.BlockID.ToProto()
+	c.AggregatedSignature = commit.AggregatedSignature
 
 	return c
 }
@@ -1043,17 +1159,18 @@ func CommitFromProto(cp *cmtproto.Commit) (*Commit, error) {
 		return nil, err
 	}
 
+	// The signatures are validated by commit.ValidateBasic, as their validity
+	// depends on whether the commit is aggregated.
 	sigs := make([]CommitSig, len(cp.Signatures))
 	for i := range cp.Signatures {
-		if err := sigs[i].FromProto(cp.Signatures[i]); err != nil {
-			return nil, err
-		}
+		sigs[i].fromProto(cp.Signatures[i])
 	}
 	commit.Signatures = sigs
 
 	commit.Height = cp.Height
 	commit.Round = cp.Round
 	commit.BlockID = *bi
+	commit.AggregatedSignature = cp.AggregatedSignature
 
 	return commit, commit.ValidateBasic()
 }
@@ -1108,9 +1225,13 @@ func (ec *ExtendedCommit) addSigsToVoteSet(voteSet *VoteSet) {
 }
 
 // ToVoteSet constructs a VoteSet from the Commit and validator set.
-// Panics if signatures from the commit can't be added to the voteset.
+// Panics if signatures from the commit can't be added to the voteset, which
+// is always the case for aggregated commits.
 // Inverse of VoteSet.MakeCommit().
 func (commit *Commit) ToVoteSet(chainID string, vals *ValidatorSet) *VoteSet {
+	if commit.IsAggregated() {
+		panic("cannot reconstruct vote set from aggregated commit")
+	}
 	voteSet := NewVoteSet(chainID, commit.Height, commit.Round, PrecommitType, vals)
 	for idx, cs := range commit.Signatures {
 		if cs.BlockIDFlag == BlockIDFlagAbsent {
@@ -1139,6 +1260,46 @@ func (ec *ExtendedCommit) EnsureExtensions(extEnabled bool) error {
 	return nil
 }
 
+// ExtendedCommitInfo aggregates the vote extensions of the ExtendedCommit,
+// and their signatures, with the validators that signed them, into the
+// ExtendedCommitInfo provided to the next proposer in PrepareProposal. vals
+// must be the validator set at the height of the commit. If extensionsEnabled
+// is true, every non-absent vote must have an extension signature, otherwise
+// none of them may have extension data.
+func (ec *ExtendedCommit) ExtendedCommitInfo(vals *ValidatorSet, extensionsEnabled bool) (abci.ExtendedCommitInfo, error) {
+	if ec.Size() != vals.Size() {
+		return abci.ExtendedCommitInfo{}, fmt.Errorf("extended commit size (%d) does not match validator set length (%d) at height %d",
+			ec.Size(), vals.Size(), ec.Height)
+	}
+
+	votes := make([]abci.ExtendedVoteInfo, ec.Size())
+	for i, val := range vals.Validators {
+		ecs := ec.ExtendedSignatures[i]
+
+		// Absent signatures have empty validator addresses, but otherwise we
+		// expect the validator addresses to be the same.
+		if ecs.BlockIDFlag != BlockIDFlagAbsent && !bytes.Equal(ecs.ValidatorAddress, val.Address) {
+			return abci.ExtendedCommitInfo{}, fmt.Errorf("validator address of extended commit signature in position %d (%s) does not match the corresponding validator's at height %d (%s)",
+				i, ecs.ValidatorAddress, ec.Height, val.Address)
+		}
+		if err := ecs.EnsureExtension(extensionsEnabled); err != nil {
+			return abci.ExtendedCommitInfo{}, fmt.Errorf("commit at height %d has problems with vote extension data; err %w", ec.Height, err)
+		}
+
+		votes[i] = abci.ExtendedVoteInfo{
+			Validator:          TM2PB.Validator(val),
+			BlockIdFlag:        cmtproto.BlockIDFlag(ecs.BlockIDFlag),
+			VoteExtension:      ecs.Extension,
+			ExtensionSignature: ecs.ExtensionSignature,
+		}
+	}
+
+	return abci.ExtendedCommitInfo{
+		Round: ec.Round,
+		Votes: votes,
+	}, nil
+}
+
 // ToCommit converts an ExtendedCommit to a Commit by removing all vote
 // extension-related fields.
 func (ec *ExtendedCommit) ToCommit() *Commit {
@@ -15,6 +15,8 @@ import (
 
 	cmtversion "github.com/cometbft/cometbft/api/cometbft/version/v1"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
+	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/merkle"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	"github.com/cometbft/cometbft/internal/bits"
@@ -277,7 +279,7 @@ func TestMaxCommitBytes(t *testing.T) {
 		BlockIDFlag:      BlockIDFlagNil,
 		ValidatorAddress: crypto.AddressHash([]byte("validator_address")),
 		Timestamp:        timestamp,
-		Signature:        crypto.CRandBytes(MaxSignatureSize),
+		Signature:        crypto.CRandBytes(ed25519.SignatureSize),
 	}
 
 	pbSig := cs.ToProto()
@@ -310,6 +312,22 @@ func TestMaxCommitBytes(t *testing.T) {
 	pb = commit.ToProto()
 
 	assert.EqualValues(t, MaxCommitBytes(MaxVotesCount), int64(pb.Size()))
+
+	// the same with BLS12-381 signatures
+	keyTypes := []string{ABCIPubKeyTypeBls12381}
+	cs.Signature = crypto.CRandBytes(bls12381.SignatureLength)
+	pbSig = cs.ToProto()
+	assert.EqualValues(t, MaxCommitSigBytesBls12381, pbSig.Size())
+
+	commit.Signatures = []CommitSig{cs}
+	pb = commit.ToProto()
+	assert.EqualValues(t, MaxCommitBytesForKeyTypes(1, keyTypes), int64(pb.Size()))
+
+	for i := 1; i < MaxVotesCount; i++ {
+		commit.Signatures = append(commit.Signatures, cs)
+	}
+	pb = commit.ToProto()
+	assert.EqualValues(t, MaxCommitBytesForKeyTypes(MaxVotesCount, keyTypes), int64(pb.Size()))
 }
 
 func TestHeaderHash(t *testing.T) {
@@ -655,6 +673,46 @@ func TestExtendedCommitToVoteSet(t *testing.T) {
 	}
 }
 
+func TestExtendedCommitInfo(t *testing.T) {
+	lastID := makeBlockIDRandom()
+	h := int64(3)
+
+	voteSet, valSet, vals := randVoteSet(h-1, 1, PrecommitType, 10, 1, true)
+	extCommit, err := MakeExtCommit(lastID, h-1, 1, voteSet, vals, cmttime.Now(), true)
+	require.NoError(t, err)
+	extCommit.ExtendedSignatures[0] = NewExtendedCommitSigAbsent()
+
+	eci, err := extCommit.ExtendedCommitInfo(valSet, true)
+	require.NoError(t, err)
+	assert.EqualValues(t, 1, eci.Round)
+	require.Len(t, eci.Votes, valSet.Size())
+	for i, vote := range eci.Votes {
+		ecs := extCommit.ExtendedSignatures[i]
+		assert.Equal(t, valSet.Validators[i].Address.Bytes(), vote.Validator.Address)
+		assert.Equal(t, valSet.Validators[i].VotingPower, vote.Validator.Power)
+		assert.EqualValues(t, ecs.BlockIDFlag, vote.BlockIdFlag)
+		assert.Equal(t, ecs.Extension, vote.VoteExtension)
+		assert.Equal(t, ecs.ExtensionSignature, vote.ExtensionSignature)
+	}
+	assert.EqualValues(t, BlockIDFlagAbsent, eci.Votes[0].BlockIdFlag)
+	assert.NotEmpty(t, eci.Votes[1].ExtensionSignature)
+
+	// The extensions must be present if and only if they are enabled.
+	_, err = extCommit.ExtendedCommitInfo(valSet, false)
+	require.Error(t, err)
+	extCommit.ExtendedSignatures[1].ExtensionSignature = nil
+	_, err = extCommit.ExtendedCommitInfo(valSet, true)
+	require.Error(t, err)
+
+	// The validator set must be the one which signed the commit.
+	otherValSet, _ := RandValidatorSet(10, 1)
+	_, err = extCommit.ExtendedCommitInfo(otherValSet, true)
+	require.Error(t, err)
+	smallerValSet, _ := RandValidatorSet(9, 1)
+	_, err = extCommit.ExtendedCommitInfo(smallerValSet, true)
+	require.Error(t, err)
+}
+
 func TestCommitToVoteSetWithVotesForNilBlock(t *testing.T) {
 	blockID := makeBlockID([]byte("blockhash"), 1000, []byte("partshash"))
 
@@ -209,6 +209,10 @@ func (b *EventBus) PublishEventCompleteProposal(data EventDataCompleteProposal)
 	return b.Publish(EventCompleteProposal, data)
 }
 
+func (b *EventBus) PublishEventProposalTimeliness(data EventDataProposalTimeliness) error {
+	return b.Publish(EventProposalTimeliness, data)
+}
+
 func (b *EventBus) PublishEventPolka(data EventDataRoundState) error {
 	return b.Publish(EventPolka, data)
 }
@@ -289,6 +293,10 @@ func (NopEventBus) PublishEventCompleteProposal(EventDataRoundState) error {
 	return nil
 }
 
+func (NopEventBus) PublishEventProposalTimeliness(EventDataProposalTimeliness) error {
+	return nil
+}
+
 func (NopEventBus) PublishEventPolka(EventDataRoundState) error {
 	return nil
 }
@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"time"
 
 	abci "github.com/cometbft/cometbft/abci/types"
 	cmtpubsub "github.com/cometbft/cometbft/internal/pubsub"
@@ -37,6 +38,9 @@ const (
 	EventValidBlock        = "ValidBlock"
 	EventVote              = "Vote"
 	EventProposalBlockPart = "ProposalBlockPart"
+
+	// PBTS diagnostics, published when a proposal for a new block is received.
+	EventProposalTimeliness = "ProposalTimeliness"
 )
 
 // ENCODING / DECODING
@@ -55,6 +59,7 @@ func init() {
 	cmtjson.RegisterType(EventDataRoundState{}, "tendermint/event/RoundState")
 	cmtjson.RegisterType(EventDataNewRound{}, "tendermint/event/NewRound")
 	cmtjson.RegisterType(EventDataCompleteProposal{}, "tendermint/event/CompleteProposal")
+	cmtjson.RegisterType(EventDataProposalTimeliness{}, "tendermint/event/ProposalTimeliness")
 	cmtjson.RegisterType(EventDataVote{}, "tendermint/event/Vote")
 	cmtjson.RegisterType(EventDataValidatorSetUpdates{}, "tendermint/event/ValidatorSetUpdates")
 	cmtjson.RegisterType(EventDataString(""), "tendermint/event/ProposalString")
@@ -117,6 +122,27 @@ type EventDataCompleteProposal struct {
 	BlockID BlockID `json:"block_id"`
 }
 
+// EventDataProposalTimeliness is the verdict of PBTS on a proposal for a new
+// block: the proposal is timely if ReceiveTime - Timestamp is within
+// [-Precision, MessageDelay + Precision]. The margins are the distances of
+// the difference to these bounds, negative if it is out of them. An
+// untimely proposal usually means the clocks of the proposer and of the node
+// are skewed by more than Precision, or MessageDelay is too small.
+type EventDataProposalTimeliness struct {
+	Height   int64   `json:"height"`
+	Round    int32   `json:"round"`
+	Proposer Address `json:"proposer"`
+
+	Timestamp    time.Time     `json:"timestamp"`
+	ReceiveTime  time.Time     `json:"receive_time"`
+	Difference   time.Duration `json:"difference"`
+	Precision    time.Duration `json:"precision"`
+	MessageDelay time.Duration `json:"message_delay"`
+	LowerMargin  time.Duration `json:"lower_margin"`
+	UpperMargin  time.Duration `json:"upper_margin"`
+	Timely       bool          `json:"timely"`
+}
+
 type EventDataVote struct {
 	Vote *Vote
 }
@@ -155,6 +181,7 @@ var (
 	EventQueryNewRound            = QueryForEvent(EventNewRound)
 	EventQueryNewRoundStep        = QueryForEvent(EventNewRoundStep)
 	EventQueryPolka               = QueryForEvent(EventPolka)
+	EventQueryProposalTimeliness  = QueryForEvent(EventProposalTimeliness)
 	EventQueryRelock              = QueryForEvent(EventRelock)
 	EventQueryTimeoutPropose      = QueryForEvent(EventTimeoutPropose)
 	EventQueryTimeoutWait         = QueryForEvent(EventTimeoutWait)
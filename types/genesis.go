@@ -32,6 +32,9 @@ type GenesisValidator struct {
 	PubKey  crypto.PubKey `json:"pub_key"`
 	Power   int64         `json:"power"`
 	Name    string        `json:"name"`
+	// ProofOfPossession of the private key of PubKey, required for BLS12-381
+	// keys.
+	ProofOfPossession cmtbytes.HexBytes `json:"proof_of_possession,omitempty"`
 }
 
 // GenesisDoc defines the initial conditions for a CometBFT blockchain, in particular its validator set.
@@ -90,6 +93,9 @@ func (genDoc *GenesisDoc) ValidateAndComplete() error {
 		if v.Power == 0 {
 			return fmt.Errorf("the genesis file cannot contain validators with no voting power: %v", v)
 		}
+		if err := VerifyProofOfPossession(v.PubKey, v.ProofOfPossession); err != nil {
+			return fmt.Errorf("validator %v in the genesis file: %w", v, err)
+		}
 		if len(v.Address) > 0 && !bytes.Equal(v.PubKey.Address(), v.Address) {
 			return fmt.Errorf("incorrect address for validator %v in the genesis file, should be %v", v, v.PubKey.Address())
 		}
@@ -7,6 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	cmtjson "github.com/cometbft/cometbft/libs/json"
 	cmttime "github.com/cometbft/cometbft/types/time"
@@ -87,7 +88,7 @@ func TestBasicGenesisDoc(t *testing.T) {
 	// create a base gendoc from struct
 	baseGenDoc := &GenesisDoc{
 		ChainID:    "abc",
-		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators: []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 	}
 	genDocBytes, err = cmtjson.Marshal(baseGenDoc)
 	require.NoError(t, err, "error marshaling genDoc")
@@ -153,6 +154,26 @@ func TestGenesisSaveAs(t *testing.T) {
 	assert.Equal(t, genDoc2.Validators, genDoc.Validators)
 }
 
+func TestGenesisBLS12381ProofOfPossession(t *testing.T) {
+	genDoc := randomGenesisDoc()
+	pubKey := bls12381.PubKey(make([]byte, bls12381.PubKeySize))
+	genDoc.Validators = []GenesisValidator{{PubKey: pubKey, Power: 10}}
+	require.ErrorContains(t, genDoc.ValidateAndComplete(), "missing proof of possession")
+
+	genDoc.Validators[0].ProofOfPossession = make([]byte, bls12381.SignatureLength)
+	require.ErrorContains(t, genDoc.ValidateAndComplete(), "invalid proof of possession")
+
+	if !bls12381.Enabled {
+		return
+	}
+	privKey, err := bls12381.GenPrivKey()
+	require.NoError(t, err)
+	proof, err := privKey.ProvePossession()
+	require.NoError(t, err)
+	genDoc.Validators = []GenesisValidator{{PubKey: privKey.PubKey(), Power: 10, ProofOfPossession: proof}}
+	require.NoError(t, genDoc.ValidateAndComplete())
+}
+
 func TestGenesisValidatorHash(t *testing.T) {
 	genDoc := randomGenesisDoc()
 	assert.NotEmpty(t, genDoc.ValidatorHash())
@@ -164,7 +185,7 @@ func randomGenesisDoc() *GenesisDoc {
 		GenesisTime:     cmttime.Now(),
 		ChainID:         "abc",
 		InitialHeight:   1000,
-		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval"}},
+		Validators:      []GenesisValidator{{pubkey.Address(), pubkey, 10, "myval", nil}},
 		ConsensusParams: DefaultConsensusParams(),
 		AppHash:         []byte{1, 2, 3},
 	}
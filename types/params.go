@@ -9,6 +9,7 @@ import (
 	gogo "github.com/cosmos/gogoproto/types"
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/ed25519"
 	"github.com/cometbft/cometbft/crypto/secp256k1"
 	"github.com/cometbft/cometbft/crypto/tmhash"
@@ -26,11 +27,13 @@ const (
 
 	ABCIPubKeyTypeEd25519   = ed25519.KeyType
 	ABCIPubKeyTypeSecp256k1 = secp256k1.KeyType
+	ABCIPubKeyTypeBls12381  = bls12381.KeyType
 )
 
 var ABCIPubKeyTypesToNames = map[string]string{
 	ABCIPubKeyTypeEd25519:   ed25519.PubKeyName,
 	ABCIPubKeyTypeSecp256k1: secp256k1.PubKeyName,
+	ABCIPubKeyTypeBls12381:  bls12381.PubKeyName,
 }
 
 // ConsensusParams contains consensus critical parameters that determine the
@@ -70,8 +73,11 @@ type VersionParams struct {
 
 // FeatureParams configure the height from which features of CometBFT are enabled.
 type FeatureParams struct {
-	VoteExtensionsEnableHeight int64 `json:"vote_extensions_enable_height"`
-	PbtsEnableHeight           int64 `json:"pbts_enable_height"`
+	VoteExtensionsEnableHeight   int64 `json:"vote_extensions_enable_height"`
+	PbtsEnableHeight             int64 `json:"pbts_enable_height"`
+	AggregatedCommitEnableHeight int64 `json:"aggregated_commit_enable_height"`
+	// Maximum size of a vote extension. 0 means MaxVoteExtensionSize.
+	VoteExtensionsMaxBytes int64 `json:"vote_extensions_max_bytes"`
 }
 
 // VoteExtensionsEnabled returns true if vote extensions are enabled at height h
@@ -89,6 +95,23 @@ func (p FeatureParams) PbtsEnabled(h int64) bool {
 	return featureEnabled(enabledHeight, h, "PBTS")
 }
 
+// MaxVoteExtensionBytes returns the maximum size in bytes of a vote
+// extension.
+func (p FeatureParams) MaxVoteExtensionBytes() int {
+	if p.VoteExtensionsMaxBytes <= 0 || p.VoteExtensionsMaxBytes > int64(MaxVoteExtensionSize) {
+		return MaxVoteExtensionSize
+	}
+	return int(p.VoteExtensionsMaxBytes)
+}
+
+// AggregatedCommitEnabled returns true if the commit of the block at height h
+// can be aggregated, and false otherwise.
+func (p FeatureParams) AggregatedCommitEnabled(h int64) bool {
+	enabledHeight := p.AggregatedCommitEnableHeight
+
+	return featureEnabled(enabledHeight, h, "Aggregated Commit")
+}
+
 // featureEnabled returns true if `enabledHeight` points to a height that is smaller than `currentHeight“.
 func featureEnabled(enableHeight int64, currentHeight int64, f string) bool {
 	if currentHeight < 1 {
@@ -177,8 +200,10 @@ func DefaultVersionParams() VersionParams {
 // Disabled by default.
 func DefaultFeatureParams() FeatureParams {
 	return FeatureParams{
-		VoteExtensionsEnableHeight: 0,
-		PbtsEnableHeight:           0,
+		VoteExtensionsEnableHeight:   0,
+		PbtsEnableHeight:             0,
+		AggregatedCommitEnableHeight: 0,
+		VoteExtensionsMaxBytes:       0,
 	}
 }
 
@@ -252,6 +277,18 @@ func (params ConsensusParams) ValidateBasic() error {
 		return fmt.Errorf("Feature.PbtsEnableHeight cannot be negative. Got: %d", params.Feature.PbtsEnableHeight)
 	}
 
+	if params.Feature.AggregatedCommitEnableHeight < 0 {
+		return fmt.Errorf("Feature.AggregatedCommitEnableHeight cannot be negative. Got: %d", params.Feature.AggregatedCommitEnableHeight)
+	}
+
+	if params.Feature.VoteExtensionsMaxBytes < 0 {
+		return fmt.Errorf("Feature.VoteExtensionsMaxBytes cannot be negative. Got: %d", params.Feature.VoteExtensionsMaxBytes)
+	}
+	if params.Feature.VoteExtensionsMaxBytes > int64(MaxVoteExtensionSize) {
+		return fmt.Errorf("Feature.VoteExtensionsMaxBytes is too big. %d > %d",
+			params.Feature.VoteExtensionsMaxBytes, MaxVoteExtensionSize)
+	}
+
 	if params.Synchrony.MessageDelay <= 0 {
 		return fmt.Errorf("synchrony.MessageDelay must be greater than 0. Got: %d",
 			params.Synchrony.MessageDelay)
@@ -292,7 +329,7 @@ func (params ConsensusParams) ValidateUpdate(updated *cmtproto.ConsensusParams,
 	return err
 }
 
-// validateUpdateFeatures validates the updated PBTSEnableHeight.
+// validateUpdateFeatures validates the updated feature enable heights.
 // | r | params...EnableHeight | updated...EnableHeight | result (nil == pass)
 // |  2 | *                    | < 0                    | EnableHeight must be positive
 // |  3 | <=0                  | 0                      | nil
@@ -318,6 +355,13 @@ func validateUpdateFeatures(params FeatureParams, updated cmtproto.FeatureParams
 			return err
 		}
 	}
+
+	if updated.AggregatedCommitEnableHeight != nil {
+		err := validateUpdateFeatureEnableHeight(params.AggregatedCommitEnableHeight, updated.AggregatedCommitEnableHeight.Value, h, "Aggregated Commit")
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -424,6 +468,14 @@ func (params ConsensusParams) Update(params2 *cmtproto.ConsensusParams) Consensu
 		if params2.Feature.PbtsEnableHeight != nil {
 			res.Feature.PbtsEnableHeight = params2.Feature.GetPbtsEnableHeight().Value
 		}
+
+		if params2.Feature.AggregatedCommitEnableHeight != nil {
+			res.Feature.AggregatedCommitEnableHeight = params2.Feature.GetAggregatedCommitEnableHeight().Value
+		}
+
+		if params2.Feature.VoteExtensionsMaxBytes != nil {
+			res.Feature.VoteExtensionsMaxBytes = params2.Feature.GetVoteExtensionsMaxBytes().Value
+		}
 	}
 	if params2.Synchrony != nil {
 		if params2.Synchrony.MessageDelay != nil {
@@ -455,8 +507,10 @@ func (params *ConsensusParams) ToProto() cmtproto.ConsensusParams {
 			App: params.Version.App,
 		},
 		Feature: &cmtproto.FeatureParams{
-			PbtsEnableHeight:           &gogo.Int64Value{Value: params.Feature.PbtsEnableHeight},
-			VoteExtensionsEnableHeight: &gogo.Int64Value{Value: params.Feature.VoteExtensionsEnableHeight},
+			PbtsEnableHeight:             &gogo.Int64Value{Value: params.Feature.PbtsEnableHeight},
+			VoteExtensionsEnableHeight:   &gogo.Int64Value{Value: params.Feature.VoteExtensionsEnableHeight},
+			AggregatedCommitEnableHeight: &gogo.Int64Value{Value: params.Feature.AggregatedCommitEnableHeight},
+			VoteExtensionsMaxBytes:       &gogo.Int64Value{Value: params.Feature.VoteExtensionsMaxBytes},
 		},
 		Synchrony: &cmtproto.SynchronyParams{
 			MessageDelay: &params.Synchrony.MessageDelay,
@@ -483,8 +537,10 @@ func ConsensusParamsFromProto(pbParams cmtproto.ConsensusParams) ConsensusParams
 			App: pbParams.Version.App,
 		},
 		Feature: FeatureParams{
-			VoteExtensionsEnableHeight: pbParams.GetFeature().GetVoteExtensionsEnableHeight().GetValue(),
-			PbtsEnableHeight:           pbParams.GetFeature().GetPbtsEnableHeight().GetValue(),
+			VoteExtensionsEnableHeight:   pbParams.GetFeature().GetVoteExtensionsEnableHeight().GetValue(),
+			PbtsEnableHeight:             pbParams.GetFeature().GetPbtsEnableHeight().GetValue(),
+			AggregatedCommitEnableHeight: pbParams.GetFeature().GetAggregatedCommitEnableHeight().GetValue(),
+			VoteExtensionsMaxBytes:       pbParams.GetFeature().GetVoteExtensionsMaxBytes().GetValue(),
 		},
 	}
 	if pbParams.GetSynchrony().GetMessageDelay() != nil {
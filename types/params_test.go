@@ -288,6 +288,43 @@ func TestConsensusParamsValidation(t *testing.T) {
 				}),
 			valid: true,
 		},
+		// vote extensions max bytes
+		{
+			name: "vote extensions max bytes -1",
+			params: makeParams(
+				makeParamsArgs{
+					blockBytes:             1,
+					evidenceAge:            2,
+					precision:              time.Nanosecond,
+					messageDelay:           time.Nanosecond,
+					voteExtensionsMaxBytes: -1,
+				}),
+			valid: false,
+		},
+		{
+			name: "vote extensions max bytes valid",
+			params: makeParams(
+				makeParamsArgs{
+					blockBytes:             1,
+					evidenceAge:            2,
+					precision:              time.Nanosecond,
+					messageDelay:           time.Nanosecond,
+					voteExtensionsMaxBytes: 1024,
+				}),
+			valid: true,
+		},
+		{
+			name: "vote extensions max bytes too big",
+			params: makeParams(
+				makeParamsArgs{
+					blockBytes:             1,
+					evidenceAge:            2,
+					precision:              time.Nanosecond,
+					messageDelay:           time.Nanosecond,
+					voteExtensionsMaxBytes: int64(MaxVoteExtensionSize) + 1,
+				}),
+			valid: false,
+		},
 	}
 	for i, tc := range testCases {
 		if tc.valid {
@@ -299,15 +336,17 @@ func TestConsensusParamsValidation(t *testing.T) {
 }
 
 type makeParamsArgs struct {
-	blockBytes          int64
-	blockGas            int64
-	evidenceAge         int64
-	maxEvidenceBytes    int64
-	pubkeyTypes         []string
-	voteExtensionHeight int64
-	pbtsHeight          int64
-	precision           time.Duration
-	messageDelay        time.Duration
+	blockBytes             int64
+	blockGas               int64
+	evidenceAge            int64
+	maxEvidenceBytes       int64
+	pubkeyTypes            []string
+	voteExtensionHeight    int64
+	pbtsHeight             int64
+	aggCommitHeight        int64
+	precision              time.Duration
+	voteExtensionsMaxBytes int64
+	messageDelay           time.Duration
 }
 
 func makeParams(args makeParamsArgs) ConsensusParams {
@@ -335,6 +374,9 @@ func makeParams(args makeParamsArgs) ConsensusParams {
 		Feature: FeatureParams{
 			VoteExtensionsEnableHeight: args.voteExtensionHeight,
 			PbtsEnableHeight:           args.pbtsHeight,
+
+			AggregatedCommitEnableHeight: args.aggCommitHeight,
+			VoteExtensionsMaxBytes:       args.voteExtensionsMaxBytes,
 		},
 	}
 }
@@ -480,6 +522,12 @@ func TestConsensusParamsUpdate(t *testing.T) {
 	}
 }
 
+func TestFeatureParamsMaxVoteExtensionBytes(t *testing.T) {
+	assert.Equal(t, MaxVoteExtensionSize, FeatureParams{}.MaxVoteExtensionBytes())
+	assert.Equal(t, 1024, FeatureParams{VoteExtensionsMaxBytes: 1024}.MaxVoteExtensionBytes())
+	assert.Equal(t, MaxVoteExtensionSize, FeatureParams{VoteExtensionsMaxBytes: int64(MaxVoteExtensionSize) + 1}.MaxVoteExtensionBytes())
+}
+
 func TestConsensusParamsUpdate_AppVersion(t *testing.T) {
 	params := makeParams(makeParamsArgs{blockBytes: 1, blockGas: 2, evidenceAge: 3})
 
@@ -583,6 +631,28 @@ func TestConsensusParamsUpdate_EnableHeight(t *testing.T) {
 		})
 	}
 
+	// Test aggregated commit enabling
+	for _, tc := range testCases {
+		t.Run(tc.name+" AggCommit", func(*testing.T) {
+			initialParams := makeParams(makeParamsArgs{
+				aggCommitHeight: tc.from,
+			})
+			update := &cmtproto.ConsensusParams{Feature: &cmtproto.FeatureParams{}}
+			if tc.to == nilTest {
+				update.Feature.AggregatedCommitEnableHeight = nil
+			} else {
+				update.Feature = &cmtproto.FeatureParams{
+					AggregatedCommitEnableHeight: &types.Int64Value{Value: tc.to},
+				}
+			}
+			if tc.expectedErr {
+				require.Error(t, initialParams.ValidateUpdate(update, tc.current))
+			} else {
+				require.NoError(t, initialParams.ValidateUpdate(update, tc.current))
+			}
+		})
+	}
+
 	// Test PBTS and VE enabling
 	for _, tc := range testCases {
 		t.Run(tc.name+"VE PBTS", func(*testing.T) {
@@ -631,6 +701,9 @@ func consensusParamsForTestProto() []ConsensusParams {
 		makeParams(makeParamsArgs{pbtsHeight: 100}),
 		makeParams(makeParamsArgs{voteExtensionHeight: 100, pbtsHeight: 42}),
 		makeParams(makeParamsArgs{pbtsHeight: 100}),
+		makeParams(makeParamsArgs{aggCommitHeight: 100}),
+		makeParams(makeParamsArgs{voteExtensionHeight: 1, pbtsHeight: 1, aggCommitHeight: 1}),
+		makeParams(makeParamsArgs{voteExtensionHeight: 1, voteExtensionsMaxBytes: 1024}),
 	}
 }
 
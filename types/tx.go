@@ -34,6 +34,16 @@ func (tx Tx) Key() TxKey {
 	return sha256.Sum256(tx)
 }
 
+// TxKeyFromBytes returns the TxKey encoded by the given bytes, as in Key()[:].
+func TxKeyFromBytes(b []byte) (TxKey, error) {
+	var txKey TxKey
+	if len(b) != TxKeySize {
+		return txKey, fmt.Errorf("invalid tx key size: expected %d, got %d", TxKeySize, len(b))
+	}
+	copy(txKey[:], b)
+	return txKey, nil
+}
+
 // String returns the hex-encoded transaction as a string.
 func (tx Tx) String() string {
 	return fmt.Sprintf("Tx{%X}", []byte(tx))
@@ -34,6 +34,17 @@ func TestTxIndex(t *testing.T) {
 	}
 }
 
+func TestTxKeyFromBytes(t *testing.T) {
+	tx := Tx("tx")
+	key := tx.Key()
+	txKey, err := TxKeyFromBytes(key[:])
+	require.NoError(t, err)
+	assert.Equal(t, tx.Key(), txKey)
+
+	_, err = TxKeyFromBytes(key[1:])
+	require.Error(t, err)
+}
+
 func TestTxIndexByHash(t *testing.T) {
 	for i := 0; i < 20; i++ {
 		txs := makeTxs(15, 60)
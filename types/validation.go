@@ -1,10 +1,12 @@
 package types
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 
 	"github.com/cometbft/cometbft/crypto/batch"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	"github.com/cometbft/cometbft/crypto/tmhash"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmterrors "github.com/cometbft/cometbft/types/errors"
@@ -41,6 +43,11 @@ func VerifyCommit(chainID string, vals *ValidatorSet, blockID BlockID,
 	// only count the signatures that are for the block
 	count := func(c CommitSig) bool { return c.BlockIDFlag == BlockIDFlagCommit }
 
+	if commit.IsAggregated() {
+		return verifyAggregatedCommit(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -103,6 +110,11 @@ func verifyCommitLightInternal(
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	if commit.IsAggregated() {
+		return verifyAggregatedCommit(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, true)
+	}
+
 	// attempt to batch verify
 	if shouldBatchVerify(vals, commit) {
 		return verifyCommitBatch(chainID, vals, commit,
@@ -178,6 +190,13 @@ func verifyCommitLightTrustingInternal(
 	// count all the remaining signatures
 	count := func(c CommitSig) bool { return true }
 
+	// an aggregated signature can only be verified if all the signers are
+	// part of the validator set.
+	if commit.IsAggregated() {
+		return verifyAggregatedCommit(chainID, vals, commit, votingPowerNeeded,
+			ignore, count, false)
+	}
+
 	// attempt to batch verify commit. As the validator set doesn't necessarily
 	// correspond with the validator set that signed the block we need to look
 	// up by address rather than index.
@@ -191,6 +210,78 @@ func verifyCommitLightTrustingInternal(
 		ignore, count, countAllSignatures, false)
 }
 
+// VerifyAggregatedCommitLightTrusting verifies that trustLevel of the trusted
+// validator set signed this aggregated commit.
+//
+// Unlike VerifyCommitLightTrusting, which needs all the signers of an
+// aggregated commit to be in the given validator set, the aggregated signature
+// is verified against vals, the validator set that signed the commit, which
+// must have signed it with +2/3 of its voting power. The trusted voting power
+// is then tallied by address.
+//
+// An ErrNotEnoughVotingPowerSigned is returned, unwrapped, only if the trusted
+// validators don't have enough voting power in the commit.
+func VerifyAggregatedCommitLightTrusting(
+	chainID string,
+	trustedVals *ValidatorSet,
+	vals *ValidatorSet,
+	commit *Commit,
+	trustLevel cmtmath.Fraction,
+) error {
+	// sanity checks
+	if trustedVals == nil {
+		return errors.New("nil trusted validator set")
+	}
+	if trustLevel.Denominator == 0 {
+		return errors.New("trustLevel has zero Denominator")
+	}
+	if commit == nil {
+		return errors.New("nil commit")
+	}
+	if !commit.IsAggregated() {
+		return errors.New("commit is not aggregated")
+	}
+
+	// verify the aggregated signature, and that +2/3 of vals signed the block.
+	if err := VerifyCommitLight(chainID, vals, commit.BlockID, commit.Height, commit); err != nil {
+		return fmt.Errorf("invalid commit: %w", err)
+	}
+
+	// safely calculate voting power needed.
+	totalVotingPowerMulByNumerator, overflow := safeMul(trustedVals.TotalVotingPower(), int64(trustLevel.Numerator))
+	if overflow {
+		return errors.New("int64 overflow while calculating voting power needed. please provide smaller trustLevel numerator")
+	}
+	votingPowerNeeded := totalVotingPowerMulByNumerator / int64(trustLevel.Denominator)
+
+	// VerifyCommitLight checked that the addresses of the commit match vals,
+	// so they are bound to the keys that produced the aggregated signature.
+	var (
+		seenVals           = make(map[int32]int, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag != BlockIDFlagCommit {
+			continue
+		}
+		valIdx, val := trustedVals.GetByAddress(commitSig.ValidatorAddress)
+		if val == nil {
+			continue
+		}
+		if firstIndex, ok := seenVals[valIdx]; ok {
+			return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, idx)
+		}
+		seenVals[valIdx] = idx
+
+		talliedVotingPower += val.VotingPower
+		if talliedVotingPower > votingPowerNeeded {
+			return nil
+		}
+	}
+
+	return ErrNotEnoughVotingPowerSigned{Got: talliedVotingPower, Needed: votingPowerNeeded}
+}
+
 // ValidateHash returns an error if the hash is not empty, but its
 // size != tmhash.Size.
 func ValidateHash(h []byte) error {
@@ -401,6 +492,89 @@ func verifyCommitSingle(
 	return nil
 }
 
+// Aggregated Verification
+
+// verifyAggregatedCommit verifies the aggregated signature of a commit.
+// Unlike verifyCommitSingle and verifyCommitBatch, it needs the keys of all the
+// validators that signed the commit, including those whose signatures are
+// ignored in the tally, because they are all part of the aggregated signature.
+// It follows that all the signatures are always checked.
+// CONTRACT: both commit and validator set should have passed validate basic.
+func verifyAggregatedCommit(
+	chainID string,
+	vals *ValidatorSet,
+	commit *Commit,
+	votingPowerNeeded int64,
+	ignoreSig func(CommitSig) bool,
+	countSig func(CommitSig) bool,
+	lookUpByIndex bool,
+) error {
+	var (
+		val                *Validator
+		valIdx             int32
+		seenVals           = make(map[int32]int, len(commit.Signatures))
+		pubKeys            = make([]bls12381.PubKey, 0, len(commit.Signatures))
+		msgs               = make([][]byte, 0, len(commit.Signatures))
+		talliedVotingPower int64
+	)
+	for idx, commitSig := range commit.Signatures {
+		if commitSig.BlockIDFlag == BlockIDFlagAbsent {
+			continue
+		}
+
+		// If the vals and commit have a 1-to-1 correspondence we can retrieve
+		// them by index else we need to retrieve them by address
+		if lookUpByIndex {
+			val = vals.Validators[idx]
+			if !bytes.Equal(val.Address, commitSig.ValidatorAddress) {
+				return fmt.Errorf("wrong validator address (#%d): want %v, got %v",
+					idx, val.Address, commitSig.ValidatorAddress)
+			}
+		} else {
+			valIdx, val = vals.GetByAddress(commitSig.ValidatorAddress)
+
+			// the aggregated signature cannot be verified without the keys of
+			// all the signers
+			if val == nil {
+				return fmt.Errorf("signer %v (#%d) of aggregated commit is not in the validator set",
+					commitSig.ValidatorAddress, idx)
+			}
+
+			// because we are getting validators by address we need to make sure
+			// that the same validator doesn't commit twice
+			if firstIndex, ok := seenVals[valIdx]; ok {
+				secondIndex := idx
+				return fmt.Errorf("double vote from %v (%d and %d)", val, firstIndex, secondIndex)
+			}
+			seenVals[valIdx] = idx
+		}
+
+		pubKey, ok := val.PubKey.(bls12381.PubKey)
+		if !ok {
+			return fmt.Errorf("validator %v at index %d does not have a %s key",
+				val, idx, bls12381.KeyType)
+		}
+		pubKeys = append(pubKeys, pubKey)
+		msgs = append(msgs, commit.VoteSignBytes(chainID, int32(idx)))
+
+		// If this signature counts then add the voting power of the validator
+		// to the tally
+		if !ignoreSig(commitSig) && countSig(commitSig) {
+			talliedVotingPower += val.VotingPower
+		}
+	}
+
+	if got, needed := talliedVotingPower, votingPowerNeeded; got <= needed {
+		return ErrNotEnoughVotingPowerSigned{Got: got, Needed: needed}
+	}
+
+	if !bls12381.VerifyAggregateSignature(pubKeys, msgs, commit.AggregatedSignature) {
+		return fmt.Errorf("wrong aggregated signature: %X", commit.AggregatedSignature)
+	}
+
+	return nil
+}
+
 func verifyBasicValsAndCommit(vals *ValidatorSet, commit *Commit, height int64, blockID BlockID) error {
 	if vals == nil {
 		return errors.New("nil validator set")
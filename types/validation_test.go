@@ -1,12 +1,14 @@
 package types
 
 import (
+	"sort"
 	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	cmtmath "github.com/cometbft/cometbft/libs/math"
 	cmttime "github.com/cometbft/cometbft/types/time"
 )
@@ -307,3 +309,99 @@ func TestValidatorSet_VerifyCommitLightTrustingErrorsOnOverflow(t *testing.T) {
 		assert.Contains(t, err.Error(), "int64 overflow")
 	}
 }
+
+func randBLSValidatorSet(t *testing.T, numValidators int, votingPower int64) (*ValidatorSet, []PrivValidator) {
+	t.Helper()
+	var (
+		valz           = make([]*Validator, numValidators)
+		privValidators = make([]PrivValidator, numValidators)
+	)
+	for i := 0; i < numValidators; i++ {
+		privKey, err := bls12381.GenPrivKey()
+		require.NoError(t, err)
+		valz[i] = NewValidator(privKey.PubKey(), votingPower)
+		privValidators[i] = NewMockPVWithParams(privKey, false, false)
+	}
+	sort.Sort(PrivValidatorsByAddress(privValidators))
+	return NewValidatorSet(valz), privValidators
+}
+
+func TestCommitAggregateRequiresBLSKeys(t *testing.T) {
+	var (
+		blockID               = makeBlockIDRandom()
+		voteSet, valSet, vals = randVoteSet(1, 1, PrecommitType, 4, 10, false)
+		extCommit, err        = MakeExtCommit(blockID, 1, 1, voteSet, vals, cmttime.Now(), false)
+	)
+	require.NoError(t, err)
+
+	_, err = extCommit.ToCommit().Aggregate(valSet)
+	require.ErrorContains(t, err, "does not have a bls12_381 key")
+}
+
+func TestValidatorSet_VerifyAggregatedCommit(t *testing.T) {
+	if !bls12381.Enabled {
+		t.Skip("bls12381 is disabled")
+	}
+
+	var (
+		chainID        = "test_chain_id"
+		blockID        = makeBlockIDRandom()
+		valSet, vals   = randBLSValidatorSet(t, 7, 10)
+		voteSet        = NewVoteSet(chainID, 1, 1, PrecommitType, valSet)
+		extCommit, err = MakeExtCommit(blockID, 1, 1, voteSet, vals, cmttime.Now(), false)
+		trustLevel     = cmtmath.Fraction{Numerator: 1, Denominator: 3}
+	)
+	require.NoError(t, err)
+	commit := extCommit.ToCommit()
+	commit.Signatures[1] = NewCommitSigAbsent()
+
+	aggCommit, err := commit.Aggregate(valSet)
+	require.NoError(t, err)
+	require.True(t, aggCommit.IsAggregated())
+	require.False(t, commit.IsAggregated())
+	for _, cs := range aggCommit.Signatures {
+		assert.Empty(t, cs.Signature)
+	}
+	require.NoError(t, aggCommit.ValidateBasic())
+	assert.NotEqual(t, commit.Hash(), aggCommit.Hash())
+
+	pbCommit := aggCommit.ToProto()
+	decoded, err := CommitFromProto(pbCommit)
+	require.NoError(t, err)
+	assert.Equal(t, aggCommit.AggregatedSignature, decoded.AggregatedSignature)
+	assert.Equal(t, aggCommit.Hash(), decoded.Hash())
+
+	require.NoError(t, valSet.VerifyCommit(chainID, blockID, 1, aggCommit))
+	require.NoError(t, valSet.VerifyCommitLight(chainID, blockID, 1, aggCommit))
+	require.NoError(t, valSet.VerifyCommitLightTrusting(chainID, aggCommit, trustLevel))
+
+	// Trusting verification by address needs the keys of all the signers...
+	partialValSet := NewValidatorSet(valSet.Validators[:3])
+	err = partialValSet.VerifyCommitLightTrusting(chainID, aggCommit, trustLevel)
+	require.ErrorContains(t, err, "is not in the validator set")
+	// ...unless the commit is verified against its own validator set.
+	require.NoError(t, partialValSet.VerifyAggregatedCommitLightTrusting(chainID, valSet, aggCommit, trustLevel))
+
+	otherValSet, _ := randBLSValidatorSet(t, 2, 10)
+	err = otherValSet.VerifyAggregatedCommitLightTrusting(chainID, valSet, aggCommit, trustLevel)
+	require.ErrorAs(t, err, &ErrNotEnoughVotingPowerSigned{})
+
+	// An absent validator can't be turned into a signer.
+	tampered, err := commit.Aggregate(valSet)
+	require.NoError(t, err)
+	tampered.Signatures[1] = tampered.Signatures[0]
+	tampered.Signatures[1].ValidatorAddress = valSet.Validators[1].Address
+	require.Error(t, valSet.VerifyCommit(chainID, blockID, 1, tampered))
+
+	// Nor can a signer be removed.
+	tampered, err = commit.Aggregate(valSet)
+	require.NoError(t, err)
+	tampered.Signatures[0] = NewCommitSigAbsent()
+	require.ErrorContains(t, valSet.VerifyCommit(chainID, blockID, 1, tampered), "wrong aggregated signature")
+
+	// An aggregated commit can't have individual signatures.
+	tampered, err = commit.Aggregate(valSet)
+	require.NoError(t, err)
+	tampered.Signatures[0].Signature = commit.Signatures[0].Signature
+	require.Error(t, tampered.ValidateBasic())
+}
@@ -8,6 +8,7 @@ import (
 
 	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
 	"github.com/cometbft/cometbft/crypto"
+	"github.com/cometbft/cometbft/crypto/bls12381"
 	ce "github.com/cometbft/cometbft/crypto/encoding"
 	cmtrand "github.com/cometbft/cometbft/internal/rand"
 )
@@ -33,6 +34,25 @@ func NewValidator(pubKey crypto.PubKey, votingPower int64) *Validator {
 	}
 }
 
+// VerifyProofOfPossession returns an error if pubKey is a BLS12-381 key and
+// proof is not a valid proof of possession of its private key. It must hold
+// before a BLS12-381 key enters a validator set, or its holder could forge
+// aggregated signatures with a rogue key. Keys of the other types don't need
+// one.
+func VerifyProofOfPossession(pubKey crypto.PubKey, proof []byte) error {
+	blsKey, ok := pubKey.(bls12381.PubKey)
+	if !ok {
+		return nil
+	}
+	if len(proof) == 0 {
+		return fmt.Errorf("missing proof of possession of BLS12-381 key %v", pubKey)
+	}
+	if !blsKey.VerifyProofOfPossession(proof) {
+		return fmt.Errorf("invalid proof of possession of BLS12-381 key %v", pubKey)
+	}
+	return nil
+}
+
 // ValidateBasic performs basic validation.
 func (v *Validator) ValidateBasic() error {
 	if v == nil {
@@ -708,6 +708,18 @@ func (vals *ValidatorSet) VerifyCommitLightTrustingAllSignatures(
 	return VerifyCommitLightTrustingAllSignatures(chainID, vals, commit, trustLevel)
 }
 
+// VerifyAggregatedCommitLightTrusting verifies that trustLevel of the validator
+// set signed this aggregated commit, and that +2/3 of commitVals, the validator
+// set that signed it, did.
+func (vals *ValidatorSet) VerifyAggregatedCommitLightTrusting(
+	chainID string,
+	commitVals *ValidatorSet,
+	commit *Commit,
+	trustLevel cmtmath.Fraction,
+) error {
+	return VerifyAggregatedCommitLightTrusting(chainID, vals, commitVals, commit, trustLevel)
+}
+
 // findPreviousProposer reverses the compare proposer priority function to find the validator
 // with the lowest proposer priority which would have been the previous proposer.
 //
@@ -31,6 +31,7 @@ var (
 	ErrVoteNil                       = errors.New("nil vote")
 	ErrVoteExtensionAbsent           = errors.New("vote extension absent")
 	ErrInvalidVoteExtension          = errors.New("invalid vote extension")
+	ErrVoteExtensionTooBig           = errors.New("vote extension is too big")
 )
 
 type ErrVoteConflictingVotes struct {
@@ -345,6 +346,11 @@ func (vote *Vote) ValidateBasic() error {
 			return fmt.Errorf("vote extension signature is too big (max: %d)", MaxSignatureSize)
 		}
 
+		// The limit set by the consensus params is enforced by the VoteSet.
+		if len(vote.Extension) > MaxVoteExtensionSize {
+			return fmt.Errorf("%w (max: %d)", ErrVoteExtensionTooBig, MaxVoteExtensionSize)
+		}
+
 		// NOTE: extended votes should have a signature regardless of
 		// whether there is any data in the extension or not however
 		// we don't know if extensions are enabled so we can only
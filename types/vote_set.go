@@ -64,6 +64,7 @@ type VoteSet struct {
 	signedMsgType     SignedMsgType
 	valSet            *ValidatorSet
 	extensionsEnabled bool
+	maxExtensionBytes int
 
 	mtx           cmtsync.Mutex
 	votesBitArray *bits.BitArray
@@ -102,9 +103,19 @@ func NewVoteSet(chainID string, height int64, round int32,
 // data for every vote added to the set.
 func NewExtendedVoteSet(chainID string, height int64, round int32,
 	signedMsgType SignedMsgType, valSet *ValidatorSet,
+) *VoteSet {
+	return NewExtendedVoteSetWithMaxExtensionBytes(chainID, height, round, signedMsgType, valSet, MaxVoteExtensionSize)
+}
+
+// NewExtendedVoteSetWithMaxExtensionBytes is like NewExtendedVoteSet, but the
+// votes with an extension bigger than maxExtensionBytes are rejected, see
+// FeatureParams.MaxVoteExtensionBytes.
+func NewExtendedVoteSetWithMaxExtensionBytes(chainID string, height int64, round int32,
+	signedMsgType SignedMsgType, valSet *ValidatorSet, maxExtensionBytes int,
 ) *VoteSet {
 	vs := NewVoteSet(chainID, height, round, signedMsgType, valSet)
 	vs.extensionsEnabled = true
+	vs.maxExtensionBytes = maxExtensionBytes
 	return vs
 }
 
@@ -216,6 +227,10 @@ func (voteSet *VoteSet) addVote(vote *Vote) (added bool, err error) {
 
 	// Check signature.
 	if voteSet.extensionsEnabled {
+		if len(vote.Extension) > voteSet.maxExtensionBytes {
+			return false, fmt.Errorf("%w: %d bytes (max: %d)",
+				ErrVoteExtensionTooBig, len(vote.Extension), voteSet.maxExtensionBytes)
+		}
 		if err := vote.VerifyVoteAndExtension(voteSet.chainID, val.PubKey); err != nil {
 			return false, fmt.Errorf("failed to verify extended vote with ChainID %s and PubKey %s: %w", voteSet.chainID, val.PubKey, err)
 		}
@@ -554,6 +554,41 @@ func TestVoteSet_VoteExtensionsEnabled(t *testing.T) {
 	}
 }
 
+func TestVoteSet_MaxExtensionBytes(t *testing.T) {
+	height, round := int64(1), int32(0)
+	valSet, privValidators := RandValidatorSet(2, 10)
+	voteSet := NewExtendedVoteSetWithMaxExtensionBytes("test_chain_id", height, round, PrecommitType, valSet, 10)
+	blockID := BlockID{crypto.CRandBytes(32), PartSetHeader{123, crypto.CRandBytes(32)}}
+
+	for i, ext := range [][]byte{make([]byte, 11), make([]byte, 10)} {
+		pubKey, err := privValidators[i].GetPubKey()
+		require.NoError(t, err)
+		vote := &Vote{
+			ValidatorAddress: pubKey.Address(),
+			ValidatorIndex:   int32(i),
+			Height:           height,
+			Round:            round,
+			Type:             PrecommitType,
+			Timestamp:        cmttime.Now(),
+			BlockID:          blockID,
+			Extension:        ext,
+		}
+		v := vote.ToProto()
+		require.NoError(t, privValidators[i].SignVote(voteSet.ChainID(), v, true))
+		vote.Signature = v.Signature
+		vote.ExtensionSignature = v.ExtensionSignature
+
+		added, err := voteSet.AddVote(vote)
+		if i == 0 {
+			require.ErrorIs(t, err, ErrVoteExtensionTooBig)
+			require.False(t, added)
+		} else {
+			require.NoError(t, err)
+			require.True(t, added)
+		}
+	}
+}
+
 // NOTE: privValidators are in order.
 func randVoteSet(
 	height int64,